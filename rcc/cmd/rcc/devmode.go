@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter/fake"
+	"github.com/radio-control/rcc/internal/radio"
+)
+
+// DevRadioIDs are the in-memory fake radios registered in dev mode.
+var DevRadioIDs = []string{"fake-01", "fake-02"}
+
+// seedDevRadios registers a fake adapter for each of DevRadioIDs with the
+// radio manager, the first becoming the active radio.
+func seedDevRadios(radioManager *radio.Manager, timeout time.Duration) error {
+	for _, id := range DevRadioIDs {
+		if err := radioManager.LoadCapabilities(id, fake.NewFakeAdapter(id), timeout); err != nil {
+			return fmt.Errorf("failed to register dev radio %s: %w", id, err)
+		}
+	}
+	return nil
+}
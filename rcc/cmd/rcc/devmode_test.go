@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/api"
+	"github.com/radio-control/rcc/internal/command"
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/radio"
+	"github.com/radio-control/rcc/internal/telemetry"
+)
+
+func TestDevModeRadiosServeTheAPI(t *testing.T) {
+	cfg := config.LoadCBTimingBaseline()
+	hub := telemetry.NewHub(cfg)
+	t.Cleanup(hub.Stop)
+
+	radioManager := radio.NewManager()
+	if err := seedDevRadios(radioManager, time.Second); err != nil {
+		t.Fatalf("seedDevRadios failed: %v", err)
+	}
+	orchestrator := command.NewOrchestratorWithRadioManager(hub, cfg, radioManager)
+	server := api.NewServer(hub, orchestrator, radioManager, 30*time.Second, 30*time.Second, 120*time.Second)
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	do := func(method, path, body string) map[string]interface{} {
+		t.Helper()
+		req := httptest.NewRequest(method, api.APIBasePath+path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s %s: expected 200, got %d: %s", method, path, w.Code, w.Body.String())
+		}
+		var resp struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("%s %s: invalid response: %v", method, path, err)
+		}
+		return resp.Data
+	}
+
+	list := do(http.MethodGet, "/radios", "")
+	items, _ := list["items"].([]interface{})
+	if len(items) != len(DevRadioIDs) {
+		t.Fatalf("Expected the %d dev radios, got %v", len(DevRadioIDs), list)
+	}
+	if list["activeRadioId"] != DevRadioIDs[0] {
+		t.Errorf("Expected %s to be active, got %v", DevRadioIDs[0], list["activeRadioId"])
+	}
+
+	// Set power reaches the fake and reads back
+	do(http.MethodPost, "/radios/fake-02/power", `{"powerDbm":27}`)
+	if got := do(http.MethodGet, "/radios/fake-02/power", "")["powerDbm"]; got != 27.0 {
+		t.Errorf("Expected fake-02 at 27 dBm, got %v", got)
+	}
+}
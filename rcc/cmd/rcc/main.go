@@ -5,7 +5,6 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
 	"os"
 	"os/signal"
 	"syscall"
@@ -15,6 +14,8 @@ import (
 	"github.com/radio-control/rcc/internal/audit"
 	"github.com/radio-control/rcc/internal/command"
 	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/logging"
+	"github.com/radio-control/rcc/internal/metrics"
 	"github.com/radio-control/rcc/internal/radio"
 	"github.com/radio-control/rcc/internal/telemetry"
 )
@@ -24,60 +25,156 @@ const (
 	DefaultPort = "8000"
 	DefaultAddr = ":" + DefaultPort
 	Version     = "1.0.0"
+
+	// Per-client rate limits (requests per second, burst)
+	CommandRateLimit   = 10.0
+	CommandRateBurst   = 20
+	TelemetryRateLimit = 1.0
+	TelemetryRateBurst = 5
+
+	// How long command responses are replayed for a repeated Idempotency-Key
+	IdempotencyTTL = 10 * time.Minute
+
+	// How long startup waits for the HTTP listener before giving up
+	ListenTimeout = 10 * time.Second
 )
 
 func main() {
-	log.Printf("Starting Radio Control Container v%s", Version)
+	// Structured JSON logs; request-scoped lines carry the correlation ID
+	logger := logging.Default()
+	bg := context.Background()
+
+	logger.Info(bg, "Starting Radio Control Container", logging.Fields{"version": Version})
 
 	// Step 1: Load configuration
 	// Source: Architecture §6.1 Initialization
-	cfg, err := config.Load()
+	// The store keeps the running config so SIGHUP can replace it
+	configStore, err := config.NewStore()
 	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
+		logger.Fatal(bg, "Failed to load configuration", logging.Fields{"error": err})
 	}
-	log.Println("Configuration loaded successfully")
+	cfg := configStore.Current()
+	logger.Info(bg, "Configuration loaded successfully", nil)
 
 	// Step 2: Initialize telemetry hub
 	// Source: Architecture §6.1 Initialization
-	telemetryHub := telemetry.NewHub(cfg)
+	var telemetryHub *telemetry.Hub
+	if cfg.EventStorePath != "" {
+		// Reload buffered events so Last-Event-ID replay survives restarts
+		telemetryHub, err = telemetry.NewHubWithStore(cfg, telemetry.NewFileEventStore(cfg.EventStorePath))
+		if err != nil {
+			logger.Fatal(bg, "Failed to load event store", logging.Fields{"error": err})
+		}
+	} else {
+		telemetryHub = telemetry.NewHub(cfg)
+	}
 	if telemetryHub == nil {
-		log.Fatal("Failed to create telemetry hub")
+		logger.Fatal(bg, "Failed to create telemetry hub", nil)
+	}
+	if cfg.EventIDStatePath != "" {
+		// Resume event IDs above their pre-restart values (CB-TIMING §6.1)
+		if err := telemetryHub.PersistEventIDs(cfg.EventIDStatePath); err != nil {
+			logger.Fatal(bg, "Failed to load event ID state", logging.Fields{"error": err})
+		}
 	}
-	log.Println("Telemetry hub initialized")
+	logger.Info(bg, "Telemetry hub initialized", nil)
 
 	// Step 3: Initialize audit logger
 	// Source: Architecture §6.1 Initialization
 	auditLogger, err := audit.NewLogger("logs")
 	if err != nil {
-		log.Fatalf("Failed to initialize audit logger: %v", err)
+		logger.Fatal(bg, "Failed to initialize audit logger", logging.Fields{"error": err})
+	}
+	auditLogger.SetAnonymousActor(cfg.AnonymousActorName)
+	if cfg.AuditHashChain {
+		if err := auditLogger.EnableHashChain(); err != nil {
+			logger.Fatal(bg, "Failed to resume audit hash chain", logging.Fields{"error": err})
+		}
 	}
-	log.Println("Audit logger initialized")
+	logger.Info(bg, "Audit logger initialized", nil)
 
 	// Step 4: Initialize radio manager
 	// Source: Architecture §6.1 Initialization
 	radioManager := radio.NewManager()
 	if radioManager == nil {
-		log.Fatal("Failed to create radio manager")
+		logger.Fatal(bg, "Failed to create radio manager", nil)
 	}
-	log.Println("Radio manager initialized")
+	radioManager.SetBandPlan(cfg.SilvusBandPlan)
+	logger.Info(bg, "Radio manager initialized", nil)
+
+	// Dev mode registers fake radios so the API works without hardware
+	if cfg.DevMode {
+		if err := seedDevRadios(radioManager, cfg.CommandTimeoutGetState); err != nil {
+			logger.Fatal(bg, "Failed to register dev radios", logging.Fields{"error": err})
+		}
+		logger.Warn(bg, "Dev mode: fake radios registered", logging.Fields{"radios": DevRadioIDs})
+	}
+
+	// Probe radio health and publish offline faults (CB-TIMING §4.1)
+	radioManager.StartHealthProbes(cfg, telemetryHub)
+
+	// Publish periodic systemStats aggregates for dashboards
+	telemetryHub.StartSystemStats(cfg.SystemStatsInterval, func() int {
+		return len(radioManager.List().Items)
+	})
 
 	// Step 5: Create command orchestrator
 	// Source: Architecture §6.1 Initialization
-	orchestrator := command.NewOrchestrator(telemetryHub, cfg)
+	// Commands resolve each radio's adapter through the radio manager
+	orchestrator := command.NewOrchestratorWithRadioManager(telemetryHub, cfg, radioManager)
 	orchestrator.SetAuditLogger(auditLogger)
+	orchestrator.SetLogger(logger)
+
+	// Command and SSE client metrics for Prometheus scrapes
+	registry := metrics.NewRegistry()
+	orchestrator.SetMetrics(registry)
+	telemetryHub.SetMetrics(registry)
 
 	// Step 6: Create API server with all components
 	// Source: Architecture §6.1 Initialization
 	server := api.NewServer(telemetryHub, orchestrator, radioManager, 30*time.Second, 30*time.Second, 120*time.Second)
 	if server == nil {
-		log.Fatal("Failed to create API server")
+		logger.Fatal(bg, "Failed to create API server", nil)
+	}
+	server.SetServiceInfo("", Version)
+	server.SetMaxBodyBytes(cfg.MaxBodyBytes)
+	httpsOnly, err := api.ParseHTTPSOnlyMode(cfg.HTTPSOnly)
+	if err != nil {
+		logger.Fatal(bg, "Invalid HTTPS-only configuration", logging.Fields{"error": err})
+	}
+	server.SetHTTPSOnly(httpsOnly)
+	if err := server.SetTrustedProxies(cfg.TrustedProxyCIDRs); err != nil {
+		logger.Fatal(bg, "Invalid trusted proxy configuration", logging.Fields{"error": err})
+	}
+	server.SetRateLimit(CommandRateLimit, CommandRateBurst)
+	server.SetTelemetryRateLimit(TelemetryRateLimit, TelemetryRateBurst)
+	server.SetIdempotencyTTL(IdempotencyTTL)
+	server.SetDegradedHealthOK(cfg.DegradedHealthOK)
+	server.SetPresets(configStore)
+	server.SetGroups(configStore)
+	server.EnableMetrics(registry, cfg.MetricsRequireAuth)
+	server.EnableAuditExport(audit.NewReader(auditLogger.GetFilePath()))
+	server.EnableConfigExport(configStore)
+	if cfg.PprofEnabled {
+		if err := server.EnablePprof(cfg.PprofAllowedCIDRs); err != nil {
+			logger.Fatal(bg, "Invalid pprof configuration", logging.Fields{"error": err})
+		}
+		logger.Warn(bg, "Profiling endpoints enabled", logging.Fields{"path": api.PprofBasePath, "allowedCIDRs": cfg.PprofAllowedCIDRs})
 	}
-	log.Println("API server created")
+	if cfg.DebugRawResponses {
+		server.EnableDebugRawResponses()
+		logger.Warn(bg, "Adapter raw responses exposed to admin tokens", nil)
+	}
+	if cfg.HeartbeatStatus {
+		// Heartbeats carry the server's radio count and uptime
+		telemetryHub.SetStatusProvider(server)
+	}
+	logger.Info(bg, "API server created", nil)
 
 	// Step 7: Start HTTP server
 	// Source: Architecture §6.1 Initialization
 	addr := getServerAddress()
-	log.Printf("Starting HTTP server on %s", addr)
+	logger.Info(bg, "Starting HTTP server", logging.Fields{"addr": addr})
 
 	// Start server in goroutine
 	serverErr := make(chan error, 1)
@@ -87,13 +184,27 @@ func main() {
 		}
 	}()
 
-	// Wait a moment for server to start
-	time.Sleep(100 * time.Millisecond)
+	// Report startup only once the listener is bound; a bind failure is fatal
+	if err := waitForListener(server, serverErr, ListenTimeout); err != nil {
+		logger.Fatal(bg, "Failed to start HTTP server", logging.Fields{"addr": addr, "error": err})
+	}
 
 	// Log successful startup
-	log.Printf("Radio Control Container started successfully")
-	log.Printf("Health endpoint: http://localhost%s/api/v1/health", addr)
-	log.Printf("API base URL: http://localhost%s/api/v1", addr)
+	logger.Info(bg, "Radio Control Container started successfully", logging.Fields{
+		"listenAddr":     server.Addr().String(),
+		"healthEndpoint": "http://localhost" + addr + api.APIBasePath + "/health",
+		"apiBaseUrl":     "http://localhost" + addr + api.APIBasePath,
+	})
+	auditLogger.LogAction(audit.WithSystemActor(bg), "startup", "", "SUCCESS", 0)
+
+	// Reload channel maps and power limits on SIGHUP without restarting
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			reloadConfig(bg, logger, configStore, orchestrator, radioManager)
+		}
+	}()
 
 	// Set up graceful shutdown
 	shutdown := make(chan os.Signal, 1)
@@ -102,33 +213,53 @@ func main() {
 	// Wait for shutdown signal or server error
 	select {
 	case sig := <-shutdown:
-		log.Printf("Received signal %v, initiating graceful shutdown...", sig)
+		logger.Info(bg, "Received signal, initiating graceful shutdown", logging.Fields{"signal": sig.String()})
 	case err := <-serverErr:
-		log.Printf("Server error: %v", err)
+		logger.Error(bg, "Server error", logging.Fields{"error": err})
 	}
 
 	// Graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Stop telemetry hub
+	// Stop health probes before the hub they publish to
+	radioManager.StopHealthProbes()
+	logger.Info(bg, "Radio health probes stopped", nil)
+
+	// Drain telemetry clients with a shutdown event before the HTTP server stops
 	telemetryHub.Stop()
-	log.Println("Telemetry hub stopped")
+	logger.Info(bg, "Telemetry hub stopped", nil)
 
 	// Stop audit logger
 	if err := auditLogger.Close(); err != nil {
-		log.Printf("Error closing audit logger: %v", err)
+		logger.Error(bg, "Error closing audit logger", logging.Fields{"error": err})
 	}
-	log.Println("Audit logger closed")
+	logger.Info(bg, "Audit logger closed", nil)
 
 	// Stop HTTP server
 	if err := server.Stop(ctx); err != nil {
-		log.Printf("Error stopping HTTP server: %v", err)
+		logger.Error(bg, "Error stopping HTTP server", logging.Fields{"error": err})
 	} else {
-		log.Println("HTTP server stopped gracefully")
+		logger.Info(bg, "HTTP server stopped gracefully", nil)
 	}
 
-	log.Println("Radio Control Container shutdown complete")
+	logger.Info(bg, "Radio Control Container shutdown complete", nil)
+}
+
+// waitForListener waits until server is listening. It returns the error
+// from serverErr if Start fails first, or an error after timeout.
+func waitForListener(server *api.Server, serverErr <-chan error, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-server.Ready():
+		return nil
+	case err := <-serverErr:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("HTTP server not listening after %v", timeout)
+	}
 }
 
 // getServerAddress returns the server address from environment or default.
@@ -138,3 +269,24 @@ func getServerAddress() string {
 	}
 	return DefaultAddr
 }
+
+// reloadConfig reloads the config store and applies the result to the
+// orchestrator and to the radio manager's channel maps. A failed reload
+// keeps the running configuration.
+func reloadConfig(ctx context.Context, logger *logging.Logger, store *config.Store, orchestrator *command.Orchestrator, radioManager *radio.Manager) {
+	cfg, err := store.Reload()
+	if err != nil {
+		logger.Error(ctx, "Configuration reload failed; keeping running configuration", logging.Fields{"error": err})
+		return
+	}
+	orchestrator.SetConfig(cfg)
+
+	// Re-derive band plan channel maps for every known radio
+	radioManager.SetBandPlan(cfg.SilvusBandPlan)
+	for _, r := range radioManager.List().Items {
+		if err := radioManager.RefreshCapabilities(r.ID, cfg.CommandTimeoutGetState); err != nil {
+			logger.Warn(ctx, "Failed to refresh channels after reload", logging.Fields{"radioId": r.ID, "error": err})
+		}
+	}
+	logger.Info(ctx, "Configuration reloaded", nil)
+}
@@ -18,12 +18,32 @@ type RadioCapabilities struct {
 	MinPowerDbm int       `json:"minPowerDbm"`
 	MaxPowerDbm int       `json:"maxPowerDbm"`
 	Channels    []Channel `json:"channels"`
+	Bands       []string  `json:"bands,omitempty"`
+
+	// Commands the radio accepts, from capability discovery; unset means
+	// the command is supported
+	SupportsSetPower   *bool `json:"supportsSetPower,omitempty"`
+	SupportsSetChannel *bool `json:"supportsSetChannel,omitempty"`
+}
+
+// CanSetPower reports whether the radio accepts power changes. Capabilities
+// that do not say, including nil ones, allow them.
+func (c *RadioCapabilities) CanSetPower() bool {
+	return c == nil || c.SupportsSetPower == nil || *c.SupportsSetPower
+}
+
+// CanSetChannel reports whether the radio accepts frequency or channel
+// changes. Capabilities that do not say, including nil ones, allow them.
+func (c *RadioCapabilities) CanSetChannel() bool {
+	return c == nil || c.SupportsSetChannel == nil || *c.SupportsSetChannel
 }
 
 // Channel represents a single channel mapping.
 type Channel struct {
 	Index        int     `json:"index"`
 	FrequencyMhz float64 `json:"frequencyMhz"`
+	Label        string  `json:"label,omitempty"` // Operator name, e.g. "Command Net"
+	Usage        string  `json:"usage,omitempty"` // Operator purpose, e.g. "primary voice"
 }
 
 // FrequencyProfile represents a supported frequency profile.
@@ -52,6 +72,26 @@ type IRadioAdapter interface {
 
 	// SupportedFrequencyProfiles returns allowed frequency/bandwidth/antenna combinations.
 	SupportedFrequencyProfiles(ctx context.Context) ([]FrequencyProfile, error)
+
+	// Ping checks that the radio is reachable, returning an error if not.
+	// Adapters that cannot check embed AdapterBase for a no-op default.
+	Ping(ctx context.Context) error
+}
+
+// ChannelIndexReader is optionally implemented by adapters whose radios track
+// the selected channel index natively, avoiding a lossy frequency reverse-lookup.
+type ChannelIndexReader interface {
+	// GetChannelIndex returns the radio's active channel index (1-based).
+	GetChannelIndex(ctx context.Context) (int, error)
+}
+
+// CommandSupportReporter is optionally implemented by adapters whose radios
+// do not accept every command, e.g. radios with fixed transmit power.
+// Capability discovery records the answers in RadioCapabilities; adapters
+// that do not implement it are taken to support every command.
+type CommandSupportReporter interface {
+	SupportsSetPower() bool
+	SupportsSetChannel() bool
 }
 
 // AdapterBase provides common functionality for adapter implementations.
@@ -81,6 +121,12 @@ func (a *AdapterBase) GetStatus() string {
 	return a.Status
 }
 
+// Ping is the default reachability check for adapters that cannot probe
+// their radio; it always reports the radio reachable.
+func (a *AdapterBase) Ping(ctx context.Context) error {
+	return nil
+}
+
 // SetStatus updates the radio status.
 func (a *AdapterBase) SetStatus(status string) {
 	a.Status = status
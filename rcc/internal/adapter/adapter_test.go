@@ -160,6 +160,10 @@ func TestAdapterBase(t *testing.T) {
 	if base.GetStatus() != "offline" {
 		t.Errorf("GetStatus after SetStatus returned %s, want offline", base.GetStatus())
 	}
+
+	if err := base.Ping(context.Background()); err != nil {
+		t.Errorf("Default Ping returned %v, want nil", err)
+	}
 }
 
 // TestRadioState ensures the RadioState struct works correctly.
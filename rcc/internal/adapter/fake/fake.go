@@ -6,6 +6,7 @@ package fake
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/radio-control/rcc/internal/adapter"
 )
@@ -14,6 +15,9 @@ import (
 type FakeAdapter struct {
 	adapter.AdapterBase
 
+	// Guards state and error simulation; dev mode serves it concurrently
+	mu sync.Mutex
+
 	// Current state
 	currentPower     float64
 	currentFrequency float64
@@ -62,6 +66,9 @@ func (f *FakeAdapter) GetState(ctx context.Context) (*adapter.RadioState, error)
 	default:
 	}
 
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	if f.simulateErrors {
 		return nil, f.getSimulatedError()
 	}
@@ -81,6 +88,9 @@ func (f *FakeAdapter) SetPower(ctx context.Context, dBm float64) error {
 	default:
 	}
 
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	if f.simulateErrors {
 		return f.getSimulatedError()
 	}
@@ -103,6 +113,9 @@ func (f *FakeAdapter) SetFrequency(ctx context.Context, frequencyMhz float64) er
 	default:
 	}
 
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	if f.simulateErrors {
 		return f.getSimulatedError()
 	}
@@ -130,6 +143,9 @@ func (f *FakeAdapter) ReadPowerActual(ctx context.Context) (float64, error) {
 	default:
 	}
 
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	if f.simulateErrors {
 		return 0, f.getSimulatedError()
 	}
@@ -146,6 +162,9 @@ func (f *FakeAdapter) SupportedFrequencyProfiles(ctx context.Context) ([]adapter
 	default:
 	}
 
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	if f.simulateErrors {
 		return nil, f.getSimulatedError()
 	}
@@ -160,16 +179,39 @@ func (f *FakeAdapter) SupportedFrequencyProfiles(ctx context.Context) ([]adapter
 	}, nil
 }
 
+// Ping reports the fake reachable unless it simulates an unavailable radio.
+func (f *FakeAdapter) Ping(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.simulateErrors && f.errorType == "UNAVAILABLE" {
+		return f.getSimulatedError()
+	}
+	return nil
+}
+
 // Helper methods for testing
 
 // SetErrorSimulation enables error simulation for testing.
 func (f *FakeAdapter) SetErrorSimulation(errorType string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	f.simulateErrors = true
 	f.errorType = errorType
 }
 
 // DisableErrorSimulation disables error simulation.
 func (f *FakeAdapter) DisableErrorSimulation() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	f.simulateErrors = false
 	f.errorType = ""
 }
@@ -192,11 +234,17 @@ func (f *FakeAdapter) getSimulatedError() error {
 
 // GetCurrentState returns the current internal state (for testing).
 func (f *FakeAdapter) GetCurrentState() (float64, float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	return f.currentPower, f.currentFrequency
 }
 
 // SetCurrentState sets the current internal state (for testing).
 func (f *FakeAdapter) SetCurrentState(power float64, frequency float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	f.currentPower = power
 	f.currentFrequency = frequency
 }
@@ -0,0 +1,48 @@
+package adapter
+
+import (
+	"context"
+	"sync"
+)
+
+// RawResponse is one vendor response exactly as an adapter received it,
+// kept for debugging adapter issues.
+type RawResponse struct {
+	Method   string `json:"method"`
+	Response string `json:"response"`
+}
+
+// RawResponses collects the vendor responses adapters receive under a
+// context from WithRawResponses. It is safe for concurrent use.
+type RawResponses struct {
+	mu        sync.Mutex
+	responses []RawResponse
+}
+
+type rawResponsesKey struct{}
+
+// WithRawResponses returns a context under which adapters that talk to a
+// vendor API record each raw response in the returned collector.
+func WithRawResponses(ctx context.Context) (context.Context, *RawResponses) {
+	raw := &RawResponses{}
+	return context.WithValue(ctx, rawResponsesKey{}, raw), raw
+}
+
+// RecordRawResponse records the vendor response to method when ctx carries
+// a collector from WithRawResponses, and does nothing otherwise.
+func RecordRawResponse(ctx context.Context, method string, response []byte) {
+	raw, ok := ctx.Value(rawResponsesKey{}).(*RawResponses)
+	if !ok {
+		return
+	}
+	raw.mu.Lock()
+	defer raw.mu.Unlock()
+	raw.responses = append(raw.responses, RawResponse{Method: method, Response: string(response)})
+}
+
+// All returns the responses recorded so far, in the order received.
+func (r *RawResponses) All() []RawResponse {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RawResponse(nil), r.responses...)
+}
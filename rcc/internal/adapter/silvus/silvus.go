@@ -0,0 +1,358 @@
+// Package silvus implements IRadioAdapter over the Silvus StreamCaster JSON-RPC API.
+//
+//   - ICD §6.1: "JSON-RPC 2.0 over HTTP POST to /streamscape_api"
+//   - Architecture §8.5: "Error normalization to INVALID_RANGE, BUSY, UNAVAILABLE, INTERNAL"
+package silvus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/logging"
+)
+
+// APIPath is the JSON-RPC endpoint exposed by Silvus radios.
+const APIPath = "/streamscape_api"
+
+// SilvusAdapter implements IRadioAdapter by issuing JSON-RPC calls to a Silvus radio.
+type SilvusAdapter struct {
+	adapter.AdapterBase
+
+	endpoint string
+	client   *http.Client
+	nextID   atomic.Int64
+}
+
+// Compile-time assertion that SilvusAdapter implements IRadioAdapter
+var _ adapter.IRadioAdapter = (*SilvusAdapter)(nil)
+
+// rpcRequest is a JSON-RPC 2.0 request.
+type rpcRequest struct {
+	JSONRPC string   `json:"jsonrpc"`
+	Method  string   `json:"method"`
+	Params  []string `json:"params,omitempty"`
+	ID      int64    `json:"id"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response.
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   json.RawMessage `json:"error,omitempty"`
+	ID      interface{}     `json:"id"`
+}
+
+// rpcError is a JSON-RPC 2.0 error object.
+type rpcError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// rpcProfile is a frequency profile as returned by supported_frequency_profiles (ICD §6.1.2).
+// The silvus-mock encodes its profiles with Go field names; frequencies and
+// bandwidth match those case-insensitively, but its AntennaMask key needs its
+// own field.
+type rpcProfile struct {
+	Frequencies     []string `json:"frequencies"`
+	Bandwidth       string   `json:"bandwidth"`
+	AntennaMask     string   `json:"antenna_mask"`
+	MockAntennaMask string   `json:"AntennaMask"`
+}
+
+// Connection pool defaults for NewTransport.
+const (
+	// Enough for a probe and a command or two in flight to the same radio
+	DefaultMaxIdleConnsPerHost = 4
+	DefaultIdleConnTimeout     = 90 * time.Second
+)
+
+// sharedTransport pools keep-alive connections for every adapter created by
+// NewSilvusAdapter.
+var sharedTransport = NewTransport()
+
+// NewTransport returns an HTTP transport tuned for JSON-RPC calls to radios:
+// the default transport with DefaultMaxIdleConnsPerHost idle connections per
+// radio kept for DefaultIdleConnTimeout.
+func NewTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	transport.IdleConnTimeout = DefaultIdleConnTimeout
+	return transport
+}
+
+// NewSilvusAdapter creates a Silvus adapter for the radio reachable at baseURL.
+// The timeout bounds each HTTP request in addition to the caller's context.
+// Adapters share one connection pool, so calls reuse keep-alive connections.
+func NewSilvusAdapter(radioID, baseURL string, timeout time.Duration) *SilvusAdapter {
+	return NewSilvusAdapterWithTransport(radioID, baseURL, timeout, sharedTransport)
+}
+
+// NewSilvusAdapterWithTransport is NewSilvusAdapter issuing calls through
+// transport, e.g. one from NewTransport with a custom pool size. A nil
+// transport uses the shared pool. The transport must be safe for concurrent
+// use, as the orchestrator and health probes call the adapter concurrently.
+func NewSilvusAdapterWithTransport(radioID, baseURL string, timeout time.Duration, transport http.RoundTripper) *SilvusAdapter {
+	if transport == nil {
+		transport = sharedTransport
+	}
+	return &SilvusAdapter{
+		AdapterBase: adapter.AdapterBase{
+			RadioID: radioID,
+			Model:   "Silvus",
+			Status:  "online",
+		},
+		endpoint: strings.TrimRight(baseURL, "/") + APIPath,
+		client:   &http.Client{Timeout: timeout, Transport: transport},
+	}
+}
+
+// GetState returns the current radio state.
+func (s *SilvusAdapter) GetState(ctx context.Context) (*adapter.RadioState, error) {
+	frequencyMhz, err := s.readFloat(ctx, "freq")
+	if err != nil {
+		return nil, err
+	}
+
+	powerDbm, err := s.readFloat(ctx, "power_dBm")
+	if err != nil {
+		return nil, err
+	}
+
+	return &adapter.RadioState{
+		PowerDbm:     powerDbm,
+		FrequencyMhz: frequencyMhz,
+	}, nil
+}
+
+// SetPower sets the transmit power in dBm.
+func (s *SilvusAdapter) SetPower(ctx context.Context, dBm float64) error {
+	_, err := s.call(ctx, "power_dBm", []string{formatFloat(dBm)})
+	return err
+}
+
+// SetFrequency sets the transmit frequency in MHz.
+// The radio enters a soft boot after a frequency change.
+func (s *SilvusAdapter) SetFrequency(ctx context.Context, frequencyMhz float64) error {
+	_, err := s.call(ctx, "freq", []string{formatFloat(frequencyMhz)})
+	return err
+}
+
+// ReadPowerActual reads the actual transmitted output power in dBm.
+func (s *SilvusAdapter) ReadPowerActual(ctx context.Context) (float64, error) {
+	return s.readFloat(ctx, "read_power_dBm")
+}
+
+// Ping checks the radio answers JSON-RPC. Only transport failures mean the
+// radio is unreachable; a vendor error still proves it responded.
+func (s *SilvusAdapter) Ping(ctx context.Context) error {
+	_, err := s.call(ctx, "read_power_dBm", nil)
+	if errors.Is(err, adapter.ErrUnavailable) {
+		return err
+	}
+	return nil
+}
+
+// SupportedFrequencyProfiles returns allowed frequency/bandwidth/antenna combinations.
+func (s *SilvusAdapter) SupportedFrequencyProfiles(ctx context.Context) ([]adapter.FrequencyProfile, error) {
+	result, err := s.call(ctx, "supported_frequency_profiles", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []rpcProfile
+	if err := json.Unmarshal(result, &raw); err != nil {
+		return nil, s.malformed("supported_frequency_profiles", err)
+	}
+
+	profiles := make([]adapter.FrequencyProfile, 0, len(raw))
+	for _, p := range raw {
+		profile, err := convertProfile(p)
+		if err != nil {
+			return nil, s.malformed("supported_frequency_profiles", err)
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, nil
+}
+
+// call issues a JSON-RPC request and returns the raw result.
+// Transport failures normalize to UNAVAILABLE; vendor errors go through NormalizeVendorError.
+func (s *SilvusAdapter) call(ctx context.Context, method string, params []string) (json.RawMessage, error) {
+	body, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+		ID:      s.nextID.Add(1),
+	})
+	if err != nil {
+		return nil, &adapter.VendorError{Code: adapter.ErrInternal, Original: err}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, &adapter.VendorError{Code: adapter.ErrInternal, Original: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if id := logging.CorrelationID(ctx); id != "" {
+		req.Header.Set(logging.CorrelationIDHeader, id)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		// Preserve context errors so callers can distinguish timeouts
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = ctxErr
+		}
+		return nil, &adapter.VendorError{Code: adapter.ErrUnavailable, Original: err}
+	}
+	// Reading the body to EOF returns the connection to the pool
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &adapter.VendorError{Code: adapter.ErrUnavailable, Original: err}
+	}
+	adapter.RecordRawResponse(ctx, method, data)
+
+	var rpcResp rpcResponse
+	if err := json.Unmarshal(data, &rpcResp); err != nil {
+		return nil, s.malformed(method, fmt.Errorf("HTTP %d: %w", resp.StatusCode, err))
+	}
+
+	if len(rpcResp.Error) > 0 && string(rpcResp.Error) != "null" {
+		return nil, s.vendorError(method, rpcResp.Error)
+	}
+
+	return rpcResp.Result, nil
+}
+
+// vendorError maps a JSON-RPC error payload to a normalized error.
+// Errors may be an object {code, message} or a bare string.
+func (s *SilvusAdapter) vendorError(method string, payload json.RawMessage) error {
+	var errObj rpcError
+	if err := json.Unmarshal(payload, &errObj); err == nil && errObj.Message != "" {
+		return adapter.NormalizeVendorError(fmt.Errorf("%s: %s", method, errObj.Message), errObj)
+	}
+
+	var message string
+	if err := json.Unmarshal(payload, &message); err == nil && message != "" {
+		return adapter.NormalizeVendorError(fmt.Errorf("%s: %s", method, message), message)
+	}
+
+	return adapter.NormalizeVendorError(fmt.Errorf("%s: %s", method, string(payload)), string(payload))
+}
+
+// malformed reports a response the adapter could not interpret.
+func (s *SilvusAdapter) malformed(method string, err error) error {
+	return &adapter.VendorError{
+		Code:     adapter.ErrInternal,
+		Original: fmt.Errorf("malformed %s response: %w", method, err),
+	}
+}
+
+// readFloat reads a single numeric value returned as ["<value>"].
+func (s *SilvusAdapter) readFloat(ctx context.Context, method string) (float64, error) {
+	result, err := s.call(ctx, method, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var values []string
+	if err := json.Unmarshal(result, &values); err != nil {
+		return 0, s.malformed(method, err)
+	}
+	if len(values) == 0 {
+		return 0, s.malformed(method, errors.New("empty result"))
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(values[0]), 64)
+	if err != nil {
+		return 0, s.malformed(method, err)
+	}
+	return value, nil
+}
+
+// convertProfile expands ICD frequency specs ("start:step:end" or single values).
+func convertProfile(p rpcProfile) (adapter.FrequencyProfile, error) {
+	profile := adapter.FrequencyProfile{}
+
+	for _, spec := range p.Frequencies {
+		freqs, err := expandFrequencySpec(spec)
+		if err != nil {
+			return profile, err
+		}
+		profile.Frequencies = append(profile.Frequencies, freqs...)
+	}
+
+	if p.Bandwidth != "" {
+		bandwidth, err := strconv.ParseFloat(p.Bandwidth, 64)
+		if err != nil {
+			return profile, fmt.Errorf("invalid bandwidth %q", p.Bandwidth)
+		}
+		profile.Bandwidth = bandwidth
+	}
+
+	antennaMask := p.AntennaMask
+	if antennaMask == "" {
+		antennaMask = p.MockAntennaMask
+	}
+	if antennaMask != "" {
+		// ICD §6.1.2: antenna mask is a hex string
+		mask, err := strconv.ParseInt(antennaMask, 16, 64)
+		if err != nil {
+			return profile, fmt.Errorf("invalid antenna mask %q", antennaMask)
+		}
+		profile.AntennaMask = int(mask)
+	}
+
+	return profile, nil
+}
+
+// expandFrequencySpec expands "start:step:end" into discrete frequencies.
+func expandFrequencySpec(spec string) ([]float64, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) == 1 {
+		freq, err := strconv.ParseFloat(strings.TrimSpace(spec), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid frequency %q", spec)
+		}
+		return []float64{freq}, nil
+	}
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid frequency range %q", spec)
+	}
+
+	start, err1 := strconv.ParseFloat(parts[0], 64)
+	step, err2 := strconv.ParseFloat(parts[1], 64)
+	end, err3 := strconv.ParseFloat(parts[2], 64)
+	if err1 != nil || err2 != nil || err3 != nil || step <= 0 || end < start {
+		return nil, fmt.Errorf("invalid frequency range %q", spec)
+	}
+
+	var freqs []float64
+	for i := 0; ; i++ {
+		freq := start + float64(i)*step
+		if freq > end {
+			break
+		}
+		freqs = append(freqs, freq)
+	}
+	return freqs, nil
+}
+
+// formatFloat formats a value without trailing zeros ("30", "2412.5").
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
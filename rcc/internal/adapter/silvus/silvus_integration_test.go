@@ -0,0 +1,130 @@
+//go:build integration
+
+package silvus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+)
+
+// silvusMockDir is the silvus-mock module relative to this package.
+const silvusMockDir = "../../../../silvus-mock"
+
+// freePort reserves an ephemeral TCP port and releases it for the mock to bind.
+func freePort(t *testing.T) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to reserve port: %v", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+// startSilvusMock builds and boots the silvus-mock emulator, returning its base URL.
+func startSilvusMock(t *testing.T) string {
+	t.Helper()
+
+	if _, err := os.Stat(silvusMockDir); err != nil {
+		t.Skipf("silvus-mock not available at %s: %v", silvusMockDir, err)
+	}
+
+	workDir := t.TempDir()
+	binary := filepath.Join(workDir, "silvusmock")
+
+	build := exec.Command("go", "build", "-o", binary, "./cmd/silvusmock")
+	build.Dir = silvusMockDir
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build silvus-mock: %v\n%s", err, out)
+	}
+
+	httpPort := freePort(t)
+	configPath := filepath.Join(workDir, "silvus-mock.yaml")
+	configYAML := fmt.Sprintf("network:\n  http:\n    port: %d\n  maintenance:\n    port: %d\n", httpPort, freePort(t))
+	if err := os.WriteFile(configPath, []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write silvus-mock config: %v", err)
+	}
+
+	cmd := exec.Command(binary)
+	cmd.Dir = workDir
+	cmd.Env = append(os.Environ(), "CBTIMING_CONFIG="+configPath)
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Failed to start silvus-mock: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", httpPort)
+
+	// Wait for the JSON-RPC endpoint to accept connections
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		resp, err := http.Post(baseURL+APIPath, "application/json", nil)
+		if err == nil {
+			resp.Body.Close()
+			return baseURL
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("silvus-mock did not become ready at %s", baseURL)
+	return ""
+}
+
+// TestSilvusAdapter_MockRoundTrip exercises set-power/get-state against the real emulator.
+func TestSilvusAdapter_MockRoundTrip(t *testing.T) {
+	baseURL := startSilvusMock(t)
+	a := NewSilvusAdapter("silvus-mock-01", baseURL, 5*time.Second)
+	ctx := context.Background()
+
+	if err := a.SetPower(ctx, 25); err != nil {
+		t.Fatalf("SetPower failed: %v", err)
+	}
+
+	state, err := a.GetState(ctx)
+	if err != nil {
+		t.Fatalf("GetState failed: %v", err)
+	}
+	if state.PowerDbm != 25 {
+		t.Errorf("Expected power 25 dBm after round-trip, got %v", state.PowerDbm)
+	}
+	if state.FrequencyMhz <= 0 {
+		t.Errorf("Expected a positive frequency, got %v", state.FrequencyMhz)
+	}
+
+	actual, err := a.ReadPowerActual(ctx)
+	if err != nil {
+		t.Fatalf("ReadPowerActual failed: %v", err)
+	}
+	if actual <= 0 || actual > 25 {
+		t.Errorf("Expected actual power at or below setpoint, got %v", actual)
+	}
+
+	profiles, err := a.SupportedFrequencyProfiles(ctx)
+	if err != nil {
+		t.Fatalf("SupportedFrequencyProfiles failed: %v", err)
+	}
+	if len(profiles) == 0 || len(profiles[0].Frequencies) == 0 {
+		t.Errorf("Expected frequency profiles from emulator, got %+v", profiles)
+	}
+}
+
+// TestSilvusAdapter_MockErrorNormalization verifies emulator errors map to standard codes.
+func TestSilvusAdapter_MockErrorNormalization(t *testing.T) {
+	baseURL := startSilvusMock(t)
+	a := NewSilvusAdapter("silvus-mock-01", baseURL, 5*time.Second)
+
+	if err := a.SetPower(context.Background(), 99); !errors.Is(err, adapter.ErrInvalidRange) {
+		t.Errorf("Expected ErrInvalidRange for out-of-range power, got %v", err)
+	}
+}
@@ -0,0 +1,355 @@
+package silvus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+)
+
+// stubRadio is a minimal JSON-RPC responder emulating the Silvus API.
+type stubRadio struct {
+	mu       sync.Mutex
+	power    string
+	freq     string
+	errors   map[string]interface{} // method -> error payload
+	requests []rpcRequest
+	delay    time.Duration
+}
+
+func newStubRadio() *stubRadio {
+	return &stubRadio{power: "30", freq: "2490.0", errors: map[string]interface{}{}}
+}
+
+func (s *stubRadio) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != APIPath || r.Method != http.MethodPost {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	var req rpcRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	if s.delay > 0 {
+		time.Sleep(s.delay)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, req)
+
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	if errPayload, ok := s.errors[req.Method]; ok {
+		resp["error"] = errPayload
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	switch req.Method {
+	case "power_dBm":
+		if len(req.Params) > 0 {
+			s.power = req.Params[0]
+			resp["result"] = []string{""}
+		} else {
+			resp["result"] = []string{s.power}
+		}
+	case "freq":
+		if len(req.Params) > 0 {
+			s.freq = req.Params[0]
+			resp["result"] = []string{""}
+		} else {
+			resp["result"] = []string{s.freq}
+		}
+	case "read_power_dBm":
+		resp["result"] = []string{"28"}
+	case "supported_frequency_profiles":
+		resp["result"] = []map[string]interface{}{
+			{"frequencies": []string{"2200:20:2260", "4700"}, "bandwidth": "-1", "antenna_mask": "F"},
+		}
+	default:
+		resp["error"] = map[string]interface{}{"code": -32601, "message": "Method not found"}
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func setupStub(t *testing.T) (*SilvusAdapter, *stubRadio) {
+	stub := newStubRadio()
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+	return NewSilvusAdapter("silvus-01", server.URL+"/", 2*time.Second), stub
+}
+
+func TestSetPowerAndGetState(t *testing.T) {
+	a, stub := setupStub(t)
+	ctx := context.Background()
+
+	if err := a.SetPower(ctx, 25); err != nil {
+		t.Fatalf("SetPower failed: %v", err)
+	}
+	if err := a.SetFrequency(ctx, 2412.5); err != nil {
+		t.Fatalf("SetFrequency failed: %v", err)
+	}
+
+	state, err := a.GetState(ctx)
+	if err != nil {
+		t.Fatalf("GetState failed: %v", err)
+	}
+	if state.PowerDbm != 25 || state.FrequencyMhz != 2412.5 {
+		t.Errorf("Expected state {25, 2412.5}, got %+v", state)
+	}
+
+	// Params are sent as ICD string values
+	if got := stub.requests[0]; got.Method != "power_dBm" || len(got.Params) != 1 || got.Params[0] != "25" {
+		t.Errorf("Unexpected power request: %+v", got)
+	}
+	if got := stub.requests[1]; got.Method != "freq" || got.Params[0] != "2412.5" {
+		t.Errorf("Unexpected freq request: %+v", got)
+	}
+	if stub.requests[0].ID == stub.requests[1].ID {
+		t.Errorf("Expected unique request IDs")
+	}
+}
+
+func TestReadPowerActual(t *testing.T) {
+	a, _ := setupStub(t)
+
+	power, err := a.ReadPowerActual(context.Background())
+	if err != nil {
+		t.Fatalf("ReadPowerActual failed: %v", err)
+	}
+	if power != 28 {
+		t.Errorf("Expected actual power 28, got %v", power)
+	}
+}
+
+func TestSupportedFrequencyProfiles(t *testing.T) {
+	a, _ := setupStub(t)
+
+	profiles, err := a.SupportedFrequencyProfiles(context.Background())
+	if err != nil {
+		t.Fatalf("SupportedFrequencyProfiles failed: %v", err)
+	}
+	if len(profiles) != 1 {
+		t.Fatalf("Expected 1 profile, got %d", len(profiles))
+	}
+
+	want := []float64{2200, 2220, 2240, 2260, 4700}
+	got := profiles[0].Frequencies
+	if len(got) != len(want) {
+		t.Fatalf("Expected frequencies %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected frequencies %v, got %v", want, got)
+			break
+		}
+	}
+	if profiles[0].Bandwidth != -1 {
+		t.Errorf("Expected bandwidth -1, got %v", profiles[0].Bandwidth)
+	}
+	if profiles[0].AntennaMask != 15 {
+		t.Errorf("Expected antenna mask 15, got %d", profiles[0].AntennaMask)
+	}
+}
+
+func TestMockProfileFieldNames(t *testing.T) {
+	// silvus-mock encodes config.FrequencyProfile without json tags
+	payload := `{"Frequencies":["2200:20:2260"],"Bandwidth":"-1","AntennaMask":"3"}`
+
+	var raw rpcProfile
+	if err := json.Unmarshal([]byte(payload), &raw); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	profile, err := convertProfile(raw)
+	if err != nil {
+		t.Fatalf("convertProfile failed: %v", err)
+	}
+
+	if len(profile.Frequencies) != 4 {
+		t.Errorf("Expected 4 frequencies, got %v", profile.Frequencies)
+	}
+	if profile.Bandwidth != -1 {
+		t.Errorf("Expected bandwidth -1, got %v", profile.Bandwidth)
+	}
+	if profile.AntennaMask != 3 {
+		t.Errorf("Expected antenna mask 3, got %d", profile.AntennaMask)
+	}
+}
+
+func TestVendorErrorNormalization(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload interface{}
+		want    error
+	}{
+		{"object invalid range", map[string]interface{}{"code": -32602, "message": "INVALID_RANGE"}, adapter.ErrInvalidRange},
+		{"object unavailable", map[string]interface{}{"code": -32602, "message": "UNAVAILABLE"}, adapter.ErrUnavailable},
+		{"object busy", map[string]interface{}{"code": -32602, "message": "BUSY"}, adapter.ErrBusy},
+		{"bare string", "INVALID_RANGE", adapter.ErrInvalidRange},
+		{"unknown token", map[string]interface{}{"code": -32603, "message": "SOMETHING_ODD"}, adapter.ErrInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, stub := setupStub(t)
+			stub.errors["power_dBm"] = tt.payload
+
+			err := a.SetPower(context.Background(), 50)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, err)
+			}
+
+			var vendorErr *adapter.VendorError
+			if !errors.As(err, &vendorErr) {
+				t.Fatalf("Expected *adapter.VendorError, got %T", err)
+			}
+			if vendorErr.Details == nil {
+				t.Errorf("Expected vendor payload in error details")
+			}
+		})
+	}
+}
+
+func TestTransportFailureIsUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	a := NewSilvusAdapter("silvus-01", url, time.Second)
+	if _, err := a.GetState(context.Background()); !errors.Is(err, adapter.ErrUnavailable) {
+		t.Errorf("Expected ErrUnavailable for unreachable radio, got %v", err)
+	}
+}
+
+func TestPing(t *testing.T) {
+	a, stub := setupStub(t)
+
+	if err := a.Ping(context.Background()); err != nil {
+		t.Errorf("Expected reachable radio, got %v", err)
+	}
+
+	// A vendor error still means the radio answered
+	stub.errors["read_power_dBm"] = map[string]interface{}{"code": -32000, "message": "RF_BUSY"}
+	if err := a.Ping(context.Background()); err != nil {
+		t.Errorf("Expected vendor error to count as reachable, got %v", err)
+	}
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+	down := NewSilvusAdapter("silvus-01", url, time.Second)
+	if err := down.Ping(context.Background()); !errors.Is(err, adapter.ErrUnavailable) {
+		t.Errorf("Expected ErrUnavailable for unreachable radio, got %v", err)
+	}
+}
+
+func TestContextTimeoutPreserved(t *testing.T) {
+	a, stub := setupStub(t)
+	stub.delay = 200 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := a.SetPower(ctx, 20)
+	if !errors.Is(err, adapter.ErrUnavailable) {
+		t.Fatalf("Expected ErrUnavailable on timeout, got %v", err)
+	}
+	var vendorErr *adapter.VendorError
+	if !errors.As(err, &vendorErr) || !errors.Is(vendorErr.Original, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded as original error, got %v", err)
+	}
+}
+
+func TestMalformedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","result":["not-a-number"],"id":1}`))
+	}))
+	defer server.Close()
+
+	a := NewSilvusAdapter("silvus-01", server.URL, time.Second)
+	if _, err := a.ReadPowerActual(context.Background()); !errors.Is(err, adapter.ErrInternal) {
+		t.Errorf("Expected ErrInternal for malformed result, got %v", err)
+	}
+}
+
+// countingTransport counts requests passed to its base transport.
+type countingTransport struct {
+	base     http.RoundTripper
+	requests atomic.Int64
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.requests.Add(1)
+	return c.base.RoundTrip(req)
+}
+
+func TestConnectionsReusedAcrossCalls(t *testing.T) {
+	var conns atomic.Int64
+	server := httptest.NewUnstartedServer(newStubRadio())
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+
+	transport := &countingTransport{base: NewTransport()}
+	a := NewSilvusAdapterWithTransport("silvus-01", server.URL, 2*time.Second, transport)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		if _, err := a.GetState(ctx); err != nil {
+			t.Fatalf("GetState %d failed: %v", i+1, err)
+		}
+	}
+	if transport.requests.Load() != 10 {
+		t.Errorf("Expected 10 requests through the transport, got %d", transport.requests.Load())
+	}
+	if conns.Load() != 1 {
+		t.Errorf("Expected sequential calls to share 1 connection, got %d", conns.Load())
+	}
+
+	// Concurrent calls share the client safely
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := a.GetState(ctx); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("Concurrent GetState failed: %v", err)
+	}
+}
+
+func BenchmarkGetState(b *testing.B) {
+	server := httptest.NewServer(newStubRadio())
+	b.Cleanup(server.Close)
+	a := NewSilvusAdapter("silvus-01", server.URL, 2*time.Second)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := a.GetState(ctx); err != nil {
+			b.Fatalf("GetState failed: %v", err)
+		}
+	}
+}
@@ -176,6 +176,23 @@ func (s *SilvusMock) ReadPowerActual(ctx context.Context) (float64, error) {
 	return s.powerDbm, nil
 }
 
+// Ping reports the mock reachable unless it simulates an unavailable radio.
+func (s *SilvusMock) Ping(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	s.mu.RLock()
+	mode := s.faultMode
+	s.mu.RUnlock()
+	if mode == "ReturnUnavailable" {
+		return s.checkFaultMode("Ping")
+	}
+	return nil
+}
+
 // SupportedFrequencyProfiles returns allowed frequency/bandwidth/antenna combinations.
 func (s *SilvusMock) SupportedFrequencyProfiles(ctx context.Context) ([]adapter.FrequencyProfile, error) {
 	// Check for context cancellation
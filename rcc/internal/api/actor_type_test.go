@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/audit"
+	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/test/fixtures"
+)
+
+func TestActorTypeRecordedInAudit(t *testing.T) {
+	server, _, orch, _ := setupAPITest(t)
+	server.authMiddleware = fixtures.NewAuthMiddleware()
+	auditLogger, err := audit.NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create audit logger: %v", err)
+	}
+	defer func() { _ = auditLogger.Close() }()
+	orch.SetAuditLogger(auditLogger)
+
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+	setPower := func(token, actorType string) int {
+		req := httptest.NewRequest("POST", "/api/v1/radios/silvus-001/power", strings.NewReader(`{"powerDbm":20}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		if actorType != "" {
+			req.Header.Set(auth.ActorTypeHeader, actorType)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := setPower("controller-token", "automation"); code != http.StatusOK {
+		t.Fatalf("Expected declared automation to succeed, got %d", code)
+	}
+	if code := setPower("controller-token", ""); code != http.StatusOK {
+		t.Fatalf("Expected interactive default to succeed, got %d", code)
+	}
+	if code := setPower("service-token", ""); code != http.StatusOK {
+		t.Fatalf("Expected service default to succeed, got %d", code)
+	}
+	if code := setPower("service-token", "human"); code != http.StatusForbidden {
+		t.Errorf("Expected service token claiming human to be forbidden, got %d", code)
+	}
+	if code := setPower("controller-token", "robot"); code != http.StatusBadRequest {
+		t.Errorf("Expected invalid actor type to be rejected, got %d", code)
+	}
+
+	entries, err := audit.NewReader(auditLogger.GetFilePath()).Query(audit.Filter{Action: "setPower"})
+	if err != nil {
+		t.Fatalf("Failed to read audit log: %v", err)
+	}
+	want := []string{auth.ActorTypeAutomation, auth.ActorTypeHuman, auth.ActorTypeAutomation}
+	if len(entries) != len(want) {
+		t.Fatalf("Expected %d setPower audit entries, got %d", len(want), len(entries))
+	}
+	for i, entry := range entries {
+		if entry.ActorType != want[i] {
+			t.Errorf("Entry %d: expected actor type %q, got %q", i, want[i], entry.ActorType)
+		}
+	}
+}
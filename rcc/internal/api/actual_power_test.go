@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/adapter/silvusmock"
+)
+
+// measuringAdapter reports a measured power that differs from the setpoint.
+type measuringAdapter struct {
+	*silvusmock.SilvusMock
+	actualDbm float64
+	actualErr error
+}
+
+func (a measuringAdapter) ReadPowerActual(ctx context.Context) (float64, error) {
+	return a.actualDbm, a.actualErr
+}
+
+func getPower(t *testing.T, server *Server, query string) map[string]interface{} {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/radios/silvus-001/power"+query, nil)
+	w := httptest.NewRecorder()
+	server.handleRadioEndpoints(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	data, ok := resp.Data.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected object data, got %v", resp.Data)
+	}
+	return data
+}
+
+func TestGetActualPower(t *testing.T) {
+	server, rm, orch, radioAdapter := setupAPITest(t)
+	mock := radioAdapter.(*silvusmock.SilvusMock)
+	if err := orch.SetPower(context.Background(), "silvus-001", 20); err != nil {
+		t.Fatalf("SetPower failed: %v", err)
+	}
+	if err := rm.SetAdapter("silvus-001", measuringAdapter{SilvusMock: mock, actualDbm: 18.5}); err != nil {
+		t.Fatalf("SetAdapter failed: %v", err)
+	}
+
+	// Default read is the setpoint, unmarked
+	data := getPower(t, server, "")
+	if data["powerDbm"] != 20.0 || data["source"] != nil {
+		t.Errorf("Expected setpoint 20 without source, got %v", data)
+	}
+
+	data = getPower(t, server, "?actual=true")
+	if data["powerDbm"] != 18.5 || data["source"] != "measured" || data["warning"] != nil {
+		t.Errorf("Expected measured 18.5, got %v", data)
+	}
+
+	// A failed measurement falls back to the setpoint with a warning
+	if err := rm.SetAdapter("silvus-001", measuringAdapter{SilvusMock: mock, actualErr: errors.New("power meter offline")}); err != nil {
+		t.Fatalf("SetAdapter failed: %v", err)
+	}
+	data = getPower(t, server, "?actual=true")
+	if data["powerDbm"] != 20.0 || data["source"] != "setpoint" || data["warning"] == nil {
+		t.Errorf("Expected setpoint fallback with warning, got %v", data)
+	}
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/audit"
+	"github.com/radio-control/rcc/test/fixtures"
+)
+
+func TestAuditActorFromClaims(t *testing.T) {
+	server, _, orch, _ := setupAPITest(t)
+	auditLogger, err := audit.NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create audit logger: %v", err)
+	}
+	defer func() { _ = auditLogger.Close() }()
+	orch.SetAuditLogger(auditLogger)
+
+	post := func() {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/radios/silvus-001/power", strings.NewReader(`{"powerDbm": 20}`))
+		req.Header.Set("Authorization", "Bearer controller-token")
+		w := httptest.NewRecorder()
+		server.handleRadioEndpoints(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	// Without auth the actor is anonymous
+	post()
+	server.authMiddleware = fixtures.NewAuthMiddleware()
+	post()
+
+	entries, err := audit.NewReader(auditLogger.GetFilePath()).Query(audit.Filter{Action: "setPower"})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 setPower entries, got %d", len(entries))
+	}
+	if entries[0].Actor != audit.DefaultAnonymousActor {
+		t.Errorf("Expected anonymous actor without auth, got %q", entries[0].Actor)
+	}
+	if entries[1].Actor != "admin-456" || entries[1].User != "admin-456" {
+		t.Errorf("Expected token subject as actor, got actor %q user %q", entries[1].Actor, entries[1].User)
+	}
+}
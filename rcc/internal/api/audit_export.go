@@ -0,0 +1,175 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/radio-control/rcc/internal/audit"
+	"github.com/radio-control/rcc/internal/auth"
+)
+
+// AuditExportPath is where the audit log export is mounted when enabled.
+const AuditExportPath = APIBasePath + "/audit/export"
+
+// Audit export formats selected with ?format=.
+const (
+	AuditFormatJSONLines = "jsonl"
+	AuditFormatCSV       = "csv"
+)
+
+// auditExportFlushEvery is how many entries are written between flushes, so
+// exports reach the client incrementally instead of buffering in memory.
+const auditExportFlushEvery = 100
+
+// auditCSVHeader names the CSV export columns; params is JSON encoded.
+var auditCSVHeader = []string{"ts", "user", "actor", "actorType", "radioId", "action", "params", "outcome", "code", "correlationId"}
+
+// EnableAuditExport serves the audit log read by reader at AuditExportPath.
+// With authentication configured, exports need a token with the audit:read
+// scope. Must be called before Start.
+func (s *Server) EnableAuditExport(reader *audit.Reader) {
+	s.auditReader = reader
+}
+
+// handleAuditExport returns the export handler chain.
+func (s *Server) handleAuditExport() http.HandlerFunc {
+	handler := s.withRateLimit(false, s.serveAuditExport)
+	if s.authMiddleware != nil {
+		return s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeAuditRead)(handler))
+	}
+	return handler
+}
+
+// serveAuditExport handles GET /audit/export, streaming the entries matching
+// the since, until, radioId and action query parameters in log order.
+func (s *Server) serveAuditExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only GET method is allowed", nil)
+		return
+	}
+
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format == "" {
+		format = AuditFormatJSONLines
+	}
+	if format != AuditFormatJSONLines && format != AuditFormatCSV {
+		WriteError(w, http.StatusBadRequest, "INVALID_RANGE",
+			fmt.Sprintf("format must be %q or %q", AuditFormatJSONLines, AuditFormatCSV), nil)
+		return
+	}
+
+	filter := audit.Filter{RadioID: query.Get("radioId"), Action: query.Get("action")}
+	for name, bound := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "BAD_REQUEST",
+				fmt.Sprintf("%s must be an RFC 3339 timestamp", name), nil)
+			return
+		}
+		*bound = t
+	}
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Until.After(filter.Since) {
+		WriteError(w, http.StatusBadRequest, "INVALID_RANGE", "until must be after since", nil)
+		return
+	}
+
+	export := &auditExport{w: w, format: format, writeTimeout: s.writeTimeout}
+	err := s.auditReader.Each(filter, export.write)
+	if err != nil && !export.started {
+		WriteError(w, http.StatusInternalServerError, "INTERNAL", "Failed to read audit log", nil)
+		return
+	}
+	// Headers are committed once streaming starts; a failure then just ends the export
+	_ = export.finish()
+}
+
+// auditExport writes entries to the response as they are read. Headers are
+// sent with the first entry so a log that cannot be opened still gets an
+// error response.
+type auditExport struct {
+	w            http.ResponseWriter
+	format       string
+	writeTimeout time.Duration
+
+	started bool
+	pending int
+	csv     *csv.Writer
+}
+
+func (e *auditExport) start() {
+	e.started = true
+	if e.format == AuditFormatCSV {
+		e.w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		e.w.Header().Set("Content-Disposition", `attachment; filename="audit.csv"`)
+		e.csv = csv.NewWriter(e.w)
+		_ = e.csv.Write(auditCSVHeader)
+	} else {
+		e.w.Header().Set("Content-Type", "application/x-ndjson")
+		e.w.Header().Set("Content-Disposition", `attachment; filename="audit.jsonl"`)
+	}
+	e.w.WriteHeader(http.StatusOK)
+}
+
+func (e *auditExport) write(entry audit.AuditEntry) error {
+	if !e.started {
+		e.start()
+	}
+
+	if e.csv != nil {
+		params, err := json.Marshal(entry.Params)
+		if err != nil {
+			return err
+		}
+		if err := e.csv.Write([]string{
+			entry.Timestamp.Format(time.RFC3339Nano), entry.User, entry.Actor, entry.ActorType,
+			entry.RadioID, entry.Action, string(params), entry.Outcome, entry.Code, entry.CorrelationID,
+		}); err != nil {
+			return err
+		}
+	} else if err := json.NewEncoder(e.w).Encode(entry); err != nil {
+		return err
+	}
+
+	if e.pending++; e.pending >= auditExportFlushEvery {
+		return e.flush()
+	}
+	return nil
+}
+
+// flush sends buffered entries and extends the write deadline, so a long
+// export outlasts the server write timeout while a stalled client does not.
+func (e *auditExport) flush() error {
+	e.pending = 0
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	rc := http.NewResponseController(e.w)
+	if e.writeTimeout > 0 {
+		_ = rc.SetWriteDeadline(time.Now().Add(e.writeTimeout))
+	}
+	if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// finish completes an export, sending headers for one with no entries.
+func (e *auditExport) finish() error {
+	if !e.started {
+		e.start()
+	}
+	return e.flush()
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/audit"
+)
+
+func writeAuditLog(t *testing.T, entries []audit.AuditEntry) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create audit log: %v", err)
+	}
+	defer file.Close()
+	enc := json.NewEncoder(file)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			t.Fatalf("Failed to write audit entry: %v", err)
+		}
+	}
+	return path
+}
+
+func TestAuditExport(t *testing.T) {
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	var entries []audit.AuditEntry
+	for i := 0; i < 250; i++ {
+		entries = append(entries, audit.AuditEntry{
+			Timestamp: base.Add(time.Duration(i) * time.Minute),
+			User:      "user-123",
+			Actor:     "user-123",
+			RadioID:   "silvus-001",
+			Action:    "setPower",
+			Params:    map[string]interface{}{"powerDbm": float64(i % 40)},
+			Outcome:   "SUCCESS",
+			Code:      "OK",
+		})
+	}
+	server, _, _, _ := setupAPITest(t)
+	server.EnableAuditExport(audit.NewReader(writeAuditLog(t, entries)))
+	handler := server.handleAuditExport()
+
+	// 200 entries, more than one flush, from minute 20 to minute 220
+	want := entries[20:220]
+	rangeQuery := "since=" + base.Add(20*time.Minute).Format(time.RFC3339) +
+		"&until=" + base.Add(220*time.Minute).Format(time.RFC3339)
+
+	t.Run("jsonl", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, AuditExportPath+"?"+rangeQuery, nil))
+		if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/x-ndjson" {
+			t.Fatalf("Expected 200 JSON lines, got %d %q: %s", w.Code, w.Header().Get("Content-Type"), w.Body.String())
+		}
+		var got []audit.AuditEntry
+		scanner := bufio.NewScanner(w.Body)
+		for scanner.Scan() {
+			var entry audit.AuditEntry
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				t.Fatalf("Invalid JSON line %q: %v", scanner.Text(), err)
+			}
+			got = append(got, entry)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("Expected %d entries, got %d", len(want), len(got))
+		}
+		for i := range want {
+			if !got[i].Timestamp.Equal(want[i].Timestamp) || got[i].Params["powerDbm"] != want[i].Params["powerDbm"] {
+				t.Fatalf("Entry %d: expected %+v, got %+v", i, want[i], got[i])
+			}
+		}
+		if !w.Flushed {
+			t.Error("Expected the export to be flushed")
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, AuditExportPath+"?format=csv&"+rangeQuery, nil))
+		if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/csv") {
+			t.Fatalf("Expected 200 CSV, got %d %q: %s", w.Code, w.Header().Get("Content-Type"), w.Body.String())
+		}
+		records, err := csv.NewReader(w.Body).ReadAll()
+		if err != nil {
+			t.Fatalf("Invalid CSV: %v", err)
+		}
+		if len(records) != len(want)+1 || strings.Join(records[0], ",") != strings.Join(auditCSVHeader, ",") {
+			t.Fatalf("Expected header and %d rows, got %d records starting %v", len(want), len(records), records[0])
+		}
+		first := records[1]
+		if first[0] != want[0].Timestamp.Format(time.RFC3339Nano) || first[5] != "setPower" || first[6] != `{"powerDbm":20}` {
+			t.Errorf("Unexpected first row %v", first)
+		}
+	})
+
+	for query, code := range map[string]string{
+		"format=xml":      "INVALID_RANGE",
+		"since=yesterday": "BAD_REQUEST",
+		"since=" + base.Add(time.Hour).Format(time.RFC3339) + "&until=" + base.Format(time.RFC3339): "INVALID_RANGE",
+	} {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, AuditExportPath+"?"+query, nil))
+		var resp Response
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusBadRequest || resp.Code != code {
+			t.Errorf("%s: expected 400 %s, got %d %s", query, code, w.Code, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// oversizedBody returns a syntactically valid JSON object of roughly size bytes.
+func oversizedBody(size int) []byte {
+	return []byte(`{"powerDbm": 20, "padding": "` + strings.Repeat("x", size) + `"}`)
+}
+
+func TestOversizedBodiesRejected(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	for _, path := range []string{
+		"/api/v1/radios/select",
+		"/api/v1/radios/silvus-001/power",
+		"/api/v1/radios/silvus-001/channel",
+	} {
+		t.Run(path, func(t *testing.T) {
+			req := httptest.NewRequest("POST", path, bytes.NewReader(oversizedBody(1<<20)))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			if w.Code != http.StatusRequestEntityTooLarge {
+				t.Fatalf("Expected 413, got %d: %s", w.Code, w.Body.String())
+			}
+			var response Response
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Result != "error" || response.Code != "PAYLOAD_TOO_LARGE" {
+				t.Errorf("Expected PAYLOAD_TOO_LARGE envelope, got result=%q code=%q", response.Result, response.Code)
+			}
+		})
+	}
+}
+
+func TestSmallMalformedBodyStillBadRequest(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+
+	for _, body := range []string{`{"powerDbm": `, `{"powerDbm": 20} trailing`, `[20]`} {
+		req := httptest.NewRequest("POST", "/api/v1/radios/silvus-001/power", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		server.handleSetPower(w, req, "silvus-001")
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Body %q: expected 400, got %d", body, w.Code)
+			continue
+		}
+		var response Response
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if response.Code != "BAD_REQUEST" {
+			t.Errorf("Body %q: expected BAD_REQUEST, got %q", body, response.Code)
+		}
+	}
+}
+
+func TestSetMaxBodyBytes(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	if server.maxBodyBytes != DefaultMaxBodyBytes {
+		t.Errorf("Expected default limit %d, got %d", DefaultMaxBodyBytes, server.maxBodyBytes)
+	}
+
+	server.SetMaxBodyBytes(16)
+	req := httptest.NewRequest("POST", "/api/v1/radios/silvus-001/power", strings.NewReader(`{"powerDbm": 20.000000}`))
+	w := httptest.NewRecorder()
+	server.handleSetPower(w, req, "silvus-001")
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected 413 with 16-byte limit, got %d", w.Code)
+	}
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+)
+
+// blockingChannelAdapter blocks SetFrequency until the command context ends.
+type blockingChannelAdapter struct {
+	adapter.IRadioAdapter
+	started chan struct{}
+}
+
+func (b *blockingChannelAdapter) SetFrequency(ctx context.Context, frequencyMhz float64) error {
+	close(b.started)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestCancelEndpointAbortsInFlightCommand(t *testing.T) {
+	server, rm, _, radioAdapter := setupAPITest(t)
+	slow := &blockingChannelAdapter{IRadioAdapter: radioAdapter, started: make(chan struct{})}
+	if err := rm.SetAdapter("silvus-001", slow); err != nil {
+		t.Fatalf("Failed to set adapter: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	// Subscribe to telemetry to observe the fault event
+	sseCtx, stopSSE := context.WithCancel(context.Background())
+	defer stopSSE()
+	sseReq, _ := http.NewRequestWithContext(sseCtx, "GET", ts.URL+"/api/v1/telemetry", nil)
+	sseResp, err := http.DefaultClient.Do(sseReq)
+	if err != nil {
+		t.Fatalf("Failed to subscribe to telemetry: %v", err)
+	}
+	defer sseResp.Body.Close()
+	events := make(chan string, 16)
+	go func() {
+		scanner := bufio.NewScanner(sseResp.Body)
+		eventType := ""
+		for scanner.Scan() {
+			line := scanner.Text()
+			if strings.HasPrefix(line, "event: ") {
+				eventType = strings.TrimPrefix(line, "event: ")
+			} else if strings.HasPrefix(line, "data: ") && eventType == "fault" {
+				events <- strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+
+	// Start a slow channel change
+	result := make(chan int, 1)
+	go func() {
+		resp, err := http.Post(ts.URL+"/api/v1/radios/silvus-001/channel", "application/json",
+			strings.NewReader(`{"frequencyMhz": 2437}`))
+		if err != nil {
+			result <- 0
+			return
+		}
+		resp.Body.Close()
+		result <- resp.StatusCode
+	}()
+
+	select {
+	case <-slow.started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("SetChannel never reached the adapter")
+	}
+
+	resp, err := http.Post(ts.URL+"/api/v1/radios/silvus-001/cancel", "application/json", nil)
+	if err != nil {
+		t.Fatalf("Cancel request failed: %v", err)
+	}
+	var response struct {
+		Result string `json:"result"`
+		Data   struct {
+			RadioID  string   `json:"radioId"`
+			Canceled []string `json:"canceled"`
+		} `json:"data"`
+	}
+	json.NewDecoder(resp.Body).Decode(&response)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200 from cancel, got %d", resp.StatusCode)
+	}
+	if response.Data.RadioID != "silvus-001" || len(response.Data.Canceled) != 1 || response.Data.Canceled[0] != "setChannel" {
+		t.Errorf("Unexpected cancel response: %+v", response)
+	}
+
+	select {
+	case status := <-result:
+		if status != http.StatusConflict {
+			t.Errorf("Expected canceled command to return 409, got %d", status)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Canceled command did not return promptly")
+	}
+
+	select {
+	case data := <-events:
+		if !strings.Contains(data, `"code":"CANCELED"`) {
+			t.Errorf("Expected CANCELED fault, got %s", data)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected fault event for canceled command")
+	}
+}
+
+func TestCancelEndpointWithoutInFlightCommand(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/radios/silvus-001/cancel", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 with no command in flight, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/radios/silvus-001/cancel", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for GET, got %d", w.Code)
+	}
+}
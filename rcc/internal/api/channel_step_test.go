@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func postChannelStep(t *testing.T, server *Server, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/radios/silvus-001/channel/step", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	server.handleRadioEndpoints(w, req)
+	return w
+}
+
+func TestChannelStep(t *testing.T) {
+	server, rm, orch, _ := setupAPITest(t)
+	r, err := rm.GetRadio("silvus-001")
+	if err != nil || len(r.Capabilities.Channels) < 2 {
+		t.Fatalf("Expected silvus-001 with a channel map, got %+v (%v)", r, err)
+	}
+	first, second := r.Capabilities.Channels[0], r.Capabilities.Channels[1]
+	if err := orch.SetChannelByIndex(context.Background(), "silvus-001", first.Index, rm); err != nil {
+		t.Fatalf("Failed to tune channel %d: %v", first.Index, err)
+	}
+
+	w := postChannelStep(t, server, `{"direction": "next"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	data, _ := resp.Data.(map[string]interface{})
+	if data["channelIndex"] != float64(second.Index) || data["frequencyMhz"] != second.FrequencyMhz {
+		t.Errorf("Expected channel %d at %v MHz, got %v", second.Index, second.FrequencyMhz, data)
+	}
+
+	for body, code := range map[string]string{
+		`{"direction": "up"}`: "INVALID_RANGE",
+		`{}`:                  "BAD_REQUEST",
+	} {
+		w := postChannelStep(t, server, body)
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if w.Code != http.StatusBadRequest || resp.Code != code {
+			t.Errorf("%s: expected 400 %s, got %d %s", body, code, w.Code, resp.Code)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/adapter/silvusmock"
+)
+
+// fixedPowerAdapter is a radio with fixed transmit power.
+type fixedPowerAdapter struct {
+	adapter.IRadioAdapter
+	powerCalls atomic.Int32
+}
+
+func (a *fixedPowerAdapter) SupportsSetPower() bool   { return false }
+func (a *fixedPowerAdapter) SupportsSetChannel() bool { return true }
+
+func (a *fixedPowerAdapter) SetPower(ctx context.Context, dBm float64) error {
+	a.powerCalls.Add(1)
+	return a.IRadioAdapter.SetPower(ctx, dBm)
+}
+
+func TestUnsupportedCommandReturnsNotImplemented(t *testing.T) {
+	server, rm, _, _ := setupAPITest(t)
+	fixed := &fixedPowerAdapter{IRadioAdapter: silvusmock.NewSilvusMock("fixed-001", []adapter.Channel{{Index: 1, FrequencyMhz: 2412}})}
+	if err := rm.LoadCapabilities("fixed-001", fixed, 5*time.Second); err != nil {
+		t.Fatalf("LoadCapabilities failed: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, APIBasePath+"/radios/fixed-001/power", strings.NewReader(`{"powerDbm": 20}`)))
+	if w.Code != http.StatusNotImplemented {
+		t.Fatalf("Expected 501 for a power change on a fixed-power radio, got %d: %s", w.Code, w.Body.String())
+	}
+	var response Response
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Code != "NOT_SUPPORTED" {
+		t.Errorf("Expected code NOT_SUPPORTED, got %q", response.Code)
+	}
+	if calls := fixed.powerCalls.Load(); calls != 0 {
+		t.Errorf("Expected the adapter not to be called, got %d SetPower calls", calls)
+	}
+
+	// Supported commands on the same radio still reach the adapter
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, APIBasePath+"/radios/fixed-001/channel", strings.NewReader(`{"frequencyMhz": 2412}`)))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200 for a channel change, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Radios whose adapters do not report support accept power changes
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, APIBasePath+"/radios/silvus-001/power", strings.NewReader(`{"powerDbm": 20}`)))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200 for silvus-001, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// ConfigPath is where the running configuration is served when enabled.
+const ConfigPath = APIBasePath + "/config"
+
+// ConfigPort returns the running configuration.
+type ConfigPort interface {
+	Current() *config.TimingConfig
+}
+
+var _ ConfigPort = (*config.Store)(nil)
+
+// EnableConfigExport serves the configuration held by source at ConfigPath,
+// so clients see the values in effect after env overrides and reloads. With
+// authentication configured it needs the read scope. Must be called before
+// Start.
+func (s *Server) EnableConfigExport(source ConfigPort) {
+	s.configSource = source
+}
+
+// handleConfig returns the config handler chain.
+func (s *Server) handleConfig() http.HandlerFunc {
+	handler := s.withRateLimit(false, s.serveConfig)
+	if s.authMiddleware != nil {
+		return s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(handler))
+	}
+	return handler
+}
+
+// serveConfig handles GET /config. timing is the configuration in the shape
+// of config.json, durations in nanoseconds; derived holds limits the server
+// computes from it and its own settings. Keys and secrets are never included.
+func (s *Server) serveConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only GET method is allowed", nil)
+		return
+	}
+
+	cfg := s.configSource.Current()
+	timing, err := configFields(cfg)
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "INTERNAL", "Failed to encode configuration", nil)
+		return
+	}
+
+	WriteSuccess(w, map[string]interface{}{
+		"timing": timing,
+		"derived": map[string]interface{}{
+			"requestTimeoutMs": s.requestTimeout().Milliseconds(),
+			"maxBodyBytes":     s.bodyLimit(),
+			"eventSigning":     cfg.EventHMACKey != "",
+		},
+	})
+}
+
+// configFields returns cfg as a JSON object. Fields tagged json:"-", such as
+// the event HMAC key, are left out, as are any whose names look like
+// credentials.
+func configFields(cfg *config.TimingConfig) (interface{}, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return sanitizeVendorDetails(fields), nil
+}
+
+// bodyLimit returns the request body size limit in effect.
+func (s *Server) bodyLimit() int64 {
+	if s.maxBodyBytes <= 0 {
+		return DefaultMaxBodyBytes
+	}
+	return s.maxBodyBytes
+}
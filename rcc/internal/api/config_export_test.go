@@ -0,0 +1,86 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/test/fixtures"
+)
+
+// fixedConfig serves one configuration as the running one.
+type fixedConfig struct {
+	cfg *config.TimingConfig
+}
+
+func (f fixedConfig) Current() *config.TimingConfig {
+	return f.cfg
+}
+
+func getConfig(t *testing.T, cfg *config.TimingConfig, token string) *httptest.ResponseRecorder {
+	t.Helper()
+	server, _, _, _ := setupAPITest(t)
+	server.authMiddleware = fixtures.NewAuthMiddleware()
+	server.EnableConfigExport(fixedConfig{cfg})
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodGet, ConfigPath, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestConfigExportMatchesRunningConfig(t *testing.T) {
+	cfg := config.LoadCBTimingBaseline()
+	cfg.HeartbeatInterval = 7 * time.Second
+	cfg.EventHMACKey = "hmac-key-do-not-leak"
+
+	w := getConfig(t, cfg, "viewer-token")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data struct {
+			Timing  map[string]interface{} `json:"timing"`
+			Derived map[string]interface{} `json:"derived"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	want, err := configFields(cfg)
+	if err != nil {
+		t.Fatalf("configFields failed: %v", err)
+	}
+	if !reflect.DeepEqual(resp.Data.Timing, want) {
+		t.Errorf("Timing does not match the running config:\ngot  %v\nwant %v", resp.Data.Timing, want)
+	}
+	if got := resp.Data.Timing["HeartbeatInterval"]; got != float64(7*time.Second) {
+		t.Errorf("Expected overridden HeartbeatInterval, got %v", got)
+	}
+	if resp.Data.Derived["eventSigning"] != true {
+		t.Errorf("Expected eventSigning true, got %v", resp.Data.Derived)
+	}
+
+	// The signing key never leaves the server
+	if body := w.Body.String(); strings.Contains(body, "hmac-key-do-not-leak") || strings.Contains(body, "EventHMACKey") {
+		t.Errorf("Config export leaked the event HMAC key: %s", body)
+	}
+}
+
+func TestConfigExportRequiresAuth(t *testing.T) {
+	w := getConfig(t, config.LoadCBTimingBaseline(), "")
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without a token, got %d", w.Code)
+	}
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/radio-control/rcc/test/fixtures"
+)
+
+func TestFineControlScopes(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	server.authMiddleware = fixtures.NewAuthMiddleware()
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	post := func(token, path, body string) int {
+		req := httptest.NewRequest("POST", "/api/v1/radios/silvus-001/"+path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	tests := []struct {
+		token   string
+		power   int
+		channel int
+	}{
+		{"channel-token", http.StatusForbidden, http.StatusOK},
+		{"power-token", http.StatusOK, http.StatusForbidden},
+		{"controller-token", http.StatusOK, http.StatusOK},
+		{"viewer-token", http.StatusForbidden, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			if code := post(tt.token, "power", `{"powerDbm":20}`); code != tt.power {
+				t.Errorf("POST power: expected %d, got %d", tt.power, code)
+			}
+			if code := post(tt.token, "channel", `{"frequencyMhz":2412.0}`); code != tt.channel {
+				t.Errorf("POST channel: expected %d, got %d", tt.channel, code)
+			}
+		})
+	}
+}
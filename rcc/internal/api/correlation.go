@@ -0,0 +1,23 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/radio-control/rcc/internal/logging"
+)
+
+// withCorrelationID assigns each request a correlation ID, carried in the
+// request context for the orchestrator, adapter, and audit logger, and echoed
+// in the X-Correlation-ID response header and envelope. A client's own
+// X-Correlation-ID is used when logging.ValidCorrelationID accepts it;
+// otherwise, or when absent, a new ID is generated.
+func withCorrelationID(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(logging.CorrelationIDHeader)
+		if !logging.ValidCorrelationID(id) {
+			id = logging.NewCorrelationID()
+		}
+		w.Header().Set(logging.CorrelationIDHeader, id)
+		next(w, r.WithContext(logging.WithCorrelationID(r.Context(), id)))
+	}
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/audit"
+	"github.com/radio-control/rcc/internal/logging"
+)
+
+// lastAuditEntry returns the last record in the audit log file.
+func lastAuditEntry(t *testing.T, path string) audit.AuditEntry {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open audit log: %v", err)
+	}
+	defer file.Close()
+
+	var entry audit.AuditEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("Invalid audit line: %v", err)
+		}
+	}
+	return entry
+}
+
+func TestCorrelationIDFlowsFromHandlerToAudit(t *testing.T) {
+	server, _, orch, _ := setupAPITest(t)
+	auditLogger, err := audit.NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create audit logger: %v", err)
+	}
+	defer auditLogger.Close()
+	orch.SetAuditLogger(auditLogger)
+	var logs bytes.Buffer
+	orch.SetLogger(logging.New(&logs))
+
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"success", `{"powerDbm": 20}`, http.StatusOK},
+		{"invalid range", `{"powerDbm": 100}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs.Reset()
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/radios/silvus-001/power", strings.NewReader(tt.body)))
+			if w.Code != tt.status {
+				t.Fatalf("Expected %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+
+			var response Response
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			id := response.CorrelationID
+			if id == "" || w.Header().Get(logging.CorrelationIDHeader) != id {
+				t.Fatalf("Expected envelope and header correlation IDs to match, got %q and %q",
+					id, w.Header().Get(logging.CorrelationIDHeader))
+			}
+
+			entry := lastAuditEntry(t, auditLogger.GetFilePath())
+			if entry.Action != "setPower" || entry.CorrelationID != id {
+				t.Errorf("Expected setPower audit record with correlationId %q, got %+v", id, entry)
+			}
+
+			var line map[string]interface{}
+			if err := json.Unmarshal(logs.Bytes(), &line); err != nil {
+				t.Fatalf("Expected one JSON log line, got %q", logs.String())
+			}
+			if line["correlationId"] != id || line["radioId"] != "silvus-001" || line["action"] != "setPower" {
+				t.Errorf("Expected log line for setPower with correlationId %q, got %v", id, line)
+			}
+		})
+	}
+}
+
+func TestCorrelationIDFromClientHeader(t *testing.T) {
+	server, _, orch, _ := setupAPITest(t)
+	auditLogger, err := audit.NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create audit logger: %v", err)
+	}
+	defer auditLogger.Close()
+	orch.SetAuditLogger(auditLogger)
+
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	tests := []struct {
+		name     string
+		supplied string
+		echoed   bool
+	}{
+		{"valid", "client-trace-42", true},
+		{"log injection", "abc\n{\"outcome\":\"SUCCESS\"}", false},
+		{"too long", strings.Repeat("x", logging.MaxCorrelationIDLength+1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/v1/radios/silvus-001/power", strings.NewReader(`{"powerDbm": 20}`))
+			req.Header.Set(logging.CorrelationIDHeader, tt.supplied)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var response Response
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			id := response.CorrelationID
+			if tt.echoed && id != tt.supplied {
+				t.Errorf("Expected supplied correlation ID %q, got %q", tt.supplied, id)
+			}
+			if !tt.echoed && (id == tt.supplied || !logging.ValidCorrelationID(id)) {
+				t.Errorf("Expected a generated correlation ID in place of %q, got %q", tt.supplied, id)
+			}
+			if w.Header().Get(logging.CorrelationIDHeader) != id {
+				t.Errorf("Expected header correlation ID %q, got %q", id, w.Header().Get(logging.CorrelationIDHeader))
+			}
+			if entry := lastAuditEntry(t, auditLogger.GetFilePath()); entry.CorrelationID != id {
+				t.Errorf("Expected audit correlationId %q, got %q", id, entry.CorrelationID)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/auth"
+)
+
+// EnableDebugRawResponses makes GET and POST power and channel responses
+// carry the adapter's raw vendor responses in details.rawResponses. With
+// authentication configured only admin-scoped tokens get them. Meant for
+// diagnosing adapter issues, never for production. Must be called before
+// Start.
+func (s *Server) EnableDebugRawResponses() {
+	s.debugRawResponses = true
+}
+
+// rawResponseContext returns the request context, recording adapter raw
+// responses when debug mode is on and the caller may see them. The
+// collector is nil otherwise.
+func (s *Server) rawResponseContext(r *http.Request) (context.Context, *adapter.RawResponses) {
+	if !s.debugRawResponses {
+		return r.Context(), nil
+	}
+	if s.authMiddleware != nil && !auth.GetClaimsFromRequest(r).HasScope(auth.ScopeAdmin) {
+		return r.Context(), nil
+	}
+	return adapter.WithRawResponses(r.Context())
+}
+
+// writeDebugSuccess is WriteSuccess with any recorded raw responses in
+// details.
+func writeDebugSuccess(w http.ResponseWriter, data interface{}, raw *adapter.RawResponses) {
+	response := SuccessResponse(data)
+	response.Details = withRawResponses(nil, raw)
+	writeResponse(w, http.StatusOK, response)
+}
+
+// writeDebugAPIError is writeAPIError with any recorded raw responses added
+// to details.
+func writeDebugAPIError(w http.ResponseWriter, err error, raw *adapter.RawResponses) {
+	status, response := toAPIError(err)
+	response.Details = withRawResponses(response.Details, raw)
+	writeResponse(w, status, response)
+}
+
+// withRawResponses adds the recorded raw responses to details. Details that
+// are not an object move under "vendor".
+func withRawResponses(details interface{}, raw *adapter.RawResponses) interface{} {
+	if raw == nil {
+		return details
+	}
+	responses := raw.All()
+	if len(responses) == 0 {
+		return details
+	}
+
+	merged := map[string]interface{}{}
+	switch d := details.(type) {
+	case nil:
+	case map[string]interface{}:
+		for key, value := range d {
+			merged[key] = value
+		}
+	default:
+		merged["vendor"] = d
+	}
+	merged["rawResponses"] = responses
+	return merged
+}
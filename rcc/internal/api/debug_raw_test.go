@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter/silvus"
+	"github.com/radio-control/rcc/test/fixtures"
+)
+
+// powerVendorResponse is the JSON-RPC response the stub radio sends for a
+// power_dBm read, exactly as it should appear in details.
+const powerVendorResponse = `{"jsonrpc":"2.0","result":["23"],"id":1,"vendor_note":"pa_temp=41C"}`
+
+// setupRawResponseServer registers a Silvus adapter talking to a stub
+// JSON-RPC radio as silvus-raw and returns a mux serving the API with
+// authentication.
+func setupRawResponseServer(t *testing.T, debug bool) *http.ServeMux {
+	radioStub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		switch req.Method {
+		case "power_dBm":
+			_, _ = w.Write([]byte(powerVendorResponse))
+		case "freq":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","result":["2412"],"id":1}`))
+		default:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","result":[{"frequencies":["2412"],"bandwidth":"20","antenna_mask":"15"}],"id":1}`))
+		}
+	}))
+	t.Cleanup(radioStub.Close)
+
+	server, rm, _, _ := setupAPITest(t)
+	if err := rm.LoadCapabilities("silvus-raw", silvus.NewSilvusAdapter("silvus-raw", radioStub.URL, 2*time.Second), 5*time.Second); err != nil {
+		t.Fatalf("LoadCapabilities failed: %v", err)
+	}
+	server.authMiddleware = fixtures.NewAuthMiddleware()
+	if debug {
+		server.EnableDebugRawResponses()
+	}
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+	return mux
+}
+
+// rawResponsesFor returns details.rawResponses from a forced-refresh power
+// read made with token, or nil when the envelope has none.
+func rawResponsesFor(t *testing.T, mux *http.ServeMux, token string) []map[string]string {
+	req := httptest.NewRequest("GET", "/api/v1/radios/silvus-raw/power?forceRefresh=true", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var envelope struct {
+		Details struct {
+			RawResponses []map[string]string `json:"rawResponses"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return envelope.Details.RawResponses
+}
+
+func TestDebugRawResponsesInDetails(t *testing.T) {
+	mux := setupRawResponseServer(t, true)
+
+	raw := rawResponsesFor(t, mux, "admin-token")
+	found := false
+	for _, entry := range raw {
+		if entry["method"] == "power_dBm" && strings.TrimSpace(entry["response"]) == powerVendorResponse {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected raw power_dBm response in details, got %v", raw)
+	}
+
+	// Without the admin scope the envelope is unchanged
+	if raw := rawResponsesFor(t, mux, "controller-token"); raw != nil {
+		t.Errorf("Expected no raw responses for a non-admin token, got %v", raw)
+	}
+}
+
+func TestDebugRawResponsesDisabledByDefault(t *testing.T) {
+	mux := setupRawResponseServer(t, false)
+
+	if raw := rawResponsesFor(t, mux, "admin-token"); raw != nil {
+		t.Errorf("Expected no raw responses without debug mode, got %v", raw)
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDryRunCommandsDoNotCallAdapter(t *testing.T) {
+	mux, counting := setupIdempotencyTest(t)
+
+	tests := []struct {
+		name   string
+		path   string
+		header bool
+		body   string
+		want   map[string]interface{}
+	}{
+		{"power query", "/api/v1/radios/silvus-001/power?dryRun=true", false, `{"powerDbm": 20}`,
+			map[string]interface{}{"powerDbm": 20.0, "dryRun": true}},
+		{"channel header", "/api/v1/radios/silvus-001/channel", true, `{"frequencyMhz": 2437}`,
+			map[string]interface{}{"frequencyMhz": 2437.0, "dryRun": true}},
+		{"channel index", "/api/v1/radios/silvus-001/channel?dryRun=true", false, `{"channelIndex": 6}`,
+			map[string]interface{}{"frequencyMhz": 2437.0, "channelIndex": 6.0, "dryRun": true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", tt.path, strings.NewReader(tt.body))
+			if tt.header {
+				req.Header.Set("X-Dry-Run", "true")
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+			}
+			var response struct {
+				Data map[string]interface{} `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			for key, value := range tt.want {
+				if response.Data[key] != value {
+					t.Errorf("Expected %s=%v, got %v", key, value, response.Data[key])
+				}
+			}
+		})
+	}
+
+	if counting.powerCalls.Load() != 0 || counting.channelCalls.Load() != 0 {
+		t.Errorf("Expected no adapter calls, got power=%d channel=%d",
+			counting.powerCalls.Load(), counting.channelCalls.Load())
+	}
+}
+
+func TestDryRunReportsValidationErrors(t *testing.T) {
+	mux, counting := setupIdempotencyTest(t)
+
+	req := httptest.NewRequest("POST", "/api/v1/radios/silvus-001/power?dryRun=true", strings.NewReader(`{"powerDbm": 100}`))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `"code":"INVALID_RANGE"`) {
+		t.Errorf("Expected 400 INVALID_RANGE, got %d: %s", w.Code, w.Body.String())
+	}
+	if counting.powerCalls.Load() != 0 {
+		t.Errorf("Expected no adapter call, got %d", counting.powerCalls.Load())
+	}
+}
@@ -7,6 +7,7 @@ import (
     "errors"
     "fmt"
     "net/http"
+    "strings"
 
     "github.com/radio-control/rcc/internal/adapter"
     "github.com/radio-control/rcc/internal/command"
@@ -30,6 +31,16 @@ var (
 
 // ToAPIError converts an error to an API error with HTTP status code and JSON body.
 func ToAPIError(err error) (int, []byte) {
+	status, response := toAPIError(err)
+	if response == nil {
+		return status, nil
+	}
+	return status, marshalResponse(response)
+}
+
+// toAPIError maps an error to an HTTP status code and error envelope.
+// The envelope is nil when err is nil.
+func toAPIError(err error) (int, *Response) {
 	if err == nil {
 		return http.StatusOK, nil
 	}
@@ -39,7 +50,7 @@ func ToAPIError(err error) (int, []byte) {
 
 	// Check if it's already an API error
 	if errors.As(err, &apiErr) {
-		return apiErr.StatusCode, marshalErrorResponse(apiErr.Code, apiErr.Message, apiErr.Details)
+		return apiErr.StatusCode, ErrorResponse(apiErr.Code, apiErr.Message, apiErr.Details)
 	}
 
 	// Check if it's a vendor error from adapter
@@ -47,46 +58,115 @@ func ToAPIError(err error) (int, []byte) {
 		// Map adapter error to API error
 		code, statusCode := mapAdapterError(vendorErr.Code)
 		message := getErrorMessage(vendorErr.Code, vendorErr.Original)
-		return statusCode, marshalErrorResponse(code, message, vendorErr.Details)
+		return statusCode, ErrorResponse(code, message, sanitizeVendorDetails(vendorErr.Details))
 	}
 
 	// Check for adapter error codes
 	if errors.Is(err, adapter.ErrInvalidRange) {
-		return http.StatusBadRequest, marshalErrorResponse("INVALID_RANGE", getErrorMessage(adapter.ErrInvalidRange, err), nil)
+		return http.StatusBadRequest, ErrorResponse("INVALID_RANGE", getErrorMessage(adapter.ErrInvalidRange, err), nil)
 	}
 	if errors.Is(err, adapter.ErrBusy) {
-		return http.StatusServiceUnavailable, marshalErrorResponse("BUSY", getErrorMessage(adapter.ErrBusy, err), nil)
+		return http.StatusServiceUnavailable, ErrorResponse("BUSY", getErrorMessage(adapter.ErrBusy, err), nil)
 	}
 	if errors.Is(err, adapter.ErrUnavailable) {
-		return http.StatusServiceUnavailable, marshalErrorResponse("UNAVAILABLE", getErrorMessage(adapter.ErrUnavailable, err), nil)
+		return http.StatusServiceUnavailable, ErrorResponse("UNAVAILABLE", getErrorMessage(adapter.ErrUnavailable, err), nil)
 	}
 	if errors.Is(err, adapter.ErrInternal) {
-		return http.StatusInternalServerError, marshalErrorResponse("INTERNAL", getErrorMessage(adapter.ErrInternal, err), nil)
+		return http.StatusInternalServerError, ErrorResponse("INTERNAL", getErrorMessage(adapter.ErrInternal, err), nil)
 	}
 
 	// Check for API-layer errors
 	if errors.Is(err, command.ErrNotFound) {
-		return http.StatusNotFound, marshalErrorResponse("NOT_FOUND", "Resource not found", nil)
+		return http.StatusNotFound, ErrorResponse("NOT_FOUND", "Resource not found", nil)
 	}
     if errors.Is(err, command.ErrInvalidParameter) {
-        return http.StatusBadRequest, marshalErrorResponse("BAD_REQUEST", "Malformed or missing required parameter", nil)
+        return http.StatusBadRequest, ErrorResponse("BAD_REQUEST", "Malformed or missing required parameter", nil)
     }
+	if errors.Is(err, command.ErrSubjectBusy) {
+		return http.StatusTooManyRequests, ErrorResponse("BUSY", "Too many concurrent commands for this client, retry with backoff", nil)
+	}
+	if errors.Is(err, command.ErrThrottled) {
+		return http.StatusTooManyRequests, ErrorResponse("THROTTLED", "Radio frequency change limit reached, retry later", nil)
+	}
+	if errors.Is(err, command.ErrModelForbidden) {
+		return http.StatusForbidden, ErrorResponse("FORBIDDEN", "Radio model is not allowed in this deployment", nil)
+	}
+	if errors.Is(err, command.ErrForbidden) {
+		return http.StatusForbidden, ErrorResponse("FORBIDDEN", "Role does not allow this command", nil)
+	}
+	if errors.Is(err, command.ErrNoChannels) {
+		return http.StatusConflict, ErrorResponse("NO_CHANNELS", "Radio reports no channels; set the channel by frequency", nil)
+	}
+	if errors.Is(err, command.ErrNotSupported) {
+		return http.StatusNotImplemented, ErrorResponse("NOT_SUPPORTED", "Radio does not support this command", nil)
+	}
+	if errors.Is(err, command.ErrStaleCapabilities) {
+		return http.StatusConflict, ErrorResponse("STALE_CAPABILITIES", "Radio capabilities are stale and could not be refreshed; retry once the radio responds", nil)
+	}
+	if errors.Is(err, command.ErrCanceled) {
+		return http.StatusConflict, ErrorResponse("CANCELED", "Command was canceled before completion", nil)
+	}
 	if errors.Is(err, ErrUnauthorizedError) {
-		return http.StatusUnauthorized, marshalErrorResponse("UNAUTHORIZED", "Authentication required", nil)
+		return http.StatusUnauthorized, ErrorResponse("UNAUTHORIZED", "Authentication required", nil)
 	}
 	if errors.Is(err, ErrForbiddenError) {
-		return http.StatusForbidden, marshalErrorResponse("FORBIDDEN", "Insufficient permissions", nil)
+		return http.StatusForbidden, ErrorResponse("FORBIDDEN", "Insufficient permissions", nil)
 	}
 	if errors.Is(err, ErrNotFoundError) {
-		return http.StatusNotFound, marshalErrorResponse("NOT_FOUND", "Resource not found", nil)
+		return http.StatusNotFound, ErrorResponse("NOT_FOUND", "Resource not found", nil)
 	}
 
 	// Default to internal server error for unknown errors
-	return http.StatusInternalServerError, marshalErrorResponse("INTERNAL", "Internal server error", map[string]interface{}{
+	return http.StatusInternalServerError, ErrorResponse("INTERNAL", "Internal server error", map[string]interface{}{
 		"original": err.Error(),
 	})
 }
 
+// sensitiveDetailKeys are substrings of vendor detail keys never returned to
+// clients (matched case-insensitively).
+var sensitiveDetailKeys = []string{"password", "passwd", "secret", "token", "apikey", "api_key", "authorization", "credential", "cookie"}
+
+// sanitizeVendorDetails returns the client-safe part of a VendorError's
+// Details: map entries whose keys are not sensitive, recursively. Payloads
+// that are not maps are opaque vendor data and are dropped.
+func sanitizeVendorDetails(details interface{}) interface{} {
+	switch d := details.(type) {
+	case map[string]interface{}:
+		sanitized := make(map[string]interface{}, len(d))
+		for key, value := range d {
+			if isSensitiveDetailKey(key) {
+				continue
+			}
+			if nested, ok := value.(map[string]interface{}); ok {
+				value = sanitizeVendorDetails(nested)
+			}
+			sanitized[key] = value
+		}
+		return sanitized
+	case map[string]string:
+		sanitized := make(map[string]interface{}, len(d))
+		for key, value := range d {
+			if !isSensitiveDetailKey(key) {
+				sanitized[key] = value
+			}
+		}
+		return sanitized
+	default:
+		return nil
+	}
+}
+
+// isSensitiveDetailKey reports whether a detail key may carry credentials.
+func isSensitiveDetailKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, sensitive := range sensitiveDetailKeys {
+		if strings.Contains(lower, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
 // mapAdapterError maps adapter error codes to API error codes and HTTP status codes.
 func mapAdapterError(adapterErr error) (string, int) {
 	switch {
@@ -130,14 +210,11 @@ func getErrorMessage(code error, original error) string {
 
 // marshalErrorResponse creates a JSON error response with correlation ID.
 func marshalErrorResponse(code, message string, details interface{}) []byte {
-	response := Response{
-		Result:        "error",
-		Code:          code,
-		Message:       message,
-		Details:       details,
-		CorrelationID: generateCorrelationID(),
-	}
+	return marshalResponse(ErrorResponse(code, message, details))
+}
 
+// marshalResponse marshals an envelope, falling back to a generic INTERNAL error.
+func marshalResponse(response *Response) []byte {
 	jsonBytes, err := json.Marshal(response)
 	if err != nil {
 		// Fallback error response if marshaling fails
@@ -145,7 +222,7 @@ func marshalErrorResponse(code, message string, details interface{}) []byte {
 			"result":        "error",
 			"code":          "INTERNAL",
 			"message":       "Failed to marshal error response",
-			"correlationId": generateCorrelationID(),
+			"correlationId": response.CorrelationID,
 		}
 		jsonBytes, _ := json.Marshal(fallback)
 		return jsonBytes
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/radio-control/rcc/internal/adapter"
@@ -68,6 +70,34 @@ func TestToAPIError(t *testing.T) {
 			expectedCode:   "BAD_REQUEST",
 			expectedMsg:    "Malformed or missing required parameter",
 		},
+		{
+			name:           "command.ErrSubjectBusy maps to HTTP 429",
+			inputError:     command.ErrSubjectBusy,
+			expectedStatus: http.StatusTooManyRequests,
+			expectedCode:   "BUSY",
+			expectedMsg:    "Too many concurrent commands for this client, retry with backoff",
+		},
+		{
+			name:           "command.ErrThrottled maps to HTTP 429",
+			inputError:     command.ErrThrottled,
+			expectedStatus: http.StatusTooManyRequests,
+			expectedCode:   "THROTTLED",
+			expectedMsg:    "Radio frequency change limit reached, retry later",
+		},
+		{
+			name:           "command.ErrModelForbidden maps to HTTP 403",
+			inputError:     command.ErrModelForbidden,
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "FORBIDDEN",
+			expectedMsg:    "Radio model is not allowed in this deployment",
+		},
+		{
+			name:           "command.ErrNoChannels maps to HTTP 409",
+			inputError:     fmt.Errorf("%w: radio radio-01 reports no channels", command.ErrNoChannels),
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "NO_CHANNELS",
+			expectedMsg:    "Radio reports no channels; set the channel by frequency",
+		},
 		{
 			name:           "ErrUnauthorizedError maps to HTTP 401",
 			inputError:     ErrUnauthorizedError,
@@ -201,6 +231,78 @@ func TestToAPIErrorWithAPIError(t *testing.T) {
 	}
 }
 
+func TestVendorErrorDetailsSanitized(t *testing.T) {
+	vendorErr := &adapter.VendorError{
+		Code:     adapter.ErrUnavailable,
+		Original: errors.New("login failed"),
+		Details: map[string]interface{}{
+			"radioID":  "silvus-001",
+			"password": "hunter2",
+			"session":  map[string]interface{}{"authToken": "abc", "attempt": 2},
+		},
+	}
+
+	_, body := ToAPIError(vendorErr)
+	var response struct {
+		Code          string                 `json:"code"`
+		CorrelationID string                 `json:"correlationId"`
+		Details       map[string]interface{} `json:"details"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if response.Code != "UNAVAILABLE" || response.CorrelationID == "" {
+		t.Errorf("Expected code and correlationId to stay, got %+v", response)
+	}
+	if response.Details["radioID"] != "silvus-001" {
+		t.Errorf("Expected radioID detail, got %v", response.Details)
+	}
+	if _, ok := response.Details["password"]; ok {
+		t.Errorf("Expected password to be omitted, got %v", response.Details)
+	}
+	session, _ := response.Details["session"].(map[string]interface{})
+	if _, ok := session["authToken"]; ok || session["attempt"] != 2.0 {
+		t.Errorf("Expected nested token omitted and attempt kept, got %v", session)
+	}
+
+	// Opaque vendor payloads are not passed through
+	_, body = ToAPIError(adapter.NormalizeVendorError(errors.New("TX_POWER_OUT_OF_RANGE"), "raw vendor payload"))
+	var raw map[string]interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if raw["details"] != nil {
+		t.Errorf("Expected opaque payload to be dropped, got %v", raw["details"])
+	}
+}
+
+func TestChannelIndexErrorSurfacesAvailableChannels(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+
+	req := httptest.NewRequest("POST", "/api/v1/radios/silvus-001/channel", strings.NewReader(`{"channelIndex":99}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	server.handleSetChannel(w, req, "silvus-001")
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for unknown channel index, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		Code    string                 `json:"code"`
+		Details map[string]interface{} `json:"details"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Code != "INVALID_RANGE" {
+		t.Errorf("Expected INVALID_RANGE, got %s", response.Code)
+	}
+	if response.Details["availableChannels"] != 3.0 || response.Details["requestedIndex"] != 99.0 {
+		t.Errorf("Expected channel resolution details, got %v", response.Details)
+	}
+}
+
 func TestMapAdapterError(t *testing.T) {
 	tests := []struct {
 		name           string
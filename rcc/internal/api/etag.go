@@ -0,0 +1,58 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// computeETag returns a strong entity tag for the JSON encoding of data.
+// The envelope is excluded so per-request correlation IDs do not change it.
+func computeETag(data interface{}) (string, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag.
+// Weak comparison is used, as RFC 9110 requires for If-None-Match.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// writeSuccessWithETag writes data like WriteSuccess with an ETag header, or
+// an empty 304 Not Modified when the request's If-None-Match matches it.
+func writeSuccessWithETag(w http.ResponseWriter, r *http.Request, data interface{}) {
+	if writeNotModified(w, r, data) {
+		return
+	}
+	WriteSuccess(w, data)
+}
+
+// writeNotModified sets the ETag header for data and, when the request's
+// If-None-Match matches it, writes an empty 304 Not Modified and returns
+// true. The caller writes the response otherwise.
+func writeNotModified(w http.ResponseWriter, r *http.Request, data interface{}) bool {
+	etag, err := computeETag(data)
+	if err != nil {
+		return false
+	}
+
+	w.Header().Set("ETag", etag)
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" && etagMatches(ifNoneMatch, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return true
+	}
+	return false
+}
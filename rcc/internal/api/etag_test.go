@@ -0,0 +1,137 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/radio"
+)
+
+// getRadiosWithETag issues GET /radios with an optional If-None-Match.
+func getRadiosWithETag(server *Server, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/api/v1/radios", nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	w := httptest.NewRecorder()
+	server.handleRadios(w, req)
+	return w
+}
+
+func TestRadiosConditionalGet(t *testing.T) {
+	server := setupRadioListTest(t)
+
+	first := getRadiosWithETag(server, "")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("Expected 200 with an ETag, got %d and ETag %q", first.Code, etag)
+	}
+
+	// An unchanged inventory is not re-sent
+	unchanged := getRadiosWithETag(server, etag)
+	if unchanged.Code != http.StatusNotModified {
+		t.Errorf("Expected 304 for unchanged inventory, got %d", unchanged.Code)
+	}
+	if unchanged.Body.Len() != 0 {
+		t.Errorf("Expected empty 304 body, got %q", unchanged.Body.String())
+	}
+	if got := unchanged.Header().Get("ETag"); got != etag {
+		t.Errorf("Expected 304 to repeat ETag %q, got %q", etag, got)
+	}
+
+	// Any radio's state change produces a new ETag
+	rm := server.radioManager.(*radio.Manager)
+	if err := rm.UpdateState("radio-03", &adapter.RadioState{PowerDbm: 12, FrequencyMhz: 5180}); err != nil {
+		t.Fatalf("UpdateState failed: %v", err)
+	}
+	changed := getRadiosWithETag(server, etag)
+	if changed.Code != http.StatusOK {
+		t.Errorf("Expected 200 after a state change, got %d", changed.Code)
+	}
+	if got := changed.Header().Get("ETag"); got == etag || got == "" {
+		t.Errorf("Expected a new ETag after a state change, got %q", got)
+	}
+}
+
+func TestETagMatches(t *testing.T) {
+	etag := `"abc"`
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{`"abc"`, true},
+		{`W/"abc"`, true},
+		{`"xyz", "abc"`, true},
+		{`*`, true},
+		{`"xyz"`, false},
+	}
+	for _, test := range tests {
+		if got := etagMatches(test.header, etag); got != test.want {
+			t.Errorf("etagMatches(%q) = %v, want %v", test.header, got, test.want)
+		}
+	}
+}
+
+// conditionalGet issues GET path through the server's routes with an
+// optional If-None-Match.
+func conditionalGet(mux *http.ServeMux, path, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", path, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestRadioStateConditionalGet(t *testing.T) {
+	server, rm, _, _ := setupAPITest(t)
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	tests := []struct {
+		path   string
+		change func(t *testing.T)
+	}{
+		{"/api/v1/radios/silvus-001/power", func(t *testing.T) {
+			if w := postWithKey(mux, "/api/v1/radios/silvus-001/power", "", `{"powerDbm":27}`); w.Code != http.StatusOK {
+				t.Fatalf("Set power failed: %d %s", w.Code, w.Body.String())
+			}
+		}},
+		{"/api/v1/radios/silvus-001/channel", func(t *testing.T) {
+			if w := postWithKey(mux, "/api/v1/radios/silvus-001/channel", "", `{"channelIndex":11}`); w.Code != http.StatusOK {
+				t.Fatalf("Set channel failed: %d %s", w.Code, w.Body.String())
+			}
+		}},
+		{"/api/v1/radios/silvus-001", func(t *testing.T) {
+			if err := rm.UpdateState("silvus-001", &adapter.RadioState{PowerDbm: 12, FrequencyMhz: 2462}); err != nil {
+				t.Fatalf("UpdateState failed: %v", err)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			first := conditionalGet(mux, tt.path, "")
+			etag := first.Header().Get("ETag")
+			if first.Code != http.StatusOK || etag == "" {
+				t.Fatalf("Expected 200 with an ETag, got %d and ETag %q", first.Code, etag)
+			}
+
+			unchanged := conditionalGet(mux, tt.path, etag)
+			if unchanged.Code != http.StatusNotModified || unchanged.Body.Len() != 0 {
+				t.Errorf("Expected an empty 304 for unchanged state, got %d %q", unchanged.Code, unchanged.Body.String())
+			}
+
+			tt.change(t)
+			changed := conditionalGet(mux, tt.path, etag)
+			if changed.Code != http.StatusOK {
+				t.Errorf("Expected 200 after a state change, got %d", changed.Code)
+			}
+			if got := changed.Header().Get("ETag"); got == etag || got == "" {
+				t.Errorf("Expected a new ETag after a state change, got %q", got)
+			}
+		})
+	}
+}
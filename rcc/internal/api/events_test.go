@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/telemetry"
+)
+
+func getEvents(t *testing.T, server *Server, path string) (int, []telemetry.Event) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	server.handleRadioEndpoints(w, req)
+	if w.Code != http.StatusOK {
+		return w.Code, nil
+	}
+	var resp struct {
+		Data struct {
+			RadioID string            `json:"radioId"`
+			Events  []telemetry.Event `json:"events"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Data.Events == nil {
+		t.Fatalf("Expected an events array, got %s", w.Body.String())
+	}
+	return w.Code, resp.Data.Events
+}
+
+func TestRadioEvents(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	hub := server.telemetryHub.(*telemetry.Hub)
+	types := []string{"powerChanged", "channelChanged", "powerChanged", "fault", "state"}
+	for _, eventType := range types {
+		if err := hub.PublishRadio("silvus-001", telemetry.Event{Type: eventType, Data: map[string]interface{}{}}); err != nil {
+			t.Fatalf("PublishRadio failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		query   string
+		wantIDs []int64
+	}{
+		{"", []int64{1, 2, 3, 4, 5}},
+		{"?after=2", []int64{3, 4, 5}},
+		{"?after=2&limit=2", []int64{3, 4}},
+		{"?after=5", []int64{}},
+	}
+	for _, tt := range tests {
+		code, events := getEvents(t, server, "/api/v1/radios/silvus-001/events"+tt.query)
+		if code != http.StatusOK {
+			t.Fatalf("%q: expected 200, got %d", tt.query, code)
+		}
+		if len(events) != len(tt.wantIDs) {
+			t.Fatalf("%q: expected %d events, got %+v", tt.query, len(tt.wantIDs), events)
+		}
+		for i, event := range events {
+			if event.ID != tt.wantIDs[i] || event.Type != types[event.ID-1] {
+				t.Errorf("%q: event %d: expected ID %d (%s), got %+v", tt.query, i, tt.wantIDs[i], types[tt.wantIDs[i]-1], event)
+			}
+		}
+	}
+
+	// A radio with no buffered events gets an empty array
+	if code, events := getEvents(t, server, "/api/v1/radios/silvus-002/events"); code != http.StatusOK || len(events) != 0 {
+		t.Errorf("Expected 200 with no events, got %d %+v", code, events)
+	}
+
+	for _, query := range []string{"?after=-1", "?after=abc", "?limit=0", "?limit=1001"} {
+		if code, _ := getEvents(t, server, "/api/v1/radios/silvus-001/events"+query); code != http.StatusBadRequest {
+			t.Errorf("%q: expected 400, got %d", query, code)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/radio-control/rcc/internal/command"
+)
+
+// ExplainPath is where clients ask how a command would be carried out.
+const ExplainPath = APIBasePath + "/explain"
+
+// PlanRejection is the error response an explained command would get.
+type PlanRejection struct {
+	Status  int         `json:"status"`
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// handleExplain handles POST /explain, returning the orchestrator's plan for
+// a command without running it.
+func (s *Server) handleExplain(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only POST method is allowed", nil)
+		return
+	}
+
+	body, ok := s.decodeBodyFields(w, r)
+	if !ok {
+		return
+	}
+	action := body.str("action", true)
+	radioID := body.str("radioId", true)
+	params := command.ExplainParams{
+		PowerDbm:     body.number("powerDbm", false),
+		FrequencyMhz: body.number("frequencyMhz", false),
+		ChannelIndex: body.integer("channelIndex", false),
+	}
+	if !body.check(w) {
+		return
+	}
+
+	if s.orchestrator == nil {
+		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE", "Service not available", nil)
+		return
+	}
+	plan, err := s.orchestrator.ExplainCommand(r.Context(), *action, *radioID, params)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	data := struct {
+		*command.CommandPlan
+		Rejection *PlanRejection `json:"rejection,omitempty"`
+	}{CommandPlan: plan}
+	if plan.Rejection != nil {
+		status, resp := toAPIError(plan.Rejection)
+		data.Rejection = &PlanRejection{Status: status, Code: resp.Code, Message: resp.Message, Details: resp.Details}
+	}
+	WriteSuccess(w, data)
+}
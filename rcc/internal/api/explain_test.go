@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/radio-control/rcc/test/fixtures"
+)
+
+// explainResponse is the envelope of POST /explain.
+type explainResponse struct {
+	Data struct {
+		Outcome       string `json:"outcome"`
+		TimeoutMs     int64  `json:"timeoutMs"`
+		Authorization struct {
+			Allowed bool   `json:"allowed"`
+			Reason  string `json:"reason"`
+		} `json:"authorization"`
+		Target struct {
+			FrequencyMhz *float64 `json:"frequencyMhz"`
+		} `json:"target"`
+		Rejection *PlanRejection `json:"rejection"`
+	} `json:"data"`
+}
+
+func postExplain(t *testing.T, token, body string) (*httptest.ResponseRecorder, explainResponse) {
+	t.Helper()
+	server, _, _, _ := setupAPITest(t)
+	server.authMiddleware = fixtures.NewAuthMiddleware()
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodPost, ExplainPath, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	var resp explainResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	return w, resp
+}
+
+func TestExplainEndpointResolvesChannelIndex(t *testing.T) {
+	w, resp := postExplain(t, "controller-token", `{"action":"setChannel","radioId":"silvus-001","channelIndex":6}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp.Data.Outcome != "execute" || resp.Data.Rejection != nil {
+		t.Errorf("Expected the command to execute, got %s (%+v)", resp.Data.Outcome, resp.Data.Rejection)
+	}
+	if resp.Data.Target.FrequencyMhz == nil || *resp.Data.Target.FrequencyMhz != 2437 {
+		t.Errorf("Expected channel 6 to resolve to 2437 MHz, got %v", resp.Data.Target.FrequencyMhz)
+	}
+	if resp.Data.TimeoutMs <= 0 {
+		t.Errorf("Expected the setChannel timeout, got %d", resp.Data.TimeoutMs)
+	}
+}
+
+func TestExplainEndpointReportsMissingScope(t *testing.T) {
+	// Viewers may ask, and learn they could not run the command
+	w, resp := postExplain(t, "viewer-token", `{"action":"setPower","radioId":"silvus-001","powerDbm":20}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp.Data.Authorization.Allowed || resp.Data.Outcome != "reject" {
+		t.Errorf("Expected the viewer to be refused, got %+v", resp.Data)
+	}
+	if resp.Data.Rejection == nil || resp.Data.Rejection.Status != http.StatusForbidden || resp.Data.Rejection.Code != "FORBIDDEN" {
+		t.Errorf("Expected a 403 FORBIDDEN rejection, got %+v", resp.Data.Rejection)
+	}
+
+	if w, _ := postExplain(t, "viewer-token", `{"action":"reboot","radioId":"silvus-001"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown action, got %d", w.Code)
+	}
+}
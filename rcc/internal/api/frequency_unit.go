@@ -0,0 +1,64 @@
+package api
+
+import (
+	"strings"
+)
+
+// frequencyUnit is a unit a channel request may give its frequency in.
+type frequencyUnit struct {
+	name   string
+	perMHz float64
+}
+
+// frequencyUnits are the accepted values of the channel request unit field.
+// The first is the default.
+var frequencyUnits = []frequencyUnit{
+	{name: "MHz", perMHz: 1},
+	{name: "kHz", perMHz: 1e3},
+	{name: "Hz", perMHz: 1e6},
+}
+
+// parseFrequencyUnit returns the unit named name, matched without regard to
+// case; nil selects MHz. ok is false for an unknown unit.
+func parseFrequencyUnit(name *string) (unit frequencyUnit, ok bool) {
+	if name == nil {
+		return frequencyUnits[0], true
+	}
+	for _, unit := range frequencyUnits {
+		if strings.EqualFold(*name, unit.name) {
+			return unit, true
+		}
+	}
+	return frequencyUnit{}, false
+}
+
+// unitNames lists the accepted units for error messages.
+func unitNames() string {
+	names := make([]string, len(frequencyUnits))
+	for i, unit := range frequencyUnits {
+		names[i] = unit.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// requestedFrequency is a channel request frequency as the client gave it,
+// before normalizing to MHz.
+type requestedFrequency struct {
+	value float64
+	unit  frequencyUnit
+}
+
+// mhz returns the frequency in MHz.
+func (f *requestedFrequency) mhz() float64 {
+	return f.value / f.unit.perMHz
+}
+
+// addTo reflects the frequency and unit back in a channel result, which
+// always carries frequencyMhz. A nil f adds nothing.
+func (f *requestedFrequency) addTo(result map[string]interface{}) map[string]interface{} {
+	if f != nil {
+		result["frequency"] = f.value
+		result["unit"] = f.unit.name
+	}
+	return result
+}
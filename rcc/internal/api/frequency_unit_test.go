@@ -0,0 +1,97 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/adapter/silvusmock"
+)
+
+func postChannel(t *testing.T, server *Server, body string) (*httptest.ResponseRecorder, map[string]interface{}) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/radios/silvus-001/channel", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	server.handleRadioEndpoints(w, req)
+	var resp Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	data, _ := resp.Data.(map[string]interface{})
+	return w, data
+}
+
+func TestSetChannelFrequencyUnits(t *testing.T) {
+	server, rm, _, radioAdapter := setupAPITest(t)
+	recorder := &recordingAdapter{SilvusMock: radioAdapter.(*silvusmock.SilvusMock)}
+	if err := rm.SetAdapter("silvus-001", recorder); err != nil {
+		t.Fatalf("SetAdapter failed: %v", err)
+	}
+
+	tests := []struct {
+		body  string
+		value float64
+		unit  string
+	}{
+		{`{"frequency": 2437}`, 2437, "MHz"},
+		{`{"frequency": 2437, "unit": "MHz"}`, 2437, "MHz"},
+		{`{"frequency": 2437000, "unit": "kHz"}`, 2437000, "kHz"},
+		{`{"frequency": 2437000000, "unit": "Hz"}`, 2437000000, "Hz"},
+		{`{"frequency": 2437000, "unit": "khz"}`, 2437000, "kHz"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			recorder.frequencyMhz = 0
+			w, data := postChannel(t, server, tt.body)
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+			}
+			if recorder.frequencyMhz != 2437 {
+				t.Errorf("Adapter received %v MHz, want 2437", recorder.frequencyMhz)
+			}
+			if data["frequencyMhz"] != 2437.0 || data["frequency"] != tt.value || data["unit"] != tt.unit {
+				t.Errorf("Expected 2437 MHz echoed as %v %s, got %v", tt.value, tt.unit, data)
+			}
+		})
+	}
+
+	// frequencyMhz alone is unchanged and echoes no unit
+	w, data := postChannel(t, server, `{"frequencyMhz": 2412}`)
+	if w.Code != http.StatusOK || recorder.frequencyMhz != 2412 {
+		t.Fatalf("Expected frequencyMhz to tune 2412 MHz, got %d and %v MHz", w.Code, recorder.frequencyMhz)
+	}
+	if _, ok := data["unit"]; ok {
+		t.Errorf("Expected no unit without frequency, got %v", data)
+	}
+}
+
+func TestSetChannelFrequencyUnitErrors(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+
+	tests := []struct {
+		name string
+		body string
+		code string
+	}{
+		{"unknown unit", `{"frequency": 2437, "unit": "GHz"}`, "BAD_REQUEST"},
+		{"unit without frequency", `{"frequencyMhz": 2437, "unit": "MHz"}`, "VALIDATION_FAILED"},
+		{"frequency and frequencyMhz", `{"frequency": 2437, "frequencyMhz": 2437}`, "VALIDATION_FAILED"},
+		{"non-positive frequency", `{"frequency": 0, "unit": "kHz"}`, "VALIDATION_FAILED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/radios/silvus-001/channel", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			server.handleRadioEndpoints(w, req)
+			var resp Response
+			_ = json.Unmarshal(w.Body.Bytes(), &resp)
+			if w.Code != http.StatusBadRequest || resp.Code != tt.code {
+				t.Errorf("Expected 400 %s, got %d %s", tt.code, w.Code, resp.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// GroupsPath is the prefix of the radio group endpoints.
+const GroupsPath = APIBasePath + "/groups/"
+
+// GroupMemberResult is one member radio's outcome of a group command. Status
+// is the HTTP status the command would have had sent to the radio directly.
+type GroupMemberResult struct {
+	RadioID  string      `json:"radioId"`
+	Status   int         `json:"status"`
+	Result   string      `json:"result"`
+	PowerDbm *float64    `json:"powerDbm,omitempty"`
+	Code     string      `json:"code,omitempty"`
+	Message  string      `json:"message,omitempty"`
+	Details  interface{} `json:"details,omitempty"`
+}
+
+// handleGroupEndpoints routes /groups/{groupId}/power.
+func (s *Server) handleGroupEndpoints(w http.ResponseWriter, r *http.Request) {
+	groupID, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, GroupsPath), "/power")
+	if !ok || groupID == "" || strings.Contains(groupID, "/") {
+		WriteError(w, http.StatusNotFound, "NOT_FOUND", "Endpoint not found", nil)
+		return
+	}
+	if r.Method != http.MethodPost {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only POST method is allowed", nil)
+		return
+	}
+
+	s.withIdempotency(w, r, "groupSetPower", groupID, func(w http.ResponseWriter, r *http.Request) {
+		s.handleGroupPower(w, r, groupID)
+	})
+}
+
+// handleGroupPower handles POST /groups/{groupId}/power, setting the power of
+// every member radio in turn. A member's failure does not stop the others;
+// the response lists each member's outcome, with 207 unless all succeeded.
+func (s *Server) handleGroupPower(w http.ResponseWriter, r *http.Request, groupID string) {
+	body, ok := s.decodeBodyFields(w, r)
+	if !ok {
+		return
+	}
+	requested := body.number("powerDbm", true)
+	if !body.check(w) {
+		return
+	}
+	powerDbm := *requested
+
+	if s.orchestrator == nil {
+		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE", "Service not available", nil)
+		return
+	}
+	var radioIDs []string
+	err := config.ErrGroupNotFound
+	if s.groups != nil {
+		radioIDs, err = s.groups.GetGroup(groupID)
+	}
+	if err != nil {
+		WriteError(w, http.StatusNotFound, "NOT_FOUND",
+			fmt.Sprintf("Radio group %q is not defined", groupID), nil)
+		return
+	}
+
+	dryRun := isDryRun(r)
+	results := make([]GroupMemberResult, 0, len(radioIDs))
+	failed := 0
+	for _, radioID := range radioIDs {
+		result := s.applyGroupMemberPower(r.Context(), radioID, powerDbm, dryRun)
+		if result.Status != http.StatusOK {
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	data := map[string]interface{}{
+		"groupId":   groupID,
+		"powerDbm":  powerDbm,
+		"succeeded": len(results) - failed,
+		"failed":    failed,
+		"results":   results,
+	}
+	if dryRun {
+		data["dryRun"] = true
+	}
+	if failed == 0 {
+		WriteSuccess(w, data)
+		return
+	}
+	response := SuccessResponse(data)
+	response.Result = "partial"
+	writeResponse(w, http.StatusMultiStatus, response)
+}
+
+// applyGroupMemberPower sets one member's power and reports the outcome as
+// the single-radio endpoint would.
+func (s *Server) applyGroupMemberPower(ctx context.Context, radioID string, powerDbm float64, dryRun bool) GroupMemberResult {
+	apply := s.orchestrator.ApplyPower
+	if dryRun {
+		apply = s.orchestrator.DryRunSetPower
+	}
+	applied, err := apply(ctx, radioID, powerDbm)
+	if err != nil {
+		status, response := toAPIError(err)
+		return GroupMemberResult{
+			RadioID: radioID,
+			Status:  status,
+			Result:  "error",
+			Code:    response.Code,
+			Message: response.Message,
+			Details: response.Details,
+		}
+	}
+	return GroupMemberResult{RadioID: radioID, Status: http.StatusOK, Result: "ok", PowerDbm: &applied}
+}
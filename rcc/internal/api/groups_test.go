@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/adapter/silvusmock"
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// groupPowerResponse is the envelope of POST /groups/{groupId}/power.
+type groupPowerResponse struct {
+	Result string `json:"result"`
+	Data   struct {
+		Succeeded int                 `json:"succeeded"`
+		Failed    int                 `json:"failed"`
+		Results   []GroupMemberResult `json:"results"`
+	} `json:"data"`
+}
+
+func postGroupPower(t *testing.T, mux *http.ServeMux, groupID, body string) (*httptest.ResponseRecorder, groupPowerResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, GroupsPath+groupID+"/power", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	var resp groupPowerResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return w, resp
+}
+
+func setupGroupTest(t *testing.T) *http.ServeMux {
+	server, rm, _, _ := setupAPITest(t)
+
+	// A second member whose adapter reports BUSY
+	busy := silvusmock.NewSilvusMock("silvus-002", []adapter.Channel{{Index: 1, FrequencyMhz: 2412}})
+	if err := rm.LoadCapabilities("silvus-002", busy, 5*time.Second); err != nil {
+		t.Fatalf("LoadCapabilities failed: %v", err)
+	}
+	busy.SetFaultMode("ReturnBusy")
+
+	server.SetGroups(&config.TimingConfig{RadioGroups: map[string][]string{
+		"platoon-1": {"silvus-001", "silvus-002", "silvus-404"},
+		"healthy":   {"silvus-001"},
+	}})
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+	return mux
+}
+
+func TestGroupPowerMixedResults(t *testing.T) {
+	mux := setupGroupTest(t)
+
+	w, resp := postGroupPower(t, mux, "platoon-1", `{"powerDbm":20}`)
+	if w.Code != http.StatusMultiStatus {
+		t.Fatalf("Expected 207, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp.Result != "partial" || resp.Data.Succeeded != 1 || resp.Data.Failed != 2 {
+		t.Errorf("Expected partial result with 1 succeeded and 2 failed, got %+v", resp)
+	}
+
+	want := []struct {
+		radioID string
+		status  int
+		code    string
+	}{
+		{"silvus-001", http.StatusOK, ""},
+		{"silvus-002", http.StatusServiceUnavailable, "BUSY"},
+		{"silvus-404", http.StatusNotFound, "NOT_FOUND"},
+	}
+	if len(resp.Data.Results) != len(want) {
+		t.Fatalf("Expected %d member results, got %+v", len(want), resp.Data.Results)
+	}
+	for i, tt := range want {
+		got := resp.Data.Results[i]
+		if got.RadioID != tt.radioID || got.Status != tt.status || got.Code != tt.code {
+			t.Errorf("Member %d: expected %s %d %q, got %+v", i, tt.radioID, tt.status, tt.code, got)
+		}
+	}
+	if got := resp.Data.Results[0]; got.Result != "ok" || got.PowerDbm == nil || *got.PowerDbm != 20 {
+		t.Errorf("Expected silvus-001 set to 20 dBm, got %+v", got)
+	}
+}
+
+func TestGroupPowerAllSucceeded(t *testing.T) {
+	mux := setupGroupTest(t)
+
+	w, resp := postGroupPower(t, mux, "healthy", `{"powerDbm":15}`)
+	if w.Code != http.StatusOK || resp.Result != "ok" || resp.Data.Succeeded != 1 {
+		t.Errorf("Expected 200 ok with one success, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestGroupPowerUnknownGroup(t *testing.T) {
+	mux := setupGroupTest(t)
+
+	req := httptest.NewRequest(http.MethodPost, GroupsPath+"nobody/power", strings.NewReader(`{"powerDbm":15}`))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an undefined group, got %d", w.Code)
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestHealthReportsCircuitBreakerState verifies breaker state is exposed in the health subsystem map.
+func TestHealthReportsCircuitBreakerState(t *testing.T) {
+	server, _, orch, radioAdapter := setupAPITestWithFault(t, "ReturnUnavailable")
+
+	// Trip the breaker with consecutive unavailable failures
+	for i := 0; i < 5; i++ {
+		if err := orch.SetPower(context.Background(), "silvus-001", 20); err == nil {
+			t.Fatalf("Expected SetPower to fail with fault mode ReturnUnavailable")
+		}
+	}
+
+	// The radio answers pings again while its breaker is still open
+	radioAdapter.ClearFaultMode()
+
+	req := httptest.NewRequest("GET", "/api/v1/health", nil)
+	w := httptest.NewRecorder()
+
+	server.handleHealth(w, req)
+
+	// An open breaker is informational and does not degrade overall health
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Response: %s", w.Code, w.Body.String())
+	}
+
+	var response Response
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	healthData, ok := response.Data.(map[string]interface{})
+	if !ok {
+		t.Fatal("Expected health data to be a map")
+	}
+	subsystems, ok := healthData["subsystems"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Expected subsystems to be a map")
+	}
+	breakers, ok := subsystems["circuitBreakers"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected circuitBreakers map in subsystems, got %v", subsystems["circuitBreakers"])
+	}
+	if breakers["silvus-001"] != "open" {
+		t.Errorf("Expected breaker for silvus-001 to be open, got %v", breakers["silvus-001"])
+	}
+}
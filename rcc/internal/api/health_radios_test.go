@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/radio"
+)
+
+// pingCountingAdapter wraps an adapter, counting pings.
+type pingCountingAdapter struct {
+	adapter.IRadioAdapter
+	pings *atomic.Int32
+}
+
+func (a pingCountingAdapter) Ping(ctx context.Context) error {
+	a.pings.Add(1)
+	return nil
+}
+
+// healthRadios calls /health and returns the status code, overall status, and radios map.
+func healthRadios(t *testing.T, server *Server) (int, string, map[string]interface{}) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	server.handleHealth(w, httptest.NewRequest("GET", "/api/v1/health", nil))
+
+	var body struct {
+		Data    map[string]interface{} `json:"data"`
+		Details map[string]interface{} `json:"details"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode health response: %v", err)
+	}
+	health := body.Data
+	if health == nil {
+		health = body.Details
+	}
+	subsystems, _ := health["subsystems"].(map[string]interface{})
+	radios, _ := subsystems["radios"].(map[string]interface{})
+	status, _ := health["status"].(string)
+	return w.Code, status, radios
+}
+
+func TestHealthReportsPerRadioReachability(t *testing.T) {
+	server, rm, _, radioAdapter := setupAPITest(t)
+	var pings atomic.Int32
+	if err := rm.LoadCapabilities("silvus-002", pingCountingAdapter{radioAdapter, &pings}, 5*time.Second); err != nil {
+		t.Fatalf("Failed to load silvus-002: %v", err)
+	}
+	if err := rm.UpdateStatus("silvus-002", radio.StatusOffline); err != nil {
+		t.Fatalf("Failed to set status: %v", err)
+	}
+
+	// One reachable radio keeps the service healthy
+	code, status, radios := healthRadios(t, server)
+	if code != http.StatusOK || status != "ok" {
+		t.Errorf("Expected 200 ok with one radio up, got %d %s", code, status)
+	}
+	if radios["silvus-001"] != "ok" || radios["silvus-002"] != "unreachable" {
+		t.Errorf("Unexpected radios map: %v", radios)
+	}
+
+	// A recovering radio has missed a probe but not yet the heartbeat timeout
+	if err := rm.UpdateStatus("silvus-001", radio.StatusRecovering); err != nil {
+		t.Fatalf("Failed to set status: %v", err)
+	}
+	if _, _, radios = healthRadios(t, server); radios["silvus-001"] != "ok" {
+		t.Errorf("Expected a recovering radio to be reported ok, got %v", radios)
+	}
+
+	// Every radio unreachable degrades health
+	if err := rm.UpdateStatus("silvus-001", radio.StatusOffline); err != nil {
+		t.Fatalf("Failed to set status: %v", err)
+	}
+	code, status, radios = healthRadios(t, server)
+	if code != http.StatusServiceUnavailable || status != "degraded" {
+		t.Errorf("Expected 503 degraded with all radios down, got %d %s", code, status)
+	}
+	if radios["silvus-001"] != "unreachable" || radios["silvus-002"] != "unreachable" {
+		t.Errorf("Unexpected radios map: %v", radios)
+	}
+
+	// Reachability comes from the health probes; /health never calls a radio
+	if got := pings.Load(); got != 0 {
+		t.Errorf("Expected /health not to ping radios, got %d pings", got)
+	}
+}
+
+func TestHealthDegradedOK(t *testing.T) {
+	server, rm, _, _ := setupAPITest(t)
+	if err := rm.UpdateStatus("silvus-001", radio.StatusOffline); err != nil {
+		t.Fatalf("Failed to set status: %v", err)
+	}
+
+	code, status, _ := healthRadios(t, server)
+	if code != http.StatusServiceUnavailable || status != "degraded" {
+		t.Errorf("Expected 503 degraded by default, got %d %s", code, status)
+	}
+
+	server.SetDegradedHealthOK(true)
+	code, status, _ = healthRadios(t, server)
+	if code != http.StatusOK || status != "degraded" {
+		t.Errorf("Expected 200 degraded with DegradedHealthOK, got %d %s", code, status)
+	}
+}
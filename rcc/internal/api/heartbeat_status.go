@@ -0,0 +1,27 @@
+package api
+
+import (
+	"time"
+
+	"github.com/radio-control/rcc/internal/telemetry"
+)
+
+// Compile-time assertion that Server supplies heartbeat status
+var _ telemetry.StatusProvider = (*Server)(nil)
+
+// HeartbeatStatus reports the online radio count and service uptime for
+// telemetry heartbeats (see telemetry.Hub.SetStatusProvider).
+func (s *Server) HeartbeatStatus() telemetry.HeartbeatStatus {
+	var status telemetry.HeartbeatStatus
+	if !s.startTime.IsZero() {
+		status.UptimeSec = int64(time.Since(s.startTime).Seconds())
+	}
+	if s.radioManager != nil {
+		for _, r := range s.radioManager.List().Items {
+			if r.Status == "online" {
+				status.ActiveRadios++
+			}
+		}
+	}
+	return status
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestServerHeartbeatStatus(t *testing.T) {
+	server, rm, _, _ := setupAPITest(t)
+	server.startTime = time.Now().Add(-90 * time.Second)
+
+	online := 0
+	for _, r := range rm.List().Items {
+		if r.Status == "online" {
+			online++
+		}
+	}
+	if online == 0 {
+		t.Fatal("Expected an online radio in the test setup")
+	}
+
+	status := server.HeartbeatStatus()
+	if status.ActiveRadios != online {
+		t.Errorf("ActiveRadios = %d, want %d", status.ActiveRadios, online)
+	}
+	if status.UptimeSec < 90 {
+		t.Errorf("UptimeSec = %d, want at least 90", status.UptimeSec)
+	}
+
+	if err := rm.UpdateStatus("silvus-001", "offline"); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+	if got := server.HeartbeatStatus().ActiveRadios; got != online-1 {
+		t.Errorf("ActiveRadios after going offline = %d, want %d", got, online-1)
+	}
+}
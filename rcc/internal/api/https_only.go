@@ -0,0 +1,71 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// HTTPSOnlyMode selects how plaintext HTTP requests are handled.
+type HTTPSOnlyMode string
+
+// HTTPS-only enforcement modes
+const (
+	// HTTPSOnlyOff serves plaintext requests (default).
+	HTTPSOnlyOff HTTPSOnlyMode = ""
+	// HTTPSOnlyRedirect answers plaintext requests with a 308 redirect to https.
+	HTTPSOnlyRedirect HTTPSOnlyMode = "redirect"
+	// HTTPSOnlyReject answers plaintext requests with 400 BAD_REQUEST.
+	HTTPSOnlyReject HTTPSOnlyMode = "reject"
+)
+
+// ParseHTTPSOnlyMode parses an HTTPS-only mode name ("", "off", "redirect" or "reject").
+func ParseHTTPSOnlyMode(value string) (HTTPSOnlyMode, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "off":
+		return HTTPSOnlyOff, nil
+	case string(HTTPSOnlyRedirect):
+		return HTTPSOnlyRedirect, nil
+	case string(HTTPSOnlyReject):
+		return HTTPSOnlyReject, nil
+	default:
+		return HTTPSOnlyOff, fmt.Errorf("invalid HTTPS-only mode %q: want off, redirect or reject", value)
+	}
+}
+
+// isSecureRequest reports whether the request arrived over TLS, either directly
+// or via a trusted TLS terminator that sets X-Forwarded-Proto. The header is
+// ignored from other addresses since clients can set it.
+func (s *Server) isSecureRequest(r *http.Request) bool {
+	if r.TLS != nil {
+		return true
+	}
+	if !remoteAddrIn(r, s.trustedProxies) {
+		return false
+	}
+	// The first value is the protocol seen by the outermost proxy
+	proto, _, _ := strings.Cut(r.Header.Get("X-Forwarded-Proto"), ",")
+	return strings.EqualFold(strings.TrimSpace(proto), "https")
+}
+
+// withHTTPSOnly enforces the configured HTTPS-only mode ahead of all routes.
+func (s *Server) withHTTPSOnly(next http.Handler) http.Handler {
+	if s.httpsOnly == HTTPSOnlyOff {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.isSecureRequest(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if s.httpsOnly == HTTPSOnlyRedirect {
+			// 308 preserves the method and body of command requests
+			http.Redirect(w, r, "https://"+r.Host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+			return
+		}
+
+		WriteError(w, http.StatusBadRequest, "BAD_REQUEST",
+			"HTTPS is required", nil)
+	})
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPSOnlyRejectsPlaintext(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	server.SetHTTPSOnly(HTTPSOnlyReject)
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+	handler := server.withHTTPSOnly(mux)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/health", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for plaintext request, got %d", w.Code)
+	}
+	var response Response
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Result != "error" || response.Code != "BAD_REQUEST" {
+		t.Errorf("Expected BAD_REQUEST envelope, got result=%q code=%q", response.Result, response.Code)
+	}
+
+	// Requests terminated by a trusted TLS proxy or over direct TLS are served
+	if err := server.SetTrustedProxies([]string{"10.0.0.0/8"}); err != nil {
+		t.Fatalf("SetTrustedProxies failed: %v", err)
+	}
+	forwarded := httptest.NewRequest("GET", "/api/v1/health", nil)
+	forwarded.RemoteAddr = "10.1.2.3:40000"
+	forwarded.Header.Set("X-Forwarded-Proto", "https")
+	direct := httptest.NewRequest("GET", "/api/v1/health", nil)
+	direct.TLS = &tls.ConnectionState{}
+	for name, req := range map[string]*http.Request{"forwarded": forwarded, "direct": direct} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected 200 for HTTPS request, got %d", name, w.Code)
+		}
+	}
+}
+
+func TestHTTPSOnlyIgnoresForwardedProtoFromUntrustedClients(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	server.SetHTTPSOnly(HTTPSOnlyReject)
+	if err := server.SetTrustedProxies([]string{"10.0.0.0/8"}); err != nil {
+		t.Fatalf("SetTrustedProxies failed: %v", err)
+	}
+	handler := server.withHTTPSOnly(http.NotFoundHandler())
+
+	// A plaintext client claiming a TLS hop is still refused
+	req := httptest.NewRequest("GET", "/api/v1/health", nil)
+	req.RemoteAddr = "192.0.2.10:40000"
+	req.Header.Set("X-Forwarded-Proto", "https")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a spoofed X-Forwarded-Proto, got %d", w.Code)
+	}
+}
+
+func TestSetTrustedProxiesRejectsInvalidCIDR(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	if err := server.SetTrustedProxies([]string{"proxy.local"}); err == nil {
+		t.Error("Expected error for invalid CIDR")
+	}
+}
+
+func TestHTTPSOnlyRedirectsPlaintext(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	server.SetHTTPSOnly(HTTPSOnlyRedirect)
+	handler := server.withHTTPSOnly(http.NotFoundHandler())
+
+	req := httptest.NewRequest("POST", "http://rcc.local:8000/api/v1/radios/select?x=1", nil)
+	req.Header.Set("X-Forwarded-Proto", "http")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusPermanentRedirect {
+		t.Fatalf("Expected 308, got %d", w.Code)
+	}
+	if got := w.Header().Get("Location"); got != "https://rcc.local:8000/api/v1/radios/select?x=1" {
+		t.Errorf("Unexpected redirect location %q", got)
+	}
+}
+
+func TestParseHTTPSOnlyMode(t *testing.T) {
+	tests := map[string]HTTPSOnlyMode{"": HTTPSOnlyOff, "off": HTTPSOnlyOff, "Redirect": HTTPSOnlyRedirect, "reject": HTTPSOnlyReject}
+	for input, want := range tests {
+		got, err := ParseHTTPSOnlyMode(input)
+		if err != nil || got != want {
+			t.Errorf("ParseHTTPSOnlyMode(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseHTTPSOnlyMode("always"); err == nil {
+		t.Error("Expected error for unknown mode")
+	}
+}
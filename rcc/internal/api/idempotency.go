@@ -0,0 +1,179 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// IdempotencyKeyHeader carries the client-chosen key for a command request.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyCache remembers successful command responses by Idempotency-Key
+// so retried requests replay the first result instead of re-invoking the
+// adapter. Keys are scoped per action and radio. A TTL of 0 or less disables
+// caching.
+type IdempotencyCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*idempotencyEntry
+
+	// now is overridable for tests
+	now func() time.Time
+}
+
+// idempotencyEntry is a cached (or in-flight) command response.
+type idempotencyEntry struct {
+	fingerprint [sha256.Size]byte
+	expires     time.Time
+	done        chan struct{} // Closed once the first request completes
+
+	status int
+	header http.Header
+	body   []byte
+}
+
+// NewIdempotencyCache creates a cache keeping responses for ttl.
+func NewIdempotencyCache(ttl time.Duration) *IdempotencyCache {
+	return &IdempotencyCache{
+		ttl:     ttl,
+		entries: make(map[string]*idempotencyEntry),
+		now:     time.Now,
+	}
+}
+
+// begin looks up key. It returns the existing entry when one is live, or
+// registers and returns a new in-flight entry with created set.
+func (c *IdempotencyCache) begin(key string, fingerprint [sha256.Size]byte) (entry *idempotencyEntry, created bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.pruneLocked(now)
+
+	if entry, exists := c.entries[key]; exists {
+		return entry, false
+	}
+	entry = &idempotencyEntry{
+		fingerprint: fingerprint,
+		expires:     now.Add(c.ttl),
+		done:        make(chan struct{}),
+	}
+	c.entries[key] = entry
+	return entry, true
+}
+
+// finish records the response for an in-flight entry. Only 2xx responses are
+// kept, so a retry after a failure re-executes the command.
+func (c *IdempotencyCache) finish(key string, entry *idempotencyEntry, rec *idempotencyRecorder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.status = rec.status
+	entry.header = rec.Header().Clone()
+	entry.body = rec.body.Bytes()
+	if rec.status < 200 || rec.status >= 300 {
+		delete(c.entries, key)
+	}
+	close(entry.done)
+}
+
+// pruneLocked drops expired completed entries; callers must hold c.mu.
+func (c *IdempotencyCache) pruneLocked(now time.Time) {
+	for key, entry := range c.entries {
+		select {
+		case <-entry.done:
+			if now.After(entry.expires) {
+				delete(c.entries, key)
+			}
+		default:
+		}
+	}
+}
+
+// idempotencyRecorder forwards a response to the client while keeping a copy.
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *idempotencyRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *idempotencyRecorder) Write(p []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	r.body.Write(p)
+	return r.ResponseWriter.Write(p)
+}
+
+// withIdempotency runs next once per Idempotency-Key within the action and
+// radio scope. A repeated key with the same body replays the cached response;
+// with a different body it returns 409 CONFLICT. Requests without the header,
+// dry runs, or with caching disabled, run normally.
+func (s *Server) withIdempotency(w http.ResponseWriter, r *http.Request, action, radioID string, next http.HandlerFunc) {
+	key := r.Header.Get(IdempotencyKeyHeader)
+	if key == "" || s.idempotency == nil || s.idempotency.ttl <= 0 || isDryRun(r) {
+		next(w, r)
+		return
+	}
+
+	// Read the body up front to fingerprint it, then hand next a fresh reader
+	limit := s.maxBodyBytes
+	if limit <= 0 {
+		limit = DefaultMaxBodyBytes
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+	if err != nil {
+		s.writeDecodeError(w, err, limit, "Failed to read request body")
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	scopedKey := action + "\x00" + radioID + "\x00" + key
+	fingerprint := sha256.Sum256(body)
+
+	for {
+		entry, created := s.idempotency.begin(scopedKey, fingerprint)
+		if created {
+			rec := &idempotencyRecorder{ResponseWriter: w}
+			defer s.idempotency.finish(scopedKey, entry, rec)
+			next(rec, r)
+			return
+		}
+
+		if entry.fingerprint != fingerprint {
+			WriteError(w, http.StatusConflict, "CONFLICT",
+				"Idempotency-Key was already used with a different request body", nil)
+			return
+		}
+
+		// Wait for the first request with this key to complete
+		select {
+		case <-entry.done:
+		case <-r.Context().Done():
+			return
+		}
+
+		if entry.status < 200 || entry.status >= 300 {
+			// The first attempt failed and was not cached; run this one
+			continue
+		}
+		for name, values := range entry.header {
+			w.Header()[name] = values
+		}
+		w.Header().Set("Idempotent-Replayed", "true")
+		w.WriteHeader(entry.status)
+		_, _ = w.Write(entry.body)
+		return
+	}
+}
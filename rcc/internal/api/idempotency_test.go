@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+)
+
+// countingAdapter counts SetPower and SetFrequency calls.
+type countingAdapter struct {
+	adapter.IRadioAdapter
+	powerCalls   atomic.Int32
+	channelCalls atomic.Int32
+}
+
+func (c *countingAdapter) SetPower(ctx context.Context, dBm float64) error {
+	c.powerCalls.Add(1)
+	return c.IRadioAdapter.SetPower(ctx, dBm)
+}
+
+func (c *countingAdapter) SetFrequency(ctx context.Context, frequencyMhz float64) error {
+	c.channelCalls.Add(1)
+	return c.IRadioAdapter.SetFrequency(ctx, frequencyMhz)
+}
+
+// setupIdempotencyTest returns a mux with idempotency enabled and a counting adapter on silvus-001.
+func setupIdempotencyTest(t *testing.T) (*http.ServeMux, *countingAdapter) {
+	t.Helper()
+	server, rm, _, radioAdapter := setupAPITest(t)
+	counting := &countingAdapter{IRadioAdapter: radioAdapter}
+	if err := rm.SetAdapter("silvus-001", counting); err != nil {
+		t.Fatalf("Failed to set adapter: %v", err)
+	}
+	server.SetIdempotencyTTL(time.Minute)
+
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+	return mux, counting
+}
+
+func postWithKey(mux *http.ServeMux, path, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyKeyReplaysSetPower(t *testing.T) {
+	mux, counting := setupIdempotencyTest(t)
+
+	first := postWithKey(mux, "/api/v1/radios/silvus-001/power", "retry-1", `{"powerDbm": 20}`)
+	second := postWithKey(mux, "/api/v1/radios/silvus-001/power", "retry-1", `{"powerDbm": 20}`)
+
+	if first.Code != http.StatusOK || second.Code != http.StatusOK {
+		t.Fatalf("Expected 200 twice, got %d and %d: %s", first.Code, second.Code, second.Body.String())
+	}
+	if got := counting.powerCalls.Load(); got != 1 {
+		t.Errorf("Expected adapter SetPower called once, got %d", got)
+	}
+	if first.Body.String() != second.Body.String() {
+		t.Errorf("Expected replayed body %q, got %q", first.Body.String(), second.Body.String())
+	}
+	if second.Header().Get("Idempotent-Replayed") != "true" {
+		t.Error("Expected Idempotent-Replayed header on duplicate")
+	}
+
+	// Without a key, every request reaches the adapter
+	postWithKey(mux, "/api/v1/radios/silvus-001/power", "", `{"powerDbm": 20}`)
+	if got := counting.powerCalls.Load(); got != 2 {
+		t.Errorf("Expected unkeyed request to call adapter, got %d calls", got)
+	}
+}
+
+func TestIdempotencyKeyReplaysSetChannel(t *testing.T) {
+	mux, counting := setupIdempotencyTest(t)
+
+	for i := 0; i < 2; i++ {
+		w := postWithKey(mux, "/api/v1/radios/silvus-001/channel", "retry-2", `{"frequencyMhz": 2437}`)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Attempt %d: expected 200, got %d: %s", i+1, w.Code, w.Body.String())
+		}
+	}
+	if got := counting.channelCalls.Load(); got != 1 {
+		t.Errorf("Expected adapter SetFrequency called once, got %d", got)
+	}
+}
+
+func TestIdempotencyKeyDifferentBodyConflicts(t *testing.T) {
+	mux, counting := setupIdempotencyTest(t)
+
+	postWithKey(mux, "/api/v1/radios/silvus-001/power", "retry-3", `{"powerDbm": 20}`)
+	w := postWithKey(mux, "/api/v1/radios/silvus-001/power", "retry-3", `{"powerDbm": 25}`)
+
+	if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), `"code":"CONFLICT"`) {
+		t.Errorf("Expected 409 CONFLICT, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := counting.powerCalls.Load(); got != 1 {
+		t.Errorf("Expected adapter SetPower called once, got %d", got)
+	}
+}
+
+func TestIdempotencyKeyScopedPerAction(t *testing.T) {
+	mux, counting := setupIdempotencyTest(t)
+
+	// The same key on a different action is a separate request
+	postWithKey(mux, "/api/v1/radios/silvus-001/power", "shared", `{"powerDbm": 20}`)
+	w := postWithKey(mux, "/api/v1/radios/silvus-001/channel", "shared", `{"frequencyMhz": 2437}`)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if counting.powerCalls.Load() != 1 || counting.channelCalls.Load() != 1 {
+		t.Errorf("Expected one call per action, got power=%d channel=%d",
+			counting.powerCalls.Load(), counting.channelCalls.Load())
+	}
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/command"
+	"github.com/radio-control/rcc/internal/config"
+)
+
+func TestRadioLimits(t *testing.T) {
+	server, rm, orch, _ := setupAPITest(t)
+	r, err := rm.GetRadio("silvus-001")
+	if err != nil {
+		t.Fatalf("GetRadio failed: %v", err)
+	}
+
+	cfg := config.LoadCBTimingBaseline()
+	cfg.CommandTimeoutSetPower = 7 * time.Second
+	cfg.PowerLimits = map[string][]config.BandPowerLimit{
+		r.Model: {{Band: "2.4GHz", LowMhz: 2400, HighMhz: 2483.5, MaxDbm: 30}},
+		"other": {{Band: "5GHz", LowMhz: 5150, HighMhz: 5850, MaxDbm: 20}},
+	}
+	orch.SetConfig(cfg)
+
+	w := httptest.NewRecorder()
+	server.handleRadioEndpoints(w, httptest.NewRequest(http.MethodGet, "/api/v1/radios/silvus-001/limits", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data command.Limits `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	limits := resp.Data
+
+	if limits.Power.MinDbm != 0 || limits.Power.MaxDbm != 39 || limits.Power.Policy != config.PowerPolicyReject {
+		t.Errorf("Unexpected power range: %+v", limits.Power)
+	}
+	if len(limits.Power.Bands) != 1 || limits.Power.Bands[0].MaxDbm != 30 {
+		t.Errorf("Expected the 30 dBm limit for model %q, got %+v", r.Model, limits.Power.Bands)
+	}
+	if len(limits.FrequencyRanges) == 0 {
+		t.Error("Expected frequency ranges")
+	}
+	if limits.TimeoutsMs.SetPower != 7000 || limits.TimeoutsMs.SetChannel != cfg.CommandTimeoutSetChannel.Milliseconds() {
+		t.Errorf("Unexpected timeouts: %+v", limits.TimeoutsMs)
+	}
+
+	w = httptest.NewRecorder()
+	server.handleRadioEndpoints(w, httptest.NewRequest(http.MethodGet, "/api/v1/radios/missing/limits", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown radio, got %d", w.Code)
+	}
+}
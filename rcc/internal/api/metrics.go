@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/internal/metrics"
+)
+
+// MetricsPath is where the Prometheus scrape endpoint is mounted when enabled.
+const MetricsPath = "/metrics"
+
+// EnableMetrics serves registry at MetricsPath. With requireAuth and
+// authentication configured, scrapes need a token with the read scope;
+// otherwise the endpoint is open like /health. Must be called before Start.
+func (s *Server) EnableMetrics(registry *metrics.Registry, requireAuth bool) {
+	s.metrics = registry
+	s.metricsRequireAuth = requireAuth
+}
+
+// handleMetrics returns the scrape handler chain.
+func (s *Server) handleMetrics() http.HandlerFunc {
+	scrape := s.metrics.Handler()
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+				"Only GET method is allowed", nil)
+			return
+		}
+		scrape(w, r)
+	}
+
+	if s.metricsRequireAuth && s.authMiddleware != nil {
+		return s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(handler))
+	}
+	return handler
+}
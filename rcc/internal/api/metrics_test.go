@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/metrics"
+	"github.com/radio-control/rcc/internal/telemetry"
+	"github.com/radio-control/rcc/test/fixtures"
+)
+
+func scrapeMetrics(t *testing.T, url, token string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url+MetricsPath, nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Scrape failed: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	server, _, orch, _ := setupAPITest(t)
+	registry := metrics.NewRegistry()
+	orch.SetMetrics(registry)
+	server.telemetryHub.(*telemetry.Hub).SetMetrics(registry)
+	server.EnableMetrics(registry, false)
+
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	for _, body := range []string{`{"powerDbm": 20}`, `{"powerDbm": 25}`, `{"powerDbm": 60}`} {
+		resp, err := http.Post(ts.URL+"/api/v1/radios/silvus-001/power", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("POST power failed: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	// An open SSE stream is counted until it disconnects
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/api/v1/telemetry", nil)
+	stream, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Telemetry subscribe failed: %v", err)
+	}
+	defer func() { _ = stream.Body.Close() }()
+
+	status, text := scrapeMetrics(t, ts.URL, "")
+	if status != http.StatusOK {
+		t.Fatalf("Expected 200 from /metrics, got %d", status)
+	}
+	for _, want := range []string{
+		`rcc_commands_total{action="setPower",result="SUCCESS"} 2`,
+		`rcc_commands_total{action="setPower",result="INVALID_RANGE"} 1`,
+		`rcc_command_latency_seconds_count{action="setPower"} 3`,
+		"rcc_sse_clients 1",
+	} {
+		if !strings.Contains(text, want+"\n") {
+			t.Errorf("Expected line %q in:\n%s", want, text)
+		}
+	}
+
+	cancel()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, text = scrapeMetrics(t, ts.URL, ""); strings.Contains(text, "rcc_sse_clients 0\n") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected rcc_sse_clients 0 after disconnect, got:\n%s", text)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestMetricsEndpointAuth(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	server.authMiddleware = fixtures.NewAuthMiddleware()
+	registry := metrics.NewRegistry()
+
+	for _, test := range []struct {
+		name        string
+		requireAuth bool
+		token       string
+		status      int
+	}{
+		{"open by default", false, "", http.StatusOK},
+		{"token required when configured", true, "", http.StatusUnauthorized},
+		{"read scope accepted", true, "viewer-token", http.StatusOK},
+		{"read scope needed", true, "telemetry-token", http.StatusForbidden},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			server.EnableMetrics(registry, test.requireAuth)
+			mux := http.NewServeMux()
+			server.RegisterRoutes(mux)
+			ts := httptest.NewServer(mux)
+			defer ts.Close()
+
+			if status, _ := scrapeMetrics(t, ts.URL, test.token); status != test.status {
+				t.Errorf("Expected status %d, got %d", test.status, status)
+			}
+		})
+	}
+
+	// Without EnableMetrics there is no endpoint
+	server.metrics = nil
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	if status, _ := scrapeMetrics(t, ts.URL, ""); status != http.StatusNotFound {
+		t.Errorf("Expected 404 with metrics disabled, got %d", status)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/radio-control/rcc/internal/adapter"
 	"github.com/radio-control/rcc/internal/command"
+	"github.com/radio-control/rcc/internal/config"
 	"github.com/radio-control/rcc/internal/radio"
 	"github.com/radio-control/rcc/internal/telemetry"
 )
@@ -15,14 +16,31 @@ import (
 type OrchestratorPort interface {
 	SelectRadio(ctx context.Context, radioID string) error
 	GetState(ctx context.Context, radioID string) (*adapter.RadioState, error)
+	RefreshState(ctx context.Context, radioID string) (*adapter.RadioState, error)
+	GetActualPower(ctx context.Context, radioID string) (float64, error)
 	SetPower(ctx context.Context, radioID string, powerDbm float64) error
 	SetChannel(ctx context.Context, radioID string, frequencyMhz float64) error
 	SetChannelByIndex(ctx context.Context, radioID string, channelIndex int, radioManager command.RadioManager) error
+	ApplyPower(ctx context.Context, radioID string, powerDbm float64) (float64, error)
+	DryRunSetPower(ctx context.Context, radioID string, powerDbm float64) (float64, error)
+	DryRunSetChannel(ctx context.Context, radioID string, frequencyMhz float64) error
+	DryRunSetChannelByIndex(ctx context.Context, radioID string, channelIndex int, radioManager command.RadioManager) (float64, error)
+	GetChannel(ctx context.Context, radioID string) (*command.ChannelState, error)
+	RefreshChannel(ctx context.Context, radioID string) (*command.ChannelState, error)
+	StepChannel(ctx context.Context, radioID string, direction command.StepDirection) (adapter.Channel, error)
+	GetCapabilities(ctx context.Context, radioID string) (*command.Capabilities, error)
+	GetLimits(ctx context.Context, radioID string) (*command.Limits, error)
+	ExplainCommand(ctx context.Context, action, radioID string, params command.ExplainParams) (*command.CommandPlan, error)
+	CancelCommand(ctx context.Context, radioID string) ([]string, error)
+	CircuitBreakerStates() map[string]string
 }
 
 // TelemetryPort defines the minimal interface the API needs from the telemetry hub.
 type TelemetryPort interface {
 	Subscribe(ctx context.Context, w http.ResponseWriter, r *http.Request) error
+	SubscribeWebSocket(ctx context.Context, w http.ResponseWriter, r *http.Request) error
+	EventsAfter(radioID string, afterID int64, limit int) []telemetry.Event
+	SetClientFilter(connectionID, subject string, filter telemetry.ClientFilter) error
 }
 
 // RadioReadPort defines the minimal interface for radio read operations.
@@ -30,9 +48,22 @@ type RadioReadPort interface {
 	GetRadio(radioID string) (*radio.Radio, error)
 	List() *radio.RadioList
 	SetActive(radioID string) error
+	GetAdapter(radioID string) (adapter.IRadioAdapter, error)
+}
+
+// PresetPort resolves named channel presets by radio model.
+type PresetPort interface {
+	GetPreset(model, name string) (config.Preset, error)
+}
+
+// GroupPort resolves radio groups to their member radio IDs.
+type GroupPort interface {
+	GetGroup(groupID string) ([]string, error)
 }
 
 // Compile-time assertions for port conformance
 var _ OrchestratorPort = (*command.Orchestrator)(nil)
 var _ TelemetryPort = (*telemetry.Hub)(nil)
 var _ RadioReadPort = (*radio.Manager)(nil)
+var _ PresetPort = (*config.Store)(nil)
+var _ GroupPort = (*config.Store)(nil)
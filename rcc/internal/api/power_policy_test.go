@@ -0,0 +1,50 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/command"
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/telemetry"
+)
+
+func TestSetPowerReportsClamp(t *testing.T) {
+	server, rm, _, _ := setupAPITest(t)
+	post := func(server *Server) *httptest.ResponseRecorder {
+		mux := http.NewServeMux()
+		server.RegisterRoutes(mux)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/radios/silvus-001/power", strings.NewReader(`{"powerDbm": 45}`)))
+		return w
+	}
+
+	// Reject mode (default) is unchanged
+	if w := post(server); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `"code":"INVALID_RANGE"`) {
+		t.Errorf("Expected 400 INVALID_RANGE in reject mode, got %d: %s", w.Code, w.Body.String())
+	}
+
+	cfg := config.LoadCBTimingBaseline()
+	cfg.PowerOutOfRangePolicy = config.PowerPolicyClamp
+	hub := telemetry.NewHub(cfg)
+	t.Cleanup(hub.Stop)
+	clampOrch := command.NewOrchestratorWithRadioManager(hub, cfg, rm)
+	w := post(NewServer(hub, clampOrch, rm, 30*time.Second, 30*time.Second, 120*time.Second))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 in clamp mode, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Data["powerDbm"] != 39.0 || response.Data["adjusted"] != true || response.Data["requestedPowerDbm"] != 45.0 {
+		t.Errorf("Expected clamped power 39 reported as adjusted from 45, got %v", response.Data)
+	}
+}
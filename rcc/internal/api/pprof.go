@@ -0,0 +1,88 @@
+package api
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/radio-control/rcc/internal/auth"
+)
+
+// PprofBasePath is where runtime profiling handlers are mounted when enabled.
+const PprofBasePath = "/debug/pprof/"
+
+// EnablePprof mounts the runtime profiling handlers under PprofBasePath for
+// admin-scoped clients whose address is within allowedCIDRs. It fails on a
+// server without authentication, which could not check the scope. Profiling
+// is off unless this is called. Must be called before Start.
+func (s *Server) EnablePprof(allowedCIDRs []string) error {
+	if s.authMiddleware == nil {
+		return fmt.Errorf("pprof requires authentication to check the admin scope")
+	}
+	nets, err := parseCIDRs(allowedCIDRs, "pprof allowed")
+	if err != nil {
+		return err
+	}
+	s.pprofNets = nets
+	return nil
+}
+
+// handlePprof returns the profiling handler chain: address allowlist, then
+// admin scope, then the net/http/pprof handlers.
+func (s *Server) handlePprof() http.HandlerFunc {
+	mux := http.NewServeMux()
+	mux.HandleFunc(PprofBasePath, pprof.Index)
+	mux.HandleFunc(PprofBasePath+"cmdline", pprof.Cmdline)
+	mux.HandleFunc(PprofBasePath+"profile", pprof.Profile)
+	mux.HandleFunc(PprofBasePath+"symbol", pprof.Symbol)
+	mux.HandleFunc(PprofBasePath+"trace", pprof.Trace)
+
+	handler := s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeAdmin)(mux.ServeHTTP))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.pprofAllowed(r) {
+			WriteError(w, http.StatusForbidden, "FORBIDDEN",
+				"Client address not allowed for debug endpoints", nil)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// pprofAllowed reports whether the request's remote address is in the pprof
+// allowlist. Forwarding headers are ignored since clients can set them.
+func (s *Server) pprofAllowed(r *http.Request) bool {
+	return remoteAddrIn(r, s.pprofNets)
+}
+
+// parseCIDRs parses an address allowlist; kind names it in errors.
+func parseCIDRs(cidrs []string, kind string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s CIDR %q: %w", kind, cidr, err)
+		}
+		nets = append(nets, network)
+	}
+	return nets, nil
+}
+
+// remoteAddrIn reports whether the request's remote address is within nets.
+func remoteAddrIn(r *http.Request, nets []*net.IPNet) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range nets {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
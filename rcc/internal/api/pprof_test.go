@@ -0,0 +1,85 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/radio-control/rcc/test/fixtures"
+)
+
+func TestPprofDisabledByDefault(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	server.authMiddleware = fixtures.NewAuthMiddleware()
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	req := httptest.NewRequest("GET", "/debug/pprof/", nil)
+	req.RemoteAddr = "127.0.0.1:40000"
+	req.Header.Set("Authorization", "Bearer admin-token")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 with pprof disabled, got %d", w.Code)
+	}
+}
+
+func TestPprofEnabled(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	server.authMiddleware = fixtures.NewAuthMiddleware()
+	if err := server.EnablePprof([]string{"127.0.0.0/8"}); err != nil {
+		t.Fatalf("EnablePprof failed: %v", err)
+	}
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	tests := []struct {
+		name       string
+		path       string
+		remoteAddr string
+		token      string
+		status     int
+	}{
+		{"admin from allowed address", "/debug/pprof/", "127.0.0.1:40000", "admin-token", http.StatusOK},
+		{"admin reads a named profile", "/debug/pprof/goroutine?debug=1", "127.0.0.1:40000", "admin-token", http.StatusOK},
+		{"controller lacks admin scope", "/debug/pprof/", "127.0.0.1:40000", "controller-token", http.StatusForbidden},
+		{"missing token", "/debug/pprof/", "127.0.0.1:40000", "", http.StatusUnauthorized},
+		{"admin from disallowed address", "/debug/pprof/", "192.0.2.10:40000", "admin-token", http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", test.path, nil)
+			req.RemoteAddr = test.remoteAddr
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			if w.Code != test.status {
+				t.Errorf("Expected status %d, got %d: %s", test.status, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestEnablePprofRequiresAuth(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	server.authMiddleware = nil
+	if err := server.EnablePprof([]string{"127.0.0.0/8"}); err == nil {
+		t.Fatal("Expected error enabling pprof without authentication")
+	}
+	if server.pprofNets != nil {
+		t.Error("Expected pprof to stay unmounted")
+	}
+}
+
+func TestEnablePprofRejectsInvalidCIDR(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	server.authMiddleware = fixtures.NewAuthMiddleware()
+	if err := server.EnablePprof([]string{"localhost"}); err == nil {
+		t.Error("Expected error for invalid CIDR")
+	}
+}
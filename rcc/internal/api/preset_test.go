@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/adapter/silvusmock"
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// recordingAdapter records the power and frequency it is told to set.
+type recordingAdapter struct {
+	*silvusmock.SilvusMock
+	powerDbm     float64
+	frequencyMhz float64
+}
+
+func (a *recordingAdapter) SetPower(ctx context.Context, dBm float64) error {
+	a.powerDbm = dBm
+	return a.SilvusMock.SetPower(ctx, dBm)
+}
+
+func (a *recordingAdapter) SetFrequency(ctx context.Context, frequencyMhz float64) error {
+	a.frequencyMhz = frequencyMhz
+	return a.SilvusMock.SetFrequency(ctx, frequencyMhz)
+}
+
+func postPreset(t *testing.T, server *Server, body string) (*httptest.ResponseRecorder, Response) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/radios/silvus-001/preset", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	server.handleRadioEndpoints(w, req)
+	var resp Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return w, resp
+}
+
+func TestApplyPreset(t *testing.T) {
+	server, rm, _, radioAdapter := setupAPITest(t)
+	recorder := &recordingAdapter{SilvusMock: radioAdapter.(*silvusmock.SilvusMock)}
+	if err := rm.SetAdapter("silvus-001", recorder); err != nil {
+		t.Fatalf("SetAdapter failed: %v", err)
+	}
+	r, err := rm.GetRadio("silvus-001")
+	if err != nil {
+		t.Fatalf("GetRadio failed: %v", err)
+	}
+
+	cfg := config.LoadCBTimingBaseline()
+	cfg.Presets = map[string]map[string]config.Preset{
+		strings.ToUpper(r.Model): {"Alpha": {FrequencyMhz: 2437, PowerDbm: 25}},
+	}
+	server.SetPresets(cfg)
+
+	w, resp := postPreset(t, server, `{"name": "Alpha"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	data, _ := resp.Data.(map[string]interface{})
+	if data["name"] != "Alpha" || data["frequencyMhz"] != 2437.0 || data["powerDbm"] != 25.0 {
+		t.Errorf("Expected preset Alpha at 2437 MHz and 25 dBm, got %v", data)
+	}
+	if recorder.frequencyMhz != 2437 || recorder.powerDbm != 25 {
+		t.Errorf("Expected adapter set to 2437 MHz and 25 dBm, got %v MHz and %v dBm",
+			recorder.frequencyMhz, recorder.powerDbm)
+	}
+
+	// Unknown presets fail without touching the radio
+	recorder.frequencyMhz, recorder.powerDbm = 0, 0
+	w, resp = postPreset(t, server, `{"name": "Bravo"}`)
+	if w.Code != http.StatusNotFound || resp.Code != "NOT_FOUND" {
+		t.Errorf("Expected 404 NOT_FOUND, got %d %s", w.Code, resp.Code)
+	}
+	if recorder.frequencyMhz != 0 || recorder.powerDbm != 0 {
+		t.Error("Expected no adapter calls for an unknown preset")
+	}
+
+	w, resp = postPreset(t, server, `{}`)
+	if w.Code != http.StatusBadRequest || resp.Code != "VALIDATION_FAILED" {
+		t.Errorf("Expected 400 VALIDATION_FAILED for a missing name, got %d %s", w.Code, resp.Code)
+	}
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ProblemContentType is the RFC 7807 media type clients request with Accept
+// to receive errors as problem details instead of the error envelope.
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix prefixes the envelope code to form the problem type URI.
+const problemTypePrefix = "urn:rcc:problem:"
+
+// Problem is an RFC 7807 problem details object. Code, Details and
+// CorrelationID carry the envelope fields as extension members.
+type Problem struct {
+	Type          string      `json:"type"`
+	Title         string      `json:"title"`
+	Status        int         `json:"status"`
+	Detail        string      `json:"detail,omitempty"`
+	Instance      string      `json:"instance,omitempty"`
+	Code          string      `json:"code"`
+	Details       interface{} `json:"details,omitempty"`
+	CorrelationID string      `json:"correlationId,omitempty"`
+}
+
+// withProblemJSON rewrites error envelopes as RFC 7807 problem details when
+// the client's Accept header asks for application/problem+json. Success
+// responses keep the normal envelope.
+func withProblemJSON(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept")
+		if !acceptsProblemJSON(r.Header.Get("Accept")) {
+			next(w, r)
+			return
+		}
+		pw := &problemWriter{ResponseWriter: w, instance: r.URL.Path}
+		next(pw, r)
+		pw.finish()
+	}
+}
+
+// acceptsProblemJSON reports whether the Accept header lists
+// application/problem+json with a non-zero quality.
+func acceptsProblemJSON(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || mediaType != ProblemContentType {
+			continue
+		}
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// problemWriter holds back JSON error responses so finish can rewrite them.
+// Everything else passes straight through.
+type problemWriter struct {
+	http.ResponseWriter
+	instance  string
+	status    int
+	buffering bool
+	buf       bytes.Buffer
+}
+
+func (pw *problemWriter) WriteHeader(status int) {
+	if pw.status != 0 {
+		return
+	}
+	pw.status = status
+	contentType := pw.Header().Get("Content-Type")
+	if status >= http.StatusBadRequest && strings.HasPrefix(contentType, "application/json") {
+		pw.buffering = true
+		return
+	}
+	pw.ResponseWriter.WriteHeader(status)
+}
+
+func (pw *problemWriter) Write(p []byte) (int, error) {
+	if pw.status == 0 {
+		pw.WriteHeader(http.StatusOK)
+	}
+	if pw.buffering {
+		return pw.buf.Write(p)
+	}
+	return pw.ResponseWriter.Write(p)
+}
+
+// Flush lets streaming handlers flush through the wrapper.
+func (pw *problemWriter) Flush() {
+	if flusher, ok := pw.ResponseWriter.(http.Flusher); ok && !pw.buffering {
+		flusher.Flush()
+	}
+}
+
+// Hijack lets WebSocket upgrades take over the connection.
+func (pw *problemWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := pw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	return hijacker.Hijack()
+}
+
+func (pw *problemWriter) Unwrap() http.ResponseWriter {
+	return pw.ResponseWriter
+}
+
+// finish writes a buffered error envelope as problem details. A body that
+// is not an error envelope is forwarded unchanged.
+func (pw *problemWriter) finish() {
+	if !pw.buffering {
+		return
+	}
+	var envelope Response
+	if err := json.Unmarshal(pw.buf.Bytes(), &envelope); err != nil || envelope.Result != "error" {
+		pw.ResponseWriter.WriteHeader(pw.status)
+		_, _ = pw.ResponseWriter.Write(pw.buf.Bytes())
+		return
+	}
+
+	problem := Problem{
+		Type:          problemTypePrefix + envelope.Code,
+		Title:         http.StatusText(pw.status),
+		Status:        pw.status,
+		Detail:        envelope.Message,
+		Instance:      pw.instance,
+		Code:          envelope.Code,
+		Details:       envelope.Details,
+		CorrelationID: envelope.CorrelationID,
+	}
+	pw.Header().Set("Content-Type", ProblemContentType)
+	pw.Header().Del("Content-Length")
+	pw.ResponseWriter.WriteHeader(pw.status)
+	_ = json.NewEncoder(pw.ResponseWriter).Encode(problem)
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/logging"
+)
+
+func postPowerAccept(t *testing.T, mux *http.ServeMux, body, accept string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/radios/silvus-001/power", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestProblemJSON(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	w := postPowerAccept(t, mux, `{"powerDbm": 100}`, "application/problem+json, application/json;q=0.5")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Type"); got != ProblemContentType {
+		t.Errorf("Expected %s, got %q", ProblemContentType, got)
+	}
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Failed to decode problem: %v", err)
+	}
+	if problem.Type != "urn:rcc:problem:INVALID_RANGE" || problem.Code != "INVALID_RANGE" ||
+		problem.Status != http.StatusBadRequest || problem.Title != "Bad Request" ||
+		problem.Detail == "" || problem.Instance != "/api/v1/radios/silvus-001/power" {
+		t.Errorf("Unexpected problem: %+v", problem)
+	}
+	if problem.CorrelationID == "" || problem.CorrelationID != w.Header().Get(logging.CorrelationIDHeader) {
+		t.Errorf("Expected the request correlation ID, got %q", problem.CorrelationID)
+	}
+
+	// Success keeps the envelope
+	w = postPowerAccept(t, mux, `{"powerDbm": 20}`, ProblemContentType)
+	var resp Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK || resp.Result != "ok" {
+		t.Errorf("Expected success envelope, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Errors keep the envelope unless problem+json is accepted
+	for _, accept := range []string{"", "application/json", "application/problem+json;q=0"} {
+		w = postPowerAccept(t, mux, `{"powerDbm": 100}`, accept)
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.Result != "error" || resp.Code != "INVALID_RANGE" {
+			t.Errorf("Accept %q: expected error envelope, got %s", accept, w.Body.String())
+		}
+	}
+}
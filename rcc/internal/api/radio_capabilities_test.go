@@ -0,0 +1,196 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/command"
+	"github.com/radio-control/rcc/internal/config"
+)
+
+func TestRadioCapabilitiesEndpoint(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	req := httptest.NewRequest("GET", "/api/v1/radios/silvus-001/capabilities", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response struct {
+		Result string `json:"result"`
+		Data   struct {
+			RadioID           string                     `json:"radioId"`
+			Channels          []adapter.Channel          `json:"channels"`
+			FrequencyProfiles []adapter.FrequencyProfile `json:"frequencyProfiles"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if response.Data.RadioID != "silvus-001" {
+		t.Errorf("Expected radioId silvus-001, got %q", response.Data.RadioID)
+	}
+
+	wantChannels := []adapter.Channel{{Index: 1, FrequencyMhz: 2412}, {Index: 6, FrequencyMhz: 2437}, {Index: 11, FrequencyMhz: 2462}}
+	if len(response.Data.Channels) != len(wantChannels) {
+		t.Fatalf("Expected %d channels, got %+v", len(wantChannels), response.Data.Channels)
+	}
+	for i, want := range wantChannels {
+		if response.Data.Channels[i] != want {
+			t.Errorf("Channel %d: expected %+v, got %+v", i, want, response.Data.Channels[i])
+		}
+	}
+
+	if len(response.Data.FrequencyProfiles) != 1 {
+		t.Fatalf("Expected 1 frequency profile, got %+v", response.Data.FrequencyProfiles)
+	}
+	profile := response.Data.FrequencyProfiles[0]
+	if len(profile.Frequencies) != 3 || profile.Frequencies[0] != 2412 {
+		t.Errorf("Expected profile frequencies from band plan, got %v", profile.Frequencies)
+	}
+	if profile.Bandwidth != 20 || profile.AntennaMask != 1 {
+		t.Errorf("Expected bandwidth 20 and antenna mask 1, got %+v", profile)
+	}
+
+	// Profile fields serialize with ICD names
+	var raw struct {
+		Data struct {
+			FrequencyProfiles []map[string]interface{} `json:"frequencyProfiles"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &raw); err != nil {
+		t.Fatalf("Failed to unmarshal raw response: %v", err)
+	}
+	for _, key := range []string{"frequencies", "bandwidth", "antenna_mask"} {
+		if _, ok := raw.Data.FrequencyProfiles[0][key]; !ok {
+			t.Errorf("Expected profile field %q in response", key)
+		}
+	}
+}
+
+func TestRadioCapabilitiesIncludesChannelLabels(t *testing.T) {
+	server, rm, _, _ := setupAPITest(t)
+	r, err := rm.GetRadio("silvus-001")
+	if err != nil {
+		t.Fatalf("GetRadio failed: %v", err)
+	}
+
+	cfg := config.LoadCBTimingBaseline()
+	cfg.SilvusBandPlan = &config.SilvusBandPlan{
+		Models: map[string]map[string][]config.SilvusChannel{
+			r.Model: {
+				"2.4GHz": {
+					{ChannelIndex: 1, FrequencyMhz: 2412, Label: "Command Net", Usage: "primary voice"},
+					{ChannelIndex: 11, FrequencyMhz: 2462, Label: "Guard"},
+				},
+			},
+		},
+	}
+	orch := command.NewOrchestrator(nil, cfg)
+	orch.SetRadioManager(rm)
+	server.orchestrator = orch
+
+	req := httptest.NewRequest("GET", "/api/v1/radios/silvus-001/capabilities", nil)
+	w := httptest.NewRecorder()
+	server.handleRadioCapabilities(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		Data struct {
+			Channels []adapter.Channel `json:"channels"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	want := []adapter.Channel{
+		{Index: 1, FrequencyMhz: 2412, Label: "Command Net", Usage: "primary voice"},
+		{Index: 6, FrequencyMhz: 2437},
+		{Index: 11, FrequencyMhz: 2462, Label: "Guard"},
+	}
+	if len(response.Data.Channels) != len(want) {
+		t.Fatalf("Expected %d channels, got %+v", len(want), response.Data.Channels)
+	}
+	for i := range want {
+		if response.Data.Channels[i] != want[i] {
+			t.Errorf("Channel %d: expected %+v, got %+v", i, want[i], response.Data.Channels[i])
+		}
+	}
+
+	// Labels are added to the response, not to the radio's stored channel plan
+	if r.Capabilities.Channels[0].Label != "" {
+		t.Errorf("Expected stored channel plan to stay unlabeled, got %+v", r.Capabilities.Channels[0])
+	}
+}
+
+func TestRadioCapabilitiesNotFound(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	req := httptest.NewRequest("GET", "/api/v1/radios/no-such-radio/capabilities", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("Expected 404, got %d", w.Code)
+	}
+	var response Response
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Code != "NOT_FOUND" {
+		t.Errorf("Expected NOT_FOUND, got %q", response.Code)
+	}
+}
+
+// noProfilesAdapter wraps an adapter whose profile query is not supported.
+type noProfilesAdapter struct {
+	adapter.IRadioAdapter
+}
+
+func (noProfilesAdapter) SupportedFrequencyProfiles(ctx context.Context) ([]adapter.FrequencyProfile, error) {
+	return nil, errors.New("supported_frequency_profiles: method not found")
+}
+
+func TestRadioCapabilitiesWithoutProfiles(t *testing.T) {
+	server, rm, _, radioAdapter := setupAPITest(t)
+	if err := rm.SetAdapter("silvus-001", noProfilesAdapter{radioAdapter}); err != nil {
+		t.Fatalf("Failed to set adapter: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/api/v1/radios/silvus-001/capabilities", nil)
+	w := httptest.NewRecorder()
+	server.handleRadioCapabilities(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 when profiles are unsupported, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var raw struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &raw); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if got := string(raw.Data["frequencyProfiles"]); got != "[]" {
+		t.Errorf("Expected empty frequencyProfiles array, got %s", got)
+	}
+	if got := string(raw.Data["channels"]); got == "[]" || got == "null" {
+		t.Errorf("Expected channels to still be reported, got %s", got)
+	}
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/radio-control/rcc/internal/radio"
+)
+
+// RadioStatesPath is the bulk state snapshot endpoint.
+const RadioStatesPath = APIBasePath + "/radios/state"
+
+// radioStateWorkers bounds how many radios a snapshot queries at once.
+const radioStateWorkers = 8
+
+// RadioStateResult is one radio's entry in a state snapshot. Status is the
+// HTTP status GET /radios/{id}/power would have had for the radio.
+type RadioStateResult struct {
+	Status       int      `json:"status"`
+	Result       string   `json:"result"`
+	PowerDbm     *float64 `json:"powerDbm,omitempty"`
+	FrequencyMhz *float64 `json:"frequencyMhz,omitempty"`
+	Code         string   `json:"code,omitempty"`
+	Message      string   `json:"message,omitempty"`
+}
+
+// handleRadioStates handles GET /radios/state, the state of every radio, or
+// of those matching ?ids=, ?status= and ?band=, in one response. A radio
+// that cannot be read is reported as an error entry; the snapshot itself
+// still succeeds.
+func (s *Server) handleRadioStates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only GET method is allowed", nil)
+		return
+	}
+	if s.radioManager == nil || s.orchestrator == nil {
+		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE", "Service not available", nil)
+		return
+	}
+
+	radioIDs := s.snapshotRadioIDs(r)
+	states := s.fetchRadioStates(r.Context(), radioIDs)
+
+	failed := 0
+	for _, state := range states {
+		if state.Status != http.StatusOK {
+			failed++
+		}
+	}
+	WriteSuccess(w, map[string]interface{}{
+		"radios":    states,
+		"succeeded": len(states) - failed,
+		"failed":    failed,
+	})
+}
+
+// snapshotRadioIDs returns the radios a snapshot covers: those named by
+// ?ids= (comma-separated, unknown IDs included so they report NOT_FOUND),
+// else every radio, narrowed by ?status= and ?band= as on GET /radios.
+func (s *Server) snapshotRadioIDs(r *http.Request) []string {
+	values := r.URL.Query()
+	filter := &radioListQuery{
+		status: strings.TrimSpace(values.Get("status")),
+		band:   strings.TrimSpace(values.Get("band")),
+	}
+
+	known := make(map[string]radio.Radio)
+	var all []string
+	for _, item := range s.radioManager.List().Items {
+		known[item.ID] = item
+		all = append(all, item.ID)
+	}
+
+	requested := all
+	if raw := values.Get("ids"); raw != "" {
+		requested = nil
+		seen := make(map[string]bool)
+		for _, id := range strings.Split(raw, ",") {
+			id = strings.TrimSpace(id)
+			if id != "" && !seen[id] {
+				seen[id] = true
+				requested = append(requested, id)
+			}
+		}
+	}
+
+	radioIDs := make([]string, 0, len(requested))
+	for _, id := range requested {
+		if item, ok := known[id]; ok && !filter.matches(item) {
+			continue
+		}
+		radioIDs = append(radioIDs, id)
+	}
+	return radioIDs
+}
+
+// fetchRadioStates reads each radio's state, at most radioStateWorkers at a
+// time, and returns the results by radio ID.
+func (s *Server) fetchRadioStates(ctx context.Context, radioIDs []string) map[string]RadioStateResult {
+	results := make(map[string]RadioStateResult, len(radioIDs))
+	var mu sync.Mutex
+
+	ids := make(chan string)
+	var wg sync.WaitGroup
+	workers := radioStateWorkers
+	if len(radioIDs) < workers {
+		workers = len(radioIDs)
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for radioID := range ids {
+				result := s.radioStateResult(ctx, radioID)
+				mu.Lock()
+				results[radioID] = result
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, radioID := range radioIDs {
+		ids <- radioID
+	}
+	close(ids)
+	wg.Wait()
+
+	return results
+}
+
+// radioStateResult reads one radio's state and reports the outcome as the
+// single-radio endpoint would.
+func (s *Server) radioStateResult(ctx context.Context, radioID string) RadioStateResult {
+	state, err := s.orchestrator.GetState(ctx, radioID)
+	if err != nil {
+		status, response := toAPIError(err)
+		return RadioStateResult{
+			Status:  status,
+			Result:  "error",
+			Code:    response.Code,
+			Message: response.Message,
+		}
+	}
+	return RadioStateResult{
+		Status:       http.StatusOK,
+		Result:       "ok",
+		PowerDbm:     &state.PowerDbm,
+		FrequencyMhz: &state.FrequencyMhz,
+	}
+}
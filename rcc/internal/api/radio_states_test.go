@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/adapter/silvusmock"
+)
+
+// radioStatesResponse is the envelope of GET /radios/state.
+type radioStatesResponse struct {
+	Data struct {
+		Radios    map[string]RadioStateResult `json:"radios"`
+		Succeeded int                         `json:"succeeded"`
+		Failed    int                         `json:"failed"`
+	} `json:"data"`
+}
+
+func getRadioStates(t *testing.T, mux *http.ServeMux, query string) radioStatesResponse {
+	t.Helper()
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, RadioStatesPath+query, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /radios/state%s: expected 200, got %d: %s", query, w.Code, w.Body.String())
+	}
+	var resp radioStatesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return resp
+}
+
+func TestRadioStatesMixedResults(t *testing.T) {
+	server, rm, _, _ := setupAPITest(t)
+
+	// A second radio whose adapter cannot be reached
+	failing := silvusmock.NewSilvusMock("silvus-002", []adapter.Channel{{Index: 1, FrequencyMhz: 2412}})
+	if err := rm.LoadCapabilities("silvus-002", failing, 5*time.Second); err != nil {
+		t.Fatalf("LoadCapabilities failed: %v", err)
+	}
+	failing.SetFaultMode("ReturnUnavailable")
+
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	resp := getRadioStates(t, mux, "")
+	if resp.Data.Succeeded != 1 || resp.Data.Failed != 1 || len(resp.Data.Radios) != 2 {
+		t.Fatalf("Expected one success and one failure, got %+v", resp.Data)
+	}
+	healthy := resp.Data.Radios["silvus-001"]
+	if healthy.Result != "ok" || healthy.PowerDbm == nil || healthy.FrequencyMhz == nil {
+		t.Errorf("Expected silvus-001 state, got %+v", healthy)
+	}
+	unavailable := resp.Data.Radios["silvus-002"]
+	if unavailable.Result != "error" || unavailable.Status != http.StatusServiceUnavailable || unavailable.Code != "UNAVAILABLE" {
+		t.Errorf("Expected an UNAVAILABLE entry for silvus-002, got %+v", unavailable)
+	}
+
+	// A subset by ID; unknown IDs are reported rather than dropped
+	resp = getRadioStates(t, mux, "?ids=silvus-001,silvus-404")
+	if len(resp.Data.Radios) != 2 || resp.Data.Radios["silvus-001"].Result != "ok" {
+		t.Fatalf("Expected only the requested radios, got %+v", resp.Data.Radios)
+	}
+	if missing := resp.Data.Radios["silvus-404"]; missing.Status != http.StatusNotFound || missing.Code != "NOT_FOUND" {
+		t.Errorf("Expected a NOT_FOUND entry for silvus-404, got %+v", missing)
+	}
+}
+
+// readTracker records the peak number of GetState calls in flight at once.
+type readTracker struct {
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+// trackedAdapter reports its GetState calls to a readTracker.
+type trackedAdapter struct {
+	adapter.IRadioAdapter
+	tracker *readTracker
+}
+
+func (a trackedAdapter) GetState(ctx context.Context) (*adapter.RadioState, error) {
+	a.tracker.mu.Lock()
+	a.tracker.inFlight++
+	if a.tracker.inFlight > a.tracker.peak {
+		a.tracker.peak = a.tracker.inFlight
+	}
+	a.tracker.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	a.tracker.mu.Lock()
+	a.tracker.inFlight--
+	a.tracker.mu.Unlock()
+	return a.IRadioAdapter.GetState(ctx)
+}
+
+func TestRadioStatesBoundsConcurrency(t *testing.T) {
+	server, rm, _, radioAdapter := setupAPITest(t)
+	tracker := &readTracker{}
+	for i := 0; i < 3*radioStateWorkers; i++ {
+		id := fmt.Sprintf("radio-%02d", i)
+		if err := rm.LoadCapabilities(id, trackedAdapter{radioAdapter, tracker}, 5*time.Second); err != nil {
+			t.Fatalf("LoadCapabilities failed: %v", err)
+		}
+	}
+	// Worker peaks count only snapshot reads, not capability loading
+	tracker.mu.Lock()
+	tracker.peak = 0
+	tracker.mu.Unlock()
+
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+	resp := getRadioStates(t, mux, "")
+	if resp.Data.Succeeded != 3*radioStateWorkers+1 {
+		t.Fatalf("Expected every radio read, got %+v", resp.Data)
+	}
+	if tracker.peak < 2 || tracker.peak > radioStateWorkers {
+		t.Errorf("Expected between 2 and %d concurrent reads, got %d", radioStateWorkers, tracker.peak)
+	}
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/radio-control/rcc/internal/radio"
+)
+
+const (
+	// DefaultRadioPageLimit is the page size used when no limit is given.
+	DefaultRadioPageLimit = 50
+
+	// MaxRadioPageLimit is the largest page size a client may request.
+	MaxRadioPageLimit = 500
+)
+
+// RadioPage is the paginated response for GET /radios.
+type RadioPage struct {
+	ActiveRadioID string        `json:"activeRadioId"`
+	Items         []radio.Radio `json:"items"`
+	Total         int           `json:"total"`
+	Limit         int           `json:"limit"`
+	Offset        int           `json:"offset"`
+}
+
+// radioListQuery holds the paging and filter parameters for GET /radios.
+type radioListQuery struct {
+	limit  int
+	offset int
+	status string
+	band   string
+}
+
+// parseRadioListQuery parses ?limit=&offset=&status=&band= from the request.
+func parseRadioListQuery(r *http.Request) (*radioListQuery, error) {
+	values := r.URL.Query()
+	query := &radioListQuery{
+		limit:  DefaultRadioPageLimit,
+		status: strings.TrimSpace(values.Get("status")),
+		band:   strings.TrimSpace(values.Get("band")),
+	}
+
+	if raw := values.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > MaxRadioPageLimit {
+			return nil, fmt.Errorf("limit must be an integer between 1 and %d", MaxRadioPageLimit)
+		}
+		query.limit = limit
+	}
+
+	if raw := values.Get("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			return nil, fmt.Errorf("offset must be a non-negative integer")
+		}
+		query.offset = offset
+	}
+
+	return query, nil
+}
+
+// apply filters the radio list and returns the requested page.
+func (q *radioListQuery) apply(list *radio.RadioList) *RadioPage {
+	filtered := make([]radio.Radio, 0, len(list.Items))
+	for _, item := range list.Items {
+		if q.matches(item) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	page := &RadioPage{
+		ActiveRadioID: list.ActiveRadioID,
+		Items:         []radio.Radio{},
+		Total:         len(filtered),
+		Limit:         q.limit,
+		Offset:        q.offset,
+	}
+
+	if q.offset < len(filtered) {
+		end := q.offset + q.limit
+		if end > len(filtered) {
+			end = len(filtered)
+		}
+		page.Items = filtered[q.offset:end]
+	}
+
+	return page
+}
+
+// matches reports whether a radio satisfies the status and band filters.
+func (q *radioListQuery) matches(item radio.Radio) bool {
+	// Status filters on current connectivity state
+	if q.status != "" && !strings.EqualFold(item.Status, q.status) {
+		return false
+	}
+
+	// Band filters on the radio's capability bands
+	if q.band != "" {
+		if item.Capabilities == nil {
+			return false
+		}
+		for _, band := range item.Capabilities.Bands {
+			if strings.EqualFold(band, q.band) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
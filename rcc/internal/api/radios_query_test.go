@@ -0,0 +1,245 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/adapter/fake"
+	"github.com/radio-control/rcc/internal/adapter/silvusmock"
+	"github.com/radio-control/rcc/internal/command"
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/radio"
+	"github.com/radio-control/rcc/internal/telemetry"
+)
+
+// bandedAdapter is a SilvusMock that reports capability bands.
+type bandedAdapter struct {
+	*silvusmock.SilvusMock
+	bands []string
+}
+
+func (a *bandedAdapter) GetBands() []string {
+	return a.bands
+}
+
+// setupRadioListTest creates a server with five radios across bands and statuses.
+func setupRadioListTest(t *testing.T) *Server {
+	cfg := config.LoadCBTimingBaseline()
+	hub := telemetry.NewHub(cfg)
+	t.Cleanup(func() { hub.Stop() })
+
+	rm := radio.NewManager()
+	radios := []struct {
+		id     string
+		bands  []string
+		status string
+	}{
+		{"radio-01", []string{"2.4GHz"}, "online"},
+		{"radio-02", []string{"2.4GHz", "5GHz"}, "offline"},
+		{"radio-03", []string{"5GHz"}, "online"},
+		{"radio-04", []string{"UHF"}, "online"},
+		{"radio-05", nil, "offline"},
+	}
+	for _, r := range radios {
+		mock := silvusmock.NewSilvusMock(r.id, []adapter.Channel{{Index: 1, FrequencyMhz: 2412}})
+		if err := rm.LoadCapabilities(r.id, &bandedAdapter{SilvusMock: mock, bands: r.bands}, 5*time.Second); err != nil {
+			t.Fatalf("Failed to load capabilities for %s: %v", r.id, err)
+		}
+		if err := rm.UpdateStatus(r.id, r.status); err != nil {
+			t.Fatalf("Failed to set status for %s: %v", r.id, err)
+		}
+	}
+
+	orch := command.NewOrchestrator(hub, cfg)
+	orch.SetRadioManager(rm)
+
+	return NewServer(hub, orch, rm, 30*time.Second, 30*time.Second, 120*time.Second)
+}
+
+// getRadioPage issues GET /radios with the query and decodes the page.
+func getRadioPage(t *testing.T, server *Server, query string) (int, RadioPage, Response) {
+	req := httptest.NewRequest("GET", "/api/v1/radios"+query, nil)
+	w := httptest.NewRecorder()
+	server.handleRadios(w, req)
+
+	var envelope struct {
+		Response
+		Data RadioPage `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	return w.Code, envelope.Data, envelope.Response
+}
+
+func radioIDs(page RadioPage) []string {
+	ids := make([]string, 0, len(page.Items))
+	for _, item := range page.Items {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+func equalIDs(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRadiosFiltering(t *testing.T) {
+	server := setupRadioListTest(t)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"no filters", "", []string{"radio-01", "radio-02", "radio-03", "radio-04", "radio-05"}},
+		{"status online", "?status=online", []string{"radio-01", "radio-03", "radio-04"}},
+		{"status case insensitive", "?status=OFFLINE", []string{"radio-02", "radio-05"}},
+		{"band", "?band=5GHz", []string{"radio-02", "radio-03"}},
+		{"band and status", "?band=2.4GHz&status=online", []string{"radio-01"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, page, _ := getRadioPage(t, server, tt.query)
+			if code != http.StatusOK {
+				t.Fatalf("Expected 200, got %d", code)
+			}
+			if got := radioIDs(page); !equalIDs(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+			if page.Total != len(tt.want) {
+				t.Errorf("Expected total %d, got %d", len(tt.want), page.Total)
+			}
+		})
+	}
+}
+
+func TestRadiosFilterByBandPlanBands(t *testing.T) {
+	cfg := config.LoadCBTimingBaseline()
+	hub := telemetry.NewHub(cfg)
+	t.Cleanup(func() { hub.Stop() })
+
+	// The fake adapter reports no bands; they come from the band plan
+	rm := radio.NewManager()
+	rm.SetBandPlan(&config.SilvusBandPlan{
+		Models: map[string]map[string][]config.SilvusChannel{
+			"Unknown-Radio": {
+				"2.4GHz": {{ChannelIndex: 1, FrequencyMhz: 2412.0}},
+				"5GHz":   {{ChannelIndex: 36, FrequencyMhz: 5180.0}},
+			},
+		},
+	})
+	if err := rm.LoadCapabilities("fake-001", fake.NewFakeAdapter("fake-001"), 5*time.Second); err != nil {
+		t.Fatalf("Failed to load capabilities: %v", err)
+	}
+	orch := command.NewOrchestrator(hub, cfg)
+	orch.SetRadioManager(rm)
+	server := NewServer(hub, orch, rm, 30*time.Second, 30*time.Second, 120*time.Second)
+
+	tests := map[string][]string{
+		"?band=5GHz":   {"fake-001"},
+		"?band=2.4ghz": {"fake-001"},
+		"?band=UHF":    {},
+	}
+	for query, want := range tests {
+		code, page, _ := getRadioPage(t, server, query)
+		if code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", query, code)
+		}
+		if got := radioIDs(page); !equalIDs(got, want) {
+			t.Errorf("%s: expected %v, got %v", query, want, got)
+		}
+	}
+}
+
+func TestRadiosPaging(t *testing.T) {
+	server := setupRadioListTest(t)
+
+	tests := []struct {
+		name       string
+		query      string
+		want       []string
+		wantLimit  int
+		wantOffset int
+	}{
+		{"default limit", "", []string{"radio-01", "radio-02", "radio-03", "radio-04", "radio-05"}, DefaultRadioPageLimit, 0},
+		{"first page", "?limit=2", []string{"radio-01", "radio-02"}, 2, 0},
+		{"middle page", "?limit=2&offset=2", []string{"radio-03", "radio-04"}, 2, 2},
+		{"partial last page", "?limit=2&offset=4", []string{"radio-05"}, 2, 4},
+		{"offset at total", "?limit=2&offset=5", []string{}, 2, 5},
+		{"offset beyond total", "?offset=100", []string{}, DefaultRadioPageLimit, 100},
+		{"max limit", "?limit=500", []string{"radio-01", "radio-02", "radio-03", "radio-04", "radio-05"}, MaxRadioPageLimit, 0},
+		{"paged filter", "?status=online&limit=1&offset=1", []string{"radio-03"}, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, page, _ := getRadioPage(t, server, tt.query)
+			if code != http.StatusOK {
+				t.Fatalf("Expected 200, got %d", code)
+			}
+			if got := radioIDs(page); !equalIDs(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+			if page.Limit != tt.wantLimit || page.Offset != tt.wantOffset {
+				t.Errorf("Expected limit=%d offset=%d, got limit=%d offset=%d", tt.wantLimit, tt.wantOffset, page.Limit, page.Offset)
+			}
+		})
+	}
+}
+
+func TestRadiosEmptyResults(t *testing.T) {
+	server := setupRadioListTest(t)
+
+	code, page, _ := getRadioPage(t, server, "?band=VHF")
+	if code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	if page.Items == nil || len(page.Items) != 0 {
+		t.Errorf("Expected empty items array, got %v", page.Items)
+	}
+	if page.Total != 0 {
+		t.Errorf("Expected total 0, got %d", page.Total)
+	}
+	if page.ActiveRadioID != "radio-01" {
+		t.Errorf("Expected activeRadioId to be preserved, got %q", page.ActiveRadioID)
+	}
+}
+
+func TestRadiosInvalidPaging(t *testing.T) {
+	server := setupRadioListTest(t)
+
+	queries := []string{
+		"?limit=0",
+		"?limit=-1",
+		"?limit=501",
+		"?limit=abc",
+		"?offset=-1",
+		"?offset=1.5",
+	}
+
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			code, _, response := getRadioPage(t, server, query)
+			if code != http.StatusBadRequest {
+				t.Errorf("Expected 400, got %d", code)
+			}
+			if response.Code != "BAD_REQUEST" {
+				t.Errorf("Expected code BAD_REQUEST, got %q", response.Code)
+			}
+		})
+	}
+}
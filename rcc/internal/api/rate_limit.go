@@ -0,0 +1,125 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/radio-control/rcc/internal/auth"
+)
+
+// maxRateLimitBuckets bounds the number of tracked clients before idle buckets are pruned.
+const maxRateLimitBuckets = 10000
+
+// RateLimiter is a token-bucket limiter keyed by client identity.
+// A rate of 0 or less disables limiting.
+type RateLimiter struct {
+	mu      sync.Mutex
+	rate    float64 // tokens per second
+	burst   int
+	buckets map[string]*tokenBucket
+
+	// now is overridable for tests
+	now func() time.Time
+}
+
+// tokenBucket holds the token state for a single client.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a limiter allowing rate requests per second with the given burst.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:    rate,
+		burst:   burst,
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// Allow consumes a token for key. When no token is available it returns false
+// and the time until the next token becomes available.
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	if l == nil || l.rate <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, exists := l.buckets[key]
+	if !exists {
+		if len(l.buckets) >= maxRateLimitBuckets {
+			l.pruneLocked(now)
+		}
+		b = &tokenBucket{tokens: float64(l.burst), last: now}
+		l.buckets[key] = b
+	}
+
+	// Refill tokens for elapsed time
+	elapsed := now.Sub(b.last).Seconds()
+	b.tokens = math.Min(float64(l.burst), b.tokens+elapsed*l.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
+
+// pruneLocked drops buckets that have refilled completely; callers must hold l.mu.
+func (l *RateLimiter) pruneLocked(now time.Time) {
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= float64(l.burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// clientIdentity returns the rate limit key for a request: the bearer subject
+// when authenticated, otherwise the remote IP.
+func clientIdentity(r *http.Request) string {
+	if claims := auth.GetClaimsFromRequest(r); claims != nil && claims.Subject != "" {
+		return "sub:" + claims.Subject
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// withRateLimit wraps next with a per-client limiter, returning 429 RATE_LIMITED when exceeded.
+// Telemetry SSE connections are counted separately from command requests.
+func (s *Server) withRateLimit(telemetry bool, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limiter := s.commandLimiter
+		if telemetry {
+			limiter = s.telemetryLimiter
+		}
+		allowed, wait := limiter.Allow(clientIdentity(r))
+		if !allowed {
+			retryAfter := int(math.Ceil(wait.Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			w.Header().Set("Retry-After", fmt.Sprintf("%d", retryAfter))
+			WriteError(w, http.StatusTooManyRequests, "RATE_LIMITED",
+				"Rate limit exceeded", map[string]interface{}{"retryAfterSec": retryAfter})
+			return
+		}
+		next(w, r)
+	}
+}
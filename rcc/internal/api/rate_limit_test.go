@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/auth"
+)
+
+// fakeClock returns a controllable time source for limiter tests.
+func fakeClock(limiter *RateLimiter) *time.Time {
+	clock := time.Unix(1700000000, 0)
+	limiter.now = func() time.Time { return clock }
+	return &clock
+}
+
+func TestRateLimitBurstAndRecovery(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	server.SetRateLimit(1, 3)
+	clock := fakeClock(server.commandLimiter)
+
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	doRequest := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/v1/radios", nil)
+		req.RemoteAddr = "192.0.2.10:5000"
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	// Burst is allowed
+	for i := 0; i < 3; i++ {
+		if w := doRequest(); w.Code != http.StatusOK {
+			t.Fatalf("Request %d: expected 200, got %d", i+1, w.Code)
+		}
+	}
+
+	// Exceeding the burst returns the RATE_LIMITED envelope
+	w := doRequest()
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429, got %d", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "1" {
+		t.Errorf("Expected Retry-After 1, got %q", got)
+	}
+
+	var response Response
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Result != "error" || response.Code != "RATE_LIMITED" {
+		t.Errorf("Expected error envelope with RATE_LIMITED, got result=%q code=%q", response.Result, response.Code)
+	}
+	if response.CorrelationID == "" {
+		t.Error("Expected correlation ID in 429 envelope")
+	}
+
+	// Recovery after the refill window
+	*clock = clock.Add(time.Second)
+	if w := doRequest(); w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 after recovery, got %d", w.Code)
+	}
+	if w := doRequest(); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429 once refilled token is spent, got %d", w.Code)
+	}
+}
+
+func TestRateLimitKeyedByClient(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	server.SetRateLimit(1, 1)
+	fakeClock(server.commandLimiter)
+
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	doRequest := func(remoteAddr string) int {
+		req := httptest.NewRequest("GET", "/api/v1/radios", nil)
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := doRequest("192.0.2.10:5000"); code != http.StatusOK {
+		t.Fatalf("Expected 200 for first client, got %d", code)
+	}
+	// Same IP on a different port shares the bucket
+	if code := doRequest("192.0.2.10:6000"); code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429 for same client IP, got %d", code)
+	}
+	if code := doRequest("192.0.2.20:5000"); code != http.StatusOK {
+		t.Fatalf("Expected 200 for second client, got %d", code)
+	}
+}
+
+func TestRateLimitKeyedBySubject(t *testing.T) {
+	limiter := NewRateLimiter(1, 1)
+	fakeClock(limiter)
+
+	withSubject := func(subject, remoteAddr string) *http.Request {
+		req := httptest.NewRequest("GET", "/api/v1/radios", nil)
+		req.RemoteAddr = remoteAddr
+		claims := &auth.Claims{Subject: subject}
+		return req.WithContext(context.WithValue(req.Context(), auth.ClaimsKey, claims))
+	}
+
+	// Bearer subject takes precedence over remote IP
+	if key := clientIdentity(withSubject("alice", "192.0.2.10:5000")); key != "sub:alice" {
+		t.Fatalf("Expected subject key, got %q", key)
+	}
+
+	if ok, _ := limiter.Allow(clientIdentity(withSubject("alice", "192.0.2.10:5000"))); !ok {
+		t.Fatal("Expected first request for alice to be allowed")
+	}
+	if ok, _ := limiter.Allow(clientIdentity(withSubject("alice", "192.0.2.99:5000"))); ok {
+		t.Fatal("Expected alice to be limited from another IP")
+	}
+	if ok, _ := limiter.Allow(clientIdentity(withSubject("bob", "192.0.2.10:5000"))); !ok {
+		t.Fatal("Expected bob to be allowed from alice's IP")
+	}
+}
+
+func TestRateLimitTelemetryCountedSeparately(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	server.SetRateLimit(1, 1)
+	server.SetTelemetryRateLimit(1, 1)
+	fakeClock(server.commandLimiter)
+	fakeClock(server.telemetryLimiter)
+
+	called := 0
+	telemetry := server.withRateLimit(true, func(w http.ResponseWriter, r *http.Request) { called++ })
+	command := server.withRateLimit(false, func(w http.ResponseWriter, r *http.Request) { called++ })
+
+	doRequest := func(h http.HandlerFunc) int {
+		req := httptest.NewRequest("GET", "/api/v1/telemetry", nil)
+		req.RemoteAddr = "192.0.2.10:5000"
+		w := httptest.NewRecorder()
+		h(w, req)
+		return w.Code
+	}
+
+	// Exhaust the command bucket; telemetry still has its own budget
+	if code := doRequest(command); code != http.StatusOK {
+		t.Fatalf("Expected command request allowed, got %d", code)
+	}
+	if code := doRequest(command); code != http.StatusTooManyRequests {
+		t.Fatalf("Expected command request limited, got %d", code)
+	}
+	if code := doRequest(telemetry); code != http.StatusOK {
+		t.Fatalf("Expected telemetry connection allowed, got %d", code)
+	}
+	if code := doRequest(telemetry); code != http.StatusTooManyRequests {
+		t.Fatalf("Expected telemetry connection limited, got %d", code)
+	}
+	if called != 2 {
+		t.Errorf("Expected 2 handler calls, got %d", called)
+	}
+}
+
+func TestRateLimitDisabledByDefault(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	for i := 0; i < 50; i++ {
+		req := httptest.NewRequest("GET", "/api/v1/radios", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Request %d: expected 200 without limiter, got %d", i+1, w.Code)
+		}
+	}
+}
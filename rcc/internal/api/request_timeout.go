@@ -0,0 +1,137 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// requestTimeoutMargin is reserved out of the write timeout so the TIMEOUT
+// envelope reaches the client before the HTTP server's own write deadline
+// closes the connection.
+const requestTimeoutMargin = time.Second
+
+// SetRequestTimeout overrides the deadline applied to each command request.
+// By default it is the write timeout less requestTimeoutMargin; 0 or less
+// restores the default. Must be called before Start.
+func (s *Server) SetRequestTimeout(d time.Duration) {
+	s.requestTimeoutOverride = d
+}
+
+// requestTimeout returns the per-request command deadline, or 0 when the
+// server has no write timeout and requests are unbounded.
+func (s *Server) requestTimeout() time.Duration {
+	if s.requestTimeoutOverride > 0 {
+		return s.requestTimeoutOverride
+	}
+	if s.writeTimeout > 2*requestTimeoutMargin {
+		return s.writeTimeout - requestTimeoutMargin
+	}
+	return s.writeTimeout
+}
+
+// withRequestTimeout runs next under a context deadline of requestTimeout.
+// The orchestrator derives its per-command timeouts from the request
+// context, so whichever deadline is tighter wins. If next has not finished
+// when the deadline passes, the client gets HTTP 503 TIMEOUT and anything
+// next writes afterwards is discarded.
+func (s *Server) withRequestTimeout(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		timeout := s.requestTimeout()
+		if timeout <= 0 {
+			next(w, r)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		tw := &timeoutWriter{header: w.Header().Clone()}
+		done := make(chan struct{})
+		panicked := make(chan interface{}, 1)
+		go func() {
+			defer func() {
+				if p := recover(); p != nil {
+					panicked <- p
+					return
+				}
+				close(done)
+			}()
+			next(tw, r.WithContext(ctx))
+		}()
+
+		select {
+		case p := <-panicked:
+			panic(p)
+		case <-done:
+			tw.flushTo(w)
+		case <-ctx.Done():
+			tw.expire()
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				WriteError(w, http.StatusServiceUnavailable, "TIMEOUT",
+					fmt.Sprintf("Request did not complete within %v", timeout), nil)
+			}
+		}
+	}
+}
+
+// timeoutWriter buffers a handler's response until withRequestTimeout
+// decides whether to forward it or replace it with a TIMEOUT envelope.
+type timeoutWriter struct {
+	mu       sync.Mutex
+	header   http.Header
+	buf      bytes.Buffer
+	status   int
+	timedOut bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(status int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut || tw.status != 0 {
+		return
+	}
+	tw.status = status
+}
+
+func (tw *timeoutWriter) Write(p []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if tw.status == 0 {
+		tw.status = http.StatusOK
+	}
+	return tw.buf.Write(p)
+}
+
+// expire marks the response abandoned so later writes fail.
+func (tw *timeoutWriter) expire() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.timedOut = true
+}
+
+// flushTo copies the buffered headers, status and body to w.
+func (tw *timeoutWriter) flushTo(w http.ResponseWriter) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	dst := w.Header()
+	for k, v := range tw.header {
+		dst[k] = v
+	}
+	if tw.status == 0 {
+		tw.status = http.StatusOK
+	}
+	w.WriteHeader(tw.status)
+	_, _ = w.Write(tw.buf.Bytes())
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter/silvusmock"
+	"github.com/radio-control/rcc/internal/command"
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/telemetry"
+)
+
+// slowAdapter holds SetPower until its context is done.
+type slowAdapter struct {
+	*silvusmock.SilvusMock
+}
+
+func (a slowAdapter) SetPower(ctx context.Context, dBm float64) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func postPower(t *testing.T, server *Server) (*httptest.ResponseRecorder, time.Duration) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/radios/silvus-001/power", strings.NewReader(`{"powerDbm": 20}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	start := time.Now()
+	server.handleRadioEndpoints(w, req)
+	return w, time.Since(start)
+}
+
+func TestRequestTimeout(t *testing.T) {
+	server, rm, _, radioAdapter := setupAPITest(t)
+	server.SetRequestTimeout(50 * time.Millisecond)
+
+	// A prompt command is forwarded unchanged
+	w, _ := postPower(t, server)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for a fast command, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		t.Errorf("Expected buffered headers to be forwarded, got %v", w.Header())
+	}
+
+	if err := rm.SetAdapter("silvus-001", slowAdapter{radioAdapter.(*silvusmock.SilvusMock)}); err != nil {
+		t.Fatalf("SetAdapter failed: %v", err)
+	}
+
+	w, elapsed := postPower(t, server)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503 for a slow command, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Result != "error" || resp.Code != "TIMEOUT" {
+		t.Errorf("Expected TIMEOUT envelope, got %+v", resp)
+	}
+	if elapsed > time.Second {
+		t.Errorf("Expected the request deadline to cut the command short, took %v", elapsed)
+	}
+}
+
+func TestRequestTimeoutOrchestratorDeadlineWins(t *testing.T) {
+	server, rm, _, radioAdapter := setupAPITest(t)
+	if err := rm.SetAdapter("silvus-001", slowAdapter{radioAdapter.(*silvusmock.SilvusMock)}); err != nil {
+		t.Fatalf("SetAdapter failed: %v", err)
+	}
+
+	// The orchestrator's SetPower timeout is tighter than the request deadline
+	cfg := config.LoadCBTimingBaseline()
+	cfg.CommandTimeoutSetPower = 50 * time.Millisecond
+	orch := command.NewOrchestrator(server.telemetryHub.(*telemetry.Hub), cfg)
+	orch.SetRadioManager(rm)
+	server.orchestrator = orch
+	server.SetRequestTimeout(5 * time.Second)
+
+	w, elapsed := postPower(t, server)
+	if elapsed > time.Second {
+		t.Errorf("Expected the orchestrator timeout to win, took %v", elapsed)
+	}
+	var resp Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Result != "error" || resp.Code == "TIMEOUT" {
+		t.Errorf("Expected the orchestrator's error rather than TIMEOUT, got %d %+v", w.Code, resp)
+	}
+}
+
+func TestRequestTimeoutDefault(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	if got := server.requestTimeout(); got != 29*time.Second {
+		t.Errorf("Expected write timeout less margin, got %v", got)
+	}
+	server.writeTimeout = 0
+	if got := server.requestTimeout(); got != 0 {
+		t.Errorf("Expected no deadline without a write timeout, got %v", got)
+	}
+}
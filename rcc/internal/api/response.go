@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"net/http"
 	"time"
+
+	"github.com/radio-control/rcc/internal/logging"
 )
 
 // Response represents the unified envelope format.
@@ -57,8 +59,22 @@ func WriteNotImplemented(w http.ResponseWriter, endpoint string) {
 		fmt.Sprintf("Endpoint %s is not yet implemented", endpoint), nil)
 }
 
+// writeAPIError writes the error envelope for err as mapped by ToAPIError.
+func writeAPIError(w http.ResponseWriter, err error) {
+	status, response := toAPIError(err)
+	writeResponse(w, status, response)
+}
+
 // writeResponse writes a JSON response to the HTTP response writer.
+// The request's correlation ID, when set, replaces the generated one so the
+// envelope matches the logs and audit records.
 func writeResponse(w http.ResponseWriter, statusCode int, response *Response) {
+	if id := w.Header().Get(logging.CorrelationIDHeader); id != "" {
+		correlated := *response
+		correlated.CorrelationID = id
+		response = &correlated
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(statusCode)
 
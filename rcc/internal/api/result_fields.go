@@ -0,0 +1,27 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+)
+
+// projectResultFields applies the ?fields= projection to a command result.
+// fields is a comma-separated list of result keys to keep; unknown names are
+// ignored. Without the parameter the result is returned unchanged.
+func projectResultFields(r *http.Request, result map[string]interface{}) map[string]interface{} {
+	raw, present := r.URL.Query()["fields"]
+	if !present {
+		return result
+	}
+
+	projected := make(map[string]interface{})
+	for _, value := range raw {
+		for _, field := range strings.Split(value, ",") {
+			field = strings.TrimSpace(field)
+			if v, ok := result[field]; ok {
+				projected[field] = v
+			}
+		}
+	}
+	return projected
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// postCommandData posts body to path and returns the envelope's data object.
+func postCommandData(t *testing.T, path, body string) map[string]interface{} {
+	t.Helper()
+	server, _, _, _ := setupAPITest(t)
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", path, strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("POST %s: expected 200, got %d: %s", path, w.Code, w.Body.String())
+	}
+
+	var response struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	return response.Data
+}
+
+func TestSetChannelFieldsProjection(t *testing.T) {
+	data := postCommandData(t, "/api/v1/radios/silvus-001/channel?fields=frequencyMhz", `{"frequencyMhz": 2437}`)
+
+	if len(data) != 1 || data["frequencyMhz"] != 2437.0 {
+		t.Errorf("Expected only frequencyMhz in data, got %v", data)
+	}
+}
+
+func TestFieldsProjectionIgnoresUnknownNames(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		body string
+		want []string
+	}{
+		{"no projection", "/api/v1/radios/silvus-001/channel", `{"frequencyMhz": 2437}`, []string{"frequencyMhz", "channelIndex"}},
+		{"unknown mixed in", "/api/v1/radios/silvus-001/channel?fields=bogus,channelIndex", `{"channelIndex": 6}`, []string{"channelIndex"}},
+		{"only unknown", "/api/v1/radios/silvus-001/power?fields=bogus", `{"powerDbm": 20}`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := postCommandData(t, tt.path, tt.body)
+			if len(data) != len(tt.want) {
+				t.Fatalf("Expected fields %v, got %v", tt.want, data)
+			}
+			for _, field := range tt.want {
+				if _, ok := data[field]; !ok {
+					t.Errorf("Expected field %q in data, got %v", field, data)
+				}
+			}
+		})
+	}
+}
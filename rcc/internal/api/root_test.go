@@ -0,0 +1,65 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRootReturnsServiceDescriptor(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	server.SetServiceInfo("", "2.3.4")
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", w.Code)
+	}
+
+	var response struct {
+		Result        string            `json:"result"`
+		Data          map[string]string `json:"data"`
+		CorrelationID string            `json:"correlationId"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Result != "ok" || response.CorrelationID == "" {
+		t.Errorf("Expected success envelope, got %s", w.Body.String())
+	}
+	if response.Data["name"] != DefaultServiceName {
+		t.Errorf("Expected name %q, got %q", DefaultServiceName, response.Data["name"])
+	}
+	if response.Data["version"] != "2.3.4" {
+		t.Errorf("Expected configured version 2.3.4, got %q", response.Data["version"])
+	}
+	if response.Data["apiBasePath"] != "/api/v1" {
+		t.Errorf("Expected apiBasePath /api/v1, got %q", response.Data["apiBasePath"])
+	}
+}
+
+func TestFaviconAndUnknownPaths(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"/favicon.ico", http.StatusNoContent},
+		{"/not-a-route", http.StatusNotFound},
+		{"/api/v2/radios", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+		if w.Code != tt.want {
+			t.Errorf("GET %s: expected %d, got %d", tt.path, tt.want, w.Code)
+		}
+	}
+}
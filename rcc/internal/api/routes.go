@@ -4,54 +4,136 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/internal/command"
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/radio"
 )
 
 // RegisterRoutes registers all OpenAPI v1 endpoints.
 func (s *Server) RegisterRoutes(mux *http.ServeMux) {
 	// API v1 base path
-	apiV1 := "/api/v1"
+	apiV1 := APIBasePath
+
+	// Every route carries a correlation ID shared by the envelope, logs, and audit records,
+	// and answers errors as problem+json to clients that ask for it
+	handle := func(pattern string, handler http.HandlerFunc) {
+		mux.HandleFunc(pattern, withCorrelationID(withProblemJSON(handler)))
+	}
+
+	// Root descriptor and favicon for probes and browsers (no auth required)
+	handle("/", s.handleRoot)
+	handle("/favicon.ico", handleFavicon)
 
 	// Health endpoint (no auth required)
-	mux.HandleFunc(apiV1+"/health", s.handleHealth)
+	handle(apiV1+"/health", s.handleHealth)
+
+	// Profiling is opt-in, address restricted, and admin scoped
+	if s.pprofNets != nil {
+		handle(PprofBasePath, s.handlePprof())
+	}
+
+	// Prometheus scrapes, optionally behind the read scope
+	if s.metrics != nil {
+		handle(MetricsPath, s.handleMetrics())
+	}
+
+	// Audit export for cold storage, behind the audit:read scope
+	if s.auditReader != nil {
+		handle(AuditExportPath, s.handleAuditExport())
+	}
+
+	// Effective runtime configuration, behind the read scope
+	if s.configSource != nil {
+		handle(ConfigPath, s.handleConfig())
+	}
 
 	// If no auth middleware, register routes without protection
 	if s.authMiddleware == nil {
 		// Capabilities endpoint
-		mux.HandleFunc(apiV1+"/capabilities", s.handleCapabilities)
+		handle(apiV1+"/capabilities", s.withRateLimit(false, s.handleCapabilities))
 
 		// Radios endpoints
-		mux.HandleFunc(apiV1+"/radios", s.handleRadios)
-		mux.HandleFunc(apiV1+"/radios/select", s.handleSelectRadio)
+		handle(apiV1+"/radios", s.withRateLimit(false, s.handleRadios))
+		handle(apiV1+"/radios/select", s.withRateLimit(false, s.withRequestTimeout(s.handleIdempotentSelectRadio)))
+		handle(RadioStatesPath, s.withRateLimit(false, s.handleRadioStates))
 
 		// Radio-specific endpoints (power, channel, individual radio)
-		mux.HandleFunc(apiV1+"/radios/", s.handleRadioEndpoints)
+		handle(apiV1+"/radios/", s.handleRadioEndpoints)
+
+		// Group commands
+		handle(GroupsPath, s.withRateLimit(false, s.withRequestTimeout(s.handleGroupEndpoints)))
+
+		// Command plans
+		handle(ExplainPath, s.withRateLimit(false, s.handleExplain))
 
 		// Telemetry endpoint
-		mux.HandleFunc(apiV1+"/telemetry", s.handleTelemetry)
+		handle(apiV1+"/telemetry", s.withRateLimit(true, s.handleTelemetry))
+		handle(apiV1+"/telemetry/ws", s.withRateLimit(true, s.handleTelemetryWebSocket))
+		handle(TelemetryControlPath, s.withRateLimit(false, s.handleTelemetryFilter))
 		return
 	}
 
 	// Register routes with authentication and authorization
+	// Rate limiting runs after authentication so clients are keyed by bearer subject
 	// Capabilities endpoint (viewer access)
-	mux.HandleFunc(apiV1+"/capabilities", s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(s.handleCapabilities)))
+	handle(apiV1+"/capabilities", s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(s.withRateLimit(false, s.handleCapabilities))))
 
 	// Radios endpoints (viewer access)
-	mux.HandleFunc(apiV1+"/radios", s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(s.handleRadios)))
+	handle(apiV1+"/radios", s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(s.withRateLimit(false, s.handleRadios))))
 
 	// Select radio endpoint (controller access)
-	mux.HandleFunc(apiV1+"/radios/select", s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeControl)(s.handleSelectRadio)))
+	handle(apiV1+"/radios/select", s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeControl)(s.withRateLimit(false, s.withRequestTimeout(s.handleIdempotentSelectRadio)))))
+
+	// Bulk state snapshot for dashboards (viewer access)
+	handle(RadioStatesPath, s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(s.withRateLimit(false, s.handleRadioStates))))
 
 	// Radio-specific endpoints (power, channel, individual radio)
-	mux.HandleFunc(apiV1+"/radios/", s.handleRadioEndpoints)
+	handle(apiV1+"/radios/", s.handleRadioEndpoints)
+
+	// Group power commands require the power scope, granted by control
+	handle(GroupsPath, s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopePower)(s.withRateLimit(false, s.withRequestTimeout(s.handleGroupEndpoints)))))
+
+	// Command plans only read, so viewers may ask what a command would do
+	handle(ExplainPath, s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(s.withRateLimit(false, s.handleExplain))))
 
 	// Telemetry endpoint (viewer access)
-	mux.HandleFunc(apiV1+"/telemetry", s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeTelemetry)(s.handleTelemetry)))
+	handle(apiV1+"/telemetry", s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeTelemetry)(s.withRateLimit(true, s.handleTelemetry))))
+	handle(apiV1+"/telemetry/ws", s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeTelemetry)(s.withRateLimit(true, s.handleTelemetryWebSocket))))
+	handle(TelemetryControlPath, s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeTelemetry)(s.withRateLimit(false, s.handleTelemetryFilter))))
+}
+
+// handleRoot handles GET / with a service descriptor.
+// Other unmatched paths fall through to a plain 404.
+func (s *Server) handleRoot(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only GET method is allowed", nil)
+		return
+	}
+
+	WriteSuccess(w, map[string]interface{}{
+		"name":        s.serviceName,
+		"version":     s.serviceVersion,
+		"apiBasePath": APIBasePath,
+	})
+}
+
+// handleFavicon answers browser favicon probes with 204 No Content.
+func handleFavicon(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
 }
 
 // handleCapabilities handles GET /capabilities
@@ -64,7 +146,7 @@ func (s *Server) handleCapabilities(w http.ResponseWriter, r *http.Request) {
 
 	// Return capabilities
 	capabilities := map[string]interface{}{
-		"telemetry": []string{"sse"},
+		"telemetry": []string{"sse", "websocket"},
 		"commands":  []string{"http-json"},
 		"version":   "1.0.0",
 	}
@@ -87,8 +169,27 @@ func (s *Server) handleRadios(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Parse paging and filter parameters
+	query, err := parseRadioListQuery(r)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
+
+	// The ETag changes whenever any radio's state does, so pollers can
+	// revalidate with If-None-Match instead of re-downloading the list
 	list := s.radioManager.List()
-	WriteSuccess(w, list)
+	writeSuccessWithETag(w, r, query.apply(list))
+}
+
+// handleIdempotentSelectRadio applies Idempotency-Key handling to
+// POST /radios/select; the selected radio is part of the body fingerprint.
+func (s *Server) handleIdempotentSelectRadio(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.handleSelectRadio(w, r)
+		return
+	}
+	s.withIdempotency(w, r, "selectRadio", "", s.handleSelectRadio)
 }
 
 // handleSelectRadio handles POST /radios/select
@@ -99,19 +200,16 @@ func (s *Server) handleSelectRadio(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Parse request (strict JSON)
-	var req struct {
-		RadioID string `json:"radioId"`
-	}
-	dec := json.NewDecoder(r.Body)
-	dec.DisallowUnknownFields()
-	if err := dec.Decode(&req); err != nil {
-		WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "Malformed JSON or unknown fields", nil)
+	// Parse request, reporting every field issue at once
+	body, ok := s.decodeBodyFields(w, r)
+	if !ok {
 		return
 	}
-	// Trailing data check
-	if err := dec.Decode(&struct{}{}); err != io.EOF {
-		WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "Trailing data after JSON object", nil)
+	radioID := body.str("radioId", true)
+	if radioID != nil && *radioID == "" {
+		body.invalid("radioId", "must not be empty")
+	}
+	if !body.check(w) {
 		return
 	}
 
@@ -122,15 +220,47 @@ func (s *Server) handleSelectRadio(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Call orchestrator to confirm selection (ping adapter/state)
-	if err := s.orchestrator.SelectRadio(r.Context(), req.RadioID); err != nil {
-		status, body := ToAPIError(err)
-		w.Header().Set("Content-Type", "application/json; charset=utf-8")
-		w.WriteHeader(status)
-		_, _ = w.Write(body)
+	if err := s.orchestrator.SelectRadio(r.Context(), *radioID); err != nil {
+		writeAPIError(w, err)
 		return
 	}
 
-	WriteSuccess(w, map[string]string{"activeRadioId": req.RadioID})
+	WriteSuccess(w, map[string]string{"activeRadioId": *radioID})
+}
+
+// decodeStrictJSON decodes a single JSON object from the request body, rejecting
+// unknown fields, trailing data and bodies over the server's size limit.
+// On failure it writes the error response and returns false.
+func (s *Server) decodeStrictJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	limit := s.maxBodyBytes
+	if limit <= 0 {
+		limit = DefaultMaxBodyBytes
+	}
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, limit))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(v); err != nil {
+		s.writeDecodeError(w, err, limit, "Malformed JSON or unknown fields")
+		return false
+	}
+	// Trailing data check
+	if err := dec.Decode(&struct{}{}); err != io.EOF {
+		s.writeDecodeError(w, err, limit, "Trailing data after JSON object")
+		return false
+	}
+	return true
+}
+
+// writeDecodeError reports a body decode failure as 413 when the size limit
+// was hit and as 400 BAD_REQUEST otherwise.
+func (s *Server) writeDecodeError(w http.ResponseWriter, err error, limit int64, message string) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		WriteError(w, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE",
+			fmt.Sprintf("Request body exceeds %d bytes", limit), nil)
+		return
+	}
+	WriteError(w, http.StatusBadRequest, "BAD_REQUEST", message, nil)
 }
 
 // handleRadioEndpoints handles all radio-specific endpoints.
@@ -146,42 +276,82 @@ func (s *Server) handleRadioEndpoints(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Rate-limited endpoint handlers
+	handlePower := s.withRateLimit(false, s.withRequestTimeout(s.handleRadioPower))
+	handleChannel := s.withRateLimit(false, s.withRequestTimeout(s.handleRadioChannel))
+	handleChannelStep := s.withRateLimit(false, s.withRequestTimeout(s.handleRadioChannelStep))
+	handleByID := s.withRateLimit(false, s.handleRadioByID)
+	handleCapabilities := s.withRateLimit(false, s.handleRadioCapabilities)
+	handleLimits := s.withRateLimit(false, s.handleRadioLimits)
+	handlePreset := s.withRateLimit(false, s.withRequestTimeout(s.handleRadioPreset))
+	handleEvents := s.withRateLimit(false, s.handleRadioEvents)
+
 	// Apply authentication and authorization based on endpoint type
 	if s.authMiddleware != nil {
 		// Route based on path suffix with appropriate auth
 		if strings.HasSuffix(path, "/power") {
 			if r.Method == http.MethodGet {
 				// GET power requires read scope
-				s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(s.handleRadioPower))(w, r)
+				s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(handlePower))(w, r)
 			} else if r.Method == http.MethodPost {
-				// POST power requires control scope
-				s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeControl)(s.handleRadioPower))(w, r)
+				// POST power requires the power scope, granted by control
+				s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopePower)(handlePower))(w, r)
 			} else {
 				s.handleRadioPower(w, r)
 			}
 		} else if strings.HasSuffix(path, "/channel") {
 			if r.Method == http.MethodGet {
 				// GET channel requires read scope
-				s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(s.handleRadioChannel))(w, r)
+				s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(handleChannel))(w, r)
 			} else if r.Method == http.MethodPost {
-				// POST channel requires control scope
-				s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeControl)(s.handleRadioChannel))(w, r)
+				// POST channel requires the channel scope, granted by control
+				s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeChannel)(handleChannel))(w, r)
 			} else {
 				s.handleRadioChannel(w, r)
 			}
+		} else if strings.HasSuffix(path, "/channel/step") {
+			// Channel stepping requires the channel scope
+			s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeChannel)(handleChannelStep))(w, r)
+		} else if strings.HasSuffix(path, "/preset") {
+			// A preset sets channel and power, so it requires both scopes
+			s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeChannel, auth.ScopePower)(handlePreset))(w, r)
+		} else if strings.HasSuffix(path, "/capabilities") {
+			// Per-radio capabilities require read scope
+			s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(handleCapabilities))(w, r)
+		} else if strings.HasSuffix(path, "/limits") {
+			// Per-radio limits require read scope
+			s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(handleLimits))(w, r)
+		} else if strings.HasSuffix(path, "/events") {
+			// Buffered events are telemetry and require the telemetry scope
+			s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeTelemetry)(handleEvents))(w, r)
+		} else if strings.HasSuffix(path, "/cancel") {
+			// Cancel requires control scope
+			s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeControl)(s.handleRadioCancel))(w, r)
 		} else {
 			// Individual radio endpoint requires read scope
-			s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(s.handleRadioByID))(w, r)
+			s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(handleByID))(w, r)
 		}
 	} else {
 		// No auth middleware, route directly
 		if strings.HasSuffix(path, "/power") {
-			s.handleRadioPower(w, r)
+			handlePower(w, r)
 		} else if strings.HasSuffix(path, "/channel") {
-			s.handleRadioChannel(w, r)
+			handleChannel(w, r)
+		} else if strings.HasSuffix(path, "/channel/step") {
+			handleChannelStep(w, r)
+		} else if strings.HasSuffix(path, "/preset") {
+			handlePreset(w, r)
+		} else if strings.HasSuffix(path, "/capabilities") {
+			handleCapabilities(w, r)
+		} else if strings.HasSuffix(path, "/limits") {
+			handleLimits(w, r)
+		} else if strings.HasSuffix(path, "/events") {
+			handleEvents(w, r)
+		} else if strings.HasSuffix(path, "/cancel") {
+			s.handleRadioCancel(w, r)
 		} else {
 			// Default to individual radio endpoint
-			s.handleRadioByID(w, r)
+			handleByID(w, r)
 		}
 	}
 }
@@ -214,7 +384,147 @@ func (s *Server) handleRadioByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	WriteSuccess(w, radio)
+	// Pollers revalidate with If-None-Match; the ETag follows the radio's state
+	writeSuccessWithETag(w, r, radio)
+}
+
+// handleRadioCapabilities handles GET /radios/{id}/capabilities
+func (s *Server) handleRadioCapabilities(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only GET method is allowed", nil)
+		return
+	}
+
+	radioID := s.extractRadioID(r.URL.Path)
+	if radioID == "" {
+		WriteError(w, http.StatusBadRequest, "INVALID_RANGE",
+			"Radio ID is required", nil)
+		return
+	}
+
+	if s.orchestrator == nil {
+		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE", "Service not available", nil)
+		return
+	}
+
+	caps, err := s.orchestrator.GetCapabilities(r.Context(), radioID)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	WriteSuccess(w, caps)
+}
+
+// handleRadioLimits handles GET /radios/{id}/limits
+func (s *Server) handleRadioLimits(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only GET method is allowed", nil)
+		return
+	}
+
+	radioID := s.extractRadioID(r.URL.Path)
+	if radioID == "" {
+		WriteError(w, http.StatusBadRequest, "INVALID_RANGE",
+			"Radio ID is required", nil)
+		return
+	}
+
+	if s.orchestrator == nil {
+		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE", "Service not available", nil)
+		return
+	}
+
+	limits, err := s.orchestrator.GetLimits(r.Context(), radioID)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	WriteSuccess(w, limits)
+}
+
+// MaxEventsLimit is the most events GET /radios/{id}/events returns at once.
+const MaxEventsLimit = 1000
+
+// handleRadioEvents handles GET /radios/{id}/events, returning the radio's
+// buffered telemetry events after ?after= (up to ?limit=) for debugging.
+func (s *Server) handleRadioEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only GET method is allowed", nil)
+		return
+	}
+
+	radioID := s.extractRadioID(r.URL.Path)
+	if radioID == "" {
+		WriteError(w, http.StatusBadRequest, "INVALID_RANGE",
+			"Radio ID is required", nil)
+		return
+	}
+
+	query := r.URL.Query()
+	var afterID int64
+	if raw := query.Get("after"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id < 0 {
+			WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "after must be a non-negative event ID", nil)
+			return
+		}
+		afterID = id
+	}
+	limit := MaxEventsLimit
+	if raw := query.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > MaxEventsLimit {
+			WriteError(w, http.StatusBadRequest, "BAD_REQUEST",
+				fmt.Sprintf("limit must be an integer between 1 and %d", MaxEventsLimit), nil)
+			return
+		}
+		limit = n
+	}
+
+	if s.telemetryHub == nil {
+		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE", "Service not available", nil)
+		return
+	}
+
+	WriteSuccess(w, map[string]interface{}{
+		"radioId": radioID,
+		"events":  s.telemetryHub.EventsAfter(radioID, afterID, limit),
+	})
+}
+
+// handleRadioCancel handles POST /radios/{id}/cancel.
+// It is not rate limited so an operator can always abort a command that is in flight.
+func (s *Server) handleRadioCancel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only POST method is allowed", nil)
+		return
+	}
+
+	radioID := s.extractRadioID(r.URL.Path)
+	if radioID == "" {
+		WriteError(w, http.StatusBadRequest, "INVALID_RANGE",
+			"Radio ID is required", nil)
+		return
+	}
+
+	if s.orchestrator == nil {
+		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE", "Service not available", nil)
+		return
+	}
+
+	actions, err := s.orchestrator.CancelCommand(r.Context(), radioID)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	WriteSuccess(w, map[string]interface{}{"radioId": radioID, "canceled": actions})
 }
 
 // handleRadioPower handles GET/POST /radios/{id}/power
@@ -231,7 +541,9 @@ func (s *Server) handleRadioPower(w http.ResponseWriter, r *http.Request) {
 	case http.MethodGet:
 		s.handleGetPower(w, r, radioID)
 	case http.MethodPost:
-		s.handleSetPower(w, r, radioID)
+		s.withIdempotency(w, r, "setPower", radioID, func(w http.ResponseWriter, r *http.Request) {
+			s.handleSetPower(w, r, radioID)
+		})
 	default:
 		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
 			"Only GET and POST methods are allowed", nil)
@@ -244,47 +556,117 @@ func (s *Server) handleGetPower(w http.ResponseWriter, r *http.Request, radioID
 		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE", "Service not available", nil)
 		return
 	}
-	state, err := s.orchestrator.GetState(r.Context(), radioID)
+	if isActualPower(r) {
+		s.handleGetActualPower(w, r, radioID)
+		return
+	}
+	getState := s.orchestrator.GetState
+	if isForceRefresh(r) {
+		getState = s.orchestrator.RefreshState
+	}
+	ctx, raw := s.rawResponseContext(r)
+	state, err := getState(ctx, radioID)
 	if err != nil {
-		status, body := ToAPIError(err)
-		w.Header().Set("Content-Type", "application/json; charset=utf-8")
-		w.WriteHeader(status)
-		_, _ = w.Write(body)
+		writeDebugAPIError(w, err, raw)
+		return
+	}
+	// The ETag covers the power alone, not any raw responses
+	power := map[string]interface{}{"powerDbm": state.PowerDbm}
+	if writeNotModified(w, r, power) {
+		return
+	}
+	writeDebugSuccess(w, power, raw)
+}
+
+// handleGetActualPower handles GET /radios/{id}/power?actual=true, returning
+// the measured power. When the radio cannot report it, the setpoint is
+// returned instead with a warning.
+func (s *Server) handleGetActualPower(w http.ResponseWriter, r *http.Request, radioID string) {
+	measured, err := s.orchestrator.GetActualPower(r.Context(), radioID)
+	if err == nil {
+		WriteSuccess(w, map[string]interface{}{"powerDbm": measured, "source": "measured"})
+		return
+	}
+	if errors.Is(err, command.ErrNotFound) {
+		writeAPIError(w, err)
+		return
+	}
+
+	state, stateErr := s.orchestrator.GetState(r.Context(), radioID)
+	if stateErr != nil {
+		writeAPIError(w, stateErr)
 		return
 	}
-	WriteSuccess(w, map[string]interface{}{"powerDbm": state.PowerDbm})
+	WriteSuccess(w, map[string]interface{}{
+		"powerDbm": state.PowerDbm,
+		"source":   "setpoint",
+		"warning":  fmt.Sprintf("Measured power unavailable: %v", err),
+	})
 }
 
 // handleSetPower handles POST /radios/{id}/power
 func (s *Server) handleSetPower(w http.ResponseWriter, r *http.Request, radioID string) {
-	// Parse request body (strict JSON)
-	var request struct {
-		PowerDbm float64 `json:"powerDbm"`
-	}
-	dec := json.NewDecoder(r.Body)
-	dec.DisallowUnknownFields()
-	if err := dec.Decode(&request); err != nil {
-		WriteError(w, http.StatusBadRequest, "BAD_REQUEST",
-			"Malformed JSON or unknown fields", nil)
+	// Parse request body, reporting every field issue at once
+	body, ok := s.decodeBodyFields(w, r)
+	if !ok {
 		return
 	}
-	if err := dec.Decode(&struct{}{}); err != io.EOF {
-		WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "Trailing data after JSON object", nil)
+	requested := body.number("powerDbm", true)
+	if !body.check(w) {
 		return
 	}
+	powerDbm := *requested
 
 	if s.orchestrator == nil {
 		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE", "Service not available", nil)
 		return
 	}
-	if err := s.orchestrator.SetPower(r.Context(), radioID, request.PowerDbm); err != nil {
-		status, body := ToAPIError(err)
-		w.Header().Set("Content-Type", "application/json; charset=utf-8")
-		w.WriteHeader(status)
-		_, _ = w.Write(body)
+
+	// Dry run validates and reports the change without actuating
+	if isDryRun(r) {
+		applied, err := s.orchestrator.DryRunSetPower(r.Context(), radioID, powerDbm)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		result := powerResult(powerDbm, applied)
+		result["dryRun"] = true
+		WriteSuccess(w, projectResultFields(r, result))
+		return
+	}
+
+	ctx, raw := s.rawResponseContext(r)
+	ctx, report := command.WithCommandReport(ctx)
+	applied, err := s.orchestrator.ApplyPower(ctx, radioID, powerDbm)
+	if err != nil {
+		writeDebugAPIError(w, err, raw)
 		return
 	}
-	WriteSuccess(w, map[string]interface{}{"powerDbm": request.PowerDbm})
+	writeDebugSuccess(w, projectResultFields(r, withNoop(powerResult(powerDbm, applied), report)), raw)
+}
+
+// withNoop marks result "noop" when the orchestrator skipped the adapter
+// because the radio was already in the requested state, and "noChange" when
+// it skipped a repeat of the value it had just applied.
+func withNoop(result map[string]interface{}, report *command.CommandReport) map[string]interface{} {
+	if report.Noop {
+		result["noop"] = true
+	}
+	if report.NoChange {
+		result["noChange"] = true
+	}
+	return result
+}
+
+// powerResult reports the applied power, noting the requested value when the
+// power policy clamped it.
+func powerResult(requested, applied float64) map[string]interface{} {
+	result := map[string]interface{}{"powerDbm": applied}
+	if applied != requested {
+		result["adjusted"] = true
+		result["requestedPowerDbm"] = requested
+	}
+	return result
 }
 
 // handleRadioChannel handles GET/POST /radios/{id}/channel
@@ -301,7 +683,9 @@ func (s *Server) handleRadioChannel(w http.ResponseWriter, r *http.Request) {
 	case http.MethodGet:
 		s.handleGetChannel(w, r, radioID)
 	case http.MethodPost:
-		s.handleSetChannel(w, r, radioID)
+		s.withIdempotency(w, r, "setChannel", radioID, func(w http.ResponseWriter, r *http.Request) {
+			s.handleSetChannel(w, r, radioID)
+		})
 	default:
 		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
 			"Only GET and POST methods are allowed", nil)
@@ -314,42 +698,68 @@ func (s *Server) handleGetChannel(w http.ResponseWriter, r *http.Request, radioI
 		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE", "Service not available", nil)
 		return
 	}
-	state, err := s.orchestrator.GetState(r.Context(), radioID)
+	getChannel := s.orchestrator.GetChannel
+	if isForceRefresh(r) {
+		getChannel = s.orchestrator.RefreshChannel
+	}
+	ctx, raw := s.rawResponseContext(r)
+	channel, err := getChannel(ctx, radioID)
 	if err != nil {
-		status, body := ToAPIError(err)
-		w.Header().Set("Content-Type", "application/json; charset=utf-8")
-		w.WriteHeader(status)
-		_, _ = w.Write(body)
+		writeDebugAPIError(w, err, raw)
+		return
+	}
+	// channelIndex is null if the frequency is not in the derived channel set
+	if writeNotModified(w, r, channel) {
 		return
 	}
-	// channelIndex may be null if not in derived set; we return frequency
-	WriteSuccess(w, map[string]interface{}{"frequencyMhz": state.FrequencyMhz, "channelIndex": nil})
+	writeDebugSuccess(w, channel, raw)
 }
 
 // handleSetChannel handles POST /radios/{id}/channel
 func (s *Server) handleSetChannel(w http.ResponseWriter, r *http.Request, radioID string) {
-	// Parse request body (strict JSON)
-	var request struct {
-		ChannelIndex *int     `json:"channelIndex,omitempty"`
-		FrequencyMhz *float64 `json:"frequencyMhz,omitempty"`
+	// Parse request body, reporting every field issue at once
+	body, ok := s.decodeBodyFields(w, r)
+	if !ok {
+		return
 	}
-	dec := json.NewDecoder(r.Body)
-	dec.DisallowUnknownFields()
-	if err := dec.Decode(&request); err != nil {
+	channelIndex := body.integer("channelIndex", false)
+	frequencyMhz := body.number("frequencyMhz", false)
+	frequency := body.number("frequency", false)
+	unitName := body.str("unit", false)
+	if !body.has("channelIndex") && !body.has("frequencyMhz") && !body.has("frequency") {
+		body.invalid("channelIndex", "channelIndex or frequencyMhz is required")
+	}
+	if channelIndex != nil && *channelIndex < 1 {
+		body.invalid("channelIndex", "must be at least 1")
+	}
+	if frequencyMhz != nil && *frequencyMhz <= 0 {
+		body.invalid("frequencyMhz", "must be positive")
+	}
+	if frequency != nil && *frequency <= 0 {
+		body.invalid("frequency", "must be positive")
+	}
+	if body.has("frequency") && body.has("frequencyMhz") {
+		body.invalid("frequency", "cannot be combined with frequencyMhz")
+	}
+	if body.has("unit") && !body.has("frequency") {
+		body.invalid("unit", "applies only to frequency")
+	}
+	unit, ok := parseFrequencyUnit(unitName)
+	if !ok {
 		WriteError(w, http.StatusBadRequest, "BAD_REQUEST",
-			"Malformed JSON or unknown fields", nil)
+			fmt.Sprintf("unit must be one of %s", unitNames()), nil)
 		return
 	}
-	if err := dec.Decode(&struct{}{}); err != io.EOF {
-		WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "Trailing data after JSON object", nil)
+	if !body.check(w) {
 		return
 	}
 
-	// Validate that at least one parameter is provided (structural)
-	if request.ChannelIndex == nil && request.FrequencyMhz == nil {
-		WriteError(w, http.StatusBadRequest, "BAD_REQUEST",
-			"Either channelIndex or frequencyMhz must be provided", nil)
-		return
+	// The orchestrator takes MHz; keep what the client sent to echo back
+	var requested *requestedFrequency
+	if frequency != nil {
+		requested = &requestedFrequency{value: *frequency, unit: unit}
+		mhz := requested.mhz()
+		frequencyMhz = &mhz
 	}
 
 	if s.orchestrator == nil {
@@ -357,31 +767,200 @@ func (s *Server) handleSetChannel(w http.ResponseWriter, r *http.Request, radioI
 		return
 	}
 
+	// Dry run validates and reports the change without actuating
+	if isDryRun(r) {
+		s.handleDryRunSetChannel(w, r, radioID, channelIndex, frequencyMhz, requested)
+		return
+	}
+
+	ctx, raw := s.rawResponseContext(r)
+	ctx, report := command.WithCommandReport(ctx)
+
 	// Frequency wins if both provided
-	if request.FrequencyMhz != nil {
-		if err := s.orchestrator.SetChannel(r.Context(), radioID, *request.FrequencyMhz); err != nil {
-			status, body := ToAPIError(err)
-			w.Header().Set("Content-Type", "application/json; charset=utf-8")
-			w.WriteHeader(status)
-			_, _ = w.Write(body)
+	if frequencyMhz != nil {
+		if err := s.orchestrator.SetChannel(ctx, radioID, *frequencyMhz); err != nil {
+			writeDebugAPIError(w, err, raw)
 			return
 		}
-		WriteSuccess(w, map[string]interface{}{"frequencyMhz": *request.FrequencyMhz, "channelIndex": request.ChannelIndex})
+		writeDebugSuccess(w, projectResultFields(r, withNoop(requested.addTo(map[string]interface{}{"frequencyMhz": *frequencyMhz, "channelIndex": channelIndex}), report)), raw)
 		return
 	}
 
 	// If only index provided, use SetChannelByIndex method
-	if request.ChannelIndex != nil {
-		if err := s.orchestrator.SetChannelByIndex(r.Context(), radioID, *request.ChannelIndex, s.radioManager); err != nil {
-			status, body := ToAPIError(err)
-			w.Header().Set("Content-Type", "application/json; charset=utf-8")
-			w.WriteHeader(status)
-			_, _ = w.Write(body)
+	if channelIndex != nil {
+		if err := s.orchestrator.SetChannelByIndex(ctx, radioID, *channelIndex, s.radioManager); err != nil {
+			writeDebugAPIError(w, err, raw)
+			return
+		}
+		writeDebugSuccess(w, projectResultFields(r, withNoop(map[string]interface{}{"frequencyMhz": nil, "channelIndex": *channelIndex}, report)), raw)
+		return
+	}
+}
+
+// handleDryRunSetChannel validates a channel change without actuating and
+// reports the frequency and index that would be applied.
+func (s *Server) handleDryRunSetChannel(w http.ResponseWriter, r *http.Request, radioID string, channelIndex *int, frequencyMhz *float64, requested *requestedFrequency) {
+	// Frequency wins if both provided, as for the real command
+	if frequencyMhz != nil {
+		if err := s.orchestrator.DryRunSetChannel(r.Context(), radioID, *frequencyMhz); err != nil {
+			writeAPIError(w, err)
 			return
 		}
-		WriteSuccess(w, map[string]interface{}{"frequencyMhz": nil, "channelIndex": *request.ChannelIndex})
+		WriteSuccess(w, projectResultFields(r, requested.addTo(map[string]interface{}{"frequencyMhz": *frequencyMhz, "channelIndex": channelIndex, "dryRun": true})))
+		return
+	}
+
+	resolved, err := s.orchestrator.DryRunSetChannelByIndex(r.Context(), radioID, *channelIndex, s.radioManager)
+	if err != nil {
+		writeAPIError(w, err)
 		return
 	}
+	WriteSuccess(w, projectResultFields(r, map[string]interface{}{"frequencyMhz": resolved, "channelIndex": *channelIndex, "dryRun": true}))
+}
+
+// handleRadioChannelStep handles POST /radios/{id}/channel/step
+func (s *Server) handleRadioChannelStep(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only POST method is allowed", nil)
+		return
+	}
+
+	radioID := s.extractRadioID(r.URL.Path)
+	if radioID == "" {
+		WriteError(w, http.StatusBadRequest, "INVALID_RANGE",
+			"Radio ID is required", nil)
+		return
+	}
+
+	s.withIdempotency(w, r, "stepChannel", radioID, func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Direction *string `json:"direction"`
+		}
+		if !s.decodeStrictJSON(w, r, &request) {
+			return
+		}
+		if request.Direction == nil {
+			WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "direction must be provided", nil)
+			return
+		}
+		direction := command.StepDirection(*request.Direction)
+		if direction != command.StepNext && direction != command.StepPrev {
+			WriteError(w, http.StatusBadRequest, "INVALID_RANGE",
+				fmt.Sprintf("direction must be %q or %q", command.StepNext, command.StepPrev), nil)
+			return
+		}
+
+		if s.orchestrator == nil {
+			WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE", "Service not available", nil)
+			return
+		}
+
+		ctx, report := command.WithCommandReport(r.Context())
+		channel, err := s.orchestrator.StepChannel(ctx, radioID, direction)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		WriteSuccess(w, projectResultFields(r, withNoop(map[string]interface{}{"frequencyMhz": channel.FrequencyMhz, "channelIndex": channel.Index}, report)))
+	})
+}
+
+// handleRadioPreset handles POST /radios/{id}/preset
+func (s *Server) handleRadioPreset(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only POST method is allowed", nil)
+		return
+	}
+
+	radioID := s.extractRadioID(r.URL.Path)
+	if radioID == "" {
+		WriteError(w, http.StatusBadRequest, "INVALID_RANGE",
+			"Radio ID is required", nil)
+		return
+	}
+
+	s.withIdempotency(w, r, "applyPreset", radioID, func(w http.ResponseWriter, r *http.Request) {
+		body, ok := s.decodeBodyFields(w, r)
+		if !ok {
+			return
+		}
+		name := body.str("name", true)
+		if name != nil && *name == "" {
+			body.invalid("name", "must not be empty")
+		}
+		if !body.check(w) {
+			return
+		}
+
+		if s.orchestrator == nil || s.radioManager == nil {
+			WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE", "Service not available", nil)
+			return
+		}
+		radio, err := s.radioManager.GetRadio(radioID)
+		if err != nil {
+			WriteError(w, http.StatusNotFound, "NOT_FOUND", "Radio not found", nil)
+			return
+		}
+		var preset config.Preset
+		err = config.ErrPresetNotFound
+		if s.presets != nil {
+			preset, err = s.presets.GetPreset(radio.Model, *name)
+		}
+		if err != nil {
+			WriteError(w, http.StatusNotFound, "NOT_FOUND",
+				fmt.Sprintf("Preset %q is not defined for model %s", *name, radio.Model), nil)
+			return
+		}
+
+		// Tune first so the power is checked against the preset's band
+		channelCtx, channelReport := command.WithCommandReport(r.Context())
+		if err := s.orchestrator.SetChannel(channelCtx, radioID, preset.FrequencyMhz); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		powerCtx, powerReport := command.WithCommandReport(r.Context())
+		applied, err := s.orchestrator.ApplyPower(powerCtx, radioID, preset.PowerDbm)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		result := map[string]interface{}{
+			"name":         *name,
+			"frequencyMhz": preset.FrequencyMhz,
+			"powerDbm":     applied,
+		}
+		// A no-op only when the radio was already on the preset
+		if channelReport.Noop && powerReport.Noop {
+			result["noop"] = true
+		}
+		WriteSuccess(w, projectResultFields(r, result))
+	})
+}
+
+// isDryRun reports whether a command request asks for validation only,
+// via ?dryRun=true or the X-Dry-Run: true header.
+func isDryRun(r *http.Request) bool {
+	if v, err := strconv.ParseBool(r.URL.Query().Get("dryRun")); err == nil && v {
+		return true
+	}
+	v, err := strconv.ParseBool(r.Header.Get("X-Dry-Run"))
+	return err == nil && v
+}
+
+// isActualPower reports whether a power read asks for the measured value
+// via ?actual=true.
+func isActualPower(r *http.Request) bool {
+	v, err := strconv.ParseBool(r.URL.Query().Get("actual"))
+	return err == nil && v
+}
+
+// isForceRefresh reports whether a read asks to bypass the orchestrator's
+// state cache via ?forceRefresh=true.
+func isForceRefresh(r *http.Request) bool {
+	v, err := strconv.ParseBool(r.URL.Query().Get("forceRefresh"))
+	return err == nil && v
 }
 
 // handleTelemetry handles GET /telemetry (SSE)
@@ -408,6 +987,24 @@ func (s *Server) handleTelemetry(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleTelemetryWebSocket handles GET /telemetry/ws (WebSocket)
+func (s *Server) handleTelemetryWebSocket(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only GET method is allowed", nil)
+		return
+	}
+
+	if s.telemetryHub == nil {
+		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE",
+			"Telemetry service not available", nil)
+		return
+	}
+
+	// The hub answers failed upgrades itself; once upgraded, errors end the stream
+	_ = s.telemetryHub.SubscribeWebSocket(r.Context(), w, r)
+}
+
 // handleHealth handles GET /health
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -427,7 +1024,12 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 
 	// Determine overall health status
 	overallStatus := "ok"
-	if !subsystems["telemetry"] || !subsystems["orchestrator"] || !subsystems["radioManager"] {
+	if subsystems["telemetry"] != true || subsystems["orchestrator"] != true || subsystems["radioManager"] != true {
+		overallStatus = "degraded"
+	}
+
+	// Degrade only when every known radio is unreachable
+	if radios, ok := subsystems["radios"].(map[string]string); ok && len(radios) > 0 && !anyRadioReachable(radios) {
 		overallStatus = "degraded"
 	}
 
@@ -439,7 +1041,7 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Return appropriate HTTP status based on health
-	if overallStatus == "ok" {
+	if overallStatus == "ok" || s.degradedHealthOK {
 		WriteSuccess(w, health)
 	} else {
 		// Return 503 Service Unavailable for degraded health
@@ -450,8 +1052,8 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 }
 
 // checkSubsystemHealth checks the health of all subsystems.
-func (s *Server) checkSubsystemHealth() map[string]bool {
-	subsystems := make(map[string]bool)
+func (s *Server) checkSubsystemHealth() map[string]interface{} {
+	subsystems := make(map[string]interface{})
 
 	// Check telemetry hub
 	subsystems["telemetry"] = s.telemetryHub != nil
@@ -465,9 +1067,47 @@ func (s *Server) checkSubsystemHealth() map[string]bool {
 	// Check auth middleware (optional, so always true if not required)
 	subsystems["auth"] = true // Auth is optional, so always considered healthy
 
+	// Report per-radio circuit breaker states (informational, does not degrade health)
+	if s.orchestrator != nil {
+		subsystems["circuitBreakers"] = s.orchestrator.CircuitBreakerStates()
+	}
+
+	// Report per-radio reachability from the health probes
+	if s.radioManager != nil {
+		subsystems["radios"] = s.radioReachability()
+	}
+
 	return subsystems
 }
 
+// radioReachability reports "ok" or "unreachable" per radio ID from the
+// status the health probes last recorded, so /health never calls a radio.
+// A radio is unreachable once it has missed the heartbeat timeout or has no
+// adapter.
+func (s *Server) radioReachability() map[string]string {
+	results := make(map[string]string)
+	for _, item := range s.radioManager.List().Items {
+		status := "ok"
+		if item.Status == radio.StatusOffline {
+			status = "unreachable"
+		} else if _, err := s.radioManager.GetAdapter(item.ID); err != nil {
+			status = "unreachable"
+		}
+		results[item.ID] = status
+	}
+	return results
+}
+
+// anyRadioReachable reports whether at least one radio is reachable.
+func anyRadioReachable(radios map[string]string) bool {
+	for _, status := range radios {
+		if status == "ok" {
+			return true
+		}
+	}
+	return false
+}
+
 // extractRadioID extracts the radio ID from a URL path.
 // Handles paths like /api/v1/radios/{id}/power, /api/v1/radios/{id}/channel, etc.
 func (s *Server) extractRadioID(path string) string {
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/radio-control/rcc/test/fixtures"
+)
+
+func TestTelemetryOnlyScope(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	server.authMiddleware = fixtures.NewAuthMiddleware()
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	tests := []struct {
+		name   string
+		token  string
+		path   string
+		status int
+	}{
+		{"telemetry-only token subscribes", "telemetry-token", "/api/v1/telemetry", http.StatusOK},
+		{"telemetry-only token cannot list radios", "telemetry-token", "/api/v1/radios", http.StatusForbidden},
+		{"telemetry-only token cannot read power", "telemetry-token", "/api/v1/radios/silvus-001/power", http.StatusForbidden},
+		{"viewer keeps telemetry", "viewer-token", "/api/v1/telemetry", http.StatusOK},
+		{"viewer keeps read", "viewer-token", "/api/v1/radios", http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", ts.URL+test.path, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Authorization", "Bearer "+test.token)
+
+			// SSE streams stay open; the status line is all that matters here
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != test.status {
+				t.Errorf("Expected status %d, got %d", test.status, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -4,13 +4,32 @@ package api
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"net"
 	"net/http"
+	"os"
+	"sync"
 	"time"
 
+	"github.com/radio-control/rcc/internal/audit"
 	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/internal/metrics"
 )
 
+// APIBasePath is the path prefix for all OpenAPI v1 endpoints.
+const APIBasePath = "/api/v1"
+
+// Service descriptor defaults reported at the root path.
+const (
+	DefaultServiceName    = "Radio Control Container"
+	DefaultServiceVersion = "1.0.0"
+)
+
+// DefaultMaxBodyBytes bounds JSON request bodies accepted by command endpoints.
+const DefaultMaxBodyBytes int64 = 64 << 10
+
 // Server represents the HTTP API server.
 type Server struct {
 	httpServer     *http.Server
@@ -22,18 +41,73 @@ type Server struct {
 	readTimeout    time.Duration
 	writeTimeout   time.Duration
 	idleTimeout    time.Duration
+	maxBodyBytes   int64
+	httpsOnly      HTTPSOnlyMode
+
+	// TLS terminators whose X-Forwarded-Proto is honored (nil trusts none)
+	trustedProxies []*net.IPNet
+
+	// Service descriptor reported at the root path
+	serviceName    string
+	serviceVersion string
+
+	// Per-client rate limiters (nil disables limiting)
+	commandLimiter   *RateLimiter
+	telemetryLimiter *RateLimiter
+
+	// Cached command responses by Idempotency-Key (nil disables)
+	idempotency *IdempotencyCache
+
+	// Address allowlist for /debug/pprof (nil disables profiling)
+	pprofNets []*net.IPNet
+
+	// Command request deadline (0 derives it from writeTimeout)
+	requestTimeoutOverride time.Duration
+
+	// Prometheus scrape endpoint (nil disables /metrics)
+	metrics            *metrics.Registry
+	metricsRequireAuth bool
+
+	// Answer degraded health with 200 instead of 503
+	degradedHealthOK bool
+
+	// Accept TLS clients without a certificate when mutual TLS is enabled
+	clientCertOptional bool
+
+	// Named channel presets (nil defines none)
+	presets PresetPort
+
+	// Radio groups for group commands (nil defines none)
+	groups GroupPort
+
+	// Audit log served by the export endpoint (nil disables it)
+	auditReader *audit.Reader
+
+	// Running configuration served at /config (nil disables it)
+	configSource ConfigPort
+
+	// Attach adapter raw responses to power and channel responses
+	debugRawResponses bool
+
+	// Closed by Start once the listener is bound; listenAddr is set first
+	readyInit  sync.Once
+	ready      chan struct{}
+	listenAddr net.Addr
 }
 
 // NewServer creates a new API server.
 func NewServer(telemetryHub TelemetryPort, orchestrator OrchestratorPort, radioManager RadioReadPort, readTimeout, writeTimeout, idleTimeout time.Duration) *Server {
 	return &Server{
-		telemetryHub: telemetryHub,
-		orchestrator: orchestrator,
-		radioManager: radioManager,
-		startTime:    time.Now(),
-		readTimeout:  readTimeout,
-		writeTimeout: writeTimeout,
-		idleTimeout:  idleTimeout,
+		telemetryHub:   telemetryHub,
+		orchestrator:   orchestrator,
+		radioManager:   radioManager,
+		startTime:      time.Now(),
+		readTimeout:    readTimeout,
+		writeTimeout:   writeTimeout,
+		idleTimeout:    idleTimeout,
+		maxBodyBytes:   DefaultMaxBodyBytes,
+		serviceName:    DefaultServiceName,
+		serviceVersion: DefaultServiceVersion,
 	}
 }
 
@@ -48,11 +122,142 @@ func NewServerWithAuth(telemetryHub TelemetryPort, orchestrator OrchestratorPort
 		readTimeout:    readTimeout,
 		writeTimeout:   writeTimeout,
 		idleTimeout:    idleTimeout,
+		maxBodyBytes:   DefaultMaxBodyBytes,
+		serviceName:    DefaultServiceName,
+		serviceVersion: DefaultServiceVersion,
+	}
+}
+
+// SetServiceInfo overrides the service name and version reported at the root path.
+// Empty values keep the current setting. Must be called before Start.
+func (s *Server) SetServiceInfo(name, version string) {
+	if name != "" {
+		s.serviceName = name
+	}
+	if version != "" {
+		s.serviceVersion = version
+	}
+}
+
+// SetMaxBodyBytes overrides the request body size limit for command endpoints.
+// A limit of 0 or less restores DefaultMaxBodyBytes. Must be called before Start.
+func (s *Server) SetMaxBodyBytes(limit int64) {
+	if limit <= 0 {
+		limit = DefaultMaxBodyBytes
 	}
+	s.maxBodyBytes = limit
 }
 
-// Start starts the HTTP server.
+// SetHTTPSOnly configures how plaintext HTTP requests are handled.
+// HTTPSOnlyOff (the default) serves them. Must be called before Start.
+func (s *Server) SetHTTPSOnly(mode HTTPSOnlyMode) {
+	s.httpsOnly = mode
+}
+
+// SetTrustedProxies sets the addresses of TLS terminators whose
+// X-Forwarded-Proto header the HTTPS-only check honors. Requests from any
+// other address count as secure only if they arrived over TLS. Must be
+// called before Start.
+func (s *Server) SetTrustedProxies(cidrs []string) error {
+	nets, err := parseCIDRs(cidrs, "trusted proxy")
+	if err != nil {
+		return err
+	}
+	s.trustedProxies = nets
+	return nil
+}
+
+// SetRateLimit configures per-client limiting of command and read requests.
+// A rate of 0 or less disables limiting. Must be called before Start.
+func (s *Server) SetRateLimit(ratePerSec float64, burst int) {
+	s.commandLimiter = NewRateLimiter(ratePerSec, burst)
+}
+
+// SetTelemetryRateLimit configures per-client limiting of telemetry SSE connections.
+// A rate of 0 or less disables limiting. Must be called before Start.
+func (s *Server) SetTelemetryRateLimit(ratePerSec float64, burst int) {
+	s.telemetryLimiter = NewRateLimiter(ratePerSec, burst)
+}
+
+// SetDegradedHealthOK makes /health answer a degraded system with HTTP 200
+// and status "degraded" rather than 503 SERVICE_DEGRADED. Must be called
+// before Start.
+func (s *Server) SetDegradedHealthOK(ok bool) {
+	s.degradedHealthOK = ok
+}
+
+// SetClientCertOptional makes StartTLS with a client CA verify a client
+// certificate only when one is presented, so clients without one can still
+// connect and authenticate with a bearer token. By default mutual TLS
+// requires a certificate and such clients fail the handshake. Must be called
+// before StartTLS.
+func (s *Server) SetClientCertOptional(optional bool) {
+	s.clientCertOptional = optional
+}
+
+// SetPresets sets where POST /radios/{id}/preset resolves preset names.
+// Must be called before Start.
+func (s *Server) SetPresets(presets PresetPort) {
+	s.presets = presets
+}
+
+// SetGroups sets where POST /groups/{groupId}/power resolves radio groups.
+// Must be called before Start.
+func (s *Server) SetGroups(groups GroupPort) {
+	s.groups = groups
+}
+
+// SetIdempotencyTTL configures how long command responses are kept for
+// replay by Idempotency-Key. A TTL of 0 or less disables the header.
+// Must be called before Start.
+func (s *Server) SetIdempotencyTTL(ttl time.Duration) {
+	s.idempotency = NewIdempotencyCache(ttl)
+}
+
+// Start starts the HTTP server and blocks until it stops. Ready is closed
+// once it is listening on addr.
 func (s *Server) Start(addr string) error {
+	return s.serve(addr, nil)
+}
+
+// StartTLS is Start over TLS with the certificate and key in certFile and
+// keyFile. A non-empty clientCAFile enables mutual TLS: clients must present
+// a certificate signed by one of its CAs (or, after SetClientCertOptional,
+// may connect without one), and the auth middleware accepts the
+// certificate's subject CN as an identity in place of a bearer token (see
+// auth.ClientCertSubject).
+func (s *Server) StartTLS(addr, certFile, keyFile, clientCAFile string) error {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load server certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in client CA file %s", clientCAFile)
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		if s.clientCertOptional {
+			tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+	}
+
+	return s.serve(addr, tlsConfig)
+}
+
+// serve binds addr, over TLS when tlsConfig is set, and serves the API
+// until the server stops.
+func (s *Server) serve(addr string, tlsConfig *tls.Config) error {
 	mux := http.NewServeMux()
 
 	// Register all routes
@@ -61,20 +266,54 @@ func (s *Server) Start(addr string) error {
 	// Create HTTP server
 	s.httpServer = &http.Server{
 		Addr:         addr,
-		Handler:      mux,
+		Handler:      s.withHTTPSOnly(mux),
 		ReadTimeout:  s.readTimeout,
 		WriteTimeout: s.writeTimeout,
 		IdleTimeout:  s.idleTimeout,
+		TLSConfig:    tlsConfig,
+	}
+
+	// Bind before signalling readiness so callers never race the listener
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
 	}
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+	s.listenAddr = listener.Addr()
+	close(s.readyChan())
 
-	// Start server
-	if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	if err := s.httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
 		return fmt.Errorf("failed to start HTTP server: %w", err)
 	}
 
 	return nil
 }
 
+// Ready returns a channel closed once Start is listening and accepting
+// connections. It stays open if Start fails to bind, so callers should also
+// watch Start's error.
+func (s *Server) Ready() <-chan struct{} {
+	return s.readyChan()
+}
+
+// Addr returns the address Start is listening on, e.g. the port chosen for
+// ":0". It is nil until Ready is closed.
+func (s *Server) Addr() net.Addr {
+	select {
+	case <-s.readyChan():
+		return s.listenAddr
+	default:
+		return nil
+	}
+}
+
+func (s *Server) readyChan() chan struct{} {
+	s.readyInit.Do(func() { s.ready = make(chan struct{}) })
+	return s.ready
+}
+
 // Stop gracefully stops the HTTP server.
 func (s *Server) Stop(ctx context.Context) error {
 	if s.httpServer == nil {
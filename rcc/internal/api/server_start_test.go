@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStartSignalsReadyOnceListening(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	if addr := server.Addr(); addr != nil {
+		t.Fatalf("Expected no address before Start, got %v", addr)
+	}
+
+	startErr := make(chan error, 1)
+	go func() { startErr <- server.Start("127.0.0.1:0") }()
+
+	select {
+	case <-server.Ready():
+	case err := <-startErr:
+		t.Fatalf("Start failed: %v", err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Ready not closed after Start")
+	}
+	t.Cleanup(func() { _ = server.Stop(context.Background()) })
+
+	// The listener is bound as soon as Ready closes, with no settling delay
+	resp, err := http.Get("http://" + server.Addr().String() + APIBasePath + "/health")
+	if err != nil {
+		t.Fatalf("Expected server to accept connections once ready, got %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestStartFailsWhenAddressInUse(t *testing.T) {
+	occupied, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer occupied.Close()
+
+	server, _, _, _ := setupAPITest(t)
+	err = server.Start(occupied.Addr().String())
+	if err == nil || !strings.Contains(err.Error(), "failed to listen") {
+		t.Fatalf("Expected listen error, got %v", err)
+	}
+	select {
+	case <-server.Ready():
+		t.Error("Expected Ready to stay open when Start cannot bind")
+	default:
+	}
+}
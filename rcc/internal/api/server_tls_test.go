@@ -0,0 +1,269 @@
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/audit"
+	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/test/fixtures"
+)
+
+// testCA issues certificates for the mTLS tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate CA key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create CA certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse CA certificate: %v", err)
+	}
+	return &testCA{cert: cert, key: key}
+}
+
+// issue returns a certificate for commonName signed by the CA.
+func (ca *testCA) issue(t *testing.T, commonName string, usage x509.ExtKeyUsage) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// writePEM writes blocks to a file in dir and returns its path.
+func writePEM(t *testing.T, dir, name string, blocks ...*pem.Block) string {
+	t.Helper()
+	var data []byte
+	for _, block := range blocks {
+		data = append(data, pem.EncodeToMemory(block)...)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// startMTLSServer starts server with mutual TLS trusting ca and returns its
+// address.
+func startMTLSServer(t *testing.T, server *Server, ca *testCA) string {
+	t.Helper()
+	dir := t.TempDir()
+	serverCert := ca.issue(t, "rcc", x509.ExtKeyUsageServerAuth)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(serverCert.PrivateKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal server key: %v", err)
+	}
+	certFile := writePEM(t, dir, "server.crt", &pem.Block{Type: "CERTIFICATE", Bytes: serverCert.Certificate[0]})
+	keyFile := writePEM(t, dir, "server.key", &pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	caFile := writePEM(t, dir, "ca.crt", &pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+
+	startErr := make(chan error, 1)
+	go func() { startErr <- server.StartTLS("127.0.0.1:0", certFile, keyFile, caFile) }()
+	select {
+	case <-server.Ready():
+	case err := <-startErr:
+		t.Fatalf("StartTLS failed: %v", err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Ready not closed after StartTLS")
+	}
+	t.Cleanup(func() { _ = server.Stop(context.Background()) })
+	return server.Addr().String()
+}
+
+// mtlsClient returns a client trusting ca that presents clientCert, if any.
+func mtlsClient(ca *testCA, clientCert *tls.Certificate) *http.Client {
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	config := &tls.Config{RootCAs: roots}
+	if clientCert != nil {
+		// Present the certificate even if the server does not list its issuer
+		config.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return clientCert, nil
+		}
+	}
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: config}, Timeout: 5 * time.Second}
+}
+
+func TestStartTLSAuthenticatesClientCertificates(t *testing.T) {
+	server, _, orch, _ := setupAPITest(t)
+	server.authMiddleware = fixtures.NewAuthMiddleware()
+	server.authMiddleware.SetClientCertClaims([]string{auth.RoleController}, []string{auth.ScopeRead, auth.ScopeControl})
+	auditLogger, err := audit.NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create audit logger: %v", err)
+	}
+	defer func() { _ = auditLogger.Close() }()
+	orch.SetAuditLogger(auditLogger)
+
+	ca := newTestCA(t, "rcc-clients")
+	addr := startMTLSServer(t, server, ca)
+	baseURL := "https://" + addr + APIBasePath
+
+	trusted := ca.issue(t, "console-07", x509.ExtKeyUsageClientAuth)
+	client := mtlsClient(ca, &trusted)
+
+	// The certificate alone authenticates, without a bearer token
+	resp, err := client.Get(baseURL + "/radios")
+	if err != nil {
+		t.Fatalf("GET /radios with a trusted certificate failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200 for a trusted certificate, got %d", resp.StatusCode)
+	}
+
+	// Commands are attributed to the certificate's CN
+	resp, err = client.Post(baseURL+"/radios/silvus-001/power", "application/json", strings.NewReader(`{"powerDbm":20}`))
+	if err != nil {
+		t.Fatalf("POST power with a trusted certificate failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200 for set power, got %d", resp.StatusCode)
+	}
+	entries, err := audit.NewReader(auditLogger.GetFilePath()).Query(audit.Filter{Action: "setPower"})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].User != "console-07" {
+		t.Errorf("Expected setPower audited for console-07, got %+v", entries)
+	}
+
+	// A bearer token still takes precedence over the certificate
+	req, _ := http.NewRequest(http.MethodPost, baseURL+"/radios/silvus-001/power", strings.NewReader(`{"powerDbm":20}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer viewer-token")
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("POST power with a viewer token failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected 403 for a viewer token, got %d", resp.StatusCode)
+	}
+}
+
+func TestStartTLSRejectsUntrustedClientCertificates(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	server.authMiddleware = fixtures.NewAuthMiddleware()
+
+	ca := newTestCA(t, "rcc-clients")
+	addr := startMTLSServer(t, server, ca)
+	healthURL := "https://" + addr + APIBasePath + "/health"
+
+	rogue := newTestCA(t, "rogue").issue(t, "console-07", x509.ExtKeyUsageClientAuth)
+	tests := []struct {
+		name string
+		cert *tls.Certificate
+	}{
+		{"untrusted certificate", &rogue},
+		{"no certificate", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := mtlsClient(ca, tt.cert).Get(healthURL)
+			if err == nil {
+				resp.Body.Close()
+				t.Fatalf("Expected the TLS handshake to fail, got %d", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestStartTLSOptionalClientCertificates(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	server.authMiddleware = fixtures.NewAuthMiddleware()
+	server.SetClientCertOptional(true)
+
+	ca := newTestCA(t, "rcc-clients")
+	addr := startMTLSServer(t, server, ca)
+	baseURL := "https://" + addr + APIBasePath
+
+	// A client without a certificate connects and authenticates by token
+	req, _ := http.NewRequest(http.MethodGet, baseURL+"/radios", nil)
+	req.Header.Set("Authorization", "Bearer viewer-token")
+	resp, err := mtlsClient(ca, nil).Do(req)
+	if err != nil {
+		t.Fatalf("GET /radios without a certificate failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200 for a bearer token, got %d", resp.StatusCode)
+	}
+
+	// A presented certificate must still be trusted
+	rogue := newTestCA(t, "rogue").issue(t, "console-07", x509.ExtKeyUsageClientAuth)
+	resp, err = mtlsClient(ca, &rogue).Get(baseURL + "/health")
+	if err == nil {
+		resp.Body.Close()
+		t.Fatalf("Expected the TLS handshake to fail for an untrusted certificate, got %d", resp.StatusCode)
+	}
+}
+
+func TestStartTLSRejectsEmptyClientCAFile(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	ca := newTestCA(t, "rcc-clients")
+	dir := t.TempDir()
+	serverCert := ca.issue(t, "rcc", x509.ExtKeyUsageServerAuth)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(serverCert.PrivateKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal server key: %v", err)
+	}
+	certFile := writePEM(t, dir, "server.crt", &pem.Block{Type: "CERTIFICATE", Bytes: serverCert.Certificate[0]})
+	keyFile := writePEM(t, dir, "server.key", &pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	emptyCA := writePEM(t, dir, "empty.crt")
+
+	err = server.StartTLS("127.0.0.1:0", certFile, keyFile, emptyCA)
+	if err == nil || !strings.Contains(err.Error(), "no certificates") {
+		t.Fatalf("Expected an empty client CA file to be rejected, got %v", err)
+	}
+}
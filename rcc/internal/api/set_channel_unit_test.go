@@ -46,7 +46,7 @@ func TestHandleSetChannel_Unit(t *testing.T) {
 			requestBody:    `{}`,
 			expectedStatus: 400,
 			expectedResult: "error",
-			expectedCode:   "BAD_REQUEST",
+			expectedCode:   "VALIDATION_FAILED",
 		},
 		{
 			name:           "invalid_json",
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/internal/telemetry"
+)
+
+// TelemetryControlPath is the prefix of the per-subscriber telemetry
+// control endpoints.
+const TelemetryControlPath = APIBasePath + "/telemetry/"
+
+// handleTelemetryFilter handles POST /telemetry/{clientId}/filter, replacing
+// the event filter of the SSE stream that subscribed with X-Client-ID
+// clientId. The stream stays open; the filter applies from the next event.
+// Only the token subject that opened the stream may change its filter.
+func (s *Server) handleTelemetryFilter(w http.ResponseWriter, r *http.Request) {
+	clientID, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, TelemetryControlPath), "/filter")
+	if !ok || clientID == "" || strings.Contains(clientID, "/") {
+		WriteError(w, http.StatusNotFound, "NOT_FOUND", "Endpoint not found", nil)
+		return
+	}
+	if r.Method != http.MethodPost {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only POST method is allowed", nil)
+		return
+	}
+
+	body, ok := s.decodeBodyFields(w, r)
+	if !ok {
+		return
+	}
+	filter := telemetry.ClientFilter{
+		EventTypes:        body.strings("eventTypes", false),
+		ExcludeEventTypes: body.strings("excludeEventTypes", false),
+		Radios:            body.strings("radios", false),
+	}
+	for _, list := range []struct {
+		field  string
+		values []string
+	}{
+		{"eventTypes", filter.EventTypes},
+		{"excludeEventTypes", filter.ExcludeEventTypes},
+		{"radios", filter.Radios},
+	} {
+		if slices.Contains(list.values, "") {
+			body.invalid(list.field, "must not contain empty strings")
+		}
+	}
+	if !body.check(w) {
+		return
+	}
+
+	if s.telemetryHub == nil {
+		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE",
+			"Telemetry service not available", nil)
+		return
+	}
+	subject := ""
+	if claims := auth.GetClaimsFromRequest(r); claims != nil {
+		subject = claims.Subject
+	}
+	if err := s.telemetryHub.SetClientFilter(clientID, subject, filter); err != nil {
+		if errors.Is(err, telemetry.ErrClientNotFound) {
+			WriteError(w, http.StatusNotFound, "NOT_FOUND",
+				fmt.Sprintf("No telemetry stream with client ID %q", clientID), nil)
+			return
+		}
+		writeAPIError(w, err)
+		return
+	}
+	WriteSuccess(w, map[string]interface{}{"clientId": clientID, "filter": filter})
+}
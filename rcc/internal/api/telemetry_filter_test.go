@@ -0,0 +1,115 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/telemetry"
+)
+
+// sseEventTypes streams the event types of an SSE response until it ends.
+func sseEventTypes(resp *http.Response) <-chan string {
+	types := make(chan string, 64)
+	go func() {
+		defer close(types)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if eventType, ok := strings.CutPrefix(scanner.Text(), "event: "); ok {
+				types <- eventType
+			}
+		}
+	}()
+	return types
+}
+
+// nextEventType returns the next event type other than heartbeat.
+func nextEventType(t *testing.T, types <-chan string) string {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case eventType, ok := <-types:
+			if !ok {
+				t.Fatal("Stream ended")
+			}
+			if eventType != "heartbeat" {
+				return eventType
+			}
+		case <-timeout:
+			t.Fatal("Timed out waiting for an event")
+		}
+	}
+}
+
+func TestTelemetryFilterExcludesStateEventsMidStream(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	hub := server.telemetryHub.(*telemetry.Hub)
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+	httpServer := httptest.NewServer(mux)
+	t.Cleanup(httpServer.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, httpServer.URL+APIBasePath+"/telemetry", nil)
+	req.Header.Set(telemetry.ClientIDHeader, "console-1")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	defer resp.Body.Close()
+	types := sseEventTypes(resp)
+	if got := nextEventType(t, types); got != "ready" {
+		t.Fatalf("Expected ready event, got %s", got)
+	}
+
+	publishState := func() {
+		_ = hub.PublishRadio("silvus-001", telemetry.Event{Type: "state", Data: map[string]interface{}{"status": "online"}})
+	}
+	publishState()
+	if got := nextEventType(t, types); got != "state" {
+		t.Fatalf("Expected state event before filtering, got %s", got)
+	}
+
+	filterResp, err := http.Post(httpServer.URL+TelemetryControlPath+"console-1/filter", "application/json",
+		strings.NewReader(`{"excludeEventTypes":["state"]}`))
+	if err != nil {
+		t.Fatalf("Filter request failed: %v", err)
+	}
+	filterResp.Body.Close()
+	if filterResp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200 from filter update, got %d", filterResp.StatusCode)
+	}
+
+	// State events stop; others still arrive on the same stream
+	publishState()
+	_ = hub.PublishRadio("silvus-001", telemetry.Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": 20}})
+	if got := nextEventType(t, types); got != "powerChanged" {
+		t.Errorf("Expected state to be filtered out and powerChanged delivered, got %s", got)
+	}
+}
+
+func TestTelemetryFilterUnknownClient(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodPost, TelemetryControlPath+"nobody/filter", strings.NewReader(`{"eventTypes":["fault"]}`))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a client that is not connected, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, TelemetryControlPath+"nobody/filter", strings.NewReader(`{"eventTypes":"fault"}`))
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "VALIDATION_FAILED") {
+		t.Errorf("Expected VALIDATION_FAILED for a non-array filter, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestTelemetryWebSocketEndpoint(t *testing.T) {
+	server, _, orch, _ := setupAPITest(t)
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+"/api/v1/telemetry/ws", nil)
+	if err != nil {
+		t.Fatalf("Failed to dial /telemetry/ws: %v", err)
+	}
+	defer conn.Close()
+
+	var frame struct {
+		Type string                 `json:"type"`
+		Data map[string]interface{} `json:"data"`
+	}
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if err := conn.ReadJSON(&frame); err != nil || frame.Type != "ready" {
+		t.Fatalf("Expected ready frame, got %+v (%v)", frame, err)
+	}
+
+	// Command events reach WebSocket clients through the same hub fan-out
+	if err := orch.SetPower(t.Context(), "silvus-001", 20); err != nil {
+		t.Fatalf("SetPower failed: %v", err)
+	}
+	for {
+		if err := conn.ReadJSON(&frame); err != nil {
+			t.Fatalf("Expected powerChanged frame: %v", err)
+		}
+		if frame.Type == "powerChanged" {
+			break
+		}
+	}
+	if frame.Data["powerDbm"] != 20.0 {
+		t.Errorf("Expected powerDbm 20, got %v", frame.Data["powerDbm"])
+	}
+}
+
+func TestTelemetryWebSocketRequiresUpgrade(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/telemetry/ws", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for non-upgrade request, got %d", w.Code)
+	}
+}
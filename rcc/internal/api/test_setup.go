@@ -42,9 +42,6 @@ func setupAPITest(t *testing.T) (*Server, *radio.Manager, *command.Orchestrator,
 	}
 	orch.SetAuditLogger(auditLogger)
 
-	// Set the active adapter on the orchestrator
-	orch.SetActiveAdapter(adapter)
-
 	// Create API server
 	server := NewServer(hub, orch, rm, 30*time.Second, 30*time.Second, 120*time.Second)
 
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+)
+
+// FieldIssue is one problem with a request body field. A VALIDATION_FAILED
+// error lists every issue found in details.
+type FieldIssue struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// bodyFields collects field issues while reading values from a decoded JSON
+// object, so a handler can report every problem with a body at once. Checks
+// here depend only on the body; limits that depend on the radio or config
+// stay with the orchestrator and fail with INVALID_RANGE.
+type bodyFields struct {
+	raw    map[string]json.RawMessage
+	read   map[string]bool
+	issues []FieldIssue
+}
+
+// decodeBodyFields decodes the request body as a single JSON object. Bodies
+// that are not a JSON object, carry trailing data or exceed the size limit
+// fail as for decodeStrictJSON; field-level problems are left to the caller.
+// On failure it writes the error response and returns false.
+func (s *Server) decodeBodyFields(w http.ResponseWriter, r *http.Request) (*bodyFields, bool) {
+	limit := s.maxBodyBytes
+	if limit <= 0 {
+		limit = DefaultMaxBodyBytes
+	}
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, limit))
+
+	var raw map[string]json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		s.writeDecodeError(w, err, limit, "Request body must be a JSON object")
+		return nil, false
+	}
+	if raw == nil {
+		WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "Request body must be a JSON object", nil)
+		return nil, false
+	}
+	if err := dec.Decode(&struct{}{}); err != io.EOF {
+		s.writeDecodeError(w, err, limit, "Trailing data after JSON object")
+		return nil, false
+	}
+	return &bodyFields{raw: raw, read: make(map[string]bool)}, true
+}
+
+// has reports whether the body sets field.
+func (b *bodyFields) has(field string) bool {
+	_, ok := b.raw[field]
+	return ok
+}
+
+// value returns field's raw JSON, marking it known. ok is false when the
+// field is absent, which is an issue if it is required.
+func (b *bodyFields) value(field string, required bool) (raw json.RawMessage, ok bool) {
+	b.read[field] = true
+	raw, ok = b.raw[field]
+	if !ok && required {
+		b.invalid(field, "is required")
+	}
+	return raw, ok
+}
+
+// decode unmarshals raw into v, rejecting null so a field cannot be set to
+// its zero value by accident.
+func decode(raw json.RawMessage, v interface{}) error {
+	if string(raw) == "null" {
+		return errors.New("null value")
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// number returns field as a number, or nil when it is absent or not a
+// number. A missing required field is an issue.
+func (b *bodyFields) number(field string, required bool) *float64 {
+	raw, ok := b.value(field, required)
+	if !ok {
+		return nil
+	}
+	var v float64
+	if err := decode(raw, &v); err != nil {
+		b.invalid(field, "must be a number")
+		return nil
+	}
+	return &v
+}
+
+// integer is number for fields that must be whole numbers.
+func (b *bodyFields) integer(field string, required bool) *int {
+	raw, ok := b.value(field, required)
+	if !ok {
+		return nil
+	}
+	var v float64
+	if err := decode(raw, &v); err != nil || v != math.Trunc(v) || math.Abs(v) > math.MaxInt32 {
+		b.invalid(field, "must be an integer")
+		return nil
+	}
+	i := int(v)
+	return &i
+}
+
+// str is number for string fields.
+func (b *bodyFields) str(field string, required bool) *string {
+	raw, ok := b.value(field, required)
+	if !ok {
+		return nil
+	}
+	var v string
+	if err := decode(raw, &v); err != nil {
+		b.invalid(field, "must be a string")
+		return nil
+	}
+	return &v
+}
+
+// strings is number for fields holding an array of strings. An empty array
+// is returned as an empty, non-nil slice.
+func (b *bodyFields) strings(field string, required bool) []string {
+	raw, ok := b.value(field, required)
+	if !ok {
+		return nil
+	}
+	v := []string{}
+	if err := decode(raw, &v); err != nil {
+		b.invalid(field, "must be an array of strings")
+		return nil
+	}
+	return v
+}
+
+// invalid records an issue with field.
+func (b *bodyFields) invalid(field, reason string) {
+	b.issues = append(b.issues, FieldIssue{Field: field, Reason: reason})
+}
+
+// check writes VALIDATION_FAILED listing every issue, including fields the
+// handler did not read, and returns false; it returns true for a clean body.
+func (b *bodyFields) check(w http.ResponseWriter) bool {
+	unknown := make([]string, 0)
+	for field := range b.raw {
+		if !b.read[field] {
+			unknown = append(unknown, field)
+		}
+	}
+	sort.Strings(unknown)
+	for _, field := range unknown {
+		b.invalid(field, "is not a known field")
+	}
+
+	if len(b.issues) == 0 {
+		return true
+	}
+	WriteError(w, http.StatusBadRequest, "VALIDATION_FAILED",
+		"Request body has invalid fields", b.issues)
+	return false
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func postBody(t *testing.T, server *Server, path, body string) (int, Response, []FieldIssue) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	if path == "/api/v1/radios/select" {
+		server.handleSelectRadio(w, req)
+	} else {
+		server.handleRadioEndpoints(w, req)
+	}
+
+	var resp struct {
+		Response
+		Details []FieldIssue `json:"details"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response %s: %v", w.Body.String(), err)
+	}
+	return w.Code, resp.Response, resp.Details
+}
+
+func TestValidationFailedListsEveryField(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+
+	tests := []struct {
+		name string
+		path string
+		body string
+		want []FieldIssue
+	}{
+		{
+			name: "SetPower",
+			path: "/api/v1/radios/silvus-001/power",
+			body: `{"power": 20, "units": "dBm"}`,
+			want: []FieldIssue{
+				{"powerDbm", "is required"},
+				{"power", "is not a known field"},
+				{"units", "is not a known field"},
+			},
+		},
+		{
+			name: "SetPower wrong type",
+			path: "/api/v1/radios/silvus-001/power",
+			body: `{"powerDbm": "20", "extra": true}`,
+			want: []FieldIssue{
+				{"powerDbm", "must be a number"},
+				{"extra", "is not a known field"},
+			},
+		},
+		{
+			name: "SetChannel",
+			path: "/api/v1/radios/silvus-001/channel",
+			body: `{"channelIndex": 0, "frequencyMhz": "2412", "band": "2.4GHz"}`,
+			want: []FieldIssue{
+				{"frequencyMhz", "must be a number"},
+				{"channelIndex", "must be at least 1"},
+				{"band", "is not a known field"},
+			},
+		},
+		{
+			name: "SetChannel wrong types",
+			path: "/api/v1/radios/silvus-001/channel",
+			body: `{"channelIndex": 1.5, "frequencyMhz": -5}`,
+			want: []FieldIssue{
+				{"channelIndex", "must be an integer"},
+				{"frequencyMhz", "must be positive"},
+			},
+		},
+		{
+			name: "SetChannel missing",
+			path: "/api/v1/radios/silvus-001/channel",
+			body: `{"index": 6}`,
+			want: []FieldIssue{
+				{"channelIndex", "channelIndex or frequencyMhz is required"},
+				{"index", "is not a known field"},
+			},
+		},
+		{
+			name: "SelectRadio",
+			path: "/api/v1/radios/select",
+			body: `{"radioId": 7, "force": true}`,
+			want: []FieldIssue{
+				{"radioId", "must be a string"},
+				{"force", "is not a known field"},
+			},
+		},
+		{
+			name: "SelectRadio empty",
+			path: "/api/v1/radios/select",
+			body: `{"radioId": ""}`,
+			want: []FieldIssue{{"radioId", "must not be empty"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, resp, issues := postBody(t, server, tt.path, tt.body)
+			if code != http.StatusBadRequest || resp.Code != "VALIDATION_FAILED" {
+				t.Fatalf("Expected 400 VALIDATION_FAILED, got %d %s", code, resp.Code)
+			}
+			if !reflect.DeepEqual(issues, tt.want) {
+				t.Errorf("Expected issues %+v, got %+v", tt.want, issues)
+			}
+		})
+	}
+}
+
+func TestValidationKeepsBadRequestForMalformedBodies(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+
+	for _, body := range []string{`{"powerDbm": 20,`, `null`, `[20]`, `not json`} {
+		code, resp, _ := postBody(t, server, "/api/v1/radios/silvus-001/power", body)
+		if code != http.StatusBadRequest || resp.Code != "BAD_REQUEST" {
+			t.Errorf("Body %q: expected 400 BAD_REQUEST, got %d %s", body, code, resp.Code)
+		}
+	}
+}
@@ -47,8 +47,8 @@ func TestAuditLogger_UnitTests(t *testing.T) {
 		}
 
 		// Verify the entry
-		if entry.User != "unknown" {
-			t.Errorf("Expected user 'unknown', got '%s'", entry.User)
+		if entry.User != "anonymous" {
+			t.Errorf("Expected user 'anonymous', got '%s'", entry.User)
 		}
 		if entry.RadioID != "silvus-001" {
 			t.Errorf("Expected radioId 'silvus-001', got '%s'", entry.RadioID)
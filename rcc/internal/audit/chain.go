@@ -0,0 +1,193 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// EnableHashChain makes every entry written from now on carry the hash of
+// the entry before it (prevHash) and its own hash over its content and that
+// link, so editing, inserting or removing an entry breaks the chain (see
+// VerifyChain). The chain resumes from the last chained entry already in the
+// log, including one in a rotated segment, and continues across Rotate.
+func (l *Logger) EnableHashChain() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lastHash, err := lastChainHash(l.filePath)
+	if err != nil {
+		return err
+	}
+	l.chained = true
+	l.lastHash = lastHash
+	return nil
+}
+
+// chainEntry links entry to prevHash, sets its hash and returns its JSON
+// line. The hash covers the JSON of the entry without its hash field, which
+// json.Marshal writes last.
+func chainEntry(entry *AuditEntry, prevHash string) ([]byte, error) {
+	entry.PrevHash = prevHash
+	entry.Hash = ""
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+	entry.Hash = entryHash(body)
+	return json.Marshal(entry)
+}
+
+// entryHash returns the hex SHA-256 of an entry's JSON without its hash.
+func entryHash(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// chainLink is the part of an entry VerifyChain checks.
+type chainLink struct {
+	PrevHash string `json:"prevHash"`
+	Hash     string `json:"hash"`
+}
+
+// parseChainLink returns line's links and the JSON its hash covers.
+func parseChainLink(line []byte) (chainLink, []byte, error) {
+	var link chainLink
+	if err := json.Unmarshal(line, &link); err != nil {
+		return link, nil, err
+	}
+	if link.Hash == "" {
+		return link, nil, nil
+	}
+	suffix := []byte(`,"hash":"` + link.Hash + `"}`)
+	if !bytes.HasSuffix(line, suffix) {
+		return link, nil, errors.New("hash is not the last field")
+	}
+	body := append(append([]byte(nil), line[:len(line)-len(suffix)]...), '}')
+	return link, body, nil
+}
+
+// VerifyChain checks the hash chain of the audit log at path, walking its
+// rotated segments (path.<timestamp>) oldest first and then path itself. It
+// returns true and -1 when the chain is intact, or false and the index of
+// the first entry that was edited, inserted or moved, counting entries
+// across segments from 0. Entries before the first chained one, written
+// before chaining was enabled, are not checked. The first chained entry's
+// link to any removed earlier segment cannot be checked. An error means a
+// segment could not be read.
+func VerifyChain(path string) (bool, int, error) {
+	segments, err := chainSegments(path)
+	if err != nil {
+		return false, -1, err
+	}
+
+	index := 0
+	started := false
+	prevHash := ""
+	for _, segment := range segments {
+		intact := true
+		err := eachLine(segment, func(line []byte, tooLong bool) bool {
+			if tooLong {
+				intact = false
+				return false
+			}
+			link, body, err := parseChainLink(line)
+			switch {
+			case err != nil:
+				intact = false
+			case link.Hash == "":
+				// Unchained entries may only precede the chain
+				intact = !started
+			case started && link.PrevHash != prevHash:
+				intact = false
+			case entryHash(body) != link.Hash:
+				intact = false
+			default:
+				started = true
+				prevHash = link.Hash
+			}
+			if !intact {
+				return false
+			}
+			index++
+			return true
+		})
+		if err != nil {
+			return false, -1, err
+		}
+		if !intact {
+			return false, index, nil
+		}
+	}
+	return true, -1, nil
+}
+
+// chainSegments returns the rotated segments of the log at path, oldest
+// first, followed by path.
+func chainSegments(path string) ([]string, error) {
+	rotated, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit log segments: %w", err)
+	}
+	// Rotation timestamps sort chronologically by name
+	sort.Strings(rotated)
+	return append(rotated, path), nil
+}
+
+// lastChainHash returns the hash of the newest chained entry in the log at
+// path or its rotated segments, or "" if there is none.
+func lastChainHash(path string) (string, error) {
+	segments, err := chainSegments(path)
+	if err != nil {
+		return "", err
+	}
+	for i := len(segments) - 1; i >= 0; i-- {
+		lastHash := ""
+		err := eachLine(segments[i], func(line []byte, tooLong bool) bool {
+			if link, _, err := parseChainLink(line); !tooLong && err == nil && link.Hash != "" {
+				lastHash = link.Hash
+			}
+			return true
+		})
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		if lastHash != "" {
+			return lastHash, nil
+		}
+	}
+	return "", nil
+}
+
+// eachLine calls fn with each non-empty line of the file at path until fn
+// returns false. Lines over DefaultMaxLineBytes are passed empty with
+// tooLong set.
+func eachLine(path string, fn func(line []byte, tooLong bool) bool) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	br := bufio.NewReader(file)
+	for {
+		line, tooLong, err := readBoundedLine(br, DefaultMaxLineBytes)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to read audit log: %w", err)
+		}
+		if (len(line) > 0 || tooLong) && !fn(line, tooLong) {
+			return nil
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+	}
+}
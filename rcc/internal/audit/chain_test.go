@@ -0,0 +1,125 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeChainedLog logs count setPower entries to a chained logger in dir,
+// rotating after the first rotateAfter of them.
+func writeChainedLog(t *testing.T, dir string, count, rotateAfter int) string {
+	t.Helper()
+	logger, err := NewLogger(dir)
+	if err != nil {
+		t.Fatalf("NewLogger() failed: %v", err)
+	}
+	defer func() { _ = logger.Close() }()
+
+	// An entry from before chaining was enabled
+	logger.LogAction(context.Background(), "startup", "", "SUCCESS", 0)
+	if err := logger.EnableHashChain(); err != nil {
+		t.Fatalf("EnableHashChain() failed: %v", err)
+	}
+	for i := 0; i < count; i++ {
+		if i == rotateAfter {
+			if err := logger.Rotate(); err != nil {
+				t.Fatalf("Rotate() failed: %v", err)
+			}
+		}
+		ctx := WithParams(context.Background(), map[string]interface{}{ParamPowerDbm: float64(20 + i)})
+		logger.LogAction(ctx, "setPower", "radio-01", "SUCCESS", time.Millisecond)
+	}
+	return logger.GetFilePath()
+}
+
+func TestVerifyChainIntactAcrossRotationAndRestart(t *testing.T) {
+	dir := t.TempDir()
+	path := writeChainedLog(t, dir, 4, 2)
+
+	// A restarted logger resumes the chain from the log
+	logger, err := NewLogger(dir)
+	if err != nil {
+		t.Fatalf("NewLogger() failed: %v", err)
+	}
+	if err := logger.EnableHashChain(); err != nil {
+		t.Fatalf("EnableHashChain() failed: %v", err)
+	}
+	logger.LogAction(context.Background(), "setChannel", "radio-01", "SUCCESS", time.Millisecond)
+	_ = logger.Close()
+
+	intact, index, err := VerifyChain(path)
+	if err != nil {
+		t.Fatalf("VerifyChain failed: %v", err)
+	}
+	if !intact || index != -1 {
+		t.Errorf("Expected an intact chain, got intact=%v index=%d", intact, index)
+	}
+
+	segments, _ := filepath.Glob(path + ".*")
+	if len(segments) != 1 {
+		t.Errorf("Expected one rotated segment, got %v", segments)
+	}
+}
+
+func TestVerifyChainDetectsTampering(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(lines [][]byte) [][]byte
+		want   int
+	}{
+		{
+			name: "modified middle entry",
+			tamper: func(lines [][]byte) [][]byte {
+				lines[2] = bytes.Replace(lines[2], []byte(`"powerDbm":21`), []byte(`"powerDbm":39`), 1)
+				return lines
+			},
+			want: 2,
+		},
+		{
+			name: "modified entry with recomputed hash",
+			tamper: func(lines [][]byte) [][]byte {
+				var entry AuditEntry
+				_ = json.Unmarshal(lines[2], &entry)
+				entry.Outcome = "ERROR"
+				lines[2], _ = chainEntry(&entry, entry.PrevHash)
+				return lines
+			},
+			want: 3,
+		},
+		{
+			name: "removed middle entry",
+			tamper: func(lines [][]byte) [][]byte {
+				return append(lines[:2], lines[3:]...)
+			},
+			want: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// startup, then four chained entries in one segment
+			path := writeChainedLog(t, t.TempDir(), 4, -1)
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read log: %v", err)
+			}
+			lines := tt.tamper(bytes.Split(bytes.TrimSuffix(data, []byte("\n")), []byte("\n")))
+			if err := os.WriteFile(path, append(bytes.Join(lines, []byte("\n")), '\n'), 0644); err != nil {
+				t.Fatalf("Failed to rewrite log: %v", err)
+			}
+
+			intact, index, err := VerifyChain(path)
+			if err != nil {
+				t.Fatalf("VerifyChain failed: %v", err)
+			}
+			if intact || index != tt.want {
+				t.Errorf("Expected tampering at entry %d, got intact=%v index=%d", tt.want, intact, index)
+			}
+		})
+	}
+}
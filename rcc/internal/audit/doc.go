@@ -1,7 +1,8 @@
 // Package audit implements the audit logger for the Radio Control Container.
 //
 // The audit logger provides append-only action logging with user, radioId, parameters,
-// outcome, and timestamp information for compliance and debugging.
+// outcome, and timestamp information for compliance and debugging. Reader queries the
+// log back, skipping over-long or corrupt lines.
 //
 // Architecture References:
 //   - Architecture §8.6: Audit log schema
@@ -11,24 +11,69 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/internal/logging"
 )
 
 // AuditEntry represents a single audit log entry.
 type AuditEntry struct {
 	Timestamp time.Time              `json:"ts"`
 	User      string                 `json:"user"`
+	Actor     string                 `json:"actor"`               // same as User, named as in the web-ui audit schema
+	ActorType string                 `json:"actorType,omitempty"` // human or automation
 	RadioID   string                 `json:"radioId"`
 	Action    string                 `json:"action"`
 	Params    map[string]interface{} `json:"params"`
 	Outcome   string                 `json:"outcome"`
 	Code      string                 `json:"code"`
+
+	// CorrelationID matches the API response envelope and structured logs
+	CorrelationID string `json:"correlationId,omitempty"`
+
+	// Hash chain links, set when the logger chains entries (see
+	// EnableHashChain). Hash must stay the last field.
+	PrevHash string `json:"prevHash,omitempty"`
+	Hash     string `json:"hash,omitempty"`
+}
+
+// Audit actors recorded when a command has no authenticated subject.
+const (
+	// DefaultAnonymousActor is used until SetAnonymousActor overrides it
+	DefaultAnonymousActor = "anonymous"
+
+	// SystemActor marks commands the service issues itself (see WithSystemActor)
+	SystemActor = "system"
+)
+
+// systemActorKey marks a context as system-initiated.
+type systemActorKey struct{}
+
+// WithSystemActor returns a context whose audit entries record SystemActor,
+// for internal commands such as startup initialization.
+func WithSystemActor(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemActorKey{}, true)
+}
+
+// paramsKey carries the parameters a command applied.
+type paramsKey struct{}
+
+// WithParams returns a context whose audit entries record params, such as
+// the power or frequency a command applied (see ReplayState).
+func WithParams(ctx context.Context, params map[string]interface{}) context.Context {
+	return context.WithValue(ctx, paramsKey{}, params)
 }
 
 // Logger implements the audit logging functionality.
 type Logger struct {
-	mu       sync.Mutex
-	filePath string
-	file     *os.File
+	mu             sync.Mutex
+	filePath       string
+	file           *os.File
+	anonymousActor string
+
+	// Hash of the last chained entry, when chaining is enabled
+	chained  bool
+	lastHash string
 }
 
 // NewLogger creates a new audit logger.
@@ -48,11 +93,20 @@ func NewLogger(logDir string) (*Logger, error) {
 	}
 
 	return &Logger{
-		filePath: filePath,
-		file:     file,
+		filePath:       filePath,
+		file:           file,
+		anonymousActor: DefaultAnonymousActor,
 	}, nil
 }
 
+// SetAnonymousActor sets the actor recorded for commands without an
+// authenticated subject. An empty name restores DefaultAnonymousActor.
+func (l *Logger) SetAnonymousActor(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.anonymousActor = name
+}
+
 // LogAction logs an audit record for a command action.
 func (l *Logger) LogAction(ctx context.Context, action, radioID, result string, latency time.Duration) {
 	// Extract user from context (if available)
@@ -60,13 +114,16 @@ func (l *Logger) LogAction(ctx context.Context, action, radioID, result string,
 	
 	// Create audit entry
 	entry := AuditEntry{
-		Timestamp: time.Now().UTC(),
-		User:      user,
-		RadioID:   radioID,
-		Action:    action,
-		Params:    l.getParamsFromContext(ctx),
-		Outcome:   result,
-		Code:      l.getCodeFromResult(result),
+		Timestamp:     time.Now().UTC(),
+		User:          user,
+		Actor:         user,
+		ActorType:     actorTypeFromContext(ctx),
+		RadioID:       radioID,
+		Action:        action,
+		Params:        l.getParamsFromContext(ctx),
+		Outcome:       result,
+		Code:          l.getCodeFromResult(result),
+		CorrelationID: logging.CorrelationID(ctx),
 	}
 
 	// Write to log file
@@ -86,13 +143,16 @@ func (l *Logger) LogControlAction(ctx context.Context, action, radioID string, p
 
 	// Create audit entry
 	entry := AuditEntry{
-		Timestamp: time.Now().UTC(),
-		User:      user,
-		RadioID:   radioID,
-		Action:    action,
-		Params:    params,
-		Outcome:   outcome,
-		Code:      code,
+		Timestamp:     time.Now().UTC(),
+		User:          user,
+		Actor:         user,
+		ActorType:     actorTypeFromContext(ctx),
+		RadioID:       radioID,
+		Action:        action,
+		Params:        params,
+		Outcome:       outcome,
+		Code:          code,
+		CorrelationID: logging.CorrelationID(ctx),
 	}
 
 	// Write to log file
@@ -105,7 +165,13 @@ func (l *Logger) writeEntry(entry AuditEntry) {
 	defer l.mu.Unlock()
 
 	// Marshal entry to JSON
-	jsonData, err := json.Marshal(entry)
+	var jsonData []byte
+	var err error
+	if l.chained {
+		jsonData, err = chainEntry(&entry, l.lastHash)
+	} else {
+		jsonData, err = json.Marshal(entry)
+	}
 	if err != nil {
 		// Log error to stderr if JSON marshaling fails
 		fmt.Fprintf(os.Stderr, "Failed to marshal audit entry: %v\n", err)
@@ -118,6 +184,9 @@ func (l *Logger) writeEntry(entry AuditEntry) {
 		fmt.Fprintf(os.Stderr, "Failed to write audit entry: %v\n", err)
 		return
 	}
+	if l.chained {
+		l.lastHash = entry.Hash
+	}
 
 	// Flush to ensure data is written to disk
 	if err := l.file.Sync(); err != nil {
@@ -127,20 +196,49 @@ func (l *Logger) writeEntry(entry AuditEntry) {
 
 // getUserFromContext extracts user information from the request context.
 func (l *Logger) getUserFromContext(ctx context.Context) string {
-	// Try to get user from auth claims
-	// This would be populated by the auth middleware
+	// Subject of the token verified by the auth middleware
+	if claims := auth.GetClaimsFromContext(ctx); claims != nil && claims.Subject != "" {
+		return claims.Subject
+	}
+
+	// Claims supplied as a plain map by callers outside the middleware
 	if claims, ok := ctx.Value("claims").(map[string]interface{}); ok {
 		if subject, ok := claims["sub"].(string); ok {
 			return subject
 		}
 	}
-	
-	// Default to "unknown" if no user context
-	return "unknown"
+
+	// Internal commands are distinguished from anonymous callers
+	if system, _ := ctx.Value(systemActorKey{}).(bool); system {
+		return SystemActor
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.anonymousActor == "" {
+		return DefaultAnonymousActor
+	}
+	return l.anonymousActor
+}
+
+// actorTypeFromContext returns the actor type resolved by the auth
+// middleware. System-initiated commands are automation; commands without
+// either record none.
+func actorTypeFromContext(ctx context.Context) string {
+	if actorType := auth.GetActorTypeFromContext(ctx); actorType != "" {
+		return actorType
+	}
+	if system, _ := ctx.Value(systemActorKey{}).(bool); system {
+		return auth.ActorTypeAutomation
+	}
+	return ""
 }
 
 // getParamsFromContext extracts parameters from the request context.
 func (l *Logger) getParamsFromContext(ctx context.Context) map[string]interface{} {
+	if params, ok := ctx.Value(paramsKey{}).(map[string]interface{}); ok {
+		return params
+	}
 	// Try to get parameters from context
 	if params, ok := ctx.Value("params").(map[string]interface{}); ok {
 		return params
@@ -218,7 +316,8 @@ func (l *Logger) GetFilePath() string {
 }
 
 // Rotate rotates the audit log file.
-// This is a placeholder for future log rotation functionality.
+// This is a placeholder for future log rotation functionality. A hash chain
+// continues from the rotated segment into the new file.
 func (l *Logger) Rotate() error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
@@ -233,6 +332,14 @@ func (l *Logger) Rotate() error {
 	// Create new file with timestamp
 	timestamp := time.Now().Format("20060102-150405")
 	newFilePath := fmt.Sprintf("%s.%s", l.filePath, timestamp)
+
+	// Never overwrite a segment rotated within the same second
+	for n := 1; ; n++ {
+		if _, err := os.Stat(newFilePath); os.IsNotExist(err) {
+			break
+		}
+		newFilePath = fmt.Sprintf("%s.%s.%03d", l.filePath, timestamp, n)
+	}
 	
 	// Rename current file
 	if err := os.Rename(l.filePath, newFilePath); err != nil {
@@ -8,6 +8,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/radio-control/rcc/internal/auth"
 )
 
 func TestNewLogger(t *testing.T) {
@@ -102,8 +104,8 @@ func TestLogAction(t *testing.T) {
 	if entry.Code != "SUCCESS" {
 		t.Errorf("Expected code 'SUCCESS', got '%s'", entry.Code)
 	}
-	if entry.User != "unknown" {
-		t.Errorf("Expected user 'unknown', got '%s'", entry.User)
+	if entry.User != "anonymous" {
+		t.Errorf("Expected user 'anonymous', got '%s'", entry.User)
 	}
 }
 
@@ -325,8 +327,8 @@ func TestGetUserFromContext(t *testing.T) {
 	// Test with no user context
 	ctx := context.Background()
 	user := logger.getUserFromContext(ctx)
-	if user != "unknown" {
-		t.Errorf("Expected user 'unknown', got '%s'", user)
+	if user != "anonymous" {
+		t.Errorf("Expected user 'anonymous', got '%s'", user)
 	}
 
 	// Test with user context
@@ -337,6 +339,47 @@ func TestGetUserFromContext(t *testing.T) {
 	if user != "user-123" {
 		t.Errorf("Expected user 'user-123', got '%s'", user)
 	}
+
+	// Claims stored by the auth middleware
+	ctxWithClaims := context.WithValue(ctx, auth.ClaimsKey, &auth.Claims{Subject: "admin-456"})
+	user = logger.getUserFromContext(ctxWithClaims)
+	if user != "admin-456" {
+		t.Errorf("Expected user 'admin-456', got '%s'", user)
+	}
+}
+
+func TestAnonymousAndSystemActors(t *testing.T) {
+	tempDir := t.TempDir()
+	logger, err := NewLogger(tempDir)
+	if err != nil {
+		t.Fatalf("NewLogger() failed: %v", err)
+	}
+	defer func() { _ = logger.Close() }()
+	logger.SetAnonymousActor("dev-operator")
+
+	// A command without a subject, then a system-initiated startup action
+	logger.LogAction(context.Background(), "setPower", "radio-01", "SUCCESS", time.Millisecond)
+	logger.LogAction(WithSystemActor(context.Background()), "startup", "", "SUCCESS", 0)
+
+	content, err := os.ReadFile(logger.GetFilePath())
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 log entries, got %d", len(lines))
+	}
+
+	want := []string{"dev-operator", SystemActor}
+	for i, line := range lines {
+		var entry AuditEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Failed to unmarshal log entry: %v", err)
+		}
+		if entry.User != want[i] {
+			t.Errorf("Entry %d: expected user '%s', got '%s'", i, want[i], entry.User)
+		}
+	}
 }
 
 func TestGetParamsFromContext(t *testing.T) {
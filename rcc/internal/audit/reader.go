@@ -0,0 +1,140 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// DefaultMaxLineBytes bounds a single audit log line read by Reader.
+const DefaultMaxLineBytes = 1 << 20
+
+// Filter selects audit entries; zero-valued fields match everything.
+type Filter struct {
+	RadioID string
+	Action  string
+	Since   time.Time // Inclusive
+	Until   time.Time // Exclusive
+}
+
+// matches reports whether entry passes the filter.
+func (f Filter) matches(entry AuditEntry) bool {
+	if f.RadioID != "" && entry.RadioID != f.RadioID {
+		return false
+	}
+	if f.Action != "" && entry.Action != f.Action {
+		return false
+	}
+	if !f.Since.IsZero() && entry.Timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !entry.Timestamp.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// Reader queries a JSON-lines audit log. Lines longer than the max line
+// length, or that are not valid entries, are skipped and reported on stderr
+// so one corrupt record does not fail the whole query.
+type Reader struct {
+	path         string
+	maxLineBytes int
+}
+
+// NewReader creates a reader for the audit log at path.
+func NewReader(path string) *Reader {
+	return &Reader{
+		path:         path,
+		maxLineBytes: DefaultMaxLineBytes,
+	}
+}
+
+// SetMaxLineBytes sets the longest line the reader will parse. Values of 0 or
+// less restore DefaultMaxLineBytes.
+func (r *Reader) SetMaxLineBytes(n int) {
+	r.maxLineBytes = n
+}
+
+// Query returns the entries matching filter in log order.
+func (r *Reader) Query(filter Filter) ([]AuditEntry, error) {
+	var entries []AuditEntry
+	err := r.Each(filter, func(entry AuditEntry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	return entries, err
+}
+
+// Each calls fn for every entry matching filter in log order, stopping at
+// the first error fn returns.
+func (r *Reader) Each(filter Filter, fn func(AuditEntry) error) error {
+	file, err := os.Open(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	maxLineBytes := r.maxLineBytes
+	if maxLineBytes <= 0 {
+		maxLineBytes = DefaultMaxLineBytes
+	}
+
+	br := bufio.NewReader(file)
+	for lineNumber := 1; ; lineNumber++ {
+		line, tooLong, err := readBoundedLine(br, maxLineBytes)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to read audit log: %w", err)
+		}
+
+		switch {
+		case tooLong:
+			fmt.Fprintf(os.Stderr, "Skipping audit log line %d: exceeds %d bytes\n", lineNumber, maxLineBytes)
+		case len(line) > 0:
+			var entry AuditEntry
+			if jsonErr := json.Unmarshal(line, &entry); jsonErr != nil {
+				fmt.Fprintf(os.Stderr, "Skipping audit log line %d: %v\n", lineNumber, jsonErr)
+			} else if filter.matches(entry) {
+				if fnErr := fn(entry); fnErr != nil {
+					return fnErr
+				}
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+	}
+}
+
+// readBoundedLine reads one line without its newline. Lines over max bytes
+// are consumed and discarded, reported with tooLong, so memory stays bounded.
+func readBoundedLine(br *bufio.Reader, max int) (line []byte, tooLong bool, err error) {
+	for {
+		chunk, err := br.ReadSlice('\n')
+		if !tooLong {
+			if len(line)+len(chunk) > max+1 {
+				// Allow for the trailing newline, which is not part of the line
+				tooLong = true
+				line = nil
+			} else {
+				line = append(line, chunk...)
+			}
+		}
+
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue
+		}
+		if len(line) > 0 && line[len(line)-1] == '\n' {
+			line = line[:len(line)-1]
+		}
+		if len(line) > max {
+			tooLong, line = true, nil
+		}
+		return line, tooLong, err
+	}
+}
@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReaderSkipsOversizedLine(t *testing.T) {
+	logger, err := NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLogger() failed: %v", err)
+	}
+	defer func() { _ = logger.Close() }()
+
+	ctx := context.Background()
+	logger.LogAction(ctx, "setPower", "radio-01", "SUCCESS", time.Millisecond)
+	logger.LogAction(ctx, "setChannel", "radio-02", "SUCCESS", time.Millisecond)
+
+	// Append a corrupt, oversized record between valid ones
+	file, err := os.OpenFile(logger.GetFilePath(), os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatalf("Failed to open log file: %v", err)
+	}
+	if _, err := file.WriteString(`{"action":"` + strings.Repeat("x", 8192) + "\"}\n"); err != nil {
+		t.Fatalf("Failed to write oversized line: %v", err)
+	}
+	_ = file.Close()
+	logger.LogAction(ctx, "setPower", "radio-01", "ERROR", time.Millisecond)
+
+	reader := NewReader(logger.GetFilePath())
+	reader.SetMaxLineBytes(1024)
+
+	entries, err := reader.Query(Filter{})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries around the oversized line, got %d", len(entries))
+	}
+	if entries[2].Outcome != "ERROR" {
+		t.Errorf("Expected entry after the oversized line to parse, got %+v", entries[2])
+	}
+
+	// Filters still apply
+	entries, err = reader.Query(Filter{RadioID: "radio-01", Action: "setPower"})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("Expected 2 setPower entries for radio-01, got %d", len(entries))
+	}
+}
+
+func TestReadBoundedLine(t *testing.T) {
+	data := "short\n" + strings.Repeat("y", 40) + "\nexact\nlast"
+	// A small buffer forces long lines to span several reads
+	br := bufio.NewReaderSize(strings.NewReader(data), 16)
+	want := []struct {
+		line    string
+		tooLong bool
+	}{
+		{"short", false},
+		{"", true},
+		{"exact", false},
+		{"last", false},
+	}
+	for i, w := range want {
+		line, tooLong, _ := readBoundedLine(br, 5)
+		if string(line) != w.line || tooLong != w.tooLong {
+			t.Errorf("Line %d: got (%q, %v), want (%q, %v)", i, line, tooLong, w.line, w.tooLong)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+)
+
+// Parameters the orchestrator records on setPower and setChannel entries.
+const (
+	ParamPowerDbm     = "powerDbm"
+	ParamFrequencyMhz = "frequencyMhz"
+)
+
+// ErrNoReplayableState is returned when a log holds no successful setPower
+// or setChannel for the radio.
+var ErrNoReplayableState = errors.New("audit: no successful setPower or setChannel for radio")
+
+// ReplayState reconstructs radioID's state at the end of entries by folding
+// its successful setPower and setChannel actions in log order. Failed and
+// skipped actions never reached the radio and are ignored. A field no
+// successful action set is left zero.
+func ReplayState(entries []AuditEntry, radioID string) (adapter.RadioState, error) {
+	var state adapter.RadioState
+	applied := false
+	for i, entry := range entries {
+		if entry.RadioID != radioID || entry.Outcome != "SUCCESS" {
+			continue
+		}
+		var key string
+		switch entry.Action {
+		case "setPower":
+			key = ParamPowerDbm
+		case "setChannel":
+			key = ParamFrequencyMhz
+		default:
+			continue
+		}
+
+		value, ok := numericParam(entry.Params[key])
+		if !ok {
+			return adapter.RadioState{}, fmt.Errorf("audit: entry %d (%s at %s) has no %s", i, entry.Action, entry.Timestamp.Format(time.RFC3339Nano), key)
+		}
+		if key == ParamPowerDbm {
+			state.PowerDbm = value
+		} else {
+			state.FrequencyMhz = value
+		}
+		applied = true
+	}
+	if !applied {
+		return adapter.RadioState{}, fmt.Errorf("%w %s", ErrNoReplayableState, radioID)
+	}
+	return state, nil
+}
+
+// numericParam returns a parameter as a float64, whether logged in memory or
+// decoded from JSON.
+func numericParam(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReplayStateFoldsSuccessfulActions(t *testing.T) {
+	logger, err := NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLogger() failed: %v", err)
+	}
+	defer func() { _ = logger.Close() }()
+
+	ctx := context.Background()
+	power := func(dBm float64) context.Context {
+		return WithParams(ctx, map[string]interface{}{ParamPowerDbm: dBm})
+	}
+	frequency := func(mhz float64) context.Context {
+		return WithParams(ctx, map[string]interface{}{ParamFrequencyMhz: mhz})
+	}
+
+	// Successes and failures interleaved with another radio's commands
+	logger.LogAction(power(20), "setPower", "radio-01", "SUCCESS", time.Millisecond)
+	logger.LogAction(frequency(2412), "setChannel", "radio-01", "SUCCESS", time.Millisecond)
+	logger.LogAction(power(33), "setPower", "radio-02", "SUCCESS", time.Millisecond)
+	logger.LogAction(power(35), "setPower", "radio-01", "INVALID_RANGE", time.Millisecond)
+	logger.LogAction(power(27), "setPower", "radio-01", "SUCCESS", time.Millisecond)
+	logger.LogAction(frequency(2437), "setChannel", "radio-01", "ERROR", time.Millisecond)
+	logger.LogAction(frequency(2462), "setChannel", "radio-02", "SUCCESS", time.Millisecond)
+	logger.LogAction(ctx, "getState", "radio-01", "SUCCESS", time.Millisecond)
+	logger.LogAction(power(30), "setPower", "radio-01", "UNAVAILABLE", time.Millisecond)
+
+	entries, err := NewReader(logger.GetFilePath()).Query(Filter{})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+
+	tests := []struct {
+		radioID      string
+		powerDbm     float64
+		frequencyMhz float64
+	}{
+		{"radio-01", 27, 2412},
+		{"radio-02", 33, 2462},
+	}
+	for _, tt := range tests {
+		state, err := ReplayState(entries, tt.radioID)
+		if err != nil {
+			t.Fatalf("ReplayState(%s) failed: %v", tt.radioID, err)
+		}
+		if state.PowerDbm != tt.powerDbm || state.FrequencyMhz != tt.frequencyMhz {
+			t.Errorf("ReplayState(%s) = %+v, want %v dBm at %v MHz", tt.radioID, state, tt.powerDbm, tt.frequencyMhz)
+		}
+	}
+}
+
+func TestReplayStateWithoutSuccessfulActions(t *testing.T) {
+	entries := []AuditEntry{
+		{RadioID: "radio-01", Action: "setPower", Outcome: "ERROR", Params: map[string]interface{}{ParamPowerDbm: 20.0}},
+		{RadioID: "radio-02", Action: "setPower", Outcome: "SUCCESS", Params: map[string]interface{}{ParamPowerDbm: 20.0}},
+	}
+	if _, err := ReplayState(entries, "radio-01"); !errors.Is(err, ErrNoReplayableState) {
+		t.Errorf("Expected ErrNoReplayableState, got %v", err)
+	}
+}
+
+func TestReplayStateRejectsEntryWithoutValue(t *testing.T) {
+	entries := []AuditEntry{
+		{RadioID: "radio-01", Action: "setPower", Outcome: "SUCCESS", Params: map[string]interface{}{}},
+	}
+	if _, err := ReplayState(entries, "radio-01"); err == nil {
+		t.Error("Expected an error for a successful setPower without powerDbm")
+	}
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ActorTypeHeader lets a client declare whether a request comes from a
+// person or from automation, for compliance review of the audit log.
+const ActorTypeHeader = "X-Actor-Type"
+
+// Actor types accepted in ActorTypeHeader.
+const (
+	ActorTypeHuman      = "human"
+	ActorTypeAutomation = "automation"
+)
+
+// ActorTypeKey stores the resolved actor type in the request context.
+const ActorTypeKey ContextKey = "actorType"
+
+// ErrInvalidActorType indicates an X-Actor-Type value other than human or automation.
+var ErrInvalidActorType = errors.New("invalid actor type")
+
+// ErrActorTypeNotAllowed indicates the token may not claim the actor type.
+var ErrActorTypeNotAllowed = errors.New("actor type not allowed for token")
+
+// ResolveActorType validates a declared actor type against the token's
+// claims. Undeclared requests default to human for interactive tokens and
+// automation for service tokens; service tokens cannot claim to be human.
+func ResolveActorType(claims *Claims, declared string) (string, error) {
+	service := claims != nil && claims.Service
+
+	switch declared {
+	case "":
+		if service {
+			return ActorTypeAutomation, nil
+		}
+		return ActorTypeHuman, nil
+	case ActorTypeAutomation:
+		return ActorTypeAutomation, nil
+	case ActorTypeHuman:
+		if service {
+			return "", ErrActorTypeNotAllowed
+		}
+		return ActorTypeHuman, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidActorType, declared)
+	}
+}
+
+// GetActorTypeFromContext returns the actor type resolved by RequireAuth, or
+// "" if the request was not authenticated.
+func GetActorTypeFromContext(ctx context.Context) string {
+	actorType, _ := ctx.Value(ActorTypeKey).(string)
+	return actorType
+}
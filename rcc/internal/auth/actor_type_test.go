@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolveActorType(t *testing.T) {
+	interactive := &Claims{Subject: "user-123"}
+	service := &Claims{Subject: "scheduler-001", Service: true}
+
+	tests := []struct {
+		name     string
+		claims   *Claims
+		declared string
+		want     string
+		wantErr  error
+	}{
+		{"interactive defaults to human", interactive, "", ActorTypeHuman, nil},
+		{"service defaults to automation", service, "", ActorTypeAutomation, nil},
+		{"interactive may declare automation", interactive, "automation", ActorTypeAutomation, nil},
+		{"service may not claim human", service, "human", "", ErrActorTypeNotAllowed},
+		{"unknown value rejected", interactive, "robot", "", ErrInvalidActorType},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ResolveActorType(test.claims, test.declared)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("Expected error %v, got %v", test.wantErr, err)
+			}
+			if got != test.want {
+				t.Errorf("Expected actor type %q, got %q", test.want, got)
+			}
+		})
+	}
+}
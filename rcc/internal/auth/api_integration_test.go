@@ -1,4 +1,4 @@
-package auth
+package auth_test
 
 import (
 	"bytes"
@@ -7,12 +7,15 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/test/fixtures"
 )
 
 // MockServer creates a test server with auth middleware
-func createTestServerWithAuth() (*testServer, *Middleware) {
+func createTestServerWithAuth() (*testServer, *auth.Middleware) {
 	// Create auth middleware with mock verifier
-	authMiddleware := NewMiddleware()
+	authMiddleware := fixtures.NewAuthMiddleware()
 
 	// Create test server (simplified for testing)
 	server := &testServer{
@@ -24,7 +27,7 @@ func createTestServerWithAuth() (*testServer, *Middleware) {
 
 // testServer is a simplified server for testing
 type testServer struct {
-	authMiddleware *Middleware
+	authMiddleware *auth.Middleware
 }
 
 func TestAPIEndpointAuthentication(t *testing.T) {
@@ -378,19 +381,19 @@ func createTestHandler(server *testServer, path string) http.HandlerFunc {
 			(strings.Contains(path, "/power") && r.Method == "POST") ||
 			(strings.Contains(path, "/channel") && r.Method == "POST"):
 			// Control operations require control scope
-			authHandler = authMiddleware.RequireAuth(authMiddleware.RequireScope(ScopeControl)(func(w http.ResponseWriter, r *http.Request) {
+			authHandler = authMiddleware.RequireAuth(authMiddleware.RequireScope(auth.ScopeControl)(func(w http.ResponseWriter, r *http.Request) {
 				w.WriteHeader(http.StatusOK)
 				_, _ = w.Write([]byte(`{"result":"ok"}`))
 			}))
 		case strings.Contains(path, "/telemetry"):
 			// Telemetry requires telemetry scope
-			authHandler = authMiddleware.RequireAuth(authMiddleware.RequireScope(ScopeTelemetry)(func(w http.ResponseWriter, r *http.Request) {
+			authHandler = authMiddleware.RequireAuth(authMiddleware.RequireScope(auth.ScopeTelemetry)(func(w http.ResponseWriter, r *http.Request) {
 				w.WriteHeader(http.StatusOK)
 				_, _ = w.Write([]byte(`{"result":"ok"}`))
 			}))
 		default:
 			// Read operations require read scope
-			authHandler = authMiddleware.RequireAuth(authMiddleware.RequireScope(ScopeRead)(func(w http.ResponseWriter, r *http.Request) {
+			authHandler = authMiddleware.RequireAuth(authMiddleware.RequireScope(auth.ScopeRead)(func(w http.ResponseWriter, r *http.Request) {
 				w.WriteHeader(http.StatusOK)
 				_, _ = w.Write([]byte(`{"result":"ok"}`))
 			}))
@@ -0,0 +1,54 @@
+package auth_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/test/fixtures"
+)
+
+// withVerifiedCert marks req as carrying a client certificate for commonName
+// that the server verified.
+func withVerifiedCert(req *http.Request, commonName string) *http.Request {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}}
+	req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	return req
+}
+
+func TestRequireAuthAcceptsClientCertIdentity(t *testing.T) {
+	m := fixtures.NewAuthMiddleware()
+	var got *auth.Claims
+	handler := m.RequireAuth(func(w http.ResponseWriter, r *http.Request) {
+		got = auth.GetClaimsFromRequest(r)
+	})
+
+	// Defaults grant viewer read and telemetry
+	w := httptest.NewRecorder()
+	handler(w, withVerifiedCert(httptest.NewRequest("GET", "/api/v1/radios", nil), "console-07"))
+	if w.Code != http.StatusOK || got == nil {
+		t.Fatalf("Expected a verified certificate to authenticate, got %d", w.Code)
+	}
+	if got.Subject != "console-07" || !got.HasScope(auth.ScopeRead) || got.HasScope(auth.ScopeControl) {
+		t.Errorf("Expected viewer claims for console-07, got %+v", got)
+	}
+
+	m.SetClientCertClaims([]string{auth.RoleController}, []string{auth.ScopeRead, auth.ScopeControl})
+	handler(httptest.NewRecorder(), withVerifiedCert(httptest.NewRequest("GET", "/api/v1/radios", nil), "console-07"))
+	if !got.HasScope(auth.ScopeControl) {
+		t.Errorf("Expected configured scopes for the certificate, got %+v", got)
+	}
+
+	// A presented but unverified certificate is not an identity
+	req := httptest.NewRequest("GET", "/api/v1/radios", nil)
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "console-07"}}}}
+	w = httptest.NewRecorder()
+	handler(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for an unverified certificate, got %d", w.Code)
+	}
+}
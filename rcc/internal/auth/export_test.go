@@ -0,0 +1,9 @@
+package auth
+
+// Middleware internals exercised by the external auth_test package.
+var (
+	ExtractBearerToken = (*Middleware).extractBearerToken
+	VerifyToken        = (*Middleware).verifyToken
+	HasRequiredScopes  = (*Middleware).hasRequiredScopes
+	HasRequiredRoles   = (*Middleware).hasRequiredRoles
+)
@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// rotatingJWKS serves one signing key at a time, like an issuer rotating
+// keys, and can be made to fail.
+type rotatingJWKS struct {
+	mu      sync.Mutex
+	kid     string
+	key     *rsa.PrivateKey
+	failing bool
+	fetches atomic.Int32
+}
+
+func newRotatingJWKS(t *testing.T, kid string) (*rotatingJWKS, *httptest.Server) {
+	j := &rotatingJWKS{}
+	j.rotate(t, kid)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		j.fetches.Add(1)
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		if j.failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(JWKSet{Keys: []JWK{{
+			Kty: "RSA",
+			Kid: j.kid,
+			Use: "sig",
+			Alg: "RS256",
+			N:   base64.RawURLEncoding.EncodeToString(j.key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString([]byte{1, 0, 1}),
+		}}})
+	}))
+	t.Cleanup(server.Close)
+	return j, server
+}
+
+// rotate replaces the served key with a new one under kid, returning the
+// private key for signing.
+func (j *rotatingJWKS) rotate(t *testing.T, kid string) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.kid, j.key = kid, key
+	return key
+}
+
+func (j *rotatingJWKS) setFailing(failing bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.failing = failing
+}
+
+func (j *rotatingJWKS) sign(t *testing.T) string {
+	t.Helper()
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return signWithKid(t, j.key, j.kid)
+}
+
+func signWithKid(t *testing.T, key *rsa.PrivateKey, kid string) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub":    "user-123",
+		"roles":  []string{RoleController},
+		"scopes": []string{ScopeRead},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func TestJWKSUnknownKidTriggersRefresh(t *testing.T) {
+	jwks, server := newRotatingJWKS(t, "key-1")
+	m, err := NewMiddlewareWithJWKS(server.URL, time.Hour)
+	if err != nil {
+		t.Fatalf("NewMiddlewareWithJWKS failed: %v", err)
+	}
+	defer m.Stop()
+	m.verifier.(*Verifier).config.JWKSMinRefreshInterval = time.Nanosecond
+
+	oldKey := jwks.key
+	if _, err := m.verifyToken(jwks.sign(t)); err != nil {
+		t.Fatalf("Expected key-1 token to verify: %v", err)
+	}
+
+	// The issuer rotates; the first token with the new kid refetches
+	jwks.rotate(t, "key-2")
+	if _, err := m.verifyToken(jwks.sign(t)); err != nil {
+		t.Fatalf("Expected key-2 token to verify after rotation: %v", err)
+	}
+	if got := jwks.fetches.Load(); got != 2 {
+		t.Errorf("Expected one refetch for the new kid, got %d fetches", got)
+	}
+
+	// The retired key no longer verifies
+	if _, err := m.verifyToken(signWithKid(t, oldKey, "key-1")); err == nil {
+		t.Error("Expected the retired key-1 to be rejected")
+	}
+}
+
+func TestJWKSFetchFailureKeepsLastGoodKeys(t *testing.T) {
+	jwks, server := newRotatingJWKS(t, "key-1")
+	m, err := NewMiddlewareWithJWKS(server.URL, 20*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewMiddlewareWithJWKS failed: %v", err)
+	}
+	defer m.Stop()
+
+	jwks.setFailing(true)
+	failedFrom := jwks.fetches.Load()
+	deadline := time.Now().Add(2 * time.Second)
+	for jwks.fetches.Load() < failedFrom+3 {
+		if time.Now().After(deadline) {
+			t.Fatal("Background refresh did not run")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if _, err := m.verifyToken(jwks.sign(t)); err != nil {
+		t.Errorf("Expected the last good key to verify while the JWKS host fails: %v", err)
+	}
+
+	// Background refreshes pick up a rotation without any token asking
+	jwks.rotate(t, "key-2")
+	jwks.setFailing(false)
+	deadline = time.Now().Add(2 * time.Second)
+	for m.verifier.(*Verifier).cachedKey("key-2") == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("Background refresh did not pick up key-2")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if m.verifier.(*Verifier).cachedKey("key-1") != nil {
+		t.Error("Expected key-1 to be dropped once the set rotated")
+	}
+}
+
+func TestNewMiddlewareWithJWKSErrors(t *testing.T) {
+	if _, err := NewMiddlewareWithJWKS("", time.Minute); err == nil {
+		t.Error("Expected an error without a JWKS URL")
+	}
+
+	jwks, server := newRotatingJWKS(t, "key-1")
+	if _, err := NewMiddlewareWithJWKS(server.URL, 0); err == nil {
+		t.Error("Expected an error for a zero refresh interval")
+	}
+	jwks.setFailing(true)
+	if _, err := NewMiddlewareWithJWKS(server.URL, time.Minute); err == nil {
+		t.Error("Expected an error when the initial fetch fails")
+	}
+}
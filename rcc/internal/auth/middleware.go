@@ -5,10 +5,13 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/radio-control/rcc/internal/logging"
 )
 
 // Claims represents the parsed token claims.
@@ -16,6 +19,9 @@ type Claims struct {
 	Subject string   `json:"sub"`
 	Roles   []string `json:"roles"`
 	Scopes  []string `json:"scopes"`
+
+	// Service marks machine-to-machine tokens, audited as automation
+	Service bool `json:"service,omitempty"`
 }
 
 // ContextKey is used for storing claims in request context.
@@ -29,6 +35,7 @@ const (
 const (
 	RoleViewer     = "viewer"
 	RoleController = "controller"
+	RoleOperator   = "operator" // Limited control; allowed actions set by config RoleActions
 )
 
 // Scope constants per OpenAPI v1 §1.2
@@ -36,25 +43,95 @@ const (
 	ScopeRead      = "read"
 	ScopeControl   = "control"
 	ScopeTelemetry = "telemetry"
+	ScopeAdmin     = "admin"      // Operational diagnostics such as /debug/pprof
+	ScopeAuditRead = "audit:read" // Audit log export
+
+	// Finer control scopes for deployments that separate power and channel
+	// changes; ScopeControl grants both
+	ScopePower   = "radio:power"
+	ScopeChannel = "radio:channel"
 )
 
+// grantedBy lists, for each scope that has them, the superscopes that grant
+// it without the token carrying it.
+var grantedBy = map[string][]string{
+	ScopePower:   {ScopeControl},
+	ScopeChannel: {ScopeControl},
+}
+
+// TokenVerifier verifies a bearer token and returns its claims. *Verifier
+// implements it for JWTs; tests use fixtures.TokenVerifier.
+type TokenVerifier interface {
+	VerifyToken(token string) (*Claims, error)
+}
+
 // Middleware handles authentication and authorization.
 type Middleware struct {
-	verifier *Verifier
+	verifier TokenVerifier
+
+	// Grants for verified client certificates (nil uses the defaults)
+	certRoles  []string
+	certScopes []string
 }
 
-// NewMiddleware creates a new auth middleware.
+// NewMiddleware creates a new auth middleware without a token verifier. It
+// rejects every bearer token, so only verified client certificates
+// authenticate.
 func NewMiddleware() *Middleware {
 	return &Middleware{}
 }
 
 // NewMiddlewareWithVerifier creates a new auth middleware with a JWT verifier.
 func NewMiddlewareWithVerifier(verifier *Verifier) *Middleware {
+	if verifier == nil {
+		return NewMiddleware()
+	}
+	return NewMiddlewareWithTokenVerifier(verifier)
+}
+
+// NewMiddlewareWithTokenVerifier creates a new auth middleware verifying
+// bearer tokens with verifier.
+func NewMiddlewareWithTokenVerifier(verifier TokenVerifier) *Middleware {
 	return &Middleware{
 		verifier: verifier,
 	}
 }
 
+// NewMiddlewareWithJWKS creates an auth middleware verifying RS256 tokens
+// against the JWKS at url, refetched every refresh so rotated signing keys
+// are picked up. A token whose kid is not in the cached set triggers an
+// early refetch before it is rejected. Failed refetches keep the last good
+// keys, but the initial fetch must succeed. Call Stop to end the refreshes.
+func NewMiddlewareWithJWKS(url string, refresh time.Duration) (*Middleware, error) {
+	if url == "" {
+		return nil, fmt.Errorf("JWKS URL is required")
+	}
+	if refresh <= 0 {
+		return nil, fmt.Errorf("JWKS refresh interval must be positive, got %v", refresh)
+	}
+
+	verifier, err := NewVerifier(VerifierConfig{
+		Algorithm:           "RS256",
+		JWKSURL:             url,
+		JWKSRefreshInterval: refresh,
+		// Background refreshes keep keys fresh; a key turns stale only after
+		// a refresh has failed
+		JWKSCacheTimeout: 2 * refresh,
+	})
+	if err != nil {
+		return nil, err
+	}
+	verifier.startJWKSRefresh(refresh)
+	return NewMiddlewareWithVerifier(verifier), nil
+}
+
+// Stop ends the verifier's background work, such as JWKS refreshes.
+func (m *Middleware) Stop() {
+	if verifier, ok := m.verifier.(*Verifier); ok {
+		verifier.Stop()
+	}
+}
+
 // RequireAuth creates middleware that requires authentication.
 func (m *Middleware) RequireAuth(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -64,24 +141,46 @@ func (m *Middleware) RequireAuth(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		// Extract bearer token
-		token, err := m.extractBearerToken(r)
-		if err != nil {
-			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED",
-				"Authentication required", nil)
-			return
+		// A verified client certificate stands in for a missing bearer token
+		var claims *Claims
+		if r.Header.Get("Authorization") == "" {
+			claims = m.clientCertClaims(r)
 		}
 
-		// Verify token and extract claims
-		claims, err := m.verifyToken(token)
+		if claims == nil {
+			// Extract bearer token
+			token, err := m.extractBearerToken(r)
+			if err != nil {
+				writeError(w, http.StatusUnauthorized, "UNAUTHORIZED",
+					"Authentication required", nil)
+				return
+			}
+
+			// Verify token and extract claims
+			claims, err = m.verifyToken(token)
+			if err != nil {
+				writeError(w, http.StatusUnauthorized, "UNAUTHORIZED",
+					"Invalid token", nil)
+				return
+			}
+		}
+
+		// Resolve whether a person or automation is acting
+		actorType, err := ResolveActorType(claims, r.Header.Get(ActorTypeHeader))
+		if errors.Is(err, ErrActorTypeNotAllowed) {
+			writeError(w, http.StatusForbidden, "FORBIDDEN",
+				"Service tokens cannot act as human", nil)
+			return
+		}
 		if err != nil {
-			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED",
-				"Invalid token", nil)
+			writeError(w, http.StatusBadRequest, "BAD_REQUEST",
+				"X-Actor-Type must be human or automation", nil)
 			return
 		}
 
 		// Store claims in context
 		ctx := context.WithValue(r.Context(), ClaimsKey, claims)
+		ctx = context.WithValue(ctx, ActorTypeKey, actorType)
 		next(w, r.WithContext(ctx))
 	}
 }
@@ -154,36 +253,10 @@ func (m *Middleware) extractBearerToken(r *http.Request) (string, error) {
 
 // verifyToken verifies the token and returns claims.
 func (m *Middleware) verifyToken(token string) (*Claims, error) {
-	// Use real verifier if available
-	if m.verifier != nil {
-		return m.verifier.VerifyToken(token)
-	}
-
-	// Fallback to mock implementation for backward compatibility
-	// This should only be used in tests
-	switch token {
-	case "viewer-token":
-		return &Claims{
-			Subject: "user-123",
-			Roles:   []string{RoleViewer},
-			Scopes:  []string{ScopeRead, ScopeTelemetry},
-		}, nil
-	case "controller-token":
-		return &Claims{
-			Subject: "admin-456",
-			Roles:   []string{RoleController},
-			Scopes:  []string{ScopeRead, ScopeControl, ScopeTelemetry},
-		}, nil
-	case "invalid-token":
-		return nil, fmt.Errorf("token verification failed")
-	default:
-		// Default to viewer for unknown tokens (test mode only)
-		return &Claims{
-			Subject: "user-unknown",
-			Roles:   []string{RoleViewer},
-			Scopes:  []string{ScopeRead, ScopeTelemetry},
-		}, nil
+	if m.verifier == nil {
+		return nil, fmt.Errorf("no token verifier configured")
 	}
+	return m.verifier.VerifyToken(token)
 }
 
 // hasRequiredScopes checks if the user has all required scopes.
@@ -193,14 +266,7 @@ func (m *Middleware) hasRequiredScopes(claims *Claims, requiredScopes []string)
 	}
 
 	for _, required := range requiredScopes {
-		found := false
-		for _, scope := range claims.Scopes {
-			if scope == required {
-				found = true
-				break
-			}
-		}
-		if !found {
+		if !hasScope(claims.Scopes, required) {
 			return false
 		}
 	}
@@ -208,6 +274,21 @@ func (m *Middleware) hasRequiredScopes(claims *Claims, requiredScopes []string)
 	return true
 }
 
+// hasScope reports whether scopes include required or a superscope of it.
+func hasScope(scopes []string, required string) bool {
+	for _, scope := range scopes {
+		if scope == required {
+			return true
+		}
+		for _, superscope := range grantedBy[required] {
+			if scope == superscope {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // hasRequiredRoles checks if the user has any of the required roles.
 func (m *Middleware) hasRequiredRoles(claims *Claims, requiredRoles []string) bool {
 	if claims == nil {
@@ -239,6 +320,22 @@ func (m *Middleware) getClaimsFromContext(ctx context.Context) *Claims {
 	return claims
 }
 
+// GetClaimsFromContext extracts claims from a context, or nil if the
+// request was not authenticated.
+func GetClaimsFromContext(ctx context.Context) *Claims {
+	claims, ok := ctx.Value(ClaimsKey).(*Claims)
+	if !ok {
+		return nil
+	}
+	return claims
+}
+
+// HasScope reports whether the claims grant required, directly or through a
+// superscope. Nil claims grant nothing.
+func (c *Claims) HasScope(required string) bool {
+	return c != nil && hasScope(c.Scopes, required)
+}
+
 // GetClaimsFromRequest extracts claims from the request context.
 // This is a helper function for use in handlers.
 func GetClaimsFromRequest(r *http.Request) *Claims {
@@ -279,11 +376,17 @@ func writeError(w http.ResponseWriter, status int, code, message string, details
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 
+	// Reuse the request's correlation ID when the API gateway assigned one
+	correlationID := w.Header().Get(logging.CorrelationIDHeader)
+	if correlationID == "" {
+		correlationID = generateCorrelationID()
+	}
+
 	response := map[string]interface{}{
 		"result":        "error",
 		"code":          code,
 		"message":       message,
-		"correlationId": generateCorrelationID(),
+		"correlationId": correlationID,
 	}
 
 	if details != nil {
@@ -1,21 +1,24 @@
-package auth
+package auth_test
 
 import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/test/fixtures"
 )
 
 func TestNewMiddleware(t *testing.T) {
-	middleware := NewMiddleware()
+	middleware := fixtures.NewAuthMiddleware()
 	if middleware == nil {
-		t.Fatal("NewMiddleware() returned nil")
+		t.Fatal("fixtures.NewAuthMiddleware() returned nil")
 	}
 }
 
 func TestExtractBearerToken(t *testing.T) {
-	middleware := NewMiddleware()
+	middleware := fixtures.NewAuthMiddleware()
 
 	tests := []struct {
 		name          string
@@ -58,7 +61,7 @@ func TestExtractBearerToken(t *testing.T) {
 				req.Header.Set("Authorization", test.authHeader)
 			}
 
-			token, err := middleware.extractBearerToken(req)
+			token, err := auth.ExtractBearerToken(middleware, req)
 
 			if test.expectError {
 				if err == nil {
@@ -77,32 +80,42 @@ func TestExtractBearerToken(t *testing.T) {
 }
 
 func TestVerifyToken(t *testing.T) {
-	middleware := NewMiddleware()
+	middleware := fixtures.NewAuthMiddleware()
 
 	tests := []struct {
 		name           string
 		token          string
 		expectError    bool
-		expectedClaims *Claims
+		expectedClaims *auth.Claims
 	}{
 		{
 			name:        "viewer token",
 			token:       "viewer-token",
 			expectError: false,
-			expectedClaims: &Claims{
+			expectedClaims: &auth.Claims{
 				Subject: "user-123",
-				Roles:   []string{RoleViewer},
-				Scopes:  []string{ScopeRead, ScopeTelemetry},
+				Roles:   []string{auth.RoleViewer},
+				Scopes:  []string{auth.ScopeRead, auth.ScopeTelemetry},
 			},
 		},
 		{
 			name:        "controller token",
 			token:       "controller-token",
 			expectError: false,
-			expectedClaims: &Claims{
+			expectedClaims: &auth.Claims{
 				Subject: "admin-456",
-				Roles:   []string{RoleController},
-				Scopes:  []string{ScopeRead, ScopeControl, ScopeTelemetry},
+				Roles:   []string{auth.RoleController},
+				Scopes:  []string{auth.ScopeRead, auth.ScopeControl, auth.ScopeTelemetry},
+			},
+		},
+		{
+			name:        "telemetry-only token",
+			token:       "telemetry-token",
+			expectError: false,
+			expectedClaims: &auth.Claims{
+				Subject: "monitor-789",
+				Roles:   []string{auth.RoleViewer},
+				Scopes:  []string{auth.ScopeTelemetry},
 			},
 		},
 		{
@@ -111,20 +124,15 @@ func TestVerifyToken(t *testing.T) {
 			expectError: true,
 		},
 		{
-			name:        "unknown token (defaults to viewer)",
+			name:        "unknown token",
 			token:       "unknown-token",
-			expectError: false,
-			expectedClaims: &Claims{
-				Subject: "user-unknown",
-				Roles:   []string{RoleViewer},
-				Scopes:  []string{ScopeRead, ScopeTelemetry},
-			},
+			expectError: true,
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			claims, err := middleware.verifyToken(test.token)
+			claims, err := auth.VerifyToken(middleware, test.token)
 
 			if test.expectError {
 				if err == nil {
@@ -151,68 +159,78 @@ func TestVerifyToken(t *testing.T) {
 	}
 }
 
+func TestVerifyTokenWithoutVerifierRejects(t *testing.T) {
+	middleware := auth.NewMiddleware()
+
+	for _, token := range []string{"viewer-token", "admin-token", "unknown-token"} {
+		if claims, err := auth.VerifyToken(middleware, token); err == nil {
+			t.Errorf("Expected %s to be rejected without a verifier, got %+v", token, claims)
+		}
+	}
+}
+
 func TestHasRequiredScopes(t *testing.T) {
-	middleware := NewMiddleware()
+	middleware := fixtures.NewAuthMiddleware()
 
-	viewerClaims := &Claims{
+	viewerClaims := &auth.Claims{
 		Subject: "user-123",
-		Roles:   []string{RoleViewer},
-		Scopes:  []string{ScopeRead, ScopeTelemetry},
+		Roles:   []string{auth.RoleViewer},
+		Scopes:  []string{auth.ScopeRead, auth.ScopeTelemetry},
 	}
 
-	controllerClaims := &Claims{
+	controllerClaims := &auth.Claims{
 		Subject: "admin-456",
-		Roles:   []string{RoleController},
-		Scopes:  []string{ScopeRead, ScopeControl, ScopeTelemetry},
+		Roles:   []string{auth.RoleController},
+		Scopes:  []string{auth.ScopeRead, auth.ScopeControl, auth.ScopeTelemetry},
 	}
 
 	tests := []struct {
 		name           string
-		claims         *Claims
+		claims         *auth.Claims
 		requiredScopes []string
 		expected       bool
 	}{
 		{
 			name:           "viewer has read scope",
 			claims:         viewerClaims,
-			requiredScopes: []string{ScopeRead},
+			requiredScopes: []string{auth.ScopeRead},
 			expected:       true,
 		},
 		{
 			name:           "viewer has telemetry scope",
 			claims:         viewerClaims,
-			requiredScopes: []string{ScopeTelemetry},
+			requiredScopes: []string{auth.ScopeTelemetry},
 			expected:       true,
 		},
 		{
 			name:           "viewer lacks control scope",
 			claims:         viewerClaims,
-			requiredScopes: []string{ScopeControl},
+			requiredScopes: []string{auth.ScopeControl},
 			expected:       false,
 		},
 		{
 			name:           "controller has all scopes",
 			claims:         controllerClaims,
-			requiredScopes: []string{ScopeRead, ScopeControl, ScopeTelemetry},
+			requiredScopes: []string{auth.ScopeRead, auth.ScopeControl, auth.ScopeTelemetry},
 			expected:       true,
 		},
 		{
 			name:           "controller has control scope",
 			claims:         controllerClaims,
-			requiredScopes: []string{ScopeControl},
+			requiredScopes: []string{auth.ScopeControl},
 			expected:       true,
 		},
 		{
 			name:           "nil claims",
 			claims:         nil,
-			requiredScopes: []string{ScopeRead},
+			requiredScopes: []string{auth.ScopeRead},
 			expected:       false,
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			result := middleware.hasRequiredScopes(test.claims, test.requiredScopes)
+			result := auth.HasRequiredScopes(middleware, test.claims, test.requiredScopes)
 			if result != test.expected {
 				t.Errorf("Expected %v, got %v", test.expected, result)
 			}
@@ -221,61 +239,61 @@ func TestHasRequiredScopes(t *testing.T) {
 }
 
 func TestHasRequiredRoles(t *testing.T) {
-	middleware := NewMiddleware()
+	middleware := fixtures.NewAuthMiddleware()
 
-	viewerClaims := &Claims{
+	viewerClaims := &auth.Claims{
 		Subject: "user-123",
-		Roles:   []string{RoleViewer},
-		Scopes:  []string{ScopeRead, ScopeTelemetry},
+		Roles:   []string{auth.RoleViewer},
+		Scopes:  []string{auth.ScopeRead, auth.ScopeTelemetry},
 	}
 
-	controllerClaims := &Claims{
+	controllerClaims := &auth.Claims{
 		Subject: "admin-456",
-		Roles:   []string{RoleController},
-		Scopes:  []string{ScopeRead, ScopeControl, ScopeTelemetry},
+		Roles:   []string{auth.RoleController},
+		Scopes:  []string{auth.ScopeRead, auth.ScopeControl, auth.ScopeTelemetry},
 	}
 
 	tests := []struct {
 		name          string
-		claims        *Claims
+		claims        *auth.Claims
 		requiredRoles []string
 		expected      bool
 	}{
 		{
 			name:          "viewer has viewer role",
 			claims:        viewerClaims,
-			requiredRoles: []string{RoleViewer},
+			requiredRoles: []string{auth.RoleViewer},
 			expected:      true,
 		},
 		{
 			name:          "viewer lacks controller role",
 			claims:        viewerClaims,
-			requiredRoles: []string{RoleController},
+			requiredRoles: []string{auth.RoleController},
 			expected:      false,
 		},
 		{
 			name:          "controller has controller role",
 			claims:        controllerClaims,
-			requiredRoles: []string{RoleController},
+			requiredRoles: []string{auth.RoleController},
 			expected:      true,
 		},
 		{
 			name:          "controller has either role",
 			claims:        controllerClaims,
-			requiredRoles: []string{RoleViewer, RoleController},
+			requiredRoles: []string{auth.RoleViewer, auth.RoleController},
 			expected:      true,
 		},
 		{
 			name:          "nil claims",
 			claims:        nil,
-			requiredRoles: []string{RoleViewer},
+			requiredRoles: []string{auth.RoleViewer},
 			expected:      false,
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			result := middleware.hasRequiredRoles(test.claims, test.requiredRoles)
+			result := auth.HasRequiredRoles(middleware, test.claims, test.requiredRoles)
 			if result != test.expected {
 				t.Errorf("Expected %v, got %v", test.expected, result)
 			}
@@ -284,11 +302,11 @@ func TestHasRequiredRoles(t *testing.T) {
 }
 
 func TestRequireAuth(t *testing.T) {
-	middleware := NewMiddleware()
+	middleware := fixtures.NewAuthMiddleware()
 
 	// Test handler that checks for claims in context
 	testHandler := func(w http.ResponseWriter, r *http.Request) {
-		claims := GetClaimsFromRequest(r)
+		claims := auth.GetClaimsFromRequest(r)
 		if claims == nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			_, _ = w.Write([]byte("No claims in context"))
@@ -367,7 +385,7 @@ func TestRequireAuth(t *testing.T) {
 }
 
 func TestRequireScope(t *testing.T) {
-	middleware := NewMiddleware()
+	middleware := fixtures.NewAuthMiddleware()
 
 	// Test handler
 	testHandler := func(w http.ResponseWriter, r *http.Request) {
@@ -384,31 +402,31 @@ func TestRequireScope(t *testing.T) {
 		{
 			name:           "viewer with read scope",
 			authHeader:     "Bearer viewer-token",
-			requiredScopes: []string{ScopeRead},
+			requiredScopes: []string{auth.ScopeRead},
 			expectedStatus: http.StatusOK,
 		},
 		{
 			name:           "viewer without control scope",
 			authHeader:     "Bearer viewer-token",
-			requiredScopes: []string{ScopeControl},
+			requiredScopes: []string{auth.ScopeControl},
 			expectedStatus: http.StatusForbidden,
 		},
 		{
 			name:           "controller with control scope",
 			authHeader:     "Bearer controller-token",
-			requiredScopes: []string{ScopeControl},
+			requiredScopes: []string{auth.ScopeControl},
 			expectedStatus: http.StatusOK,
 		},
 		{
 			name:           "controller with multiple scopes",
 			authHeader:     "Bearer controller-token",
-			requiredScopes: []string{ScopeRead, ScopeControl, ScopeTelemetry},
+			requiredScopes: []string{auth.ScopeRead, auth.ScopeControl, auth.ScopeTelemetry},
 			expectedStatus: http.StatusOK,
 		},
 		{
 			name:           "no auth header",
 			authHeader:     "",
-			requiredScopes: []string{ScopeRead},
+			requiredScopes: []string{auth.ScopeRead},
 			expectedStatus: http.StatusUnauthorized,
 		},
 	}
@@ -432,8 +450,59 @@ func TestRequireScope(t *testing.T) {
 	}
 }
 
+func TestRequireScopeMatrix(t *testing.T) {
+	middleware := fixtures.NewAuthMiddleware()
+	testHandler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	// Expected status for each token against each control scope
+	matrix := []struct {
+		token   string
+		power   int
+		channel int
+		control int
+	}{
+		{"controller-token", http.StatusOK, http.StatusOK, http.StatusOK},
+		{"channel-token", http.StatusForbidden, http.StatusOK, http.StatusForbidden},
+		{"power-token", http.StatusOK, http.StatusForbidden, http.StatusForbidden},
+		{"viewer-token", http.StatusForbidden, http.StatusForbidden, http.StatusForbidden},
+	}
+
+	for _, row := range matrix {
+		for scope, want := range map[string]int{auth.ScopePower: row.power, auth.ScopeChannel: row.channel, auth.ScopeControl: row.control} {
+			t.Run(row.token+"/"+scope, func(t *testing.T) {
+				req := httptest.NewRequest("POST", "/test", nil)
+				req.Header.Set("Authorization", "Bearer "+row.token)
+				w := httptest.NewRecorder()
+
+				middleware.RequireAuth(middleware.RequireScope(scope)(testHandler))(w, req)
+
+				if w.Code != want {
+					t.Errorf("Expected status %d, got %d", want, w.Code)
+				}
+			})
+		}
+	}
+
+	// A route needing both fine scopes accepts control but not either alone
+	for token, want := range map[string]int{
+		"controller-token": http.StatusOK,
+		"channel-token":    http.StatusForbidden,
+		"power-token":      http.StatusForbidden,
+	} {
+		req := httptest.NewRequest("POST", "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		middleware.RequireAuth(middleware.RequireScope(auth.ScopeChannel, auth.ScopePower)(testHandler))(w, req)
+		if w.Code != want {
+			t.Errorf("%s on power+channel route: expected status %d, got %d", token, want, w.Code)
+		}
+	}
+}
+
 func TestRequireRole(t *testing.T) {
-	middleware := NewMiddleware()
+	middleware := fixtures.NewAuthMiddleware()
 
 	// Test handler
 	testHandler := func(w http.ResponseWriter, r *http.Request) {
@@ -450,31 +519,31 @@ func TestRequireRole(t *testing.T) {
 		{
 			name:           "viewer with viewer role",
 			authHeader:     "Bearer viewer-token",
-			requiredRoles:  []string{RoleViewer},
+			requiredRoles:  []string{auth.RoleViewer},
 			expectedStatus: http.StatusOK,
 		},
 		{
 			name:           "viewer without controller role",
 			authHeader:     "Bearer viewer-token",
-			requiredRoles:  []string{RoleController},
+			requiredRoles:  []string{auth.RoleController},
 			expectedStatus: http.StatusForbidden,
 		},
 		{
 			name:           "controller with controller role",
 			authHeader:     "Bearer controller-token",
-			requiredRoles:  []string{RoleController},
+			requiredRoles:  []string{auth.RoleController},
 			expectedStatus: http.StatusOK,
 		},
 		{
 			name:           "controller with either role",
 			authHeader:     "Bearer controller-token",
-			requiredRoles:  []string{RoleViewer, RoleController},
+			requiredRoles:  []string{auth.RoleViewer, auth.RoleController},
 			expectedStatus: http.StatusOK,
 		},
 		{
 			name:           "no auth header",
 			authHeader:     "",
-			requiredRoles:  []string{RoleViewer},
+			requiredRoles:  []string{auth.RoleViewer},
 			expectedStatus: http.StatusUnauthorized,
 		},
 	}
@@ -499,7 +568,7 @@ func TestRequireRole(t *testing.T) {
 }
 
 func TestGetClaimsFromRequest(t *testing.T) {
-	middleware := NewMiddleware()
+	middleware := fixtures.NewAuthMiddleware()
 
 	// Test with claims in context
 	req := httptest.NewRequest("GET", "/test", nil)
@@ -508,14 +577,14 @@ func TestGetClaimsFromRequest(t *testing.T) {
 	// Process through auth middleware to add claims to context
 	w := httptest.NewRecorder()
 	handler := middleware.RequireAuth(func(w http.ResponseWriter, r *http.Request) {
-		claims := GetClaimsFromRequest(r)
+		claims := auth.GetClaimsFromRequest(r)
 		if claims == nil {
 			t.Error("Expected claims, got nil")
 		}
 		if claims.Subject != "user-123" {
 			t.Errorf("Expected subject 'user-123', got '%s'", claims.Subject)
 		}
-		if !strings.Contains(strings.Join(claims.Roles, ","), RoleViewer) {
+		if !strings.Contains(strings.Join(claims.Roles, ","), auth.RoleViewer) {
 			t.Errorf("Expected viewer role, got %v", claims.Roles)
 		}
 	})
@@ -523,25 +592,25 @@ func TestGetClaimsFromRequest(t *testing.T) {
 
 	// Test without claims in context
 	req2 := httptest.NewRequest("GET", "/test", nil)
-	claims := GetClaimsFromRequest(req2)
+	claims := auth.GetClaimsFromRequest(req2)
 	if claims != nil {
 		t.Error("Expected nil claims, got non-nil")
 	}
 }
 
 func TestRoleAndScopeHelpers(t *testing.T) {
-	middleware := NewMiddleware()
+	middleware := fixtures.NewAuthMiddleware()
 
-	viewerClaims := &Claims{
+	viewerClaims := &auth.Claims{
 		Subject: "user-123",
-		Roles:   []string{RoleViewer},
-		Scopes:  []string{ScopeRead, ScopeTelemetry},
+		Roles:   []string{auth.RoleViewer},
+		Scopes:  []string{auth.ScopeRead, auth.ScopeTelemetry},
 	}
 
-	controllerClaims := &Claims{
+	controllerClaims := &auth.Claims{
 		Subject: "admin-456",
-		Roles:   []string{RoleController},
-		Scopes:  []string{ScopeRead, ScopeControl, ScopeTelemetry},
+		Roles:   []string{auth.RoleController},
+		Scopes:  []string{auth.ScopeRead, auth.ScopeControl, auth.ScopeTelemetry},
 	}
 
 	// Test role helpers
@@ -592,7 +661,7 @@ func TestRoleAndScopeHelpers(t *testing.T) {
 
 func TestContextKey(t *testing.T) {
 	// Test that context key is properly defined
-	if ClaimsKey != "claims" {
-		t.Errorf("Expected ClaimsKey to be 'claims', got '%s'", ClaimsKey)
+	if auth.ClaimsKey != "claims" {
+		t.Errorf("Expected auth.ClaimsKey to be 'claims', got '%s'", auth.ClaimsKey)
 	}
 }
@@ -11,6 +11,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -37,8 +38,21 @@ type VerifierConfig struct {
 	// JWKS configuration
 	JWKSRefreshInterval time.Duration
 	JWKSCacheTimeout    time.Duration
+
+	// Least time between on-demand JWKS fetches, made when a token names an
+	// unknown key ID or a stale key. Defaults to 10s, and never exceeds
+	// JWKSRefreshInterval.
+	JWKSMinRefreshInterval time.Duration
 }
 
+// defaultJWKSMinRefreshInterval is the JWKSMinRefreshInterval used when none
+// is configured, so tokens with made-up key IDs cannot hammer the JWKS host.
+const defaultJWKSMinRefreshInterval = 10 * time.Second
+
+// errJWKSRefreshThrottled is returned when an on-demand JWKS fetch is skipped
+// because one was attempted within the minimum refresh interval.
+var errJWKSRefreshThrottled = errors.New("JWKS refreshed too recently")
+
 // JWK represents a JSON Web Key.
 type JWK struct {
 	Kty string `json:"kty"`
@@ -64,10 +78,21 @@ type JWKSCacheEntry struct {
 type Verifier struct {
 	config     VerifierConfig
 	publicKey  *rsa.PublicKey
-	jwksCache  map[string]*JWKSCacheEntry
-	jwksMutex  sync.RWMutex
-	lastFetch  time.Time
 	httpClient *http.Client
+
+	// Keys of the last good JWKS by kid, and when it was fetched and when a
+	// fetch was last attempted; guarded by jwksMutex
+	jwksCache   map[string]*JWKSCacheEntry
+	jwksMutex   sync.RWMutex
+	lastFetch   time.Time
+	lastAttempt time.Time
+
+	// Serializes JWKS fetches so concurrent misses fetch once
+	fetchMu sync.Mutex
+
+	// Closed by Stop to end background JWKS refreshes
+	stopRefresh chan struct{}
+	stopOnce    sync.Once
 }
 
 // NewVerifier creates a new JWT verifier.
@@ -221,10 +246,14 @@ func (v *Verifier) extractClaimsFromMap(claims *jwt.MapClaims) (*Claims, error)
 		return nil, fmt.Errorf("invalid scopes: %v", scopes)
 	}
 
+	// Service tokens are optional; absent means interactive
+	service, _ := (*claims)["service"].(bool)
+
 	return &Claims{
 		Subject: sub,
 		Roles:   roles,
 		Scopes:  scopes,
+		Service: service,
 	}, nil
 }
 
@@ -258,6 +287,7 @@ func (v *Verifier) validateRoles(roles []string) bool {
 	validRoles := map[string]bool{
 		RoleViewer:     true,
 		RoleController: true,
+		RoleOperator:   true,
 	}
 
 	for _, role := range roles {
@@ -275,6 +305,8 @@ func (v *Verifier) validateScopes(scopes []string) bool {
 		ScopeRead:      true,
 		ScopeControl:   true,
 		ScopeTelemetry: true,
+		ScopePower:     true,
+		ScopeChannel:   true,
 	}
 
 	for _, scope := range scopes {
@@ -309,10 +341,50 @@ func (v *Verifier) loadPublicKeyFromPEM(pemData string) error {
 
 // fetchJWKS fetches the JSON Web Key Set from the configured URL.
 func (v *Verifier) fetchJWKS() error {
+	v.fetchMu.Lock()
+	defer v.fetchMu.Unlock()
+	return v.fetchJWKSLocked()
+}
+
+// refreshJWKS fetches the JWKS on demand, or returns errJWKSRefreshThrottled
+// when a fetch was attempted within the minimum refresh interval.
+func (v *Verifier) refreshJWKS() error {
+	v.fetchMu.Lock()
+	defer v.fetchMu.Unlock()
+
+	v.jwksMutex.RLock()
+	lastAttempt := v.lastAttempt
+	v.jwksMutex.RUnlock()
+	if time.Since(lastAttempt) < v.minRefreshInterval() {
+		return errJWKSRefreshThrottled
+	}
+	return v.fetchJWKSLocked()
+}
+
+// minRefreshInterval returns the least time between on-demand fetches.
+func (v *Verifier) minRefreshInterval() time.Duration {
+	interval := v.config.JWKSMinRefreshInterval
+	if interval <= 0 {
+		interval = defaultJWKSMinRefreshInterval
+	}
+	if refresh := v.config.JWKSRefreshInterval; refresh > 0 && refresh < interval {
+		interval = refresh
+	}
+	return interval
+}
+
+// fetchJWKSLocked fetches the JWKS and replaces the cached keys with it, so
+// keys retired by the issuer stop verifying. On failure, including a set
+// with no usable keys, the cached keys are kept. Callers hold fetchMu.
+func (v *Verifier) fetchJWKSLocked() error {
 	if v.config.JWKSURL == "" {
 		return fmt.Errorf("JWKS URL not configured")
 	}
 
+	v.jwksMutex.Lock()
+	v.lastAttempt = time.Now()
+	v.jwksMutex.Unlock()
+
 	resp, err := v.httpClient.Get(v.config.JWKSURL)
 	if err != nil {
 		return fmt.Errorf("failed to fetch JWKS: %w", err)
@@ -333,64 +405,86 @@ func (v *Verifier) fetchJWKS() error {
 		return fmt.Errorf("failed to parse JWKS: %w", err)
 	}
 
-	// Cache the keys
-	v.jwksMutex.Lock()
-	defer v.jwksMutex.Unlock()
-
+	// use and alg are optional in a JWK; when present they must match
 	now := time.Now()
+	keys := make(map[string]*JWKSCacheEntry)
 	for _, key := range jwks.Keys {
-		if key.Kty == "RSA" && key.Use == "sig" && key.Alg == "RS256" {
-			pubKey, err := v.jwkToRSAPublicKey(key)
-			if err != nil {
-				continue // Skip invalid keys
-			}
-			v.jwksCache[key.Kid] = &JWKSCacheEntry{
-				Key:       pubKey,
-				Timestamp: now,
-			}
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") || (key.Alg != "" && key.Alg != "RS256") {
+			continue
+		}
+		pubKey, err := v.jwkToRSAPublicKey(key)
+		if err != nil {
+			continue // Skip invalid keys
+		}
+		keys[key.Kid] = &JWKSCacheEntry{
+			Key:       pubKey,
+			Timestamp: now,
 		}
 	}
+	if len(keys) == 0 {
+		return fmt.Errorf("JWKS has no usable RS256 signing keys")
+	}
 
-	v.lastFetch = time.Now()
+	v.jwksMutex.Lock()
+	v.jwksCache = keys
+	v.lastFetch = now
+	v.jwksMutex.Unlock()
 	return nil
 }
 
-// getKeyFromJWKS gets a public key from the JWKS cache.
+// getKeyFromJWKS gets a public key from the JWKS cache. A kid that is not
+// cached, perhaps because the issuer just rotated keys, or whose key is older
+// than JWKSCacheTimeout causes a refetch first. If the refetch fails, the
+// last good keys still verify.
 func (v *Verifier) getKeyFromJWKS(kid string) (*rsa.PublicKey, error) {
-	v.jwksMutex.RLock()
-	entry, exists := v.jwksCache[kid]
-	v.jwksMutex.RUnlock()
+	entry := v.cachedKey(kid)
+	if entry != nil && time.Since(entry.Timestamp) < v.config.JWKSCacheTimeout {
+		return entry.Key, nil
+	}
 
-	if exists {
-		// Check if cache entry is still valid
-		if time.Since(entry.Timestamp) < v.config.JWKSCacheTimeout {
-			return entry.Key, nil
-		}
-		// Entry expired, will need refresh
+	refreshErr := v.refreshJWKS()
+	if entry = v.cachedKey(kid); entry != nil {
+		return entry.Key, nil
 	}
+	if refreshErr != nil && !errors.Is(refreshErr, errJWKSRefreshThrottled) {
+		return nil, fmt.Errorf("key not found: %s: %w", kid, refreshErr)
+	}
+	return nil, fmt.Errorf("key not found: %s", kid)
+}
 
-	// Check if we need to refresh JWKS
-	if time.Since(v.lastFetch) > v.config.JWKSRefreshInterval {
-		v.jwksMutex.Lock()
-		if time.Since(v.lastFetch) > v.config.JWKSRefreshInterval {
-			if err := v.fetchJWKS(); err != nil {
-				v.jwksMutex.Unlock()
-				return nil, fmt.Errorf("failed to refresh JWKS: %w", err)
+// cachedKey returns the cached key for kid, or nil.
+func (v *Verifier) cachedKey(kid string) *JWKSCacheEntry {
+	v.jwksMutex.RLock()
+	defer v.jwksMutex.RUnlock()
+	return v.jwksCache[kid]
+}
+
+// startJWKSRefresh refetches the JWKS every interval until Stop. Failed
+// fetches keep the last good keys.
+func (v *Verifier) startJWKSRefresh(interval time.Duration) {
+	v.stopRefresh = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-v.stopRefresh:
+				return
+			case <-ticker.C:
+				_ = v.fetchJWKS()
 			}
 		}
-		v.jwksMutex.Unlock()
-
-		// Try again after refresh
-		v.jwksMutex.RLock()
-		entry, exists = v.jwksCache[kid]
-		v.jwksMutex.RUnlock()
+	}()
+}
 
-		if exists {
-			return entry.Key, nil
+// Stop ends background JWKS refreshes. It is safe to call more than once,
+// and on verifiers that do not refresh.
+func (v *Verifier) Stop() {
+	v.stopOnce.Do(func() {
+		if v.stopRefresh != nil {
+			close(v.stopRefresh)
 		}
-	}
-
-	return nil, fmt.Errorf("key not found: %s", kid)
+	})
 }
 
 // jwkToRSAPublicKey converts a JWK to an RSA public key.
@@ -418,15 +512,8 @@ func (v *Verifier) jwkToRSAPublicKey(jwk JWK) (*rsa.PublicKey, error) {
 	}, nil
 }
 
-// base64URLDecode decodes base64url encoded data.
+// base64URLDecode decodes unpadded base64url data, as JWK members are
+// encoded (RFC 7518 §6.3).
 func base64URLDecode(data string) ([]byte, error) {
-	// Add padding if needed
-	switch len(data) % 4 {
-	case 2:
-		data += "=="
-	case 3:
-		data += "="
-	}
-
 	return base64.RawURLEncoding.DecodeString(data)
 }
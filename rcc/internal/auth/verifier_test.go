@@ -356,6 +356,11 @@ func TestValidateScopes(t *testing.T) {
 			scopes:   []string{ScopeRead, "admin"},
 			expected: false,
 		},
+		{
+			name:     "fine control scopes",
+			scopes:   []string{ScopeRead, ScopePower, ScopeChannel},
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
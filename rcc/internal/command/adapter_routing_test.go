@@ -0,0 +1,82 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/radio"
+)
+
+// recordingAdapter returns a MockAdapter that counts SetPower and SetFrequency calls.
+func recordingAdapter(calls *[]string, name string) *MockAdapter {
+	return &MockAdapter{
+		SetPowerFunc: func(ctx context.Context, dBm float64) error {
+			*calls = append(*calls, name+":setPower")
+			return nil
+		},
+		SetFrequencyFunc: func(ctx context.Context, frequencyMhz float64) error {
+			*calls = append(*calls, name+":setChannel")
+			return nil
+		},
+	}
+}
+
+func TestCommandsRouteToEachRadiosAdapter(t *testing.T) {
+	var calls []string
+	rm := radio.NewManager()
+	for _, id := range []string{"radio-01", "radio-02"} {
+		if err := rm.LoadCapabilities(id, recordingAdapter(&calls, id), time.Second); err != nil {
+			t.Fatalf("Failed to load %s: %v", id, err)
+		}
+	}
+	orchestrator := NewOrchestratorWithRadioManager(nil, config.LoadCBTimingBaseline(), rm)
+	ctx := context.Background()
+
+	// radio-01 is selected, yet radio-02's commands must reach radio-02
+	if rm.GetActive() != "radio-01" {
+		t.Fatalf("Expected radio-01 active, got %s", rm.GetActive())
+	}
+	if err := orchestrator.SetPower(ctx, "radio-02", 20); err != nil {
+		t.Fatalf("SetPower radio-02 failed: %v", err)
+	}
+	if err := orchestrator.SetChannel(ctx, "radio-01", 2437); err != nil {
+		t.Fatalf("SetChannel radio-01 failed: %v", err)
+	}
+
+	// Selecting a radio does not redirect commands for the other one
+	if err := orchestrator.SelectRadio(ctx, "radio-02"); err != nil {
+		t.Fatalf("SelectRadio radio-02 failed: %v", err)
+	}
+	if err := orchestrator.SetPower(ctx, "radio-01", 10); err != nil {
+		t.Fatalf("SetPower radio-01 failed: %v", err)
+	}
+
+	want := []string{"radio-02:setPower", "radio-01:setChannel", "radio-01:setPower"}
+	if len(calls) != len(want) {
+		t.Fatalf("Expected calls %v, got %v", want, calls)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("Call %d: expected %s, got %s", i, want[i], calls[i])
+		}
+	}
+}
+
+func TestDeprecatedActiveAdapterIsIgnoredWhenManagerResolves(t *testing.T) {
+	var calls []string
+	rm := radio.NewManager()
+	if err := rm.LoadCapabilities("radio-01", recordingAdapter(&calls, "radio-01"), time.Second); err != nil {
+		t.Fatalf("Failed to load radio-01: %v", err)
+	}
+	orchestrator := NewOrchestratorWithRadioManager(nil, config.LoadCBTimingBaseline(), rm)
+	orchestrator.SetActiveAdapter(recordingAdapter(&calls, "active"))
+
+	if err := orchestrator.SetPower(context.Background(), "radio-01", 20); err != nil {
+		t.Fatalf("SetPower failed: %v", err)
+	}
+	if len(calls) != 1 || calls[0] != "radio-01:setPower" {
+		t.Errorf("Expected radio-01's adapter to be used, got %v", calls)
+	}
+}
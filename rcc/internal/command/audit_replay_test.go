@@ -0,0 +1,47 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/audit"
+)
+
+func TestAuditLogReplaysToRadioState(t *testing.T) {
+	orchestrator := newLockTestOrchestrator(t, map[string]*MockAdapter{
+		"radio-01": {
+			SetPowerFunc: func(ctx context.Context, dBm float64) error {
+				if dBm == 30 {
+					return errors.New("radio busy")
+				}
+				return nil
+			},
+		},
+	})
+	logger, err := audit.NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLogger() failed: %v", err)
+	}
+	defer func() { _ = logger.Close() }()
+	orchestrator.SetAuditLogger(logger)
+
+	ctx := context.Background()
+	_ = orchestrator.SetPower(ctx, "radio-01", 25)
+	_ = orchestrator.SetChannel(ctx, "radio-01", 2437)
+	if err := orchestrator.SetPower(ctx, "radio-01", 30); err == nil {
+		t.Fatal("Expected the 30 dBm command to fail")
+	}
+
+	entries, err := audit.NewReader(logger.GetFilePath()).Query(audit.Filter{})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	state, err := audit.ReplayState(entries, "radio-01")
+	if err != nil {
+		t.Fatalf("ReplayState failed: %v", err)
+	}
+	if state.PowerDbm != 25 || state.FrequencyMhz != 2437 {
+		t.Errorf("Replayed state = %+v, want 25 dBm at 2437 MHz", state)
+	}
+}
@@ -0,0 +1,76 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/internal/radio"
+)
+
+// ErrForbidden indicates the caller's roles do not allow the command.
+var ErrForbidden = errors.New("FORBIDDEN")
+
+// ErrModelForbidden indicates the radio's model is not in AllowedModels.
+// It matches ErrForbidden with errors.Is.
+var ErrModelForbidden = fmt.Errorf("%w: radio model not allowed", ErrForbidden)
+
+// authorize checks the caller's roles against the config RoleActions
+// allowlist. Requests without claims, and configs without an allowlist,
+// are not restricted here; scope checks still apply at the API layer.
+func (o *Orchestrator) authorize(ctx context.Context, action, radioID string, start time.Time) error {
+	if _, ok := o.allowingRole(ctx, action); ok {
+		return nil
+	}
+
+	o.logAudit(ctx, action, radioID, "FORBIDDEN", time.Since(start))
+	return ErrForbidden
+}
+
+// allowingRole returns the first of the caller's roles that RoleActions
+// allows action for. ok is true with an empty role when no allowlist
+// applies to the request.
+func (o *Orchestrator) allowingRole(ctx context.Context, action string) (role string, ok bool) {
+	claims := auth.GetClaimsFromContext(ctx)
+	cfg := o.config.Load()
+	if claims == nil || cfg == nil || cfg.RoleActions == nil {
+		return "", true
+	}
+
+	for _, role := range claims.Roles {
+		for _, allowed := range cfg.RoleActions[role] {
+			if allowed == "*" || allowed == action {
+				return role, true
+			}
+		}
+	}
+	return "", false
+}
+
+// authorizeModel checks the commanded radio's model against the config
+// AllowedModels list (case-insensitive). An empty list allows every model.
+func (o *Orchestrator) authorizeModel(ctx context.Context, action string, r *radio.Radio, start time.Time) error {
+	if o.modelAllowed(r.Model) {
+		return nil
+	}
+
+	o.logAudit(ctx, action, r.ID, "FORBIDDEN", time.Since(start))
+	return ErrModelForbidden
+}
+
+// modelAllowed reports whether AllowedModels admits model.
+func (o *Orchestrator) modelAllowed(model string) bool {
+	allowed := o.timing().AllowedModels
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, m := range allowed {
+		if strings.EqualFold(m, model) {
+			return true
+		}
+	}
+	return false
+}
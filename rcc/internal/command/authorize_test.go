@@ -0,0 +1,95 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/internal/radio"
+)
+
+// withRoles returns a context carrying claims with the given roles.
+func withRoles(roles ...string) context.Context {
+	return context.WithValue(context.Background(), auth.ClaimsKey, &auth.Claims{
+		Subject: "user-test",
+		Roles:   roles,
+		Scopes:  []string{auth.ScopeRead, auth.ScopeControl},
+	})
+}
+
+func TestOperatorRoleCanSetPowerButNotChannel(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.SetActiveAdapter(&MockAdapter{})
+	ctx := withRoles(auth.RoleOperator)
+
+	if err := orchestrator.SetPower(ctx, "radio-01", 20); err != nil {
+		t.Errorf("Expected operator to set power, got %v", err)
+	}
+	if err := orchestrator.SetChannel(ctx, "radio-01", 2437); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden for operator SetChannel, got %v", err)
+	}
+	if err := orchestrator.SetChannelByIndex(ctx, "radio-01", 6, orchestrator.radioManager); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden for operator SetChannelByIndex, got %v", err)
+	}
+}
+
+func TestRoleAllowlist(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.SetActiveAdapter(&MockAdapter{})
+
+	if err := orchestrator.SetPower(withRoles(auth.RoleViewer), "radio-01", 20); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden for viewer, got %v", err)
+	}
+	if err := orchestrator.SetChannel(withRoles(auth.RoleController), "radio-01", 2437); err != nil {
+		t.Errorf("Expected controller to set channel, got %v", err)
+	}
+
+	// Any role granting the action is enough
+	if err := orchestrator.SetChannel(withRoles(auth.RoleOperator, auth.RoleController), "radio-01", 2437); err != nil {
+		t.Errorf("Expected operator+controller to set channel, got %v", err)
+	}
+
+	// Unauthenticated internal callers and a nil allowlist are not restricted
+	if err := orchestrator.SetChannel(context.Background(), "radio-01", 2437); err != nil {
+		t.Errorf("Expected call without claims to succeed, got %v", err)
+	}
+	orchestrator.timing().RoleActions = nil
+	if err := orchestrator.SetPower(withRoles(auth.RoleViewer), "radio-01", 20); err != nil {
+		t.Errorf("Expected no enforcement with nil allowlist, got %v", err)
+	}
+}
+
+func TestAllowedModels(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.SetActiveAdapter(&MockAdapter{})
+	auditLogger := &MockAuditLogger{}
+	orchestrator.SetAuditLogger(auditLogger)
+
+	radios := orchestrator.radioManager.(*MockRadioManager).Radios
+	radios["radio-01"].Model = "Silvus"
+	radios["radio-02"] = &radio.Radio{ID: "radio-02", Model: "Unapproved"}
+	orchestrator.timing().AllowedModels = []string{"silvus"}
+	ctx := context.Background()
+
+	if err := orchestrator.SelectRadio(ctx, "radio-01"); err != nil {
+		t.Errorf("Expected allowed model to be selected, got %v", err)
+	}
+	if err := orchestrator.SelectRadio(ctx, "radio-02"); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden selecting disallowed model, got %v", err)
+	}
+	if err := orchestrator.SetPower(ctx, "radio-02", 20); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden commanding disallowed model, got %v", err)
+	}
+
+	last := auditLogger.Actions[len(auditLogger.Actions)-1]
+	if last.Action != "setPower" || last.RadioID != "radio-02" || last.Result != "FORBIDDEN" {
+		t.Errorf("Expected FORBIDDEN audit for radio-02, got %+v", last)
+	}
+
+	// An empty list allows every model
+	orchestrator.timing().AllowedModels = nil
+	if err := orchestrator.SelectRadio(ctx, "radio-02"); err != nil {
+		t.Errorf("Expected any model allowed with empty list, got %v", err)
+	}
+}
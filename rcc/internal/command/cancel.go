@@ -0,0 +1,100 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrCanceled indicates an in-flight command was canceled by an operator.
+var ErrCanceled = errors.New("CANCELED")
+
+// inflightCommands tracks cancellable commands per radio.
+type inflightCommands struct {
+	mu       sync.Mutex
+	nextID   uint64
+	commands map[string]map[uint64]*inflightCommand
+}
+
+// inflightCommand is a single running command and its cancel function.
+type inflightCommand struct {
+	action   string
+	cancel   context.CancelFunc
+	canceled atomic.Bool
+}
+
+// wasCanceled reports whether the command was canceled via CancelCommand.
+func (c *inflightCommand) wasCanceled() bool {
+	return c.canceled.Load()
+}
+
+// startCommand derives the command context with the given timeout and registers
+// it as in flight for radioID. The returned finish function must be called when
+// the command completes.
+func (o *Orchestrator) startCommand(ctx context.Context, radioID, action string, timeout time.Duration) (context.Context, *inflightCommand, func()) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	cmd := &inflightCommand{action: action, cancel: cancel}
+
+	o.inflight.mu.Lock()
+	if o.inflight.commands == nil {
+		o.inflight.commands = make(map[string]map[uint64]*inflightCommand)
+	}
+	o.inflight.nextID++
+	id := o.inflight.nextID
+	if o.inflight.commands[radioID] == nil {
+		o.inflight.commands[radioID] = make(map[uint64]*inflightCommand)
+	}
+	o.inflight.commands[radioID][id] = cmd
+	o.inflight.mu.Unlock()
+
+	finish := func() {
+		o.inflight.mu.Lock()
+		delete(o.inflight.commands[radioID], id)
+		if len(o.inflight.commands[radioID]) == 0 {
+			delete(o.inflight.commands, radioID)
+		}
+		o.inflight.mu.Unlock()
+		cancel()
+	}
+	return ctx, cmd, finish
+}
+
+// CancelCommand cancels every command in flight for radioID and returns the
+// actions canceled. Canceled commands return ErrCanceled and publish a fault.
+// It returns ErrNotFound when no command is in flight.
+func (o *Orchestrator) CancelCommand(ctx context.Context, radioID string) ([]string, error) {
+	start := time.Now()
+
+	if err := o.authorize(ctx, "cancel", radioID, start); err != nil {
+		return nil, err
+	}
+
+	o.inflight.mu.Lock()
+	commands := o.inflight.commands[radioID]
+	actions := make([]string, 0, len(commands))
+	for _, cmd := range commands {
+		cmd.canceled.Store(true)
+		cmd.cancel()
+		actions = append(actions, cmd.action)
+	}
+	o.inflight.mu.Unlock()
+
+	if len(actions) == 0 {
+		o.logAudit(ctx, "cancel", radioID, "NOT_FOUND", time.Since(start))
+		return nil, ErrNotFound
+	}
+
+	o.logAudit(ctx, "cancel", radioID, "SUCCESS", time.Since(start))
+	return actions, nil
+}
+
+// commandCanceled records an operator-canceled command and returns ErrCanceled.
+// Cancellation is not a radio failure, so no breaker outcome is recorded.
+func (o *Orchestrator) commandCanceled(ctx context.Context, action, radioID string, latency time.Duration) error {
+	o.breaker.Release(radioID)
+	o.logAudit(ctx, action, radioID, "CANCELED", latency)
+	o.publishFaultEvent(radioID, ErrCanceled, "Command canceled by operator")
+	return ErrCanceled
+}
@@ -0,0 +1,108 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// blockingAdapter returns a MockAdapter whose SetFrequency blocks until its context ends.
+func blockingAdapter(started chan<- struct{}) *MockAdapter {
+	return &MockAdapter{
+		SetFrequencyFunc: func(ctx context.Context, frequencyMhz float64) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+}
+
+func TestCancelCommandAbortsInFlightSetChannel(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.breaker = NewCircuitBreaker(orchestrator.timing())
+
+	started := make(chan struct{})
+	orchestrator.SetActiveAdapter(blockingAdapter(started))
+
+	result := make(chan error, 1)
+	go func() {
+		result <- orchestrator.SetChannel(context.Background(), "radio-01", 2437)
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("SetChannel never reached the adapter")
+	}
+
+	actions, err := orchestrator.CancelCommand(context.Background(), "radio-01")
+	if err != nil {
+		t.Fatalf("CancelCommand failed: %v", err)
+	}
+	if len(actions) != 1 || actions[0] != "setChannel" {
+		t.Errorf("Expected [setChannel] canceled, got %v", actions)
+	}
+
+	select {
+	case err := <-result:
+		if !errors.Is(err, ErrCanceled) {
+			t.Errorf("Expected ErrCanceled, got %v", err)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("Canceled SetChannel did not return promptly")
+	}
+
+	// Cancellation is not an adapter failure
+	if state := orchestrator.breaker.State("radio-01"); state != BreakerClosed {
+		t.Errorf("Expected breaker to stay closed, got %s", state)
+	}
+
+	// Nothing left in flight
+	if _, err := orchestrator.CancelCommand(context.Background(), "radio-01"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after command finished, got %v", err)
+	}
+}
+
+func TestCancelCommandWithoutInFlightCommand(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+
+	if _, err := orchestrator.CancelCommand(context.Background(), "radio-01"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestCanceledHalfOpenProbeReleasesBreaker(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	breaker := NewCircuitBreaker(orchestrator.timing())
+	now := time.Now()
+	breaker.now = func() time.Time { return now }
+	orchestrator.breaker = breaker
+
+	// Trip the breaker, then let the cooldown elapse so the next command probes
+	for i := 0; i < breaker.failureThreshold; i++ {
+		breaker.Record("radio-01", context.DeadlineExceeded)
+	}
+	now = now.Add(breaker.cooldown)
+
+	started := make(chan struct{})
+	orchestrator.SetActiveAdapter(blockingAdapter(started))
+
+	result := make(chan error, 1)
+	go func() {
+		result <- orchestrator.SetChannel(context.Background(), "radio-01", 2437)
+	}()
+	<-started
+
+	if _, err := orchestrator.CancelCommand(context.Background(), "radio-01"); err != nil {
+		t.Fatalf("CancelCommand failed: %v", err)
+	}
+	if err := <-result; !errors.Is(err, ErrCanceled) {
+		t.Fatalf("Expected ErrCanceled, got %v", err)
+	}
+
+	// The probe slot is free for the next command
+	if err := breaker.Allow("radio-01"); err != nil {
+		t.Errorf("Expected next half-open probe to be allowed, got %v", err)
+	}
+}
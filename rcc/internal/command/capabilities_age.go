@@ -0,0 +1,37 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrStaleCapabilities indicates a channel index was given for a radio whose
+// capabilities are older than CapabilitiesMaxAge and could not be refreshed,
+// so the index may resolve against an outdated channel map.
+var ErrStaleCapabilities = errors.New("STALE_CAPABILITIES")
+
+// checkCapabilitiesAge refreshes radioID's capabilities when they are older
+// than CapabilitiesMaxAge, or audits STALE_CAPABILITIES and returns
+// ErrStaleCapabilities when the refresh fails. Radio managers that do not
+// track capability age are not checked.
+func (o *Orchestrator) checkCapabilitiesAge(ctx context.Context, action, radioID string, radioManager RadioManager, start time.Time) error {
+	cfg := o.timing()
+	if cfg.CapabilitiesMaxAge <= 0 {
+		return nil
+	}
+	refresher, ok := radioManager.(CapabilityRefresher)
+	if !ok {
+		return nil
+	}
+	age, err := refresher.CapabilitiesAge(radioID)
+	if err != nil || age <= cfg.CapabilitiesMaxAge {
+		return nil
+	}
+
+	if err := refresher.RefreshCapabilities(radioID, cfg.CommandTimeoutGetState); err != nil {
+		o.logAudit(ctx, action, radioID, "STALE_CAPABILITIES", time.Since(start))
+		return ErrStaleCapabilities
+	}
+	return nil
+}
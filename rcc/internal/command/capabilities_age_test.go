@@ -0,0 +1,71 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// agingRadioManager ages capabilities on a fake clock. Refreshes fail while
+// refreshErr is set.
+type agingRadioManager struct {
+	*MockRadioManager
+	now        time.Time
+	loadedAt   time.Time
+	refreshErr error
+}
+
+func (m *agingRadioManager) CapabilitiesAge(radioID string) (time.Duration, error) {
+	return m.now.Sub(m.loadedAt), nil
+}
+
+func (m *agingRadioManager) RefreshCapabilities(radioID string, timeout time.Duration) error {
+	if m.refreshErr != nil {
+		return m.refreshErr
+	}
+	m.loadedAt = m.now
+	return nil
+}
+
+func TestSetChannelByIndexRejectsStaleCapabilities(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.timing().CapabilitiesMaxAge = 10 * time.Minute
+	frequencyMhz := 2412.0
+	orchestrator.SetActiveAdapter(tunedAdapter(&frequencyMhz))
+
+	start := time.Now()
+	rm := &agingRadioManager{
+		MockRadioManager: orchestrator.radioManager.(*MockRadioManager),
+		now:              start,
+		loadedAt:         start,
+		refreshErr:       errors.New("radio unreachable"),
+	}
+	ctx := context.Background()
+
+	// Within the max age the cached channel map is used
+	rm.now = start.Add(10 * time.Minute)
+	if err := orchestrator.SetChannelByIndex(ctx, "radio-01", 6, rm); err != nil {
+		t.Fatalf("Expected fresh capabilities to be accepted, got %v", err)
+	}
+
+	// Past it, commands fail for as long as the refresh does
+	rm.now = start.Add(10*time.Minute + time.Second)
+	for i := 0; i < 2; i++ {
+		if err := orchestrator.SetChannelByIndex(ctx, "radio-01", 11, rm); !errors.Is(err, ErrStaleCapabilities) {
+			t.Fatalf("Expected ErrStaleCapabilities, got %v", err)
+		}
+	}
+	if frequencyMhz != 2437 {
+		t.Errorf("Expected stale commands not to tune the radio, got %v MHz", frequencyMhz)
+	}
+
+	// A successful refresh lifts the rejection
+	rm.refreshErr = nil
+	if err := orchestrator.SetChannelByIndex(ctx, "radio-01", 11, rm); err != nil {
+		t.Fatalf("Expected command to succeed after refresh, got %v", err)
+	}
+	if frequencyMhz != 2462 || !rm.loadedAt.Equal(rm.now) {
+		t.Errorf("Expected refresh and tune to 2462 MHz, got %v MHz loaded at %v", frequencyMhz, rm.loadedAt)
+	}
+}
@@ -0,0 +1,119 @@
+package command
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/radio"
+	"github.com/radio-control/rcc/internal/telemetry"
+)
+
+// recordingPublisher keeps every event the orchestrator publishes.
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []telemetry.Event
+}
+
+func (p *recordingPublisher) PublishRadio(radioID string, event telemetry.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	event.Radio = radioID
+	p.events = append(p.events, event)
+	return nil
+}
+
+// lastOfType returns the most recent event of eventType.
+func (p *recordingPublisher) lastOfType(t *testing.T, eventType string) telemetry.Event {
+	t.Helper()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := len(p.events) - 1; i >= 0; i-- {
+		if p.events[i].Type == eventType {
+			return p.events[i]
+		}
+	}
+	t.Fatalf("No %s event published", eventType)
+	return telemetry.Event{}
+}
+
+func TestSetChannelEventCarriesResolvedIndex(t *testing.T) {
+	tests := []struct {
+		name         string
+		frequencyMhz float64
+		want         interface{}
+	}{
+		{"channel plan match", 2437.0, 6},
+		{"within tolerance", 2462.3, 11},
+		{"not on a channel", 2450.0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orchestrator := setupTestOrchestrator(t)
+			frequencyMhz := 2412.0
+			orchestrator.SetActiveAdapter(tunedAdapter(&frequencyMhz))
+			publisher := &recordingPublisher{}
+			orchestrator.telemetryHub = publisher
+
+			if err := orchestrator.SetChannel(context.Background(), "radio-01", tt.frequencyMhz); err != nil {
+				t.Fatalf("SetChannel() error = %v", err)
+			}
+
+			data := publisher.lastOfType(t, "channelChanged").Data
+			if got := data["channelIndex"]; got != tt.want {
+				t.Errorf("channelIndex = %v (%T), want %v", got, got, tt.want)
+			}
+			if data["frequencyMhz"] != tt.frequencyMhz {
+				t.Errorf("frequencyMhz = %v, want %v", data["frequencyMhz"], tt.frequencyMhz)
+			}
+		})
+	}
+}
+
+func TestSetChannelEventResolvesIndexFromBandPlan(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.SetRadioManager(&MockRadioManager{
+		Radios: map[string]*radio.Radio{
+			"radio-01": {ID: "radio-01", Model: "Silvus-Scout"},
+		},
+	})
+	orchestrator.timing().SilvusBandPlan = &config.SilvusBandPlan{
+		Models: map[string]map[string][]config.SilvusChannel{
+			"Silvus-Scout": {
+				"2.4GHz": {
+					{ChannelIndex: 1, FrequencyMhz: 2412.0},
+					{ChannelIndex: 2, FrequencyMhz: 2417.0},
+				},
+			},
+		},
+	}
+	frequencyMhz := 2412.0
+	orchestrator.SetActiveAdapter(tunedAdapter(&frequencyMhz))
+	publisher := &recordingPublisher{}
+	orchestrator.telemetryHub = publisher
+
+	if err := orchestrator.SetChannel(context.Background(), "radio-01", 2417.0); err != nil {
+		t.Fatalf("SetChannel() error = %v", err)
+	}
+	if got := publisher.lastOfType(t, "channelChanged").Data["channelIndex"]; got != 2 {
+		t.Errorf("channelIndex = %v, want 2", got)
+	}
+}
+
+func TestSetChannelByIndexEventCarriesIndex(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	frequencyMhz := 2412.0
+	orchestrator.SetActiveAdapter(tunedAdapter(&frequencyMhz))
+	publisher := &recordingPublisher{}
+	orchestrator.telemetryHub = publisher
+
+	if err := orchestrator.SetChannelByIndex(context.Background(), "radio-01", 11, orchestrator.radioManager); err != nil {
+		t.Fatalf("SetChannelByIndex() error = %v", err)
+	}
+	data := publisher.lastOfType(t, "channelChanged").Data
+	if data["channelIndex"] != 11 || data["frequencyMhz"] != 2462.0 {
+		t.Errorf("channelChanged data = %v, want index 11 at 2462 MHz", data)
+	}
+}
@@ -0,0 +1,80 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/adapter"
+)
+
+// nativeIndexAdapter reports a channel index tracked by the radio itself.
+type nativeIndexAdapter struct {
+	MockAdapter
+	index int
+	err   error
+}
+
+func (n *nativeIndexAdapter) GetChannelIndex(ctx context.Context) (int, error) {
+	return n.index, n.err
+}
+
+func TestGetChannelPrefersNativeIndex(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.breaker = NewCircuitBreaker(orchestrator.timing())
+
+	// Frequency 2412 reverse-maps to channel 1, but the radio reports channel 6
+	orchestrator.SetActiveAdapter(&nativeIndexAdapter{index: 6})
+
+	channel, err := orchestrator.GetChannel(context.Background(), "radio-01")
+	if err != nil {
+		t.Fatalf("GetChannel failed: %v", err)
+	}
+	if channel.ChannelIndex == nil || *channel.ChannelIndex != 6 {
+		t.Errorf("Expected native channel index 6, got %v", channel.ChannelIndex)
+	}
+	if channel.FrequencyMhz != 2412 {
+		t.Errorf("Expected frequency 2412, got %v", channel.FrequencyMhz)
+	}
+}
+
+func TestGetChannelFallsBackToReverseLookup(t *testing.T) {
+	stateAt := func(freq float64) *MockAdapter {
+		return &MockAdapter{GetStateFunc: func(ctx context.Context) (*adapter.RadioState, error) {
+			return &adapter.RadioState{FrequencyMhz: freq}, nil
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		adapter adapter.IRadioAdapter
+		want    *int
+	}{
+		{"no native support", stateAt(2437), intPtr(6)},
+		{"native lookup fails", &nativeIndexAdapter{MockAdapter: *stateAt(2462), err: errors.New("not supported")}, intPtr(11)},
+		{"frequency outside channel set", stateAt(2450), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orchestrator := setupTestOrchestrator(t)
+			orchestrator.breaker = NewCircuitBreaker(orchestrator.timing())
+			orchestrator.SetActiveAdapter(tt.adapter)
+
+			channel, err := orchestrator.GetChannel(context.Background(), "radio-01")
+			if err != nil {
+				t.Fatalf("GetChannel failed: %v", err)
+			}
+			switch {
+			case tt.want == nil && channel.ChannelIndex != nil:
+				t.Errorf("Expected nil channel index, got %d", *channel.ChannelIndex)
+			case tt.want != nil && (channel.ChannelIndex == nil || *channel.ChannelIndex != *tt.want):
+				t.Errorf("Expected channel index %d, got %v", *tt.want, channel.ChannelIndex)
+			}
+		})
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -0,0 +1,98 @@
+package command
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// StepDirection selects the adjacent channel for StepChannel.
+type StepDirection string
+
+// Channel step directions.
+const (
+	StepNext StepDirection = "next"
+	StepPrev StepDirection = "prev"
+)
+
+// StepChannel tunes the radio to the channel after (StepNext) or before
+// (StepPrev) its current one in the radio's channel map, and returns the
+// channel tuned to. Past either end of the map ChannelStepPolicy wraps to
+// the other end or clamps at the end. A radio whose frequency is not on a
+// known channel fails with ErrInvalidRange. The change itself is a
+// setChannel command, audited and limited as SetChannelByIndex.
+func (o *Orchestrator) StepChannel(ctx context.Context, radioID string, direction StepDirection) (adapter.Channel, error) {
+	start := time.Now()
+
+	// Enforce the per-role command allowlist before reading the radio
+	if err := o.authorize(ctx, "setChannel", radioID, start); err != nil {
+		return adapter.Channel{}, err
+	}
+
+	if direction != StepNext && direction != StepPrev {
+		o.logAudit(ctx, "setChannel", radioID, "INVALID_RANGE", time.Since(start))
+		return adapter.Channel{}, adapter.ErrInvalidRange
+	}
+
+	if o.radioManager == nil {
+		o.logAudit(ctx, "setChannel", radioID, "UNAVAILABLE", time.Since(start))
+		return adapter.Channel{}, adapter.ErrUnavailable
+	}
+	r, err := o.radioManager.GetRadio(radioID)
+	if err != nil {
+		o.logAudit(ctx, "setChannel", radioID, "NOT_FOUND", time.Since(start))
+		return adapter.Channel{}, ErrNotFound
+	}
+	var channels []adapter.Channel
+	if r.Capabilities != nil {
+		channels = append(channels, r.Capabilities.Channels...)
+	}
+	if len(channels) == 0 {
+		o.logAudit(ctx, "setChannel", radioID, "NO_CHANNELS", time.Since(start))
+		return adapter.Channel{}, ErrNoChannels
+	}
+	sort.Slice(channels, func(i, j int) bool { return channels[i].Index < channels[j].Index })
+
+	current, err := o.GetChannel(ctx, radioID)
+	if err != nil {
+		return adapter.Channel{}, err
+	}
+	position := -1
+	if current.ChannelIndex != nil {
+		for i, ch := range channels {
+			if ch.Index == *current.ChannelIndex {
+				position = i
+				break
+			}
+		}
+	}
+	if position < 0 {
+		o.logAudit(ctx, "setChannel", radioID, "INVALID_RANGE", time.Since(start))
+		return adapter.Channel{}, adapter.ErrInvalidRange
+	}
+
+	target := channels[stepPosition(position, len(channels), direction, o.timing().ChannelStepPolicy)]
+	if _, err := o.setChannelByIndex(ctx, radioID, target.Index, o.radioManager, false); err != nil {
+		return adapter.Channel{}, err
+	}
+	return target, nil
+}
+
+// stepPosition returns the position after stepping from position in a
+// channel list of length n, wrapping or clamping at the ends.
+func stepPosition(position, n int, direction StepDirection, policy string) int {
+	next := position + 1
+	if direction == StepPrev {
+		next = position - 1
+	}
+	if next >= 0 && next < n {
+		return next
+	}
+	if policy == config.ChannelStepClamp {
+		return position
+	}
+	return (next + n) % n
+}
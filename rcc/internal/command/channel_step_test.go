@@ -0,0 +1,85 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// tunedAdapter reports the last frequency it was set to.
+func tunedAdapter(frequencyMhz *float64) *MockAdapter {
+	return &MockAdapter{
+		SetFrequencyFunc: func(ctx context.Context, f float64) error {
+			*frequencyMhz = f
+			return nil
+		},
+		GetStateFunc: func(ctx context.Context) (*adapter.RadioState, error) {
+			return &adapter.RadioState{PowerDbm: 20, FrequencyMhz: *frequencyMhz}, nil
+		},
+	}
+}
+
+func TestStepChannel(t *testing.T) {
+	tests := []struct {
+		policy string
+		want   []int
+	}{
+		{config.ChannelStepWrap, []int{6, 11, 1}},
+		{config.ChannelStepClamp, []int{6, 11, 11}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			orchestrator := setupTestOrchestrator(t)
+			orchestrator.timing().ChannelStepPolicy = tt.policy
+			frequencyMhz := 2412.0
+			orchestrator.SetActiveAdapter(tunedAdapter(&frequencyMhz))
+
+			// Step forward from channel 1 past the last channel
+			for i, want := range tt.want {
+				ch, err := orchestrator.StepChannel(context.Background(), "radio-01", StepNext)
+				if err != nil {
+					t.Fatalf("Step %d failed: %v", i+1, err)
+				}
+				if ch.Index != want || frequencyMhz != ch.FrequencyMhz {
+					t.Errorf("Step %d: expected channel %d, got %+v at %v MHz", i+1, want, ch, frequencyMhz)
+				}
+			}
+		})
+	}
+}
+
+func TestStepChannelPrevious(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	frequencyMhz := 2412.0
+	orchestrator.SetActiveAdapter(tunedAdapter(&frequencyMhz))
+
+	// Wrapping is the default
+	ch, err := orchestrator.StepChannel(context.Background(), "radio-01", StepPrev)
+	if err != nil || ch.Index != 11 {
+		t.Errorf("Expected prev from channel 1 to wrap to 11, got %+v (%v)", ch, err)
+	}
+	ch, err = orchestrator.StepChannel(context.Background(), "radio-01", StepPrev)
+	if err != nil || ch.Index != 6 {
+		t.Errorf("Expected prev from channel 11 to reach 6, got %+v (%v)", ch, err)
+	}
+}
+
+func TestStepChannelOffChannel(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	frequencyMhz := 2420.0
+	orchestrator.SetActiveAdapter(tunedAdapter(&frequencyMhz))
+
+	if _, err := orchestrator.StepChannel(context.Background(), "radio-01", StepNext); !errors.Is(err, adapter.ErrInvalidRange) {
+		t.Errorf("Expected ErrInvalidRange off a known channel, got %v", err)
+	}
+	if frequencyMhz != 2420.0 {
+		t.Errorf("Expected the radio to stay at 2420 MHz, got %v", frequencyMhz)
+	}
+	if _, err := orchestrator.StepChannel(context.Background(), "radio-01", "up"); !errors.Is(err, adapter.ErrInvalidRange) {
+		t.Errorf("Expected ErrInvalidRange for an unknown direction, got %v", err)
+	}
+}
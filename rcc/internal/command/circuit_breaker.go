@@ -0,0 +1,199 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// Circuit breaker states.
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half-open"
+)
+
+// CircuitBreaker tracks consecutive adapter failures per radio and fails
+// commands fast while a radio's adapter is down.
+//
+// A breaker opens after FailureThreshold consecutive failures, rejects
+// commands for the cooldown window, then half-opens and lets a single probe
+// command through. A successful probe closes the breaker; a failed probe
+// re-opens it for another cooldown window.
+type CircuitBreaker struct {
+	mu               sync.Mutex
+	failureThreshold int
+	cooldown         time.Duration
+	radios           map[string]*breakerState
+
+	// now is overridable for tests
+	now func() time.Time
+}
+
+// breakerState holds the breaker state for a single radio.
+type breakerState struct {
+	state         string
+	failures      int
+	openedAt      time.Time
+	probeInFlight bool
+}
+
+// NewCircuitBreaker creates a circuit breaker using thresholds from the timing config.
+// A nil config or a threshold of 0 yields a disabled breaker that always allows commands.
+func NewCircuitBreaker(timingConfig *config.TimingConfig) *CircuitBreaker {
+	cb := &CircuitBreaker{
+		radios: make(map[string]*breakerState),
+		now:    time.Now,
+	}
+	if timingConfig != nil {
+		cb.failureThreshold = timingConfig.BreakerFailureThreshold
+		cb.cooldown = timingConfig.BreakerCooldown
+	}
+	return cb
+}
+
+// enabled reports whether the breaker is active.
+func (cb *CircuitBreaker) enabled() bool {
+	return cb != nil && cb.failureThreshold > 0
+}
+
+// Allow reports whether a command may be sent to the radio's adapter.
+// It returns adapter.ErrUnavailable while the breaker is open.
+func (cb *CircuitBreaker) Allow(radioID string) error {
+	if !cb.enabled() {
+		return nil
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	rs, exists := cb.radios[radioID]
+	if !exists {
+		return nil
+	}
+
+	switch rs.state {
+	case BreakerOpen:
+		if cb.now().Sub(rs.openedAt) < cb.cooldown {
+			return adapter.ErrUnavailable
+		}
+		// Cooldown elapsed: half-open and let this command probe the adapter
+		rs.state = BreakerHalfOpen
+		rs.probeInFlight = true
+		return nil
+	case BreakerHalfOpen:
+		// Only one probe at a time while half-open
+		if rs.probeInFlight {
+			return adapter.ErrUnavailable
+		}
+		rs.probeInFlight = true
+		return nil
+	}
+
+	return nil
+}
+
+// Record records the outcome of an adapter call for the radio.
+// Only unavailability and timeouts count as failures; other errors
+// (e.g. INVALID_RANGE, BUSY) show the adapter is responsive.
+func (cb *CircuitBreaker) Record(radioID string, err error) {
+	if !cb.enabled() {
+		return
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	rs, exists := cb.radios[radioID]
+	if !exists {
+		rs = &breakerState{state: BreakerClosed}
+		cb.radios[radioID] = rs
+	}
+	rs.probeInFlight = false
+
+	if !isBreakerFailure(err) {
+		rs.state = BreakerClosed
+		rs.failures = 0
+		return
+	}
+
+	rs.failures++
+	if rs.state == BreakerHalfOpen || rs.failures >= cb.failureThreshold {
+		rs.state = BreakerOpen
+		rs.openedAt = cb.now()
+	}
+}
+
+// Release ends a command without recording an outcome, freeing the half-open
+// probe slot so the next command can probe the adapter.
+func (cb *CircuitBreaker) Release(radioID string) {
+	if !cb.enabled() {
+		return
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if rs, exists := cb.radios[radioID]; exists {
+		rs.probeInFlight = false
+	}
+}
+
+// State returns the breaker state for the radio.
+func (cb *CircuitBreaker) State(radioID string) string {
+	if !cb.enabled() {
+		return BreakerClosed
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	rs, exists := cb.radios[radioID]
+	if !exists {
+		return BreakerClosed
+	}
+	if rs.state == BreakerOpen && cb.now().Sub(rs.openedAt) >= cb.cooldown {
+		return BreakerHalfOpen
+	}
+	return rs.state
+}
+
+// States returns a snapshot of breaker states for all tracked radios.
+func (cb *CircuitBreaker) States() map[string]string {
+	states := make(map[string]string)
+	if !cb.enabled() {
+		return states
+	}
+
+	cb.mu.Lock()
+	radioIDs := make([]string, 0, len(cb.radios))
+	for radioID := range cb.radios {
+		radioIDs = append(radioIDs, radioID)
+	}
+	cb.mu.Unlock()
+
+	for _, radioID := range radioIDs {
+		states[radioID] = cb.State(radioID)
+	}
+	return states
+}
+
+// isBreakerFailure reports whether an adapter error indicates the adapter is down.
+func isBreakerFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, adapter.ErrUnavailable) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	// VendorError unwraps to its normalized code; check the vendor error for timeouts
+	var vendorErr *adapter.VendorError
+	if errors.As(err, &vendorErr) {
+		return errors.Is(vendorErr.Original, context.DeadlineExceeded)
+	}
+	return false
+}
@@ -0,0 +1,225 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// setupBreakerOrchestrator creates an orchestrator with a controllable adapter and breaker clock.
+func setupBreakerOrchestrator(t *testing.T, threshold int, cooldown time.Duration) (*Orchestrator, *MockAdapter, *time.Time) {
+	cfg := config.LoadCBTimingBaseline()
+	cfg.BreakerFailureThreshold = threshold
+	cfg.BreakerCooldown = cooldown
+
+	orch := setupTestOrchestrator(t)
+	orch.SetConfig(cfg)
+	orch.breaker = NewCircuitBreaker(cfg)
+
+	clock := time.Unix(1700000000, 0)
+	orch.breaker.now = func() time.Time { return clock }
+
+	mockAdapter := &MockAdapter{}
+	orch.SetActiveAdapter(mockAdapter)
+
+	return orch, mockAdapter, &clock
+}
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	orch, mockAdapter, clock := setupBreakerOrchestrator(t, 3, 10*time.Second)
+	ctx := context.Background()
+
+	adapterCalls := 0
+	adapterDown := true
+	mockAdapter.SetPowerFunc = func(ctx context.Context, dBm float64) error {
+		adapterCalls++
+		if adapterDown {
+			return adapter.ErrUnavailable
+		}
+		return nil
+	}
+
+	// Closed: failures are passed through until the threshold is reached
+	for i := 0; i < 3; i++ {
+		if state := orch.breaker.State("radio-01"); state != BreakerClosed {
+			t.Fatalf("Expected breaker closed before failure %d, got %s", i+1, state)
+		}
+		if err := orch.SetPower(ctx, "radio-01", 20); !errors.Is(err, adapter.ErrUnavailable) {
+			t.Fatalf("Expected ErrUnavailable from adapter, got %v", err)
+		}
+	}
+	if adapterCalls != 3 {
+		t.Fatalf("Expected 3 adapter calls, got %d", adapterCalls)
+	}
+
+	// Open: commands short-circuit without reaching the adapter
+	if state := orch.breaker.State("radio-01"); state != BreakerOpen {
+		t.Fatalf("Expected breaker open, got %s", state)
+	}
+	if err := orch.SetPower(ctx, "radio-01", 20); !errors.Is(err, adapter.ErrUnavailable) {
+		t.Fatalf("Expected ErrUnavailable while open, got %v", err)
+	}
+	if adapterCalls != 3 {
+		t.Fatalf("Expected adapter not to be called while open, got %d calls", adapterCalls)
+	}
+
+	// Half-open: after cooldown a failed probe re-opens the breaker
+	*clock = clock.Add(10 * time.Second)
+	if state := orch.breaker.State("radio-01"); state != BreakerHalfOpen {
+		t.Fatalf("Expected breaker half-open after cooldown, got %s", state)
+	}
+	if err := orch.SetPower(ctx, "radio-01", 20); !errors.Is(err, adapter.ErrUnavailable) {
+		t.Fatalf("Expected ErrUnavailable from failed probe, got %v", err)
+	}
+	if adapterCalls != 4 {
+		t.Fatalf("Expected probe to reach adapter, got %d calls", adapterCalls)
+	}
+	if state := orch.breaker.State("radio-01"); state != BreakerOpen {
+		t.Fatalf("Expected breaker re-opened after failed probe, got %s", state)
+	}
+
+	// Half-open: a successful probe closes the breaker
+	*clock = clock.Add(10 * time.Second)
+	adapterDown = false
+	if err := orch.SetPower(ctx, "radio-01", 20); err != nil {
+		t.Fatalf("Expected successful probe, got %v", err)
+	}
+	if state := orch.breaker.State("radio-01"); state != BreakerClosed {
+		t.Fatalf("Expected breaker closed after successful probe, got %s", state)
+	}
+	if err := orch.SetPower(ctx, "radio-01", 20); err != nil {
+		t.Fatalf("Expected command to succeed once closed, got %v", err)
+	}
+	if adapterCalls != 6 {
+		t.Fatalf("Expected 6 adapter calls, got %d", adapterCalls)
+	}
+}
+
+func TestCircuitBreakerPerRadio(t *testing.T) {
+	cb := NewCircuitBreaker(&config.TimingConfig{BreakerFailureThreshold: 1, BreakerCooldown: time.Minute})
+
+	cb.Record("radio-01", adapter.ErrUnavailable)
+
+	if err := cb.Allow("radio-01"); !errors.Is(err, adapter.ErrUnavailable) {
+		t.Errorf("Expected radio-01 to be short-circuited, got %v", err)
+	}
+	if err := cb.Allow("radio-02"); err != nil {
+		t.Errorf("Expected radio-02 to be allowed, got %v", err)
+	}
+
+	states := cb.States()
+	if states["radio-01"] != BreakerOpen {
+		t.Errorf("Expected radio-01 open in snapshot, got %q", states["radio-01"])
+	}
+	if _, exists := states["radio-02"]; exists {
+		t.Errorf("Expected untracked radio-02 to be absent from snapshot")
+	}
+}
+
+func TestCircuitBreakerIgnoresNonAvailabilityErrors(t *testing.T) {
+	cb := NewCircuitBreaker(&config.TimingConfig{BreakerFailureThreshold: 2, BreakerCooldown: time.Minute})
+
+	cb.Record("radio-01", adapter.ErrUnavailable)
+	cb.Record("radio-01", adapter.ErrInvalidRange)
+	cb.Record("radio-01", adapter.ErrUnavailable)
+
+	if state := cb.State("radio-01"); state != BreakerClosed {
+		t.Errorf("Expected INVALID_RANGE to reset failure count, got %s", state)
+	}
+
+	// Timeouts wrapped by NormalizeVendorError count as failures
+	cb.Record("radio-01", adapter.NormalizeVendorError(context.DeadlineExceeded, nil))
+	if state := cb.State("radio-01"); state != BreakerOpen {
+		t.Errorf("Expected timeout to open breaker, got %s", state)
+	}
+}
+
+func TestCircuitBreakerHalfOpenSingleProbe(t *testing.T) {
+	cb := NewCircuitBreaker(&config.TimingConfig{BreakerFailureThreshold: 1, BreakerCooldown: time.Second})
+	clock := time.Unix(1700000000, 0)
+	cb.now = func() time.Time { return clock }
+
+	cb.Record("radio-01", adapter.ErrUnavailable)
+	clock = clock.Add(time.Second)
+
+	if err := cb.Allow("radio-01"); err != nil {
+		t.Fatalf("Expected first probe to be allowed, got %v", err)
+	}
+	if err := cb.Allow("radio-01"); !errors.Is(err, adapter.ErrUnavailable) {
+		t.Errorf("Expected concurrent probe to be rejected, got %v", err)
+	}
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	var nilBreaker *CircuitBreaker
+	if err := nilBreaker.Allow("radio-01"); err != nil {
+		t.Errorf("Expected nil breaker to allow, got %v", err)
+	}
+	nilBreaker.Record("radio-01", adapter.ErrUnavailable)
+
+	cb := NewCircuitBreaker(&config.TimingConfig{BreakerFailureThreshold: 0})
+	for i := 0; i < 10; i++ {
+		cb.Record("radio-01", adapter.ErrUnavailable)
+	}
+	if err := cb.Allow("radio-01"); err != nil {
+		t.Errorf("Expected disabled breaker to allow, got %v", err)
+	}
+	if len(cb.States()) != 0 {
+		t.Errorf("Expected no tracked states for disabled breaker")
+	}
+}
+
+// unmeasurableAdapter is a MockAdapter for a radio that cannot measure its
+// output power.
+type unmeasurableAdapter struct {
+	MockAdapter
+}
+
+func (a *unmeasurableAdapter) ReadPowerActual(ctx context.Context) (float64, error) {
+	return 0, errors.New("power measurement not supported")
+}
+
+func TestGetActualPowerFreesHalfOpenProbe(t *testing.T) {
+	tests := []struct {
+		name    string
+		adapter func(*MockAdapter) adapter.IRadioAdapter
+		wantErr bool
+	}{
+		{"measured", func(m *MockAdapter) adapter.IRadioAdapter { return m }, false},
+		{"not measurable", func(m *MockAdapter) adapter.IRadioAdapter { return &unmeasurableAdapter{MockAdapter: *m} }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orch, mockAdapter, clock := setupBreakerOrchestrator(t, 1, 10*time.Second)
+			ctx := context.Background()
+
+			adapterDown := true
+			mockAdapter.SetPowerFunc = func(ctx context.Context, dBm float64) error {
+				if adapterDown {
+					return adapter.ErrUnavailable
+				}
+				return nil
+			}
+			orch.SetActiveAdapter(tt.adapter(mockAdapter))
+
+			// Trip the breaker, then wait out the cooldown
+			if err := orch.SetPower(ctx, "radio-01", 20); !errors.Is(err, adapter.ErrUnavailable) {
+				t.Fatalf("Expected ErrUnavailable from adapter, got %v", err)
+			}
+			*clock = clock.Add(10 * time.Second)
+			adapterDown = false
+
+			// The read is the half-open probe
+			if _, err := orch.GetActualPower(ctx, "radio-01"); (err != nil) != tt.wantErr {
+				t.Fatalf("GetActualPower error = %v, want error %v", err, tt.wantErr)
+			}
+			if err := orch.SetPower(ctx, "radio-01", 20); err != nil {
+				t.Fatalf("Expected SetPower to succeed after the probe, got %v", err)
+			}
+		})
+	}
+}
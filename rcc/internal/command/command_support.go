@@ -0,0 +1,40 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/radio-control/rcc/internal/radio"
+)
+
+// ErrNotSupported indicates a command the radio does not accept, per the
+// feature flags in its capabilities, e.g. a power change on a radio with
+// fixed transmit power.
+var ErrNotSupported = errors.New("NOT_SUPPORTED")
+
+// checkSupported audits NOT_SUPPORTED and returns ErrNotSupported when r's
+// capabilities say it does not accept action, so the adapter is never
+// called for it.
+func (o *Orchestrator) checkSupported(ctx context.Context, action string, r *radio.Radio, start time.Time) error {
+	if err := unsupported(action, r); err != nil {
+		o.logAudit(ctx, action, r.ID, "NOT_SUPPORTED", time.Since(start))
+		return err
+	}
+	return nil
+}
+
+// unsupported returns ErrNotSupported when r does not accept action.
+func unsupported(action string, r *radio.Radio) error {
+	supported := true
+	switch action {
+	case "setPower":
+		supported = r.Capabilities.CanSetPower()
+	case "setChannel":
+		supported = r.Capabilities.CanSetChannel()
+	}
+	if !supported {
+		return ErrNotSupported
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/radio"
+)
+
+func TestModelCommandTimeoutOverride(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.SetActiveAdapter(&MockAdapter{
+		// The radio takes 100ms to apply power
+		SetPowerFunc: func(ctx context.Context, dBm float64) error {
+			select {
+			case <-time.After(100 * time.Millisecond):
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+
+	radios := orchestrator.radioManager.(*MockRadioManager).Radios
+	radios["radio-01"].Model = "Slow-Radio"
+	radios["radio-02"] = &radio.Radio{ID: "radio-02", Model: "Silvus-Scout"}
+	cfg := orchestrator.timing()
+	cfg.CommandTimeoutSetPower = 30 * time.Millisecond
+	cfg.CommandTimeoutOverrides = map[string]config.CommandTimeoutOverride{
+		"slow-radio": {SetPower: time.Second},
+	}
+
+	ctx := context.Background()
+	if err := orchestrator.SetPower(ctx, "radio-01", 20); err != nil {
+		t.Errorf("Expected the overridden model to outlast the delay, got %v", err)
+	}
+	if err := orchestrator.SetPower(ctx, "radio-02", 20); err == nil {
+		t.Error("Expected the default timeout to expire before the radio applied power")
+	}
+}
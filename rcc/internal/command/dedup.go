@@ -0,0 +1,119 @@
+package command
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// appliedCommands records the last power and frequency successfully applied
+// to each radio, for CommandDedupWindow.
+type appliedCommands struct {
+	mu      sync.Mutex
+	entries map[string]appliedCommand
+}
+
+// appliedCommand is what was last applied to a radio and when.
+type appliedCommand struct {
+	powerDbm     float64
+	powerAt      time.Time
+	frequencyMhz float64
+	frequencyAt  time.Time
+}
+
+// recordPower notes dBm as applied to radioID at now.
+func (a *appliedCommands) recordPower(radioID string, dBm float64, now time.Time) {
+	a.update(radioID, func(entry *appliedCommand) {
+		entry.powerDbm, entry.powerAt = dBm, now
+	})
+}
+
+// recordFrequency notes frequencyMhz as applied to radioID at now.
+func (a *appliedCommands) recordFrequency(radioID string, frequencyMhz float64, now time.Time) {
+	a.update(radioID, func(entry *appliedCommand) {
+		entry.frequencyMhz, entry.frequencyAt = frequencyMhz, now
+	})
+}
+
+// forget drops what was applied to radioID, so its next command actuates.
+// Called when a command fails and the radio's setting is no longer certain.
+func (a *appliedCommands) forget(radioID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.entries, radioID)
+}
+
+func (a *appliedCommands) update(radioID string, apply func(*appliedCommand)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.entries == nil {
+		a.entries = make(map[string]appliedCommand)
+	}
+	entry := a.entries[radioID]
+	apply(&entry)
+	a.entries[radioID] = entry
+}
+
+// recent returns what was last applied to radioID.
+func (a *appliedCommands) recent(radioID string) (appliedCommand, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	entry, ok := a.entries[radioID]
+	return entry, ok
+}
+
+// skipDuplicatePower reports whether SetPower to dBm can be answered without
+// the adapter because dBm was applied to the radio within
+// CommandDedupWindow. A skipped command is audited as DEDUPLICATED.
+func (o *Orchestrator) skipDuplicatePower(ctx context.Context, radioID string, dBm float64, start time.Time) bool {
+	if !o.duplicatePower(radioID, dBm, start) {
+		return false
+	}
+	o.markDuplicate(ctx, "setPower", radioID, start)
+	return true
+}
+
+// skipDuplicateFrequency is skipDuplicatePower for SetChannel.
+func (o *Orchestrator) skipDuplicateFrequency(ctx context.Context, radioID string, frequencyMhz float64, start time.Time) bool {
+	if !o.duplicateFrequency(radioID, frequencyMhz, start) {
+		return false
+	}
+	o.markDuplicate(ctx, "setChannel", radioID, start)
+	return true
+}
+
+// duplicatePower reports whether dBm was applied to radioID within
+// CommandDedupWindow of now.
+func (o *Orchestrator) duplicatePower(radioID string, dBm float64, now time.Time) bool {
+	if !o.dedupEligible(radioID) {
+		return false
+	}
+	last, ok := o.applied.recent(radioID)
+	return ok && !last.powerAt.IsZero() && last.powerDbm == dBm && o.withinDedupWindow(last.powerAt, now)
+}
+
+// duplicateFrequency is duplicatePower for frequencies.
+func (o *Orchestrator) duplicateFrequency(radioID string, frequencyMhz float64, now time.Time) bool {
+	if !o.dedupEligible(radioID) {
+		return false
+	}
+	last, ok := o.applied.recent(radioID)
+	return ok && !last.frequencyAt.IsZero() && last.frequencyMhz == frequencyMhz && o.withinDedupWindow(last.frequencyAt, now)
+}
+
+// dedupEligible reports whether deduplication applies to radioID. As with
+// noop detection, a radio whose breaker is not closed is always commanded.
+func (o *Orchestrator) dedupEligible(radioID string) bool {
+	return o.timing().CommandDedupWindow > 0 && o.breaker.State(radioID) == BreakerClosed
+}
+
+func (o *Orchestrator) withinDedupWindow(appliedAt, now time.Time) bool {
+	return now.Sub(appliedAt) < o.timing().CommandDedupWindow
+}
+
+func (o *Orchestrator) markDuplicate(ctx context.Context, action, radioID string, start time.Time) {
+	if report, ok := ctx.Value(commandReportKey{}).(*CommandReport); ok {
+		report.NoChange = true
+	}
+	o.logAudit(ctx, action, radioID, "DEDUPLICATED", time.Since(start))
+}
@@ -0,0 +1,115 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCommandDedupWindow(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.timing().CommandDedupWindow = time.Minute
+	auditLogger := &MockAuditLogger{}
+	orchestrator.SetAuditLogger(auditLogger)
+
+	var setPowerCalls atomic.Int32
+	orchestrator.SetActiveAdapter(&MockAdapter{
+		SetPowerFunc: func(ctx context.Context, dBm float64) error {
+			setPowerCalls.Add(1)
+			return nil
+		},
+	})
+
+	// Identical consecutive calls reach the adapter once
+	for i := 0; i < 3; i++ {
+		ctx, report := WithCommandReport(context.Background())
+		if err := orchestrator.SetPower(ctx, "radio-01", 20); err != nil {
+			t.Fatalf("SetPower %d failed: %v", i, err)
+		}
+		if report.NoChange != (i > 0) {
+			t.Errorf("SetPower %d: expected NoChange %v, got %v", i, i > 0, report.NoChange)
+		}
+	}
+	if got := setPowerCalls.Load(); got != 1 {
+		t.Errorf("Expected one adapter call within the window, got %d", got)
+	}
+	if last := auditLogger.Actions[len(auditLogger.Actions)-1]; last.Action != "setPower" || last.Result != "DEDUPLICATED" {
+		t.Errorf("Expected DEDUPLICATED audit entry, got %+v", last)
+	}
+
+	// A value change always actuates
+	ctx, report := WithCommandReport(context.Background())
+	if err := orchestrator.SetPower(ctx, "radio-01", 25); err != nil {
+		t.Fatalf("SetPower failed: %v", err)
+	}
+	if got := setPowerCalls.Load(); got != 2 || report.NoChange {
+		t.Errorf("Expected a new power to reach the adapter, got %d calls (noChange %v)", got, report.NoChange)
+	}
+
+	// An expired window actuates again
+	orchestrator.timing().CommandDedupWindow = time.Millisecond
+	time.Sleep(5 * time.Millisecond)
+	if err := orchestrator.SetPower(context.Background(), "radio-01", 25); err != nil {
+		t.Fatalf("SetPower failed: %v", err)
+	}
+	if got := setPowerCalls.Load(); got != 3 {
+		t.Errorf("Expected the same power to reach the adapter after the window, got %d calls", got)
+	}
+}
+
+func TestCommandDedupWindowForgetsAfterFailure(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.timing().CommandDedupWindow = time.Minute
+
+	var calls atomic.Int32
+	var fail atomic.Bool
+	orchestrator.SetActiveAdapter(&MockAdapter{
+		SetFrequencyFunc: func(ctx context.Context, frequencyMhz float64) error {
+			calls.Add(1)
+			if fail.Load() {
+				return errors.New("radio busy")
+			}
+			return nil
+		},
+	})
+
+	if err := orchestrator.SetChannel(context.Background(), "radio-01", 2412); err != nil {
+		t.Fatalf("SetChannel failed: %v", err)
+	}
+	fail.Store(true)
+	if err := orchestrator.SetChannel(context.Background(), "radio-01", 2437); err == nil {
+		t.Fatal("Expected SetChannel to fail")
+	}
+
+	// The radio may be on either frequency now, so 2412 is commanded again
+	fail.Store(false)
+	ctx, report := WithCommandReport(context.Background())
+	if err := orchestrator.SetChannel(ctx, "radio-01", 2412); err != nil {
+		t.Fatalf("SetChannel failed: %v", err)
+	}
+	if got := calls.Load(); got != 3 || report.NoChange {
+		t.Errorf("Expected SetChannel after a failure to reach the adapter, got %d calls (noChange %v)", got, report.NoChange)
+	}
+}
+
+func TestCommandDedupWindowOffByDefault(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+
+	var calls atomic.Int32
+	orchestrator.SetActiveAdapter(&MockAdapter{
+		SetPowerFunc: func(ctx context.Context, dBm float64) error {
+			calls.Add(1)
+			return nil
+		},
+	})
+	for i := 0; i < 2; i++ {
+		if err := orchestrator.SetPower(context.Background(), "radio-01", 20); err != nil {
+			t.Fatalf("SetPower failed: %v", err)
+		}
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("Expected every SetPower to reach the adapter by default, got %d calls", got)
+	}
+}
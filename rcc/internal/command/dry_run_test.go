@@ -0,0 +1,86 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// failingAdapterCalls returns a MockAdapter that fails the test if actuated.
+func failingAdapterCalls(t *testing.T) *MockAdapter {
+	return &MockAdapter{
+		SetPowerFunc: func(ctx context.Context, dBm float64) error {
+			t.Errorf("Dry run called adapter SetPower(%v)", dBm)
+			return nil
+		},
+		SetFrequencyFunc: func(ctx context.Context, frequencyMhz float64) error {
+			t.Errorf("Dry run called adapter SetFrequency(%v)", frequencyMhz)
+			return nil
+		},
+	}
+}
+
+func TestDryRunSkipsAdapterAndAudits(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.SetActiveAdapter(failingAdapterCalls(t))
+	auditLogger := &MockAuditLogger{}
+	orchestrator.SetAuditLogger(auditLogger)
+	ctx := context.Background()
+
+	if _, err := orchestrator.DryRunSetPower(ctx, "radio-01", 20); err != nil {
+		t.Errorf("DryRunSetPower failed: %v", err)
+	}
+	if err := orchestrator.DryRunSetChannel(ctx, "radio-01", 2437); err != nil {
+		t.Errorf("DryRunSetChannel failed: %v", err)
+	}
+	frequency, err := orchestrator.DryRunSetChannelByIndex(ctx, "radio-01", 6, orchestrator.radioManager)
+	if err != nil {
+		t.Errorf("DryRunSetChannelByIndex failed: %v", err)
+	}
+	if frequency != 2437.0 {
+		t.Errorf("Expected channel 6 to resolve to 2437 MHz, got %v", frequency)
+	}
+
+	if len(auditLogger.Actions) != 3 {
+		t.Fatalf("Expected 3 audit entries, got %d", len(auditLogger.Actions))
+	}
+	for _, action := range auditLogger.Actions {
+		if action.Result != "DRY_RUN" {
+			t.Errorf("Expected DRY_RUN audit result for %s, got %s", action.Action, action.Result)
+		}
+	}
+}
+
+func TestDryRunSurfacesValidationErrors(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.SetActiveAdapter(failingAdapterCalls(t))
+	ctx := context.Background()
+
+	if _, err := orchestrator.DryRunSetPower(ctx, "radio-01", 100); !errors.Is(err, adapter.ErrInvalidRange) {
+		t.Errorf("Expected ErrInvalidRange for out-of-range power, got %v", err)
+	}
+	if err := orchestrator.DryRunSetChannel(ctx, "radio-01", -5); !errors.Is(err, adapter.ErrInvalidRange) {
+		t.Errorf("Expected ErrInvalidRange for invalid frequency, got %v", err)
+	}
+	if _, err := orchestrator.DryRunSetChannelByIndex(ctx, "radio-01", 99, orchestrator.radioManager); !errors.Is(err, adapter.ErrInvalidRange) {
+		t.Errorf("Expected ErrInvalidRange for unknown channel index, got %v", err)
+	}
+	if _, err := orchestrator.DryRunSetPower(ctx, "radio-99", 20); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for unknown radio, got %v", err)
+	}
+}
+
+func TestDryRunIgnoresOpenBreaker(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.SetActiveAdapter(failingAdapterCalls(t))
+	orchestrator.breaker = NewCircuitBreaker(&config.TimingConfig{BreakerFailureThreshold: 1, BreakerCooldown: time.Minute})
+	orchestrator.breaker.Record("radio-01", adapter.ErrUnavailable)
+
+	if _, err := orchestrator.DryRunSetPower(context.Background(), "radio-01", 20); err != nil {
+		t.Errorf("Expected dry run to validate while breaker is open, got %v", err)
+	}
+}
@@ -0,0 +1,299 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/radio"
+)
+
+// Outcomes of an explained command.
+const (
+	PlanExecute  = "execute"  // The adapter would be called
+	PlanNoop     = "noop"     // Answered without the adapter; see SkipNoopCommands
+	PlanNoChange = "noChange" // Answered without the adapter; see CommandDedupWindow
+	PlanReject   = "reject"   // A check would fail; see CommandPlan.Rejection
+)
+
+// actionScopes are the API scopes each explainable action requires.
+var actionScopes = map[string]string{
+	"setPower":    auth.ScopePower,
+	"setChannel":  auth.ScopeChannel,
+	"selectRadio": auth.ScopeControl,
+}
+
+// ExplainParams are the parameters of an explained command: PowerDbm for
+// setPower, FrequencyMhz or ChannelIndex for setChannel, none for
+// selectRadio.
+type ExplainParams struct {
+	PowerDbm     *float64
+	FrequencyMhz *float64
+	ChannelIndex *int
+}
+
+// CommandPlan is how the orchestrator would carry out a command.
+type CommandPlan struct {
+	Action        string            `json:"action"`
+	RadioID       string            `json:"radioId"`
+	Model         string            `json:"model"`
+	Authorization PlanAuthorization `json:"authorization"`
+	Target        PlanTarget        `json:"target"`
+	Limits        []PlanLimit       `json:"limits"`
+	TimeoutMs     int64             `json:"timeoutMs"`
+	Outcome       string            `json:"outcome"`
+
+	// The error the command would fail with when Outcome is PlanReject
+	Rejection error `json:"-"`
+}
+
+// PlanAuthorization is whether the caller may run the command, and why.
+type PlanAuthorization struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason"`
+}
+
+// PlanTarget holds the values the adapter would be sent, after channel index
+// resolution and power clamping.
+type PlanTarget struct {
+	PowerDbm     *float64 `json:"powerDbm,omitempty"`
+	FrequencyMhz *float64 `json:"frequencyMhz,omitempty"`
+	ChannelIndex *int     `json:"channelIndex,omitempty"`
+}
+
+// PlanLimit is one check the command would run and whether it passed.
+type PlanLimit struct {
+	Name   string `json:"name"`
+	Detail string `json:"detail"`
+	Passed bool   `json:"passed"`
+}
+
+// ExplainCommand resolves what action ("setPower", "setChannel" or
+// "selectRadio") would do to radioID with params: the target values, each
+// limit checked, the timeout and the authorization decision. Nothing is
+// sent to the adapter and nothing is audited. The checks are the command's
+// own; those that need the radio use what the service already knows: the
+// channel plan and cached frequency profiles, and the cached state for band
+// power limits. The plan stops at the first failing check, as the command
+// would.
+//
+// Errors are returned only when no plan can be made: ErrInvalidParameter
+// for an unknown action or missing parameter, ErrNotFound for an unknown
+// radio.
+func (o *Orchestrator) ExplainCommand(ctx context.Context, action, radioID string, params ExplainParams) (*CommandPlan, error) {
+	switch action {
+	case "setPower":
+		if params.PowerDbm == nil {
+			return nil, fmt.Errorf("%w: setPower needs powerDbm", ErrInvalidParameter)
+		}
+	case "setChannel":
+		if (params.FrequencyMhz == nil) == (params.ChannelIndex == nil) {
+			return nil, fmt.Errorf("%w: setChannel needs one of frequencyMhz or channelIndex", ErrInvalidParameter)
+		}
+	case "selectRadio":
+	default:
+		return nil, fmt.Errorf("%w: unknown action %q", ErrInvalidParameter, action)
+	}
+	if radioID == "" {
+		return nil, fmt.Errorf("%w: radioId is required", ErrInvalidParameter)
+	}
+
+	if o.radioManager == nil {
+		return nil, adapter.ErrUnavailable
+	}
+	r, err := o.radioManager.GetRadio(radioID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	plan := &CommandPlan{
+		Action:    action,
+		RadioID:   radioID,
+		Model:     r.Model,
+		Limits:    []PlanLimit{},
+		TimeoutMs: o.timing().CommandTimeout(r.Model, action).Milliseconds(),
+		Outcome:   PlanExecute,
+	}
+	if err := o.explainAuthorization(ctx, plan, r); err == nil && o.explainSupport(plan, r) == nil {
+		switch action {
+		case "setPower":
+			o.explainSetPower(ctx, plan, r, *params.PowerDbm)
+		case "setChannel":
+			o.explainSetChannel(ctx, plan, params)
+		case "selectRadio":
+			o.explainAvailability(plan)
+		}
+	}
+	return plan, nil
+}
+
+// reject marks the plan as failing with err.
+func (p *CommandPlan) reject(err error) error {
+	p.Outcome = PlanReject
+	p.Rejection = err
+	return err
+}
+
+// check records a limit and, when it fails, rejects the plan with err.
+func (p *CommandPlan) check(name, detail string, err error) error {
+	p.Limits = append(p.Limits, PlanLimit{Name: name, Detail: detail, Passed: err == nil})
+	if err != nil {
+		return p.reject(err)
+	}
+	return nil
+}
+
+// explainAuthorization fills in the authorization decision: the API scope
+// the action needs, the RoleActions allowlist and AllowedModels.
+func (o *Orchestrator) explainAuthorization(ctx context.Context, plan *CommandPlan, r *radio.Radio) error {
+	claims := auth.GetClaimsFromContext(ctx)
+	scope := actionScopes[plan.Action]
+	switch role, allowed := o.allowingRole(ctx, plan.Action); {
+	case claims != nil && !claims.HasScope(scope):
+		plan.Authorization.Reason = fmt.Sprintf("token lacks the %s scope", scope)
+	case !allowed:
+		plan.Authorization.Reason = fmt.Sprintf("no role of the caller allows %s", plan.Action)
+		return plan.reject(ErrForbidden)
+	case !o.modelAllowed(r.Model):
+		plan.Authorization.Reason = fmt.Sprintf("model %s is not in AllowedModels", r.Model)
+		return plan.reject(ErrModelForbidden)
+	case role != "":
+		plan.Authorization.Allowed = true
+		plan.Authorization.Reason = fmt.Sprintf("role %s allows %s", role, plan.Action)
+		return nil
+	case claims == nil:
+		plan.Authorization.Allowed = true
+		plan.Authorization.Reason = "request is not authenticated; no scope or role checks apply"
+		return nil
+	default:
+		plan.Authorization.Allowed = true
+		plan.Authorization.Reason = fmt.Sprintf("token has the %s scope; no role allowlist is configured", scope)
+		return nil
+	}
+	return plan.reject(ErrForbidden)
+}
+
+// explainSupport records whether the radio's capabilities accept the
+// action. selectRadio is always accepted and not recorded.
+func (o *Orchestrator) explainSupport(plan *CommandPlan, r *radio.Radio) error {
+	if plan.Action == "selectRadio" {
+		return nil
+	}
+	return plan.check("supported", "radio capabilities accept "+plan.Action, unsupported(plan.Action, r))
+}
+
+// explainSetPower follows setPower's checks for dBm.
+func (o *Orchestrator) explainSetPower(ctx context.Context, plan *CommandPlan, r *radio.Radio, dBm float64) {
+	applied, detail, err := o.applyPowerPolicy(dBm)
+	if plan.check("powerRange", detail, err) != nil {
+		return
+	}
+
+	limited, detail, err := o.applyBandPowerLimit(ctx, r, nil, applied)
+	if detail != "" && plan.check("bandPowerLimit", detail, err) != nil {
+		return
+	}
+	plan.Target.PowerDbm = &limited
+
+	switch {
+	case o.noopPower(r.ID, limited):
+		plan.Outcome = PlanNoop
+	case o.duplicatePower(r.ID, limited, time.Now()):
+		plan.Outcome = PlanNoChange
+	default:
+		o.explainAvailability(plan)
+	}
+}
+
+// explainSetChannel follows setChannel's or setChannelByIndex's checks.
+func (o *Orchestrator) explainSetChannel(ctx context.Context, plan *CommandPlan, params ExplainParams) {
+	radioID := plan.RadioID
+	var frequencyMhz float64
+	if params.ChannelIndex != nil {
+		index := *params.ChannelIndex
+		var err error
+		if index < 1 {
+			err = adapter.ErrInvalidRange
+		} else {
+			frequencyMhz, err = o.resolveChannelIndex(ctx, radioID, index, nil)
+		}
+		detail := fmt.Sprintf("channel %d", index)
+		if err == nil {
+			detail += fmt.Sprintf(" is %v MHz", frequencyMhz)
+		}
+		if plan.check("channelIndex", detail, err) != nil {
+			return
+		}
+		plan.Target.ChannelIndex = &index
+	} else {
+		frequencyMhz = *params.FrequencyMhz
+		plan.Target.ChannelIndex = o.channelIndexFor(radioID, frequencyMhz)
+	}
+	plan.Target.FrequencyMhz = &frequencyMhz
+
+	detail, err := o.validateFrequencyRange(frequencyMhz)
+	if plan.check("frequencyRange", detail, err) != nil {
+		return
+	}
+
+	// A resolved channel index is in the channel plan by definition, so
+	// setChannelByIndex checks only the frequency range
+	if params.ChannelIndex == nil {
+		profiles, _ := o.cachedFrequencyProfiles(radioID)
+		detail, err := o.validateFrequencySupported(radioID, frequencyMhz, profiles)
+		if detail != "" && plan.check("supportedFrequency", detail, err) != nil {
+			return
+		}
+	}
+
+	switch {
+	case o.noopFrequency(radioID, frequencyMhz):
+		plan.Outcome = PlanNoop
+		return
+	case o.duplicateFrequency(radioID, frequencyMhz, time.Now()):
+		plan.Outcome = PlanNoChange
+		return
+	}
+	if o.explainAvailability(plan) != nil {
+		return
+	}
+
+	if limit := o.timing().MaxFrequencyChangesPerMinute; limit > 0 {
+		used := o.recentFrequencyChanges(radioID, time.Now())
+		var err error
+		if used >= limit {
+			err = ErrThrottled
+		}
+		plan.check("maxFrequencyChangesPerMinute", fmt.Sprintf("%d of %d changes used in the last minute", used, limit), err)
+	}
+}
+
+// explainAvailability records the recovering policy and circuit breaker
+// checks every command passes before reaching the adapter.
+func (o *Orchestrator) explainAvailability(plan *CommandPlan) error {
+	if o.adapterFor(plan.RadioID) == nil {
+		return plan.check("adapter", "no adapter for the radio", adapter.ErrUnavailable)
+	}
+
+	if status := o.radioStatus(plan.RadioID); status == radio.StatusRecovering {
+		policy := o.timing().RecoveringCommandPolicy
+		switch policy {
+		case config.RecoveringPolicyReject:
+			return plan.check("recovering", "radio is recovering; policy reject", adapter.ErrUnavailable)
+		case config.RecoveringPolicyQueue:
+			plan.check("recovering", fmt.Sprintf("radio is recovering; would wait up to %v", o.timing().RecoveringQueueTimeout), nil)
+		default:
+			plan.check("recovering", "radio is recovering; policy allow", nil)
+		}
+	}
+
+	state := o.breaker.State(plan.RadioID)
+	var err error
+	if state == BreakerOpen {
+		err = adapter.ErrUnavailable
+	}
+	return plan.check("circuitBreaker", state, err)
+}
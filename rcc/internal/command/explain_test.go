@@ -0,0 +1,146 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// planLimit returns the plan's limit called name.
+func planLimit(t *testing.T, plan *CommandPlan, name string) PlanLimit {
+	t.Helper()
+	for _, limit := range plan.Limits {
+		if limit.Name == name {
+			return limit
+		}
+	}
+	t.Fatalf("Plan has no %s limit: %+v", name, plan.Limits)
+	return PlanLimit{}
+}
+
+func TestExplainSetChannelByIndex(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.timing().CommandTimeoutSetChannel = 12 * time.Second
+	auditLogger := &MockAuditLogger{}
+	orchestrator.SetAuditLogger(auditLogger)
+	var adapterCalls atomic.Int32
+	orchestrator.SetActiveAdapter(&MockAdapter{
+		SetFrequencyFunc: func(ctx context.Context, frequencyMhz float64) error {
+			adapterCalls.Add(1)
+			return nil
+		},
+		GetStateFunc: func(ctx context.Context) (*adapter.RadioState, error) {
+			adapterCalls.Add(1)
+			return &adapter.RadioState{}, nil
+		},
+	})
+
+	index := 6
+	plan, err := orchestrator.ExplainCommand(withRoles(auth.RoleController), "setChannel", "radio-01", ExplainParams{ChannelIndex: &index})
+	if err != nil {
+		t.Fatalf("ExplainCommand failed: %v", err)
+	}
+
+	if plan.Outcome != PlanExecute || plan.Rejection != nil {
+		t.Errorf("Expected the command to execute, got %s (%v)", plan.Outcome, plan.Rejection)
+	}
+	if plan.Target.FrequencyMhz == nil || *plan.Target.FrequencyMhz != 2437 {
+		t.Errorf("Expected channel 6 to resolve to 2437 MHz, got %v", plan.Target.FrequencyMhz)
+	}
+	if limit := planLimit(t, plan, "frequencyRange"); !limit.Passed || limit.Detail != "100-6000 MHz" {
+		t.Errorf("Expected a passed frequency range limit of 100-6000 MHz, got %+v", limit)
+	}
+	if plan.TimeoutMs != 12000 {
+		t.Errorf("Expected the setChannel timeout of 12000 ms, got %d", plan.TimeoutMs)
+	}
+	if !plan.Authorization.Allowed || plan.Authorization.Reason != "role controller allows setChannel" {
+		t.Errorf("Expected the controller role to be allowed, got %+v", plan.Authorization)
+	}
+
+	// Explaining neither actuates nor audits
+	if got := adapterCalls.Load(); got != 0 {
+		t.Errorf("Expected no adapter calls, got %d", got)
+	}
+	if len(auditLogger.Actions) != 0 {
+		t.Errorf("Expected no audit records, got %+v", auditLogger.Actions)
+	}
+}
+
+func TestExplainMatchesCommandChecks(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.SetActiveAdapter(&MockAdapter{})
+	ctx := context.Background()
+
+	frequencyMhz := 2437.0
+	plan, _ := orchestrator.ExplainCommand(ctx, "setChannel", "radio-01", ExplainParams{FrequencyMhz: &frequencyMhz})
+	if limit := planLimit(t, plan, "supportedFrequency"); !limit.Passed || limit.Detail != "2402-2472 MHz" {
+		t.Errorf("Expected a passed supported frequency limit of 2402-2472 MHz, got %+v", limit)
+	}
+
+	// A frequency outside the channel plan is rejected with the command's error
+	frequencyMhz = 5180
+	plan, _ = orchestrator.ExplainCommand(ctx, "setChannel", "radio-01", ExplainParams{FrequencyMhz: &frequencyMhz})
+	commandErr := orchestrator.DryRunSetChannel(ctx, "radio-01", frequencyMhz)
+	if plan.Rejection == nil || commandErr == nil || plan.Rejection.Error() != commandErr.Error() {
+		t.Errorf("Expected the plan to fail as the command does (%v), got %v", commandErr, plan.Rejection)
+	}
+
+	// So is a power the command would reject
+	dBm := 45.0
+	plan, _ = orchestrator.ExplainCommand(ctx, "setPower", "radio-01", ExplainParams{PowerDbm: &dBm})
+	_, commandErr = orchestrator.DryRunSetPower(ctx, "radio-01", dBm)
+	if limit := planLimit(t, plan, "powerRange"); limit.Passed || !errors.Is(plan.Rejection, adapter.ErrInvalidRange) || !errors.Is(commandErr, adapter.ErrInvalidRange) {
+		t.Errorf("Expected both to reject %v dBm, got %+v (%v) and %v", dBm, limit, plan.Rejection, commandErr)
+	}
+}
+
+func TestExplainRejections(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.SetActiveAdapter(&MockAdapter{})
+
+	// The operator role may not change channels
+	index := 6
+	plan, err := orchestrator.ExplainCommand(withRoles(auth.RoleOperator), "setChannel", "radio-01", ExplainParams{ChannelIndex: &index})
+	if err != nil {
+		t.Fatalf("ExplainCommand failed: %v", err)
+	}
+	if plan.Authorization.Allowed || plan.Outcome != PlanReject || !errors.Is(plan.Rejection, ErrForbidden) {
+		t.Errorf("Expected the operator to be forbidden, got %+v", plan)
+	}
+
+	// Unknown channels fail index resolution
+	index = 4
+	plan, _ = orchestrator.ExplainCommand(context.Background(), "setChannel", "radio-01", ExplainParams{ChannelIndex: &index})
+	if limit := planLimit(t, plan, "channelIndex"); limit.Passed || !errors.Is(plan.Rejection, adapter.ErrInvalidRange) {
+		t.Errorf("Expected channel 4 to be rejected, got %+v (%v)", limit, plan.Rejection)
+	}
+
+	// Band power limits reject or clamp as the policy says
+	orchestrator.timing().PowerLimits = map[string][]config.BandPowerLimit{
+		"": {{Band: "2.4GHz", LowMhz: 2400, HighMhz: 2500, MaxDbm: 20}},
+	}
+	dBm := 30.0
+	plan, _ = orchestrator.ExplainCommand(context.Background(), "setPower", "radio-01", ExplainParams{PowerDbm: &dBm})
+	if limit := planLimit(t, plan, "bandPowerLimit"); limit.Passed || !errors.Is(plan.Rejection, adapter.ErrInvalidRange) {
+		t.Errorf("Expected 30 dBm to exceed the band limit, got %+v (%v)", limit, plan.Rejection)
+	}
+	orchestrator.timing().PowerOutOfRangePolicy = config.PowerPolicyClamp
+	plan, _ = orchestrator.ExplainCommand(context.Background(), "setPower", "radio-01", ExplainParams{PowerDbm: &dBm})
+	if plan.Outcome != PlanExecute || plan.Target.PowerDbm == nil || *plan.Target.PowerDbm != 20 {
+		t.Errorf("Expected 30 dBm to be clamped to 20 dBm, got %s %v", plan.Outcome, plan.Target.PowerDbm)
+	}
+
+	// Malformed requests get no plan
+	if _, err := orchestrator.ExplainCommand(context.Background(), "setPower", "radio-01", ExplainParams{}); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("Expected ErrInvalidParameter without powerDbm, got %v", err)
+	}
+	if _, err := orchestrator.ExplainCommand(context.Background(), "setPower", "radio-99", ExplainParams{PowerDbm: &dBm}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown radio, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrThrottled indicates a radio has reached its frequency change limit
+// (config MaxFrequencyChangesPerMinute).
+var ErrThrottled = errors.New("THROTTLED")
+
+// frequencyChangeWindow is the period MaxFrequencyChangesPerMinute counts over.
+const frequencyChangeWindow = time.Minute
+
+// frequencyChanges records recent frequency changes per radio so rapid
+// channel hopping cannot stress the radio hardware.
+type frequencyChanges struct {
+	mu      sync.Mutex
+	history map[string][]time.Time
+}
+
+// allowFrequencyChange reserves one of radioID's frequency changes for the
+// current window, or audits THROTTLED and returns ErrThrottled when none
+// remain. A limit of 0 is not restricted.
+func (o *Orchestrator) allowFrequencyChange(ctx context.Context, action, radioID string, start time.Time) error {
+	limit := o.timing().MaxFrequencyChangesPerMinute
+	if limit <= 0 {
+		return nil
+	}
+
+	o.frequencyChanges.mu.Lock()
+	if o.frequencyChanges.history == nil {
+		o.frequencyChanges.history = make(map[string][]time.Time)
+	}
+	cutoff := start.Add(-frequencyChangeWindow)
+	recent := o.frequencyChanges.history[radioID]
+	for len(recent) > 0 && !recent[0].After(cutoff) {
+		recent = recent[1:]
+	}
+	if len(recent) >= limit {
+		o.frequencyChanges.history[radioID] = recent
+		o.frequencyChanges.mu.Unlock()
+		o.logAudit(ctx, action, radioID, "THROTTLED", time.Since(start))
+		return ErrThrottled
+	}
+	o.frequencyChanges.history[radioID] = append(recent, start)
+	o.frequencyChanges.mu.Unlock()
+	return nil
+}
+
+// recentFrequencyChanges returns how many of radioID's frequency changes
+// fall in the window ending at now, without reserving one.
+func (o *Orchestrator) recentFrequencyChanges(radioID string, now time.Time) int {
+	o.frequencyChanges.mu.Lock()
+	defer o.frequencyChanges.mu.Unlock()
+
+	cutoff := now.Add(-frequencyChangeWindow)
+	count := 0
+	for _, at := range o.frequencyChanges.history[radioID] {
+		if at.After(cutoff) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,131 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+)
+
+func TestFrequencyChangeRateLimit(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.timing().MaxFrequencyChangesPerMinute = 2
+	auditLogger := &MockAuditLogger{}
+	orchestrator.SetAuditLogger(auditLogger)
+
+	var calls atomic.Int32
+	orchestrator.SetActiveAdapter(&MockAdapter{
+		SetFrequencyFunc: func(ctx context.Context, frequencyMhz float64) error {
+			calls.Add(1)
+			return nil
+		},
+	})
+	ctx := context.Background()
+
+	if err := orchestrator.SetChannel(ctx, "radio-01", 2412); err != nil {
+		t.Fatalf("First SetChannel failed: %v", err)
+	}
+	if err := orchestrator.SetChannelByIndex(ctx, "radio-01", 6, orchestrator.radioManager); err != nil {
+		t.Fatalf("Second SetChannel failed: %v", err)
+	}
+
+	// Both forms count toward the same per-radio limit
+	for _, set := range []func() error{
+		func() error { return orchestrator.SetChannel(ctx, "radio-01", 2462) },
+		func() error { return orchestrator.SetChannelByIndex(ctx, "radio-01", 11, orchestrator.radioManager) },
+	} {
+		if err := set(); !errors.Is(err, ErrThrottled) {
+			t.Errorf("Expected THROTTLED over the limit, got %v", err)
+		}
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("Expected throttled changes to skip the adapter, got %d calls", got)
+	}
+	if last := auditLogger.Actions[len(auditLogger.Actions)-1]; last.Result != "THROTTLED" {
+		t.Errorf("Expected THROTTLED audit entry, got %+v", last)
+	}
+
+	// Power is not a frequency change
+	if err := orchestrator.SetPower(ctx, "radio-01", 20); err != nil {
+		t.Errorf("Expected SetPower to be unaffected, got %v", err)
+	}
+
+	// Changes older than a minute free up the window
+	orchestrator.frequencyChanges.mu.Lock()
+	history := orchestrator.frequencyChanges.history["radio-01"]
+	history[0] = history[0].Add(-frequencyChangeWindow)
+	orchestrator.frequencyChanges.mu.Unlock()
+	if err := orchestrator.SetChannel(ctx, "radio-01", 2462); err != nil {
+		t.Errorf("Expected a change once the oldest left the window, got %v", err)
+	}
+
+	// 0 disables the limit
+	orchestrator.timing().MaxFrequencyChangesPerMinute = 0
+	if err := orchestrator.SetChannel(ctx, "radio-01", 2437); err != nil {
+		t.Errorf("Expected no limit when disabled, got %v", err)
+	}
+}
+
+func TestFrequencyChangeRateLimitWindow(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.timing().MaxFrequencyChangesPerMinute = 1
+	start := time.Now()
+
+	if err := orchestrator.allowFrequencyChange(context.Background(), "setChannel", "radio-01", start); err != nil {
+		t.Fatalf("First change failed: %v", err)
+	}
+	if err := orchestrator.allowFrequencyChange(context.Background(), "setChannel", "radio-01", start.Add(59*time.Second)); !errors.Is(err, ErrThrottled) {
+		t.Errorf("Expected THROTTLED within the window, got %v", err)
+	}
+	if err := orchestrator.allowFrequencyChange(context.Background(), "setChannel", "radio-02", start); err != nil {
+		t.Errorf("Expected the limit to be per radio, got %v", err)
+	}
+	if err := orchestrator.allowFrequencyChange(context.Background(), "setChannel", "radio-01", start.Add(frequencyChangeWindow)); err != nil {
+		t.Errorf("Expected a change after the window, got %v", err)
+	}
+}
+
+func TestThrottledChannelChangeFreesHalfOpenProbe(t *testing.T) {
+	tests := []struct {
+		name       string
+		setChannel func(o *Orchestrator) error
+	}{
+		{"by frequency", func(o *Orchestrator) error { return o.SetChannel(context.Background(), "radio-01", 2437) }},
+		{"by index", func(o *Orchestrator) error {
+			return o.SetChannelByIndex(context.Background(), "radio-01", 6, o.radioManager)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orch, mockAdapter, clock := setupBreakerOrchestrator(t, 1, 10*time.Second)
+			orch.timing().MaxFrequencyChangesPerMinute = 1
+			ctx := context.Background()
+
+			adapterDown := true
+			mockAdapter.SetFrequencyFunc = func(ctx context.Context, frequencyMhz float64) error {
+				if adapterDown {
+					return adapter.ErrUnavailable
+				}
+				return nil
+			}
+
+			// A failed change trips the breaker and uses the radio's one change
+			if err := orch.SetChannel(ctx, "radio-01", 2412); !errors.Is(err, adapter.ErrUnavailable) {
+				t.Fatalf("Expected ErrUnavailable from adapter, got %v", err)
+			}
+			*clock = clock.Add(10 * time.Second)
+			adapterDown = false
+
+			// Throttled while half-open: the probe slot must be released
+			if err := tt.setChannel(orch); !errors.Is(err, ErrThrottled) {
+				t.Fatalf("Expected ErrThrottled, got %v", err)
+			}
+			if err := orch.SetPower(ctx, "radio-01", 20); err != nil {
+				t.Fatalf("Expected SetPower to probe the radio after the throttled change, got %v", err)
+			}
+		})
+	}
+}
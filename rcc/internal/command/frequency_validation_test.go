@@ -0,0 +1,184 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/radio"
+)
+
+// profileAdapter is a MockAdapter that advertises fixed frequency profiles.
+type profileAdapter struct {
+	MockAdapter
+	profiles     []adapter.FrequencyProfile
+	profilesErr  error
+	setCalls     int
+	profileCalls int
+}
+
+func (p *profileAdapter) SupportedFrequencyProfiles(ctx context.Context) ([]adapter.FrequencyProfile, error) {
+	p.profileCalls++
+	return p.profiles, p.profilesErr
+}
+
+func (p *profileAdapter) SetFrequency(ctx context.Context, frequencyMhz float64) error {
+	p.setCalls++
+	return nil
+}
+
+// newFrequencyTestOrchestrator wires an orchestrator to a single radio with the given channels.
+func newFrequencyTestOrchestrator(channels []adapter.Channel, a adapter.IRadioAdapter) *Orchestrator {
+	cfg := config.LoadCBTimingBaseline()
+	orchestrator := &Orchestrator{breaker: NewCircuitBreaker(cfg)}
+	orchestrator.SetConfig(cfg)
+	orchestrator.SetRadioManager(&MockRadioManager{
+		Radios: map[string]*radio.Radio{
+			"radio-01": {ID: "radio-01", Capabilities: &adapter.RadioCapabilities{Channels: channels}},
+		},
+	})
+	orchestrator.SetActiveAdapter(a)
+	return orchestrator
+}
+
+func TestSetChannelRejectsFrequencyOutsideChannelPlan(t *testing.T) {
+	a := &profileAdapter{}
+	orchestrator := newFrequencyTestOrchestrator([]adapter.Channel{
+		{Index: 1, FrequencyMhz: 2412},
+		{Index: 6, FrequencyMhz: 2437},
+		{Index: 11, FrequencyMhz: 2462},
+	}, a)
+
+	err := orchestrator.SetChannel(context.Background(), "radio-01", 5180)
+	if !errors.Is(err, adapter.ErrInvalidRange) {
+		t.Fatalf("Expected ErrInvalidRange for 5GHz on a 2.4GHz radio, got %v", err)
+	}
+	if a.setCalls != 0 {
+		t.Errorf("Expected adapter not to be called for out-of-band frequency, got %d calls", a.setCalls)
+	}
+
+	// In-band frequencies, including between channels, still reach the adapter
+	for _, freq := range []float64{2412, 2450, 2462} {
+		if err := orchestrator.SetChannel(context.Background(), "radio-01", freq); err != nil {
+			t.Errorf("SetChannel(%v) should succeed, got %v", freq, err)
+		}
+	}
+	if a.setCalls != 3 {
+		t.Errorf("Expected 3 adapter calls, got %d", a.setCalls)
+	}
+}
+
+func TestSetChannelValidatesAgainstFrequencyProfiles(t *testing.T) {
+	// Continuous 4.9GHz range advertised by the adapter, no channel plan
+	var freqs []float64
+	for f := 4700.0; f <= 4900; f += 5 {
+		freqs = append(freqs, f)
+	}
+	a := &profileAdapter{profiles: []adapter.FrequencyProfile{{Frequencies: freqs, Bandwidth: 10}}}
+	orchestrator := newFrequencyTestOrchestrator(nil, a)
+
+	tests := []struct {
+		frequency float64
+		valid     bool
+	}{
+		{4700, true},
+		{4812.5, true},
+		{4905, true}, // Within half bandwidth of the upper edge
+		{4910, false},
+		{2412, false},
+	}
+	for _, tt := range tests {
+		err := orchestrator.SetChannel(context.Background(), "radio-01", tt.frequency)
+		if tt.valid && err != nil {
+			t.Errorf("SetChannel(%v) should succeed, got %v", tt.frequency, err)
+		}
+		if !tt.valid && !errors.Is(err, adapter.ErrInvalidRange) {
+			t.Errorf("SetChannel(%v): expected ErrInvalidRange, got %v", tt.frequency, err)
+		}
+	}
+}
+
+func TestSetChannelIgnoresUnavailableProfiles(t *testing.T) {
+	// Profile query failures fall back to the coarse range check
+	a := &profileAdapter{profilesErr: adapter.ErrUnavailable}
+	orchestrator := newFrequencyTestOrchestrator(nil, a)
+
+	if err := orchestrator.SetChannel(context.Background(), "radio-01", 5180); err != nil {
+		t.Errorf("Expected coarse validation only without profiles, got %v", err)
+	}
+}
+
+// newProfileCacheTestOrchestrator loads a profileAdapter that reports no
+// profiles, so the radio has no channel plan, into a real radio manager.
+func newProfileCacheTestOrchestrator(t *testing.T) (*Orchestrator, *profileAdapter) {
+	t.Helper()
+	a := &profileAdapter{}
+	rm := radio.NewManager()
+	if err := rm.LoadCapabilities("radio-01", a, time.Second); err != nil {
+		t.Fatalf("Failed to load radio-01: %v", err)
+	}
+	a.profileCalls = 0
+	return NewOrchestratorWithRadioManager(nil, config.LoadCBTimingBaseline(), rm), a
+}
+
+func TestSelectRadioCachesFrequencyProfiles(t *testing.T) {
+	orchestrator, a := newProfileCacheTestOrchestrator(t)
+	a.profiles = []adapter.FrequencyProfile{{Frequencies: []float64{4900, 4920}, Bandwidth: 20}}
+	ctx := context.Background()
+
+	if err := orchestrator.SelectRadio(ctx, "radio-01"); err != nil {
+		t.Fatalf("SelectRadio failed: %v", err)
+	}
+	if a.profileCalls != 1 {
+		t.Fatalf("Expected profiles fetched once on select, got %d calls", a.profileCalls)
+	}
+
+	// Later profile changes on the adapter are not seen; the cache is used
+	a.profiles = []adapter.FrequencyProfile{{Frequencies: []float64{2412}, Bandwidth: 20}}
+	for _, freq := range []float64{4900, 4915, 4930} {
+		if err := orchestrator.SetChannel(ctx, "radio-01", freq); err != nil {
+			t.Errorf("SetChannel(%v) should succeed against cached profiles, got %v", freq, err)
+		}
+	}
+	if err := orchestrator.SetChannel(ctx, "radio-01", 2412); !errors.Is(err, adapter.ErrInvalidRange) {
+		t.Errorf("Expected ErrInvalidRange outside the cached profiles, got %v", err)
+	}
+	if a.profileCalls != 1 {
+		t.Errorf("Expected SetChannel to reuse cached profiles, got %d calls", a.profileCalls)
+	}
+}
+
+func TestSelectRadioCachesNoProfilesWhenUnsupported(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantCalls int
+	}{
+		// Unsupported: an empty set is cached and profile validation skipped
+		{"unsupported", errors.New("frequency profiles not implemented"), 1},
+		// Busy: nothing is cached and SetChannel queries the adapter again
+		{"busy", adapter.ErrBusy, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orchestrator, a := newProfileCacheTestOrchestrator(t)
+			a.profilesErr = tt.err
+			ctx := context.Background()
+
+			if err := orchestrator.SelectRadio(ctx, "radio-01"); err != nil {
+				t.Fatalf("SelectRadio failed: %v", err)
+			}
+			for _, freq := range []float64{2412, 4900} {
+				if err := orchestrator.SetChannel(ctx, "radio-01", freq); err != nil {
+					t.Errorf("SetChannel(%v) should succeed without profiles, got %v", freq, err)
+				}
+			}
+			if a.profileCalls != tt.wantCalls {
+				t.Errorf("Expected %d profile queries, got %d", tt.wantCalls, a.profileCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package command
+
+import (
+	"context"
+	"math"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// Transmit power range accepted by SetPower before band limits apply.
+const (
+	minPowerDbm = 0
+	maxPowerDbm = 39
+)
+
+// Frequency range accepted by SetChannel before the radio's own bands apply.
+const (
+	minFrequencyMhz = 100
+	maxFrequencyMhz = 6000
+)
+
+// Limits describes what a radio accepts, for clients building controls.
+type Limits struct {
+	RadioID         string           `json:"radioId"`
+	Model           string           `json:"model"`
+	Power           PowerLimits      `json:"power"`
+	FrequencyRanges []FrequencyRange `json:"frequencyRanges"`
+	TimeoutsMs      CommandTimeouts  `json:"timeoutsMs"`
+}
+
+// PowerLimits is the accepted power range, the out-of-range policy and the
+// configured per-band ceilings for the radio's model.
+type PowerLimits struct {
+	MinDbm float64                 `json:"minDbm"`
+	MaxDbm float64                 `json:"maxDbm"`
+	Policy string                  `json:"policy"`
+	Bands  []config.BandPowerLimit `json:"bands"`
+}
+
+// FrequencyRange is a span of frequencies the radio can be tuned within.
+type FrequencyRange struct {
+	LowMhz       float64 `json:"lowMhz"`
+	HighMhz      float64 `json:"highMhz"`
+	BandwidthMhz float64 `json:"bandwidthMhz,omitempty"`
+}
+
+// CommandTimeouts are the per-command timeouts, in milliseconds.
+type CommandTimeouts struct {
+	SetPower    int64 `json:"setPower"`
+	SetChannel  int64 `json:"setChannel"`
+	SelectRadio int64 `json:"selectRadio"`
+	GetState    int64 `json:"getState"`
+}
+
+// GetLimits returns the power limits, frequency ranges and command timeouts
+// that apply to the radio. Frequency ranges come from the adapter's
+// frequency profiles, or the span of the channel plan when it reports none.
+func (o *Orchestrator) GetLimits(ctx context.Context, radioID string) (*Limits, error) {
+	if o.radioManager == nil {
+		return nil, adapter.ErrUnavailable
+	}
+	r, err := o.radioManager.GetRadio(radioID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	cfg := o.timing()
+	policy := cfg.PowerOutOfRangePolicy
+	if policy == "" {
+		policy = config.PowerPolicyReject
+	}
+	limits := &Limits{
+		RadioID: radioID,
+		Model:   r.Model,
+		Power: PowerLimits{
+			MinDbm: minPowerDbm,
+			MaxDbm: maxPowerDbm,
+			Policy: policy,
+			Bands:  []config.BandPowerLimit{},
+		},
+		FrequencyRanges: []FrequencyRange{},
+		TimeoutsMs: CommandTimeouts{
+			SetPower:    cfg.CommandTimeout(r.Model, "setPower").Milliseconds(),
+			SetChannel:  cfg.CommandTimeout(r.Model, "setChannel").Milliseconds(),
+			SelectRadio: cfg.CommandTimeout(r.Model, "selectRadio").Milliseconds(),
+			GetState:    cfg.CommandTimeout(r.Model, "getState").Milliseconds(),
+		},
+	}
+	if bands := o.powerLimitsFor(r.Model); bands != nil {
+		limits.Power.Bands = bands
+	}
+
+	for _, profile := range o.frequencyProfiles(ctx, radioID) {
+		if low, high, ok := span(profile.Frequencies); ok {
+			limits.FrequencyRanges = append(limits.FrequencyRanges, FrequencyRange{LowMhz: low, HighMhz: high, BandwidthMhz: profile.Bandwidth})
+		}
+	}
+	if len(limits.FrequencyRanges) == 0 && r.Capabilities != nil {
+		frequencies := make([]float64, 0, len(r.Capabilities.Channels))
+		for _, ch := range r.Capabilities.Channels {
+			frequencies = append(frequencies, ch.FrequencyMhz)
+		}
+		if low, high, ok := span(frequencies); ok {
+			limits.FrequencyRanges = append(limits.FrequencyRanges, FrequencyRange{LowMhz: low, HighMhz: high})
+		}
+	}
+
+	return limits, nil
+}
+
+// span returns the lowest and highest of frequencies; ok is false when
+// there are none.
+func span(frequencies []float64) (low, high float64, ok bool) {
+	if len(frequencies) == 0 {
+		return 0, 0, false
+	}
+	low, high = math.Inf(1), math.Inf(-1)
+	for _, f := range frequencies {
+		low = math.Min(low, f)
+		high = math.Max(high, f)
+	}
+	return low, high, true
+}
@@ -0,0 +1,104 @@
+package command
+
+import (
+	"context"
+	"time"
+)
+
+// CommandReport carries details of a successful command back to a caller
+// that asked for them with WithCommandReport.
+type CommandReport struct {
+	// Noop is set when the target already matched the radio's known state
+	// and the adapter was not called.
+	Noop bool
+
+	// NoChange is set when the same value was applied within
+	// CommandDedupWindow and the adapter was not called.
+	NoChange bool
+}
+
+type commandReportKey struct{}
+
+// WithCommandReport returns a context under which SetPower, ApplyPower,
+// SetChannel and SetChannelByIndex fill in the returned report.
+func WithCommandReport(ctx context.Context) (context.Context, *CommandReport) {
+	report := &CommandReport{}
+	return context.WithValue(ctx, commandReportKey{}, report), report
+}
+
+// skipNoopPower reports whether SetPower to dBm can be answered without the
+// adapter because SkipNoopCommands is on and the radio is known to be at
+// dBm. A skipped command is audited as NOOP.
+func (o *Orchestrator) skipNoopPower(ctx context.Context, radioID string, dBm float64, start time.Time) bool {
+	if !o.noopPower(radioID, dBm) {
+		return false
+	}
+	o.markNoop(ctx, "setPower", radioID, start)
+	return true
+}
+
+// skipNoopFrequency is skipNoopPower for SetChannel.
+func (o *Orchestrator) skipNoopFrequency(ctx context.Context, radioID string, frequencyMhz float64, start time.Time) bool {
+	if !o.noopFrequency(radioID, frequencyMhz) {
+		return false
+	}
+	o.markNoop(ctx, "setChannel", radioID, start)
+	return true
+}
+
+// noopPower reports whether noop detection applies to radioID and it is
+// known to be at dBm.
+func (o *Orchestrator) noopPower(radioID string, dBm float64) bool {
+	if !o.noopEligible(radioID) {
+		return false
+	}
+	current, ok := o.knownPower(radioID)
+	return ok && current == dBm
+}
+
+// noopFrequency is noopPower for frequencies.
+func (o *Orchestrator) noopFrequency(radioID string, frequencyMhz float64) bool {
+	if !o.noopEligible(radioID) {
+		return false
+	}
+	current, ok := o.knownFrequency(radioID)
+	return ok && current == frequencyMhz
+}
+
+// knownPower returns the power a set or read established for radioID within
+// the config StateCacheTTL, since its status last changed.
+func (o *Orchestrator) knownPower(radioID string) (float64, bool) {
+	return o.states.knownPower(radioID, o.radioEpoch(radioID), time.Now(), o.timing().StateCacheTTL)
+}
+
+// knownFrequency is knownPower for the tuned frequency.
+func (o *Orchestrator) knownFrequency(radioID string) (float64, bool) {
+	return o.states.knownFrequency(radioID, o.radioEpoch(radioID), time.Now(), o.timing().StateCacheTTL)
+}
+
+// radioEpoch returns the radio's status epoch as the radio manager reports
+// it, or 0 when it cannot be read.
+func (o *Orchestrator) radioEpoch(radioID string) uint64 {
+	if o.radioManager == nil {
+		return 0
+	}
+	r, err := o.radioManager.GetRadio(radioID)
+	if err != nil || r == nil {
+		return 0
+	}
+	return r.StatusEpoch
+}
+
+// noopEligible reports whether noop detection applies to radioID. A radio
+// whose breaker is not closed may have lost its state, so it is always
+// commanded.
+func (o *Orchestrator) noopEligible(radioID string) bool {
+	return o.timing().SkipNoopCommands && o.breaker.State(radioID) == BreakerClosed
+}
+
+func (o *Orchestrator) markNoop(ctx context.Context, action, radioID string, start time.Time) {
+	if report, ok := ctx.Value(commandReportKey{}).(*CommandReport); ok {
+		report.Noop = true
+	}
+	o.logAudit(ctx, action, radioID, "NOOP", time.Since(start))
+}
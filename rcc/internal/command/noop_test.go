@@ -0,0 +1,151 @@
+package command
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+)
+
+func TestSkipNoopCommands(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.timing().SkipNoopCommands = true
+	auditLogger := &MockAuditLogger{}
+	orchestrator.SetAuditLogger(auditLogger)
+
+	var setPowerCalls, setFrequencyCalls atomic.Int32
+	orchestrator.SetActiveAdapter(&MockAdapter{
+		SetPowerFunc: func(ctx context.Context, dBm float64) error {
+			setPowerCalls.Add(1)
+			return nil
+		},
+		SetFrequencyFunc: func(ctx context.Context, frequencyMhz float64) error {
+			setFrequencyCalls.Add(1)
+			return nil
+		},
+		GetStateFunc: func(ctx context.Context) (*adapter.RadioState, error) {
+			return &adapter.RadioState{PowerDbm: 20, FrequencyMhz: 2412}, nil
+		},
+	})
+
+	// The first set reaches the adapter; repeating it is a noop
+	if err := orchestrator.SetPower(context.Background(), "radio-01", 20); err != nil {
+		t.Fatalf("SetPower failed: %v", err)
+	}
+	ctx, report := WithCommandReport(context.Background())
+	if err := orchestrator.SetPower(ctx, "radio-01", 20); err != nil {
+		t.Fatalf("SetPower failed: %v", err)
+	}
+	if got := setPowerCalls.Load(); got != 1 {
+		t.Errorf("Expected repeated SetPower to skip the adapter, got %d calls", got)
+	}
+	if !report.Noop {
+		t.Error("Expected the report to mark the repeated SetPower as noop")
+	}
+	if last := auditLogger.Actions[len(auditLogger.Actions)-1]; last.Action != "setPower" || last.Result != "NOOP" {
+		t.Errorf("Expected NOOP audit entry, got %+v", last)
+	}
+
+	// A different target is applied
+	ctx, report = WithCommandReport(context.Background())
+	if err := orchestrator.SetPower(ctx, "radio-01", 25); err != nil {
+		t.Fatalf("SetPower failed: %v", err)
+	}
+	if got := setPowerCalls.Load(); got != 2 || report.Noop {
+		t.Errorf("Expected a new power to reach the adapter, got %d calls (noop %v)", got, report.Noop)
+	}
+
+	// Channel changes keep the known power, and vice versa
+	if err := orchestrator.SetChannel(context.Background(), "radio-01", 2437); err != nil {
+		t.Fatalf("SetChannel failed: %v", err)
+	}
+	if err := orchestrator.SetChannelByIndex(context.Background(), "radio-01", 6, orchestrator.radioManager); err != nil {
+		t.Fatalf("SetChannelByIndex failed: %v", err)
+	}
+	if err := orchestrator.SetPower(context.Background(), "radio-01", 25); err != nil {
+		t.Fatalf("SetPower failed: %v", err)
+	}
+	if setFrequencyCalls.Load() != 1 || setPowerCalls.Load() != 2 {
+		t.Errorf("Expected noops for channel 6 and 25 dBm, got %d frequency and %d power calls",
+			setFrequencyCalls.Load(), setPowerCalls.Load())
+	}
+
+	// A fresh read that disagrees replaces the known state
+	orchestrator.timing().StateCacheTTL = time.Minute
+	if _, err := orchestrator.RefreshState(context.Background(), "radio-01"); err != nil {
+		t.Fatalf("RefreshState failed: %v", err)
+	}
+	if err := orchestrator.SetPower(context.Background(), "radio-01", 25); err != nil {
+		t.Fatalf("SetPower failed: %v", err)
+	}
+	if got := setPowerCalls.Load(); got != 3 {
+		t.Errorf("Expected SetPower after a read of 20 dBm to reach the adapter, got %d calls", got)
+	}
+
+	// Off by default
+	orchestrator.timing().SkipNoopCommands = false
+	if err := orchestrator.SetPower(context.Background(), "radio-01", 25); err != nil {
+		t.Fatalf("SetPower failed: %v", err)
+	}
+	if got := setPowerCalls.Load(); got != 4 {
+		t.Errorf("Expected every SetPower to reach the adapter when disabled, got %d calls", got)
+	}
+}
+
+func TestNoopKnownStateDropped(t *testing.T) {
+	tests := []struct {
+		name  string
+		apply func(t *testing.T, orchestrator *Orchestrator, failSet *atomic.Bool)
+	}{
+		{"adapter error", func(t *testing.T, orchestrator *Orchestrator, failSet *atomic.Bool) {
+			failSet.Store(true)
+			if err := orchestrator.SetPower(context.Background(), "radio-01", 25); err == nil {
+				t.Fatal("Expected SetPower to fail")
+			}
+			failSet.Store(false)
+		}},
+		{"expired", func(t *testing.T, orchestrator *Orchestrator, failSet *atomic.Bool) {
+			orchestrator.timing().StateCacheTTL = time.Millisecond
+			time.Sleep(5 * time.Millisecond)
+		}},
+		{"status changed", func(t *testing.T, orchestrator *Orchestrator, failSet *atomic.Bool) {
+			r, _ := orchestrator.radioManager.GetRadio("radio-01")
+			r.StatusEpoch++
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orchestrator := setupTestOrchestrator(t)
+			orchestrator.timing().SkipNoopCommands = true
+			orchestrator.timing().StateCacheTTL = time.Minute
+
+			var setPowerCalls atomic.Int32
+			var failSet atomic.Bool
+			orchestrator.SetActiveAdapter(&MockAdapter{
+				SetPowerFunc: func(ctx context.Context, dBm float64) error {
+					setPowerCalls.Add(1)
+					if failSet.Load() {
+						return adapter.ErrInvalidRange
+					}
+					return nil
+				},
+			})
+
+			if err := orchestrator.SetPower(context.Background(), "radio-01", 20); err != nil {
+				t.Fatalf("SetPower failed: %v", err)
+			}
+			tt.apply(t, orchestrator, &failSet)
+			calls := setPowerCalls.Load()
+
+			if err := orchestrator.SetPower(context.Background(), "radio-01", 20); err != nil {
+				t.Fatalf("SetPower failed: %v", err)
+			}
+			if got := setPowerCalls.Load(); got != calls+1 {
+				t.Errorf("Expected SetPower to reach the adapter once the known power was dropped, got %d calls", got-calls)
+			}
+		})
+	}
+}
@@ -2,36 +2,84 @@ package command
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/audit"
 	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/logging"
+	"github.com/radio-control/rcc/internal/metrics"
 	"github.com/radio-control/rcc/internal/radio"
 	"github.com/radio-control/rcc/internal/telemetry"
 )
 
-// Orchestrator routes validated API intents to the active adapter.
+// Orchestrator routes validated API intents to each radio's adapter.
 type Orchestrator struct {
-	// Active radio adapter
+	// Fallback adapter for radio managers that cannot resolve one per radio
 	activeAdapter adapter.IRadioAdapter
 
-	// Telemetry hub for event publishing
-	telemetryHub *telemetry.Hub
+	// Telemetry hub for event publishing; NoopPublisher when none is given
+	telemetryHub EventPublisher
 
-	// Configuration for validation
-	config *config.TimingConfig
+	// Configuration for validation, replaced atomically by SetConfig
+	config atomic.Pointer[config.TimingConfig]
 
 	// Audit logger (to be implemented)
 	auditLogger AuditLogger
 
+	// Structured logger for command outcomes (nil disables logging)
+	logger *logging.Logger
+
+	// Command counts and latencies for /metrics (nil disables)
+	metrics *metrics.Registry
+
 	// Radio manager for channel index resolution
 	radioManager RadioManager
+
+	// Per-radio circuit breaker for failing fast on unavailable adapters
+	breaker *CircuitBreaker
+
+	// In-flight commands per radio, cancellable via CancelCommand
+	inflight inflightCommands
+
+	// In-flight command counts per authenticated subject
+	subjects subjectCommands
+
+	// Recent frequency changes per radio for MaxFrequencyChangesPerMinute
+	frequencyChanges frequencyChanges
+
+	// Last GetState result per radio, invalidated by successful sets
+	states stateCache
+
+	// Last applied power and frequency per radio for CommandDedupWindow
+	applied appliedCommands
+
+	// Per-radio locks so commands to one radio run one at a time
+	radioLocks radioLocks
+
+	// Baseline timing used when config is nil, created on first use
+	fallbackOnce   sync.Once
+	fallbackConfig *config.TimingConfig
 }
 
 // Compile-time assertion that radio.Manager implements RadioManager
 var _ RadioManager = (*radio.Manager)(nil)
 
+// Compile-time assertion that radio.Manager resolves adapters per radio
+var _ AdapterResolver = (*radio.Manager)(nil)
+
+// Compile-time assertion that radio.Manager tracks capability age
+var _ CapabilityRefresher = (*radio.Manager)(nil)
+
+// Compile-time assertion that radio.Manager caches frequency profiles
+var _ FrequencyProfileCache = (*radio.Manager)(nil)
+
 // Compile-time assertion that Orchestrator implements OrchestratorPort
 var _ OrchestratorPort = (*Orchestrator)(nil)
 
@@ -42,117 +90,317 @@ type AuditLogger interface {
 
 // NewOrchestrator creates a new command orchestrator.
 func NewOrchestrator(telemetryHub *telemetry.Hub, timingConfig *config.TimingConfig) *Orchestrator {
-	return &Orchestrator{
-		telemetryHub: telemetryHub,
-		config:       timingConfig,
+	o := &Orchestrator{
+		telemetryHub: newPublisher(telemetryHub),
+		breaker:      NewCircuitBreaker(timingConfig),
 	}
+	o.config.Store(timingConfig)
+	return o
 }
 
 // NewOrchestratorWithRadioManager creates a new command orchestrator with radio manager.
 func NewOrchestratorWithRadioManager(telemetryHub *telemetry.Hub, timingConfig *config.TimingConfig, radioManager RadioManager) *Orchestrator {
-	return &Orchestrator{
-		telemetryHub: telemetryHub,
-		config:       timingConfig,
+	o := &Orchestrator{
+		telemetryHub: newPublisher(telemetryHub),
 		radioManager: radioManager,
+		breaker:      NewCircuitBreaker(timingConfig),
 	}
+	o.config.Store(timingConfig)
+	return o
 }
 
-// SetActiveAdapter sets the active radio adapter.
+// SetActiveAdapter sets the fallback adapter used when the radio manager does
+// not provide one for the commanded radio.
+//
+// Deprecated: commands resolve the adapter per radio from the radio manager
+// (see AdapterResolver); register adapters there instead.
 func (o *Orchestrator) SetActiveAdapter(adapter adapter.IRadioAdapter) {
 	o.activeAdapter = adapter
 }
 
 // SetPower sets the transmit power for the active radio in dBm.
 func (o *Orchestrator) SetPower(ctx context.Context, radioID string, dBm float64) error {
+	_, err := o.setPower(ctx, radioID, dBm, false)
+	return err
+}
+
+// ApplyPower is SetPower returning the power actually applied, which differs
+// from dBm when the PowerOutOfRangePolicy clamps it.
+func (o *Orchestrator) ApplyPower(ctx context.Context, radioID string, dBm float64) (float64, error) {
+	return o.setPower(ctx, radioID, dBm, false)
+}
+
+// DryRunSetPower runs SetPower's validation without calling the adapter,
+// returning the power that would be applied.
+func (o *Orchestrator) DryRunSetPower(ctx context.Context, radioID string, dBm float64) (float64, error) {
+	return o.setPower(ctx, radioID, dBm, true)
+}
+
+// setPower validates and applies a power change, returning the applied power.
+// With dryRun it returns after validation, auditing DRY_RUN instead of
+// actuating.
+func (o *Orchestrator) setPower(ctx context.Context, radioID string, dBm float64, dryRun bool) (float64, error) {
 	start := time.Now()
 
+	// Enforce the per-role command allowlist
+	if err := o.authorize(ctx, "setPower", radioID, start); err != nil {
+		return 0, err
+	}
+
+	// Limit concurrent commands per authenticated subject
+	release, err := o.acquireSubject(ctx, "setPower", radioID, start)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
 	// Ensure radio exists via radio manager
 	if o.radioManager == nil {
 		o.logAudit(ctx, "setPower", radioID, "UNAVAILABLE", time.Since(start))
-		return adapter.ErrUnavailable
+		return 0, adapter.ErrUnavailable
 	}
-	if _, err := o.radioManager.GetRadio(radioID); err != nil {
+	commanded, err := o.radioManager.GetRadio(radioID)
+	if err != nil {
 		o.logAudit(ctx, "setPower", radioID, "NOT_FOUND", time.Since(start))
-		return ErrNotFound
+		return 0, ErrNotFound
 	}
 
-	// Validate power range
-	if err := o.validatePowerRange(dBm); err != nil {
+	// Only approved radio models may be commanded
+	if err := o.authorizeModel(ctx, "setPower", commanded, start); err != nil {
+		return 0, err
+	}
+
+	// Radios without the capability reject the command before the adapter
+	if err := o.checkSupported(ctx, "setPower", commanded, start); err != nil {
+		return 0, err
+	}
+
+	// Validate power range, or clamp into it when configured
+	dBm, _, err = o.applyPowerPolicy(dBm)
+	if err != nil {
 		o.logAudit(ctx, "setPower", radioID, "INVALID_RANGE", time.Since(start))
-		return err
+		return 0, err
 	}
 
 	// Check if adapter is available
-	if o.activeAdapter == nil {
+	radioAdapter := o.adapterFor(radioID)
+	if radioAdapter == nil {
 		o.logAudit(ctx, "setPower", radioID, "UNAVAILABLE", time.Since(start))
-		return adapter.ErrUnavailable
+		return 0, adapter.ErrUnavailable
 	}
 
-	// Execute command with timeout
-	timeout := o.config.CommandTimeoutSetPower
-	ctx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
+	// Commands to one radio run one at a time, in the order they queue; a
+	// dry run does not queue
+	if !dryRun {
+		unlock, err := o.lockRadio(ctx, "setPower", radioID, start)
+		if err != nil {
+			return 0, err
+		}
+		defer unlock()
+	}
+
+	// Enforce the limit for the band the radio is tuned to, under the radio
+	// lock so a channel change queued ahead cannot retune it in between
+	dBm, _, err = o.applyBandPowerLimit(ctx, commanded, radioAdapter, dBm)
+	if err != nil {
+		o.logAudit(ctx, "setPower", radioID, "INVALID_RANGE", time.Since(start))
+		return 0, err
+	}
+
+	// Dry run stops after validation, before touching the radio
+	if dryRun {
+		o.logAudit(ctx, "setPower", radioID, "DRY_RUN", time.Since(start))
+		return dBm, nil
+	}
+
+	// Skip the adapter when the radio is already at the target power
+	if o.skipNoopPower(ctx, radioID, dBm, start) {
+		return dBm, nil
+	}
+
+	// Skip the adapter when the same power was just applied
+	if o.skipDuplicatePower(ctx, radioID, dBm, start) {
+		return dBm, nil
+	}
+
+	// Hold or reject commands to a recovering radio as configured
+	if err := o.awaitRecovery(ctx, "setPower", radioID, start); err != nil {
+		return 0, err
+	}
+
+	// Fail fast while the radio's circuit breaker is open
+	if err := o.breaker.Allow(radioID); err != nil {
+		o.logAudit(ctx, "setPower", radioID, "UNAVAILABLE", time.Since(start))
+		return 0, err
+	}
+
+	// Execute command with timeout; cancellable via CancelCommand
+	ctx, cmd, finish := o.startCommand(ctx, radioID, "setPower", o.commandTimeout(radioID, "setPower"))
+	defer finish()
 
-	err := o.activeAdapter.SetPower(ctx, dBm)
+	err = radioAdapter.SetPower(ctx, dBm)
 	latency := time.Since(start)
 
 	if err != nil {
+		// The radio may or may not have applied it
+		o.applied.forget(radioID)
+		o.states.forget(radioID)
+
+		if cmd.wasCanceled() {
+			return 0, o.commandCanceled(ctx, "setPower", radioID, latency)
+		}
+
 		// Map adapter error to normalized code
 		normalizedErr := adapter.NormalizeVendorError(err, nil)
+		o.breaker.Record(radioID, normalizedErr)
 		o.logAudit(ctx, "setPower", radioID, "ERROR", latency)
 
 		// Publish fault event
 		o.publishFaultEvent(radioID, normalizedErr, "Failed to set power")
 
-		return normalizedErr
+		return 0, normalizedErr
 	}
 
+	o.breaker.Record(radioID, nil)
+	o.states.recordPower(radioID, dBm, o.radioEpoch(radioID), time.Now())
+	o.applied.recordPower(radioID, dBm, time.Now())
+
 	// Log successful action
-	o.logAudit(ctx, "setPower", radioID, "SUCCESS", latency)
+	o.logAudit(audit.WithParams(ctx, map[string]interface{}{audit.ParamPowerDbm: dBm}), "setPower", radioID, "SUCCESS", latency)
 
 	// Publish power changed event
 	o.publishPowerChangedEvent(radioID, dBm)
 
-	return nil
+	return dBm, nil
 }
 
 // SetChannel sets the channel for the active radio by frequency or index.
 func (o *Orchestrator) SetChannel(ctx context.Context, radioID string, frequencyMhz float64) error {
+	return o.setChannel(ctx, radioID, frequencyMhz, false)
+}
+
+// DryRunSetChannel runs SetChannel's validation without calling the adapter.
+func (o *Orchestrator) DryRunSetChannel(ctx context.Context, radioID string, frequencyMhz float64) error {
+	return o.setChannel(ctx, radioID, frequencyMhz, true)
+}
+
+// setChannel validates and applies a frequency change. With dryRun it returns
+// after validation, auditing DRY_RUN instead of actuating.
+func (o *Orchestrator) setChannel(ctx context.Context, radioID string, frequencyMhz float64, dryRun bool) error {
 	start := time.Now()
 
+	// Enforce the per-role command allowlist
+	if err := o.authorize(ctx, "setChannel", radioID, start); err != nil {
+		return err
+	}
+
+	// Limit concurrent commands per authenticated subject
+	release, err := o.acquireSubject(ctx, "setChannel", radioID, start)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	// Ensure radio exists via radio manager
 	if o.radioManager == nil {
 		o.logAudit(ctx, "setChannel", radioID, "UNAVAILABLE", time.Since(start))
 		return adapter.ErrUnavailable
 	}
-	if _, err := o.radioManager.GetRadio(radioID); err != nil {
+	commanded, err := o.radioManager.GetRadio(radioID)
+	if err != nil {
 		o.logAudit(ctx, "setChannel", radioID, "NOT_FOUND", time.Since(start))
 		return ErrNotFound
 	}
 
+	// Only approved radio models may be commanded
+	if err := o.authorizeModel(ctx, "setChannel", commanded, start); err != nil {
+		return err
+	}
+
+	// Radios without the capability reject the command before the adapter
+	if err := o.checkSupported(ctx, "setChannel", commanded, start); err != nil {
+		return err
+	}
+
 	// Validate frequency range
-	if err := o.validateFrequencyRange(frequencyMhz); err != nil {
+	if _, err := o.validateFrequencyRange(frequencyMhz); err != nil {
 		o.logAudit(ctx, "setChannel", radioID, "INVALID_RANGE", time.Since(start))
 		return err
 	}
 
 	// Check if adapter is available
-	if o.activeAdapter == nil {
+	radioAdapter := o.adapterFor(radioID)
+	if radioAdapter == nil {
 		o.logAudit(ctx, "setChannel", radioID, "UNAVAILABLE", time.Since(start))
 		return adapter.ErrUnavailable
 	}
 
-	// Execute command with timeout
-	timeout := o.config.CommandTimeoutSetChannel
-	ctx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
+	// Reject frequencies the radio cannot tune before reaching the adapter
+	if _, err := o.validateFrequencySupported(radioID, frequencyMhz, o.frequencyProfiles(ctx, radioID)); err != nil {
+		o.logAudit(ctx, "setChannel", radioID, "INVALID_RANGE", time.Since(start))
+		return err
+	}
+
+	// Dry run stops after validation, before touching the radio
+	if dryRun {
+		o.logAudit(ctx, "setChannel", radioID, "DRY_RUN", time.Since(start))
+		return nil
+	}
+
+	// Commands to one radio run one at a time, in the order they queue
+	unlock, err := o.lockRadio(ctx, "setChannel", radioID, start)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Skip the adapter when the radio is already on the target frequency
+	if o.skipNoopFrequency(ctx, radioID, frequencyMhz, start) {
+		return nil
+	}
+
+	// Skip the adapter when the same frequency was just applied
+	if o.skipDuplicateFrequency(ctx, radioID, frequencyMhz, start) {
+		return nil
+	}
+
+	// Hold or reject commands to a recovering radio as configured
+	if err := o.awaitRecovery(ctx, "setChannel", radioID, start); err != nil {
+		return err
+	}
 
-	err := o.activeAdapter.SetFrequency(ctx, frequencyMhz)
+	// Fail fast while the radio's circuit breaker is open
+	if err := o.breaker.Allow(radioID); err != nil {
+		o.logAudit(ctx, "setChannel", radioID, "UNAVAILABLE", time.Since(start))
+		return err
+	}
+
+	// Protect the hardware from rapid channel hopping; a throttled command
+	// never reaches the adapter, so it frees any half-open probe slot
+	if err := o.allowFrequencyChange(ctx, "setChannel", radioID, start); err != nil {
+		o.breaker.Release(radioID)
+		return err
+	}
+
+	// Execute command with timeout; cancellable via CancelCommand
+	ctx, cmd, finish := o.startCommand(ctx, radioID, "setChannel", o.commandTimeout(radioID, "setChannel"))
+	defer finish()
+
+	err = radioAdapter.SetFrequency(ctx, frequencyMhz)
 	latency := time.Since(start)
 
 	if err != nil {
+		// The radio may or may not have applied it
+		o.applied.forget(radioID)
+		o.states.forget(radioID)
+
+		if cmd.wasCanceled() {
+			return o.commandCanceled(ctx, "setChannel", radioID, latency)
+		}
+
 		// Map adapter error to normalized code
 		normalizedErr := adapter.NormalizeVendorError(err, nil)
+		o.breaker.Record(radioID, normalizedErr)
 		o.logAudit(ctx, "setChannel", radioID, "ERROR", latency)
 
 		// Publish fault event
@@ -161,86 +409,202 @@ func (o *Orchestrator) SetChannel(ctx context.Context, radioID string, frequency
 		return normalizedErr
 	}
 
+	o.breaker.Record(radioID, nil)
+	o.states.recordFrequency(radioID, frequencyMhz, o.radioEpoch(radioID), time.Now())
+	o.applied.recordFrequency(radioID, frequencyMhz, time.Now())
+
 	// Log successful action
-	o.logAudit(ctx, "setChannel", radioID, "SUCCESS", latency)
+	o.logAudit(audit.WithParams(ctx, map[string]interface{}{audit.ParamFrequencyMhz: frequencyMhz}), "setChannel", radioID, "SUCCESS", latency)
 
-	// Publish channel changed event
-	o.publishChannelChangedEvent(radioID, frequencyMhz, 0) // channelIndex will be derived later
+	// Publish channel changed event with the index the frequency maps to
+	o.publishChannelChangedEvent(radioID, frequencyMhz, o.channelIndexFor(radioID, frequencyMhz))
 
 	return nil
 }
 
 // SetChannelByIndex sets the channel for the active radio by channel index.
 func (o *Orchestrator) SetChannelByIndex(ctx context.Context, radioID string, channelIndex int, radioManager RadioManager) error {
+	_, err := o.setChannelByIndex(ctx, radioID, channelIndex, radioManager, false)
+	return err
+}
+
+// DryRunSetChannelByIndex runs SetChannelByIndex's validation and index
+// resolution without calling the adapter, returning the resolved frequency.
+func (o *Orchestrator) DryRunSetChannelByIndex(ctx context.Context, radioID string, channelIndex int, radioManager RadioManager) (float64, error) {
+	return o.setChannelByIndex(ctx, radioID, channelIndex, radioManager, true)
+}
+
+// setChannelByIndex resolves channelIndex and applies the frequency, returning
+// it. With dryRun it returns after resolution, auditing DRY_RUN instead of
+// actuating.
+func (o *Orchestrator) setChannelByIndex(ctx context.Context, radioID string, channelIndex int, radioManager RadioManager, dryRun bool) (float64, error) {
 	start := time.Now()
 
+	// Enforce the per-role command allowlist
+	if err := o.authorize(ctx, "setChannel", radioID, start); err != nil {
+		return 0, err
+	}
+
+	// Limit concurrent commands per authenticated subject
+	release, err := o.acquireSubject(ctx, "setChannel", radioID, start)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
 	// Ensure radio exists via radio manager
 	if o.radioManager == nil {
 		o.logAudit(ctx, "setChannel", radioID, "UNAVAILABLE", time.Since(start))
-		return adapter.ErrUnavailable
+		return 0, adapter.ErrUnavailable
 	}
-	if _, err := o.radioManager.GetRadio(radioID); err != nil {
+	commanded, err := o.radioManager.GetRadio(radioID)
+	if err != nil {
 		o.logAudit(ctx, "setChannel", radioID, "NOT_FOUND", time.Since(start))
-		return ErrNotFound
+		return 0, ErrNotFound
+	}
+
+	// Only approved radio models may be commanded
+	if err := o.authorizeModel(ctx, "setChannel", commanded, start); err != nil {
+		return 0, err
+	}
+
+	// Radios without the capability reject the command before the adapter
+	if err := o.checkSupported(ctx, "setChannel", commanded, start); err != nil {
+		return 0, err
 	}
 
 	// Validate channel index bounds (1-based)
 	if channelIndex < 1 {
 		o.logAudit(ctx, "setChannel", radioID, "INVALID_RANGE", time.Since(start))
-		return adapter.ErrInvalidRange
+		return 0, adapter.ErrInvalidRange
 	}
 
 	// Check if adapter is available
-	if o.activeAdapter == nil {
+	radioAdapter := o.adapterFor(radioID)
+	if radioAdapter == nil {
 		o.logAudit(ctx, "setChannel", radioID, "UNAVAILABLE", time.Since(start))
-		return adapter.ErrUnavailable
+		return 0, adapter.ErrUnavailable
+	}
+
+	// Never resolve an index against a channel map past its max age
+	if err := o.checkCapabilitiesAge(ctx, "setChannel", radioID, radioManager, start); err != nil {
+		return 0, err
 	}
 
 	// Resolve channel index to frequency via radio manager
 	frequencyMhz, err := o.resolveChannelIndex(ctx, radioID, channelIndex, radioManager)
 	if err != nil {
-		o.logAudit(ctx, "setChannel", radioID, "INVALID_RANGE", time.Since(start))
-		return err
+		outcome := "INVALID_RANGE"
+		if errors.Is(err, ErrNoChannels) {
+			outcome = "NO_CHANNELS"
+		}
+		o.logAudit(ctx, "setChannel", radioID, outcome, time.Since(start))
+		return 0, err
 	}
 
 	// Validate resolved frequency range
-	if err := o.validateFrequencyRange(frequencyMhz); err != nil {
+	if _, err := o.validateFrequencyRange(frequencyMhz); err != nil {
 		o.logAudit(ctx, "setChannel", radioID, "INVALID_RANGE", time.Since(start))
-		return err
+		return 0, err
 	}
 
-	// Execute command with timeout
-	timeout := o.config.CommandTimeoutSetChannel
-	ctx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
+	// Dry run stops after index resolution, before touching the radio
+	if dryRun {
+		o.logAudit(ctx, "setChannel", radioID, "DRY_RUN", time.Since(start))
+		return frequencyMhz, nil
+	}
 
-	err = o.activeAdapter.SetFrequency(ctx, frequencyMhz)
+	// Commands to one radio run one at a time, in the order they queue
+	unlock, err := o.lockRadio(ctx, "setChannel", radioID, start)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	// Skip the adapter when the radio is already on the target frequency
+	if o.skipNoopFrequency(ctx, radioID, frequencyMhz, start) {
+		return frequencyMhz, nil
+	}
+
+	// Skip the adapter when the same frequency was just applied
+	if o.skipDuplicateFrequency(ctx, radioID, frequencyMhz, start) {
+		return frequencyMhz, nil
+	}
+
+	// Hold or reject commands to a recovering radio as configured
+	if err := o.awaitRecovery(ctx, "setChannel", radioID, start); err != nil {
+		return 0, err
+	}
+
+	// Fail fast while the radio's circuit breaker is open
+	if err := o.breaker.Allow(radioID); err != nil {
+		o.logAudit(ctx, "setChannel", radioID, "UNAVAILABLE", time.Since(start))
+		return 0, err
+	}
+
+	// Protect the hardware from rapid channel hopping; a throttled command
+	// never reaches the adapter, so it frees any half-open probe slot
+	if err := o.allowFrequencyChange(ctx, "setChannel", radioID, start); err != nil {
+		o.breaker.Release(radioID)
+		return 0, err
+	}
+
+	// Execute command with timeout; cancellable via CancelCommand
+	ctx, cmd, finish := o.startCommand(ctx, radioID, "setChannel", o.commandTimeout(radioID, "setChannel"))
+	defer finish()
+
+	err = radioAdapter.SetFrequency(ctx, frequencyMhz)
 	latency := time.Since(start)
 
 	if err != nil {
+		// The radio may or may not have applied it
+		o.applied.forget(radioID)
+		o.states.forget(radioID)
+
+		if cmd.wasCanceled() {
+			return 0, o.commandCanceled(ctx, "setChannel", radioID, latency)
+		}
+
 		// Map adapter error to normalized code
 		normalizedErr := adapter.NormalizeVendorError(err, nil)
+		o.breaker.Record(radioID, normalizedErr)
 		o.logAudit(ctx, "setChannel", radioID, "ERROR", latency)
 
 		// Publish fault event
 		o.publishFaultEvent(radioID, normalizedErr, "Failed to set channel")
 
-		return normalizedErr
+		return 0, normalizedErr
 	}
 
+	o.breaker.Record(radioID, nil)
+	o.states.recordFrequency(radioID, frequencyMhz, o.radioEpoch(radioID), time.Now())
+	o.applied.recordFrequency(radioID, frequencyMhz, time.Now())
+
 	// Log successful action
-	o.logAudit(ctx, "setChannel", radioID, "SUCCESS", latency)
+	o.logAudit(audit.WithParams(ctx, map[string]interface{}{audit.ParamFrequencyMhz: frequencyMhz}), "setChannel", radioID, "SUCCESS", latency)
 
 	// Publish channel changed event with resolved frequency and channel index
-	o.publishChannelChangedEvent(radioID, frequencyMhz, channelIndex)
+	o.publishChannelChangedEvent(radioID, frequencyMhz, &channelIndex)
 
-	return nil
+	return frequencyMhz, nil
 }
 
 // SelectRadio selects the active radio for subsequent operations.
 func (o *Orchestrator) SelectRadio(ctx context.Context, radioID string) error {
 	start := time.Now()
 
+	// Enforce the per-role command allowlist
+	if err := o.authorize(ctx, "selectRadio", radioID, start); err != nil {
+		return err
+	}
+
+	// Limit concurrent commands per authenticated subject
+	release, err := o.acquireSubject(ctx, "selectRadio", radioID, start)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	// Validate radio ID
 	if radioID == "" {
 		o.logAudit(ctx, "selectRadio", radioID, "BAD_REQUEST", time.Since(start))
@@ -252,11 +616,17 @@ func (o *Orchestrator) SelectRadio(ctx context.Context, radioID string) error {
 		o.logAudit(ctx, "selectRadio", radioID, "UNAVAILABLE", time.Since(start))
 		return adapter.ErrUnavailable
 	}
-	if _, err := o.radioManager.GetRadio(radioID); err != nil {
+	commanded, err := o.radioManager.GetRadio(radioID)
+	if err != nil {
 		o.logAudit(ctx, "selectRadio", radioID, "NOT_FOUND", time.Since(start))
 		return ErrNotFound
 	}
 
+	// Only approved radio models may be commanded
+	if err := o.authorizeModel(ctx, "selectRadio", commanded, start); err != nil {
+		return err
+	}
+
 	// Select the active radio via RadioManager per Architecture §5
 	if err := o.radioManager.SetActive(radioID); err != nil {
 		o.logAudit(ctx, "selectRadio", radioID, "NOT_FOUND", time.Since(start))
@@ -264,23 +634,46 @@ func (o *Orchestrator) SelectRadio(ctx context.Context, radioID string) error {
 	}
 
 	// Check if adapter is available
-	if o.activeAdapter == nil {
+	radioAdapter := o.adapterFor(radioID)
+	if radioAdapter == nil {
 		o.logAudit(ctx, "selectRadio", radioID, "UNAVAILABLE", time.Since(start))
 		return adapter.ErrUnavailable
 	}
 
-	// Execute command with timeout
-	timeout := o.config.CommandTimeoutSelectRadio
-	ctx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
+	// Commands to one radio run one at a time, in the order they queue
+	unlock, err := o.lockRadio(ctx, "selectRadio", radioID, start)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Hold or reject commands to a recovering radio as configured
+	if err := o.awaitRecovery(ctx, "selectRadio", radioID, start); err != nil {
+		return err
+	}
+
+	// Fail fast while the radio's circuit breaker is open
+	if err := o.breaker.Allow(radioID); err != nil {
+		o.logAudit(ctx, "selectRadio", radioID, "UNAVAILABLE", time.Since(start))
+		return err
+	}
+
+	// Execute command with timeout; cancellable via CancelCommand
+	ctx, cmd, finish := o.startCommand(ctx, radioID, "selectRadio", o.commandTimeout(radioID, "selectRadio"))
+	defer finish()
 
 	// For now, just validate the adapter is responsive
-	_, err := o.activeAdapter.GetState(ctx)
+	_, err = radioAdapter.GetState(ctx)
 	latency := time.Since(start)
 
 	if err != nil {
+		if cmd.wasCanceled() {
+			return o.commandCanceled(ctx, "selectRadio", radioID, latency)
+		}
+
 		// Map adapter error to normalized code
 		normalizedErr := adapter.NormalizeVendorError(err, nil)
+		o.breaker.Record(radioID, normalizedErr)
 		o.logAudit(ctx, "selectRadio", radioID, "ERROR", latency)
 
 		// Publish fault event
@@ -289,17 +682,83 @@ func (o *Orchestrator) SelectRadio(ctx context.Context, radioID string) error {
 		return normalizedErr
 	}
 
+	o.breaker.Record(radioID, nil)
+
 	// Log successful action
 	o.logAudit(ctx, "selectRadio", radioID, "SUCCESS", latency)
 
+	// Cache the radio's frequency profiles for later channel validation
+	o.cacheFrequencyProfiles(ctx, radioID, radioAdapter)
+
 	// Publish state event to confirm selection
 	o.publishStateEvent(radioID)
 
 	return nil
 }
 
-// GetState retrieves the current state of the active radio.
+// GetState retrieves the current state of the radio. Results are served from
+// cache for the config StateCacheTTL; successful sets invalidate the cache.
 func (o *Orchestrator) GetState(ctx context.Context, radioID string) (*adapter.RadioState, error) {
+	return o.getState(ctx, radioID, false)
+}
+
+// RefreshState is GetState bypassing the cache; the fresh result is cached.
+func (o *Orchestrator) RefreshState(ctx context.Context, radioID string) (*adapter.RadioState, error) {
+	return o.getState(ctx, radioID, true)
+}
+
+// GetActualPower reads the radio's measured transmit power in dBm, as
+// opposed to the setpoint GetState reports. It is never cached. Its outcome
+// is recorded with the circuit breaker like any adapter call, which frees a
+// half-open probe slot; a radio that cannot measure output power does not
+// count as down, as only unavailability and timeouts do.
+func (o *Orchestrator) GetActualPower(ctx context.Context, radioID string) (float64, error) {
+	start := time.Now()
+
+	// Ensure radio exists via radio manager
+	if o.radioManager == nil {
+		o.logAudit(ctx, "getActualPower", radioID, "UNAVAILABLE", time.Since(start))
+		return 0, adapter.ErrUnavailable
+	}
+	if _, err := o.radioManager.GetRadio(radioID); err != nil {
+		o.logAudit(ctx, "getActualPower", radioID, "NOT_FOUND", time.Since(start))
+		return 0, ErrNotFound
+	}
+
+	// Check if adapter is available
+	radioAdapter := o.adapterFor(radioID)
+	if radioAdapter == nil {
+		o.logAudit(ctx, "getActualPower", radioID, "UNAVAILABLE", time.Since(start))
+		return 0, adapter.ErrUnavailable
+	}
+
+	// Fail fast while the radio's circuit breaker is open
+	if err := o.breaker.Allow(radioID); err != nil {
+		o.logAudit(ctx, "getActualPower", radioID, "UNAVAILABLE", time.Since(start))
+		return 0, err
+	}
+
+	// Execute read with timeout
+	ctx, cancel := context.WithTimeout(ctx, o.commandTimeout(radioID, "getState"))
+	defer cancel()
+
+	dBm, err := radioAdapter.ReadPowerActual(ctx)
+	latency := time.Since(start)
+	if err != nil {
+		o.states.forget(radioID)
+		normalizedErr := adapter.NormalizeVendorError(err, nil)
+		o.breaker.Record(radioID, normalizedErr)
+		o.logAudit(ctx, "getActualPower", radioID, "ERROR", latency)
+		return 0, normalizedErr
+	}
+	o.breaker.Record(radioID, nil)
+
+	o.logAudit(ctx, "getActualPower", radioID, "SUCCESS", latency)
+	return dBm, nil
+}
+
+// getState implements GetState; forceRefresh skips cached results.
+func (o *Orchestrator) getState(ctx context.Context, radioID string, forceRefresh bool) (*adapter.RadioState, error) {
 	start := time.Now()
 
 	// Ensure radio exists via radio manager
@@ -313,22 +772,41 @@ func (o *Orchestrator) GetState(ctx context.Context, radioID string) (*adapter.R
 	}
 
 	// Check if adapter is available
-	if o.activeAdapter == nil {
+	radioAdapter := o.adapterFor(radioID)
+	if radioAdapter == nil {
 		o.logAudit(ctx, "getState", radioID, "UNAVAILABLE", time.Since(start))
 		return nil, adapter.ErrUnavailable
 	}
 
+	// Serve polling reads from cache
+	ttl := o.timing().StateCacheTTL
+	cached, generation := o.states.get(radioID, start)
+	if cached != nil && !forceRefresh && ttl > 0 {
+		o.logAudit(ctx, "getState", radioID, "SUCCESS", time.Since(start))
+		return cached, nil
+	}
+
+	// Fail fast while the radio's circuit breaker is open
+	if err := o.breaker.Allow(radioID); err != nil {
+		o.logAudit(ctx, "getState", radioID, "UNAVAILABLE", time.Since(start))
+		return nil, err
+	}
+
 	// Execute command with timeout
-	timeout := o.config.CommandTimeoutGetState
+	timeout := o.commandTimeout(radioID, "getState")
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	state, err := o.activeAdapter.GetState(ctx)
+	state, err := radioAdapter.GetState(ctx)
 	latency := time.Since(start)
 
 	if err != nil {
+		// The radio's state is in doubt until it is read again
+		o.states.forget(radioID)
+
 		// Map adapter error to normalized code
 		normalizedErr := adapter.NormalizeVendorError(err, nil)
+		o.breaker.Record(radioID, normalizedErr)
 		o.logAudit(ctx, "getState", radioID, "ERROR", latency)
 
 		// Publish fault event
@@ -337,42 +815,354 @@ func (o *Orchestrator) GetState(ctx context.Context, radioID string) (*adapter.R
 		return nil, normalizedErr
 	}
 
+	o.breaker.Record(radioID, nil)
+	if ttl > 0 && state != nil {
+		o.states.put(radioID, state, generation, o.radioEpoch(radioID), start, ttl)
+	}
+
 	// Log successful action
 	o.logAudit(ctx, "getState", radioID, "SUCCESS", latency)
 
 	return state, nil
 }
 
+// ChannelState is the radio's current frequency and, when known, its channel index.
+type ChannelState struct {
+	FrequencyMhz float64 `json:"frequencyMhz"`
+	ChannelIndex *int    `json:"channelIndex"`
+}
+
+// channelMatchToleranceMhz bounds how far a frequency may be from a channel's
+// center and still reverse-map to that channel index.
+const channelMatchToleranceMhz = 0.5
+
+// GetChannel returns the radio's current frequency and channel index. Adapters
+// implementing adapter.ChannelIndexReader report the radio's own active index;
+// otherwise the frequency is reverse-mapped through the channel plan, and the
+// index is nil when the frequency is not in the derived channel set.
+func (o *Orchestrator) GetChannel(ctx context.Context, radioID string) (*ChannelState, error) {
+	return o.getChannel(ctx, radioID, false)
+}
+
+// RefreshChannel is GetChannel reading state without the cache.
+func (o *Orchestrator) RefreshChannel(ctx context.Context, radioID string) (*ChannelState, error) {
+	return o.getChannel(ctx, radioID, true)
+}
+
+// getChannel implements GetChannel; forceRefresh skips cached state.
+func (o *Orchestrator) getChannel(ctx context.Context, radioID string, forceRefresh bool) (*ChannelState, error) {
+	state, err := o.getState(ctx, radioID, forceRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ChannelState{FrequencyMhz: state.FrequencyMhz}
+
+	if reader, ok := o.adapterFor(radioID).(adapter.ChannelIndexReader); ok {
+		indexCtx, cancel := context.WithTimeout(ctx, o.commandTimeout(radioID, "getState"))
+		index, err := reader.GetChannelIndex(indexCtx)
+		cancel()
+		if err == nil && index >= 1 {
+			result.ChannelIndex = &index
+			return result, nil
+		}
+	}
+
+	result.ChannelIndex = o.channelIndexFor(radioID, state.FrequencyMhz)
+	return result, nil
+}
+
+// channelIndexFor reverse-maps frequencyMhz to a channel index through the
+// radio's channel plan, then the Silvus band plan for its model. It returns
+// nil when the frequency is on no known channel.
+func (o *Orchestrator) channelIndexFor(radioID string, frequencyMhz float64) *int {
+	if o.radioManager == nil {
+		return nil
+	}
+	r, err := o.radioManager.GetRadio(radioID)
+	if err != nil {
+		return nil
+	}
+
+	if r.Capabilities != nil {
+		for _, ch := range r.Capabilities.Channels {
+			if math.Abs(ch.FrequencyMhz-frequencyMhz) <= channelMatchToleranceMhz {
+				index := ch.Index
+				return &index
+			}
+		}
+	}
+	if channel, ok := o.timing().SilvusBandPlan.FindSilvusChannel(r.Model, frequencyMhz); ok {
+		index := channel.ChannelIndex
+		return &index
+	}
+	return nil
+}
+
+// Capabilities describes the channels and frequency profiles a radio supports.
+type Capabilities struct {
+	RadioID           string                     `json:"radioId"`
+	Channels          []adapter.Channel          `json:"channels"`
+	FrequencyProfiles []adapter.FrequencyProfile `json:"frequencyProfiles"`
+}
+
+// GetCapabilities returns the radio's channel plan from the radio manager and
+// the active adapter's supported frequency profiles. Adapters that cannot
+// report profiles yield an empty profile list rather than an error.
+func (o *Orchestrator) GetCapabilities(ctx context.Context, radioID string) (*Capabilities, error) {
+	if o.radioManager == nil {
+		return nil, adapter.ErrUnavailable
+	}
+	r, err := o.radioManager.GetRadio(radioID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	caps := &Capabilities{
+		RadioID:           radioID,
+		Channels:          []adapter.Channel{},
+		FrequencyProfiles: []adapter.FrequencyProfile{},
+	}
+	if r.Capabilities != nil && r.Capabilities.Channels != nil {
+		caps.Channels = o.labelChannels(r.Model, r.Capabilities.Channels)
+	}
+	if profiles := o.frequencyProfiles(ctx, radioID); profiles != nil {
+		caps.FrequencyProfiles = profiles
+	}
+
+	return caps, nil
+}
+
+// labelChannels copies channels with the label and usage configured for the
+// same frequency in the band plan, leaving the radio manager's plan untouched.
+func (o *Orchestrator) labelChannels(model string, channels []adapter.Channel) []adapter.Channel {
+	bandPlan := o.timing().SilvusBandPlan
+	if bandPlan == nil {
+		return channels
+	}
+
+	labeled := make([]adapter.Channel, len(channels))
+	for i, channel := range channels {
+		if planned, ok := bandPlan.FindSilvusChannel(model, channel.FrequencyMhz); ok {
+			channel.Label = planned.Label
+			channel.Usage = planned.Usage
+		}
+		labeled[i] = channel
+	}
+	return labeled
+}
+
+// CircuitBreakerStates returns the circuit breaker state for each tracked radio.
+func (o *Orchestrator) CircuitBreakerStates() map[string]string {
+	return o.breaker.States()
+}
+
+// adapterFor returns the adapter for radioID, preferring the radio manager's
+// per-radio adapter over the deprecated active adapter.
+func (o *Orchestrator) adapterFor(radioID string) adapter.IRadioAdapter {
+	if resolver, ok := o.radioManager.(AdapterResolver); ok {
+		if radioAdapter, err := resolver.GetAdapter(radioID); err == nil && radioAdapter != nil {
+			return radioAdapter
+		}
+	}
+	return o.activeAdapter
+}
+
+// commandTimeout returns the deadline for action on radioID: its model's
+// CommandTimeoutOverrides entry, else the CB-TIMING default.
+func (o *Orchestrator) commandTimeout(radioID, action string) time.Duration {
+	model := ""
+	if o.radioManager != nil {
+		if r, err := o.radioManager.GetRadio(radioID); err == nil && r != nil {
+			model = r.Model
+		}
+	}
+	return o.timing().CommandTimeout(model, action)
+}
+
+// timing returns the orchestrator's timing config, falling back to the
+// CB-TIMING baseline (with a one-time warning) when none was provided.
+func (o *Orchestrator) timing() *config.TimingConfig {
+	if cfg := o.config.Load(); cfg != nil {
+		return cfg
+	}
+	o.fallbackOnce.Do(func() {
+		o.fallbackConfig = config.LoadCBTimingBaseline()
+		o.logger.Warn(context.Background(), "Orchestrator has no timing config; using CB-TIMING baseline timeouts", nil)
+	})
+	return o.fallbackConfig
+}
+
+// applyPowerPolicy returns the power to apply for a requested dBm. Out-of-range
+// values fail with ErrInvalidRange, or are clamped to the nearest limit when
+// the PowerOutOfRangePolicy is clamp. detail describes the check for
+// ExplainCommand.
+func (o *Orchestrator) applyPowerPolicy(dBm float64) (applied float64, detail string, err error) {
+	policy := o.timing().PowerOutOfRangePolicy
+	if policy == "" {
+		policy = config.PowerPolicyReject
+	}
+	detail = fmt.Sprintf("%v-%v dBm, out-of-range policy %s", minPowerDbm, maxPowerDbm, policy)
+
+	err = o.validatePowerRange(dBm)
+	if err == nil || policy != config.PowerPolicyClamp {
+		return dBm, detail, err
+	}
+	applied = math.Max(minPowerDbm, math.Min(maxPowerDbm, dBm))
+	return applied, detail + fmt.Sprintf("; %v dBm clamped to %v dBm", dBm, applied), nil
+}
+
 // validatePowerRange validates the power range.
 func (o *Orchestrator) validatePowerRange(dBm float64) error {
-	if dBm < 0 || dBm > 39 {
+	if dBm < minPowerDbm || dBm > maxPowerDbm {
 		return adapter.ErrInvalidRange
 	}
 	return nil
 }
 
-// validateFrequencyRange validates the frequency range.
-func (o *Orchestrator) validateFrequencyRange(frequencyMhz float64) error {
-	// Basic frequency validation - more sophisticated validation will be added later
-	// with derived channel maps
-	if frequencyMhz <= 0 {
-		return adapter.ErrInvalidRange
+// validateFrequencyRange validates the frequency range. detail describes
+// the check for ExplainCommand.
+func (o *Orchestrator) validateFrequencyRange(frequencyMhz float64) (detail string, err error) {
+	detail = fmt.Sprintf("%v-%v MHz", minFrequencyMhz, maxFrequencyMhz)
+
+	// Check against reasonable frequency ranges; the radio's own bands are
+	// checked by validateFrequencySupported
+	if frequencyMhz < minFrequencyMhz || frequencyMhz > maxFrequencyMhz {
+		return detail, adapter.ErrInvalidRange
 	}
+	return detail, nil
+}
 
-	// Check against reasonable frequency ranges (will be enhanced with channel maps)
-	if frequencyMhz < 100 || frequencyMhz > 6000 {
-		return adapter.ErrInvalidRange
+// cacheFrequencyProfiles queries a selected radio's supported frequency
+// profiles once and caches them on its radio entry. An adapter that cannot
+// report profiles caches an empty set, so profile validation is skipped for
+// the radio; a busy or unavailable one caches nothing and is queried again
+// when the profiles are next needed.
+func (o *Orchestrator) cacheFrequencyProfiles(ctx context.Context, radioID string, radioAdapter adapter.IRadioAdapter) {
+	cache, ok := o.radioManager.(FrequencyProfileCache)
+	if !ok {
+		return
 	}
 
-	return nil
+	profiles, err := radioAdapter.SupportedFrequencyProfiles(ctx)
+	if err != nil {
+		normalizedErr := adapter.NormalizeVendorError(err, nil)
+		if errors.Is(normalizedErr, adapter.ErrBusy) || errors.Is(normalizedErr, adapter.ErrUnavailable) {
+			return
+		}
+		profiles = []adapter.FrequencyProfile{}
+	}
+	_ = cache.CacheFrequencyProfiles(radioID, profiles)
 }
 
-// publishPowerChangedEvent publishes a power changed event.
-func (o *Orchestrator) publishPowerChangedEvent(radioID string, powerDbm float64) {
-	if o.telemetryHub == nil {
-		return // Skip if no telemetry hub
+// frequencyProfiles returns the radio's supported frequency profiles, from the
+// cache SelectRadio fills when present, else from its adapter. Adapter queries
+// are best-effort: errors yield none, and the query is skipped while the
+// breaker is not closed so a failing adapter is not probed outside it.
+func (o *Orchestrator) frequencyProfiles(ctx context.Context, radioID string) []adapter.FrequencyProfile {
+	if profiles, cached := o.cachedFrequencyProfiles(radioID); cached {
+		return profiles
 	}
 
+	radioAdapter := o.adapterFor(radioID)
+	if radioAdapter == nil || o.breaker.State(radioID) != BreakerClosed {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.commandTimeout(radioID, "getState"))
+	defer cancel()
+
+	profiles, err := radioAdapter.SupportedFrequencyProfiles(ctx)
+	if err != nil {
+		return nil
+	}
+	return profiles
+}
+
+// cachedFrequencyProfiles returns the profiles SelectRadio cached for the
+// radio, without querying its adapter.
+func (o *Orchestrator) cachedFrequencyProfiles(radioID string) ([]adapter.FrequencyProfile, bool) {
+	cache, ok := o.radioManager.(FrequencyProfileCache)
+	if !ok {
+		return nil, false
+	}
+	return cache.CachedFrequencyProfiles(radioID)
+}
+
+// channelEdgeToleranceMhz widens a channel plan's span by half a 20 MHz channel
+// so frequencies near the outermost channels remain in band.
+const channelEdgeToleranceMhz = 10.0
+
+// channelPlanBand returns the span of the radio's advertised channels widened
+// by channelEdgeToleranceMhz; ok is false when it advertises none.
+func (o *Orchestrator) channelPlanBand(radioID string) (low, high float64, ok bool) {
+	if o.radioManager == nil {
+		return 0, 0, false
+	}
+	r, err := o.radioManager.GetRadio(radioID)
+	if err != nil || r.Capabilities == nil || len(r.Capabilities.Channels) == 0 {
+		return 0, 0, false
+	}
+	low, high = r.Capabilities.Channels[0].FrequencyMhz, r.Capabilities.Channels[0].FrequencyMhz
+	for _, ch := range r.Capabilities.Channels[1:] {
+		low = math.Min(low, ch.FrequencyMhz)
+		high = math.Max(high, ch.FrequencyMhz)
+	}
+	return low - channelEdgeToleranceMhz, high + channelEdgeToleranceMhz, true
+}
+
+// validateFrequencySupported checks the frequency against the radio's advertised
+// channels and the given frequency profiles. A frequency is in band if it falls
+// within the channel plan's span or any profile's range. Radios that advertise
+// neither are only subject to validateFrequencyRange; detail, describing the
+// bands for ExplainCommand, is then empty.
+func (o *Orchestrator) validateFrequencySupported(radioID string, frequencyMhz float64, profiles []adapter.FrequencyProfile) (detail string, err error) {
+	type band struct{ low, high float64 }
+	var bands []band
+
+	if low, high, ok := o.channelPlanBand(radioID); ok {
+		bands = append(bands, band{low, high})
+	}
+
+	for _, p := range profiles {
+		if len(p.Frequencies) == 0 {
+			continue
+		}
+		low, high := p.Frequencies[0], p.Frequencies[0]
+		for _, f := range p.Frequencies[1:] {
+			low = math.Min(low, f)
+			high = math.Max(high, f)
+		}
+		halfWidth := math.Max(p.Bandwidth, 0) / 2
+		bands = append(bands, band{low - halfWidth, high + halfWidth})
+	}
+
+	if len(bands) == 0 {
+		return "", nil
+	}
+	spans := make([]string, len(bands))
+	for i, b := range bands {
+		spans[i] = fmt.Sprintf("%v-%v MHz", b.low, b.high)
+	}
+	detail = strings.Join(spans, ", ")
+	for _, b := range bands {
+		if frequencyMhz >= b.low && frequencyMhz <= b.high {
+			return detail, nil
+		}
+	}
+
+	return detail, &adapter.VendorError{
+		Code:     adapter.ErrInvalidRange,
+		Original: fmt.Errorf("frequency %.3f MHz not supported by radio %s", frequencyMhz, radioID),
+		Details: map[string]interface{}{
+			"radioID":      radioID,
+			"frequencyMhz": frequencyMhz,
+		},
+	}
+}
+
+// publishPowerChangedEvent publishes a power changed event.
+func (o *Orchestrator) publishPowerChangedEvent(radioID string, powerDbm float64) {
 	event := telemetry.Event{
 		Type: "powerChanged",
 		Data: map[string]interface{}{
@@ -382,16 +1172,18 @@ func (o *Orchestrator) publishPowerChangedEvent(radioID string, powerDbm float64
 		},
 	}
 
-	if err := o.telemetryHub.PublishRadio(radioID, event); err != nil {
+	if err := o.publisher().PublishRadio(radioID, event); err != nil {
 		// Publish fault event for telemetry failure
 		o.publishFaultEvent(radioID, err, "Failed to publish power changed event")
 	}
 }
 
-// publishChannelChangedEvent publishes a channel changed event.
-func (o *Orchestrator) publishChannelChangedEvent(radioID string, frequencyMhz float64, channelIndex int) {
-	if o.telemetryHub == nil {
-		return // Skip if no telemetry hub
+// publishChannelChangedEvent publishes a channel changed event. A nil
+// channelIndex, for a frequency on no known channel, is sent as null.
+func (o *Orchestrator) publishChannelChangedEvent(radioID string, frequencyMhz float64, channelIndex *int) {
+	var index interface{}
+	if channelIndex != nil {
+		index = *channelIndex
 	}
 
 	event := telemetry.Event{
@@ -399,12 +1191,12 @@ func (o *Orchestrator) publishChannelChangedEvent(radioID string, frequencyMhz f
 		Data: map[string]interface{}{
 			"radioId":      radioID,
 			"frequencyMhz": frequencyMhz,
-			"channelIndex": channelIndex,
+			"channelIndex": index,
 			"ts":           time.Now().UTC().Format(time.RFC3339),
 		},
 	}
 
-	if err := o.telemetryHub.PublishRadio(radioID, event); err != nil {
+	if err := o.publisher().PublishRadio(radioID, event); err != nil {
 		// Publish fault event for telemetry failure
 		o.publishFaultEvent(radioID, err, "Failed to publish channel changed event")
 	}
@@ -412,10 +1204,6 @@ func (o *Orchestrator) publishChannelChangedEvent(radioID string, frequencyMhz f
 
 // publishStateEvent publishes a state event.
 func (o *Orchestrator) publishStateEvent(radioID string) {
-	if o.telemetryHub == nil {
-		return // Skip if no telemetry hub
-	}
-
 	event := telemetry.Event{
 		Type: "state",
 		Data: map[string]interface{}{
@@ -425,7 +1213,7 @@ func (o *Orchestrator) publishStateEvent(radioID string) {
 		},
 	}
 
-	if err := o.telemetryHub.PublishRadio(radioID, event); err != nil {
+	if err := o.publisher().PublishRadio(radioID, event); err != nil {
 		// Publish fault event for telemetry failure
 		o.publishFaultEvent(radioID, err, "Failed to publish state event")
 	}
@@ -433,10 +1221,6 @@ func (o *Orchestrator) publishStateEvent(radioID string) {
 
 // publishFaultEvent publishes a fault event.
 func (o *Orchestrator) publishFaultEvent(radioID string, err error, message string) {
-	if o.telemetryHub == nil {
-		return // Skip if no telemetry hub
-	}
-
 	event := telemetry.Event{
 		Type: "fault",
 		Data: map[string]interface{}{
@@ -447,17 +1231,46 @@ func (o *Orchestrator) publishFaultEvent(radioID string, err error, message stri
 		},
 	}
 
-	if err := o.telemetryHub.PublishRadio(radioID, event); err != nil {
+	if err := o.publisher().PublishRadio(radioID, event); err != nil {
 		// Silently log telemetry failure to avoid infinite recursion
 		// This is a fault event itself, so we don't publish another fault
 	}
 }
 
-// logAudit logs an audit record for a command action.
+// logAudit logs an audit record and a structured log line for a command action.
+// Both carry the request's correlation ID from ctx.
 func (o *Orchestrator) logAudit(ctx context.Context, action, radioID, result string, latency time.Duration) {
 	if o.auditLogger != nil {
 		o.auditLogger.LogAction(ctx, action, radioID, result, latency)
 	}
+	if o.metrics != nil {
+		o.metrics.ObserveCommand(action, result, latency)
+	}
+
+	fields := logging.Fields{
+		"radioId":   radioID,
+		"action":    action,
+		"result":    result,
+		"latencyMs": latency.Milliseconds(),
+	}
+	if result == "SUCCESS" {
+		o.logger.Info(ctx, "Command completed", fields)
+	} else {
+		o.logger.Warn(ctx, "Command failed", fields)
+	}
+}
+
+// SetMetrics records every command outcome and latency in registry.
+func (o *Orchestrator) SetMetrics(registry *metrics.Registry) {
+	o.metrics = registry
+}
+
+// SetConfig replaces the configuration used by commands, for example after
+// a config reload. It is safe to call while commands are in flight; each
+// config read sees either the old or the new configuration. The circuit
+// breaker keeps the thresholds it was created with.
+func (o *Orchestrator) SetConfig(timingConfig *config.TimingConfig) {
+	o.config.Store(timingConfig)
 }
 
 // SetAuditLogger sets the audit logger.
@@ -465,6 +1278,11 @@ func (o *Orchestrator) SetAuditLogger(logger AuditLogger) {
 	o.auditLogger = logger
 }
 
+// SetLogger sets the structured logger for command outcomes.
+func (o *Orchestrator) SetLogger(logger *logging.Logger) {
+	o.logger = logger
+}
+
 // SetRadioManager sets the radio manager for channel index resolution.
 func (o *Orchestrator) SetRadioManager(radioManager RadioManager) {
 	o.radioManager = radioManager
@@ -473,11 +1291,11 @@ func (o *Orchestrator) SetRadioManager(radioManager RadioManager) {
 // resolveChannelIndex resolves a channel index to frequency via radio manager or Silvus band plan.
 func (o *Orchestrator) resolveChannelIndex(ctx context.Context, radioID string, channelIndex int, radioManager RadioManager) (float64, error) {
 	// First, try to resolve using Silvus band plan if available
-	if o.config != nil && o.config.SilvusBandPlan != nil {
+	if cfg := o.config.Load(); cfg != nil && cfg.SilvusBandPlan != nil {
 		// Try to get model and band from radio manager
 		model, band, err := o.getRadioModelAndBand(ctx, radioID, radioManager)
 		if err == nil {
-			frequency, err := o.config.SilvusBandPlan.GetSilvusChannelFrequency(model, band, channelIndex)
+			frequency, err := cfg.SilvusBandPlan.GetSilvusChannelFrequency(model, band, channelIndex)
 			if err == nil {
 				return frequency, nil
 			}
@@ -538,16 +1356,11 @@ func (o *Orchestrator) resolveChannelIndexFromRadioManager(ctx context.Context,
 		return 0, fmt.Errorf("radio %s not found: %w", radioID, err)
 	}
 
-	// Extract capabilities from radio
-	capabilities := radio.Capabilities
-	if capabilities == nil {
-		return 0, fmt.Errorf("radio %s has no capabilities", radioID)
-	}
-
-	channels := capabilities.Channels
-	if len(channels) == 0 {
-		return 0, fmt.Errorf("radio %s has no channels", radioID)
+	// Radios without a channel plan cannot resolve any index
+	if radio.Capabilities == nil || len(radio.Capabilities.Channels) == 0 {
+		return 0, fmt.Errorf("%w: radio %s reports no channels", ErrNoChannels, radioID)
 	}
+	channels := radio.Capabilities.Channels
 
 	// Find channel with matching index
 	for _, channel := range channels {
@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -270,6 +271,43 @@ func TestResolveChannelIndexWithMissingChannels(t *testing.T) {
 	}
 }
 
+// TestChannelLessRadio tests that a radio without channels rejects index
+// commands with NO_CHANNELS but still accepts frequency commands.
+func TestChannelLessRadio(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.radioManager.(*MockRadioManager).Radios["radio-01"].Capabilities.Channels = nil
+	auditLogger := &MockAuditLogger{}
+	orchestrator.SetAuditLogger(auditLogger)
+
+	var tuned float64
+	orchestrator.SetActiveAdapter(&MockAdapter{
+		SetFrequencyFunc: func(ctx context.Context, frequencyMhz float64) error {
+			tuned = frequencyMhz
+			return nil
+		},
+	})
+	ctx := context.Background()
+
+	err := orchestrator.SetChannelByIndex(ctx, "radio-01", 1, nil)
+	if !errors.Is(err, ErrNoChannels) {
+		t.Fatalf("Expected NO_CHANNELS for index command, got %v", err)
+	}
+	if got := auditLogger.Actions[len(auditLogger.Actions)-1].Result; got != "NO_CHANNELS" {
+		t.Errorf("Expected NO_CHANNELS audit outcome, got %q", got)
+	}
+
+	// Frequency commands fall back to the coarse range check
+	if err := orchestrator.SetChannel(ctx, "radio-01", 2412); err != nil {
+		t.Fatalf("Expected frequency command to succeed, got %v", err)
+	}
+	if tuned != 2412 {
+		t.Errorf("Expected adapter tuned to 2412 MHz, got %v", tuned)
+	}
+	if err := orchestrator.SetChannel(ctx, "radio-01", 1); !errors.Is(err, adapter.ErrInvalidRange) {
+		t.Errorf("Expected INVALID_RANGE outside the coarse range, got %v", err)
+	}
+}
+
 // TestResolveChannelIndexWithNilCapabilities tests resolveChannelIndex when radio has nil capabilities
 func TestResolveChannelIndexWithNilCapabilities(t *testing.T) {
 	cfg := config.LoadCBTimingBaseline()
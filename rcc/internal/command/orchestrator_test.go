@@ -1,6 +1,7 @@
 package command
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/radio-control/rcc/internal/adapter"
 	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/logging"
 	"github.com/radio-control/rcc/internal/radio"
 	"github.com/radio-control/rcc/internal/telemetry"
 )
@@ -50,6 +52,10 @@ func (m *MockAdapter) SupportedFrequencyProfiles(ctx context.Context) ([]adapter
 	return []adapter.FrequencyProfile{}, nil
 }
 
+func (m *MockAdapter) Ping(ctx context.Context) error {
+	return nil
+}
+
 // MockAuditLogger is a mock implementation of AuditLogger for testing.
 type MockAuditLogger struct {
 	Actions []AuditAction
@@ -75,9 +81,8 @@ func (m *MockAuditLogger) LogAction(ctx context.Context, action, radioID, result
 func setupTestOrchestrator(t *testing.T) *Orchestrator {
 	cfg := config.LoadCBTimingBaseline()
 	
-	orchestrator := &Orchestrator{
-		config: cfg,
-	}
+	orchestrator := &Orchestrator{}
+	orchestrator.SetConfig(cfg)
 
 	// Set up radio manager
 	mockRadioManager := &MockRadioManager{
@@ -103,11 +108,10 @@ func TestNewOrchestrator(t *testing.T) {
 	cfg := config.LoadCBTimingBaseline()
 
 	// Create orchestrator without telemetry hub to avoid hanging
-	orchestrator := &Orchestrator{
-		config: cfg,
-	}
+	orchestrator := &Orchestrator{}
+	orchestrator.SetConfig(cfg)
 
-	if orchestrator.config != cfg {
+	if orchestrator.timing() != cfg {
 		t.Error("Config not set correctly")
 	}
 }
@@ -115,9 +119,8 @@ func TestNewOrchestrator(t *testing.T) {
 func TestSetPower(t *testing.T) {
 	cfg := config.LoadCBTimingBaseline()
 
-	orchestrator := &Orchestrator{
-		config: cfg,
-	}
+	orchestrator := &Orchestrator{}
+	orchestrator.SetConfig(cfg)
 
 	// Test with no radio manager
 	err := orchestrator.SetPower(context.Background(), "radio-01", 30)
@@ -214,9 +217,10 @@ func TestSetChannelValidation(t *testing.T) {
 		{-1.0, false},
 		{0.0, false},
 		{50.0, false}, // Too low
-		{100.0, true},
+		{100.0, false}, // Outside radio's 2.4GHz channels
 		{2412.0, true},
-		{6000.0, true},
+		{2462.0, true},
+		{6000.0, false}, // Outside radio's 2.4GHz channels
 		{7000.0, false}, // Too high
 	}
 
@@ -404,9 +408,9 @@ func TestSetChannelByIndex(t *testing.T) {
 	}
 
 	orchestrator := &Orchestrator{
-		config:       cfg,
 		radioManager: mockRadioManager,
 	}
+	orchestrator.SetConfig(cfg)
 
 	// Test with no adapter
 	err := orchestrator.SetChannelByIndex(context.Background(), "radio-01", 1, mockRadioManager)
@@ -443,9 +447,9 @@ func TestSetChannelByIndexValidation(t *testing.T) {
 	}
 
 	orchestrator := &Orchestrator{
-		config:       cfg,
 		radioManager: mockRadioManager,
 	}
+	orchestrator.SetConfig(cfg)
 	mockAdapter := &MockAdapter{}
 	orchestrator.SetActiveAdapter(mockAdapter)
 
@@ -496,9 +500,9 @@ func TestSetChannelByIndexTableTests(t *testing.T) {
 	}
 
 	orchestrator := &Orchestrator{
-		config:       cfg,
 		radioManager: mockRadioManager,
 	}
+	orchestrator.SetConfig(cfg)
 	mockAdapter := &MockAdapter{}
 	orchestrator.SetActiveAdapter(mockAdapter)
 
@@ -551,7 +555,7 @@ func TestSetChannelFrequencyPassthrough(t *testing.T) {
 		{2412.0, true, "valid 2.4GHz frequency"},
 		{2417.0, true, "valid 2.4GHz frequency"},
 		{2422.0, true, "valid 2.4GHz frequency"},
-		{5000.0, true, "valid 5GHz frequency"},
+		{5000.0, false, "5GHz outside radio's 2.4GHz channels"},
 		{0.0, false, "zero frequency (invalid)"},
 		{-100.0, false, "negative frequency (invalid)"},
 		{50.0, false, "too low frequency"},
@@ -594,9 +598,9 @@ func TestResolveChannelIndex(t *testing.T) {
 	}
 
 	orchestrator := &Orchestrator{
-		config:       cfg,
 		radioManager: mockRadioManager,
 	}
+	orchestrator.SetConfig(cfg)
 
 	// Test successful resolution
 	freq, err := orchestrator.resolveChannelIndex(context.Background(), "radio-01", 1, mockRadioManager)
@@ -651,9 +655,9 @@ func TestSetChannelByIndexAdapterCalledWithResolvedFrequency(t *testing.T) {
 	}
 
 	orchestrator := &Orchestrator{
-		config:       cfg,
 		radioManager: mockRadioManager,
 	}
+	orchestrator.SetConfig(cfg)
 	orchestrator.SetActiveAdapter(mockAdapter)
 
 	// Test that adapter is called with resolved frequency
@@ -715,7 +719,7 @@ func TestNewOrchestratorWithRadioManager(t *testing.T) {
 		t.Error("TelemetryHub not set correctly")
 	}
 
-	if orchestrator.config != cfg {
+	if orchestrator.timing() != cfg {
 		t.Error("Config not set correctly")
 	}
 
@@ -1053,3 +1057,39 @@ func TestResolveChannelIndexInvalidChannel(t *testing.T) {
 		t.Error("Expected error for invalid channel index")
 	}
 }
+
+func TestNilConfigUsesBaselineTimeouts(t *testing.T) {
+	var logs bytes.Buffer
+	baseline := config.LoadCBTimingBaseline()
+
+	var deadline time.Duration
+	orchestrator := NewOrchestrator(nil, nil)
+	orchestrator.SetLogger(logging.New(&logs))
+	orchestrator.SetRadioManager(setupTestOrchestrator(t).radioManager)
+	orchestrator.SetActiveAdapter(&MockAdapter{
+		SetPowerFunc: func(ctx context.Context, dBm float64) error {
+			if d, ok := ctx.Deadline(); ok {
+				deadline = time.Until(d)
+			}
+			return nil
+		},
+	})
+
+	if err := orchestrator.SetPower(context.Background(), "radio-01", 20); err != nil {
+		t.Fatalf("SetPower with nil config failed: %v", err)
+	}
+	if deadline <= 0 || deadline > baseline.CommandTimeoutSetPower || deadline < baseline.CommandTimeoutSetPower-time.Second {
+		t.Errorf("Expected baseline setPower timeout %v, got deadline in %v", baseline.CommandTimeoutSetPower, deadline)
+	}
+	if !strings.Contains(logs.String(), "using CB-TIMING baseline timeouts") {
+		t.Errorf("Expected nil-config warning, got %q", logs.String())
+	}
+
+	// The warning is logged once, not per command
+	if err := orchestrator.SetPower(context.Background(), "radio-01", 21); err != nil {
+		t.Fatalf("Second SetPower failed: %v", err)
+	}
+	if n := strings.Count(logs.String(), "using CB-TIMING baseline timeouts"); n != 1 {
+		t.Errorf("Expected one nil-config warning, got %d", n)
+	}
+}
@@ -4,6 +4,7 @@ package command
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/radio-control/rcc/internal/adapter"
 	"github.com/radio-control/rcc/internal/radio"
@@ -13,9 +14,19 @@ import (
 type OrchestratorPort interface {
 	SelectRadio(ctx context.Context, radioID string) error
 	GetState(ctx context.Context, radioID string) (*adapter.RadioState, error)
+	RefreshState(ctx context.Context, radioID string) (*adapter.RadioState, error)
 	SetPower(ctx context.Context, radioID string, powerDbm float64) error
 	SetChannel(ctx context.Context, radioID string, frequencyMhz float64) error
 	SetChannelByIndex(ctx context.Context, radioID string, channelIndex int, radioManager RadioManager) error
+	ApplyPower(ctx context.Context, radioID string, powerDbm float64) (float64, error)
+	DryRunSetPower(ctx context.Context, radioID string, powerDbm float64) (float64, error)
+	DryRunSetChannel(ctx context.Context, radioID string, frequencyMhz float64) error
+	DryRunSetChannelByIndex(ctx context.Context, radioID string, channelIndex int, radioManager RadioManager) (float64, error)
+	GetChannel(ctx context.Context, radioID string) (*ChannelState, error)
+	RefreshChannel(ctx context.Context, radioID string) (*ChannelState, error)
+	GetCapabilities(ctx context.Context, radioID string) (*Capabilities, error)
+	CancelCommand(ctx context.Context, radioID string) ([]string, error)
+	CircuitBreakerStates() map[string]string
 }
 
 // RadioManager interface for channel index resolution
@@ -24,8 +35,35 @@ type RadioManager interface {
 	SetActive(radioID string) error
 }
 
+// AdapterResolver is implemented by radio managers that hold an adapter per
+// radio. The orchestrator uses it to route each command to the commanded
+// radio's adapter rather than the last selected one.
+type AdapterResolver interface {
+	GetAdapter(radioID string) (adapter.IRadioAdapter, error)
+}
+
+// CapabilityRefresher is implemented by radio managers that track when each
+// radio's capabilities were loaded. The orchestrator uses it to refresh stale
+// capabilities before resolving channel indices (config CapabilitiesMaxAge).
+type CapabilityRefresher interface {
+	CapabilitiesAge(radioID string) (time.Duration, error)
+	RefreshCapabilities(radioID string, timeout time.Duration) error
+}
+
+// FrequencyProfileCache is implemented by radio managers that can hold each
+// radio's supported frequency profiles. SelectRadio fills it so SetChannel
+// validates against the cached profiles rather than querying the adapter.
+type FrequencyProfileCache interface {
+	CacheFrequencyProfiles(radioID string, profiles []adapter.FrequencyProfile) error
+	CachedFrequencyProfiles(radioID string) ([]adapter.FrequencyProfile, bool)
+}
+
 // ErrNotFound indicates a requested radio was not found.
 var ErrNotFound = errors.New("NOT_FOUND")
 
+// ErrNoChannels indicates a channel index was given for a radio that reports
+// no channels; such radios can only be tuned by frequency.
+var ErrNoChannels = errors.New("NO_CHANNELS")
+
 // ErrInvalidParameter indicates a required parameter is missing or structurally invalid.
 var ErrInvalidParameter = errors.New("BAD_REQUEST")
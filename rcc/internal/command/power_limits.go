@@ -0,0 +1,129 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/radio"
+)
+
+// applyBandPowerLimit checks dBm against the config PowerLimits for the
+// band the radio is currently tuned to, clamping instead of rejecting under
+// PowerPolicyClamp. When the current frequency cannot be read, the model's
+// lowest band limit applies. A nil radioAdapter is never queried for the
+// frequency. detail describes the limit for ExplainCommand and is empty
+// when none applies.
+func (o *Orchestrator) applyBandPowerLimit(ctx context.Context, r *radio.Radio, radioAdapter adapter.IRadioAdapter, dBm float64) (limited float64, detail string, err error) {
+	limits := o.powerLimitsFor(r.Model)
+	if len(limits) == 0 {
+		return dBm, "", nil
+	}
+
+	frequencyMhz, known := o.currentFrequency(ctx, r.ID, radioAdapter)
+	limit, ok := bandPowerLimit(limits, frequencyMhz, known)
+	if !ok {
+		return dBm, "", nil
+	}
+
+	detail = fmt.Sprintf("band %s: at most %v dBm", limit.Band, limit.MaxDbm)
+	if !known {
+		detail += " (current frequency unknown; most restrictive band)"
+	}
+	limited, err = o.enforceBandPowerLimit(r.ID, dBm, limit, frequencyMhz)
+	if err == nil && limited != dBm {
+		detail += fmt.Sprintf("; %v dBm clamped to %v dBm", dBm, limited)
+	}
+	return limited, detail, err
+}
+
+// enforceBandPowerLimit checks dBm against limit, clamping instead of
+// rejecting under PowerPolicyClamp. frequencyMhz is reported in the error
+// when known (non-zero).
+func (o *Orchestrator) enforceBandPowerLimit(radioID string, dBm float64, limit config.BandPowerLimit, frequencyMhz float64) (float64, error) {
+	if dBm <= limit.MaxDbm {
+		return dBm, nil
+	}
+	if o.timing().PowerOutOfRangePolicy == config.PowerPolicyClamp {
+		return limit.MaxDbm, nil
+	}
+
+	details := map[string]interface{}{
+		"radioID":     radioID,
+		"band":        limit.Band,
+		"maxPowerDbm": limit.MaxDbm,
+	}
+	if frequencyMhz > 0 {
+		details["frequencyMhz"] = frequencyMhz
+	}
+	return 0, &adapter.VendorError{
+		Code:     adapter.ErrInvalidRange,
+		Original: fmt.Errorf("power %v dBm exceeds %v dBm limit for band %s", dBm, limit.MaxDbm, limit.Band),
+		Details:  details,
+	}
+}
+
+// powerLimitsFor returns the configured band limits for a radio model.
+func (o *Orchestrator) powerLimitsFor(model string) []config.BandPowerLimit {
+	for name, limits := range o.timing().PowerLimits {
+		if strings.EqualFold(name, model) {
+			return limits
+		}
+	}
+	return nil
+}
+
+// bandPowerLimit selects the limit covering the radio's frequency, or the
+// most restrictive limit when the frequency is not known. ok is false when
+// the radio is tuned outside every limited band.
+func bandPowerLimit(limits []config.BandPowerLimit, frequencyMhz float64, known bool) (limit config.BandPowerLimit, ok bool) {
+	if known {
+		for _, l := range limits {
+			if l.Contains(frequencyMhz) {
+				return l, true
+			}
+		}
+		return config.BandPowerLimit{}, false
+	}
+
+	limit = limits[0]
+	for _, l := range limits[1:] {
+		if l.MaxDbm < limit.MaxDbm {
+			limit = l
+		}
+	}
+	return limit, true
+}
+
+// currentFrequency returns the radio's tuned frequency from the state cache,
+// or by querying radioAdapter while its breaker is closed. A nil
+// radioAdapter is not queried.
+func (o *Orchestrator) currentFrequency(ctx context.Context, radioID string, radioAdapter adapter.IRadioAdapter) (float64, bool) {
+	now := time.Now()
+	cached, generation := o.states.get(radioID, now)
+	if cached != nil {
+		return cached.FrequencyMhz, true
+	}
+	if radioAdapter == nil || o.breaker.State(radioID) != BreakerClosed {
+		return 0, false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.commandTimeout(radioID, "getState"))
+	defer cancel()
+
+	state, err := radioAdapter.GetState(ctx)
+	if err != nil {
+		o.states.forget(radioID)
+		return 0, false
+	}
+	if state == nil {
+		return 0, false
+	}
+	if ttl := o.timing().StateCacheTTL; ttl > 0 {
+		o.states.put(radioID, state, generation, o.radioEpoch(radioID), now, ttl)
+	}
+	return state.FrequencyMhz, true
+}
@@ -0,0 +1,117 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
+)
+
+func TestBandPowerLimits(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.radioManager.(*MockRadioManager).Radios["radio-01"].Model = "Silvus"
+	orchestrator.timing().PowerLimits = map[string][]config.BandPowerLimit{
+		"silvus": {
+			{Band: "2.4GHz", LowMhz: 2400, HighMhz: 2500, MaxDbm: 30},
+			{Band: "5GHz", LowMhz: 5150, HighMhz: 5850, MaxDbm: 20},
+		},
+	}
+
+	var frequency atomic.Value
+	frequency.Store(2412.0)
+	stateErr := errors.New("radio not responding")
+	orchestrator.SetActiveAdapter(&MockAdapter{
+		GetStateFunc: func(ctx context.Context) (*adapter.RadioState, error) {
+			f := frequency.Load().(float64)
+			if f == 0 {
+				return nil, stateErr
+			}
+			return &adapter.RadioState{PowerDbm: 10, FrequencyMhz: f}, nil
+		},
+	})
+	ctx := context.Background()
+
+	if err := orchestrator.SetPower(ctx, "radio-01", 25); err != nil {
+		t.Errorf("Expected 25 dBm to be valid in 2.4GHz band, got %v", err)
+	}
+
+	frequency.Store(5180.0)
+	err := orchestrator.SetPower(ctx, "radio-01", 25)
+	if !errors.Is(err, adapter.ErrInvalidRange) {
+		t.Fatalf("Expected INVALID_RANGE for 25 dBm in 5GHz band, got %v", err)
+	}
+	var vendorErr *adapter.VendorError
+	if !errors.As(err, &vendorErr) || vendorErr.Details.(map[string]interface{})["band"] != "5GHz" {
+		t.Errorf("Expected 5GHz band in error details, got %v", err)
+	}
+	if err := orchestrator.SetPower(ctx, "radio-01", 20); err != nil {
+		t.Errorf("Expected 20 dBm to be valid in 5GHz band, got %v", err)
+	}
+
+	// Unknown frequency falls back to the most restrictive band
+	frequency.Store(0.0)
+	if err := orchestrator.SetPower(ctx, "radio-01", 25); !errors.Is(err, adapter.ErrInvalidRange) {
+		t.Errorf("Expected INVALID_RANGE with unknown frequency, got %v", err)
+	}
+
+	// Clamp policy applies the band ceiling instead of rejecting
+	frequency.Store(5180.0)
+	orchestrator.timing().PowerOutOfRangePolicy = config.PowerPolicyClamp
+	applied, err := orchestrator.ApplyPower(ctx, "radio-01", 25)
+	if err != nil || applied != 20 {
+		t.Errorf("Expected power clamped to 20 dBm, got %v (err %v)", applied, err)
+	}
+}
+
+func TestBandPowerLimitCheckedAfterQueuedChannelChange(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.radioManager.(*MockRadioManager).Radios["radio-01"].Model = "Silvus"
+	orchestrator.timing().PowerLimits = map[string][]config.BandPowerLimit{
+		"silvus": {
+			{Band: "low", LowMhz: 2400, HighMhz: 2425, MaxDbm: 30},
+			{Band: "high", LowMhz: 2425, HighMhz: 2500, MaxDbm: 20},
+		},
+	}
+
+	var frequency atomic.Value
+	frequency.Store(2412.0)
+	tuning, retune := make(chan struct{}), make(chan struct{})
+	orchestrator.SetActiveAdapter(&MockAdapter{
+		SetFrequencyFunc: func(ctx context.Context, frequencyMhz float64) error {
+			close(tuning)
+			<-retune
+			frequency.Store(frequencyMhz)
+			return nil
+		},
+		GetStateFunc: func(ctx context.Context) (*adapter.RadioState, error) {
+			return &adapter.RadioState{PowerDbm: 10, FrequencyMhz: frequency.Load().(float64)}, nil
+		},
+	})
+	ctx := context.Background()
+
+	channelDone := make(chan error, 1)
+	go func() { channelDone <- orchestrator.SetChannel(ctx, "radio-01", 2437) }()
+	select {
+	case <-tuning:
+	case err := <-channelDone:
+		t.Fatalf("SetChannel returned before tuning: %v", err)
+	}
+
+	// 25 dBm is fine in the low band the radio is in now, but it queues
+	// behind the move to the high band
+	powerDone := make(chan error, 1)
+	go func() { powerDone <- orchestrator.SetPower(ctx, "radio-01", 25) }()
+	time.Sleep(20 * time.Millisecond)
+	close(retune)
+
+	if err := <-channelDone; err != nil {
+		t.Fatalf("SetChannel failed: %v", err)
+	}
+	if err := <-powerDone; !errors.Is(err, adapter.ErrInvalidRange) {
+		t.Errorf("Expected INVALID_RANGE for 25 dBm once tuned to the high band, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
+)
+
+func TestPowerOutOfRangePolicy(t *testing.T) {
+	var applied []float64
+	mockAdapter := &MockAdapter{
+		SetPowerFunc: func(ctx context.Context, dBm float64) error {
+			applied = append(applied, dBm)
+			return nil
+		},
+	}
+	ctx := context.Background()
+
+	// Reject (the default) keeps INVALID_RANGE and never reaches the adapter
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.SetActiveAdapter(mockAdapter)
+	if _, err := orchestrator.ApplyPower(ctx, "radio-01", 45); !errors.Is(err, adapter.ErrInvalidRange) {
+		t.Errorf("Expected ErrInvalidRange in reject mode, got %v", err)
+	}
+	if len(applied) != 0 {
+		t.Fatalf("Expected no adapter call in reject mode, got %v", applied)
+	}
+
+	// Clamp applies the nearest limit
+	orchestrator.timing().PowerOutOfRangePolicy = config.PowerPolicyClamp
+	for _, tc := range []struct{ requested, want float64 }{{45, 39}, {-3, 0}, {20, 20}} {
+		got, err := orchestrator.ApplyPower(ctx, "radio-01", tc.requested)
+		if err != nil {
+			t.Errorf("ApplyPower(%v) failed in clamp mode: %v", tc.requested, err)
+		}
+		if got != tc.want {
+			t.Errorf("ApplyPower(%v) = %v, want %v", tc.requested, got, tc.want)
+		}
+	}
+	if len(applied) != 3 || applied[0] != 39 || applied[1] != 0 {
+		t.Errorf("Expected adapter to receive clamped values, got %v", applied)
+	}
+}
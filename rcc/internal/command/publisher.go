@@ -0,0 +1,41 @@
+package command
+
+import (
+	"github.com/radio-control/rcc/internal/telemetry"
+)
+
+// EventPublisher is the part of the telemetry hub the orchestrator publishes
+// command events through.
+type EventPublisher interface {
+	PublishRadio(radioID string, event telemetry.Event) error
+}
+
+// Compile-time assertion that telemetry.Hub implements EventPublisher
+var _ EventPublisher = (*telemetry.Hub)(nil)
+
+// NoopPublisher discards every event. The orchestrator uses it when it is
+// created without a telemetry hub.
+type NoopPublisher struct{}
+
+// PublishRadio discards event and reports success.
+func (NoopPublisher) PublishRadio(radioID string, event telemetry.Event) error {
+	return nil
+}
+
+// newPublisher returns hub as an EventPublisher, or NoopPublisher when hub
+// is nil so the interface never holds a nil *telemetry.Hub.
+func newPublisher(hub *telemetry.Hub) EventPublisher {
+	if hub == nil {
+		return NoopPublisher{}
+	}
+	return hub
+}
+
+// publisher returns the event publisher, or NoopPublisher for orchestrators
+// built without a constructor.
+func (o *Orchestrator) publisher() EventPublisher {
+	if o.telemetryHub == nil {
+		return NoopPublisher{}
+	}
+	return o.telemetryHub
+}
@@ -0,0 +1,63 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/radio"
+	"github.com/radio-control/rcc/internal/telemetry"
+)
+
+func TestNoopPublisherWithoutHub(t *testing.T) {
+	rm := &MockRadioManager{
+		Radios: map[string]*radio.Radio{
+			"radio-01": {
+				ID: "radio-01",
+				Capabilities: &adapter.RadioCapabilities{
+					Channels: []adapter.Channel{{Index: 1, FrequencyMhz: 2412.0}},
+				},
+			},
+		},
+	}
+	orchestrator := NewOrchestratorWithRadioManager(nil, config.LoadCBTimingBaseline(), rm)
+	orchestrator.SetActiveAdapter(&MockAdapter{})
+
+	if _, ok := orchestrator.telemetryHub.(NoopPublisher); !ok {
+		t.Fatalf("publisher = %T, want NoopPublisher", orchestrator.telemetryHub)
+	}
+
+	ctx := context.Background()
+	if err := orchestrator.SetPower(ctx, "radio-01", 20.0); err != nil {
+		t.Errorf("SetPower() error = %v", err)
+	}
+	if err := orchestrator.SetChannel(ctx, "radio-01", 2412.0); err != nil {
+		t.Errorf("SetChannel() error = %v", err)
+	}
+	if err := orchestrator.SetChannelByIndex(ctx, "radio-01", 1, rm); err != nil {
+		t.Errorf("SetChannelByIndex() error = %v", err)
+	}
+}
+
+func TestNoopPublisherDiscardsEvents(t *testing.T) {
+	event := telemetry.Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": 20.0}}
+	if err := (NoopPublisher{}).PublishRadio("radio-01", event); err != nil {
+		t.Errorf("PublishRadio() error = %v, want nil", err)
+	}
+
+	// Orchestrators built without a constructor publish to a NoopPublisher too
+	orchestrator := &Orchestrator{}
+	if _, ok := orchestrator.publisher().(NoopPublisher); !ok {
+		t.Errorf("publisher() = %T, want NoopPublisher", orchestrator.publisher())
+	}
+}
+
+func TestNewPublisherKeepsHub(t *testing.T) {
+	hub := telemetry.NewHub(config.LoadCBTimingBaseline())
+	defer hub.Stop()
+
+	if got := newPublisher(hub); got != hub {
+		t.Errorf("newPublisher(hub) = %v, want hub", got)
+	}
+}
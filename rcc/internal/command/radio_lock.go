@@ -0,0 +1,51 @@
+package command
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// radioLocks serializes actuating commands per radio so the adapter never
+// sees two of them interleaved; commands to different radios still run
+// concurrently.
+type radioLocks struct {
+	mu    sync.Mutex
+	locks map[string]chan struct{}
+}
+
+// lockFor returns radioID's lock, a channel holding a token while a command
+// owns the radio.
+func (l *radioLocks) lockFor(radioID string) chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.locks == nil {
+		l.locks = make(map[string]chan struct{})
+	}
+	lock, ok := l.locks[radioID]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		l.locks[radioID] = lock
+	}
+	return lock
+}
+
+// lockRadio waits until no other command holds radioID and takes it. The
+// returned unlock function must be called when the command completes. A
+// command whose context is done, before or while it waits, abandons the
+// queue and is audited as UNAVAILABLE.
+func (o *Orchestrator) lockRadio(ctx context.Context, action, radioID string, start time.Time) (func(), error) {
+	if err := ctx.Err(); err != nil {
+		o.logAudit(ctx, action, radioID, "UNAVAILABLE", time.Since(start))
+		return nil, err
+	}
+
+	lock := o.radioLocks.lockFor(radioID)
+	select {
+	case lock <- struct{}{}:
+		return func() { <-lock }, nil
+	case <-ctx.Done():
+		o.logAudit(ctx, action, radioID, "UNAVAILABLE", time.Since(start))
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,186 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/radio"
+)
+
+// callSpan is when an adapter call started and returned.
+type callSpan struct {
+	start, end time.Time
+}
+
+// timedAdapter returns a MockAdapter whose sets take hold and record their
+// spans.
+func timedAdapter(mu *sync.Mutex, spans *[]callSpan, hold time.Duration) *MockAdapter {
+	record := func() error {
+		start := time.Now()
+		time.Sleep(hold)
+		mu.Lock()
+		*spans = append(*spans, callSpan{start: start, end: time.Now()})
+		mu.Unlock()
+		return nil
+	}
+	return &MockAdapter{
+		SetPowerFunc:     func(ctx context.Context, dBm float64) error { return record() },
+		SetFrequencyFunc: func(ctx context.Context, frequencyMhz float64) error { return record() },
+	}
+}
+
+func newLockTestOrchestrator(t *testing.T, adapters map[string]*MockAdapter) *Orchestrator {
+	t.Helper()
+	rm := radio.NewManager()
+	for id, a := range adapters {
+		if err := rm.LoadCapabilities(id, a, time.Second); err != nil {
+			t.Fatalf("Failed to load %s: %v", id, err)
+		}
+	}
+	return NewOrchestratorWithRadioManager(nil, config.LoadCBTimingBaseline(), rm)
+}
+
+func TestCommandsToOneRadioRunSequentially(t *testing.T) {
+	var mu sync.Mutex
+	var spans []callSpan
+	orchestrator := newLockTestOrchestrator(t, map[string]*MockAdapter{
+		"radio-01": timedAdapter(&mu, &spans, 20*time.Millisecond),
+	})
+
+	ctx := context.Background()
+	commands := []func() error{
+		func() error { return orchestrator.SetPower(ctx, "radio-01", 25) },
+		func() error { return orchestrator.SetPower(ctx, "radio-01", 30) },
+		func() error { return orchestrator.SetChannel(ctx, "radio-01", 2437) },
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, len(commands))
+	for _, command := range commands {
+		wg.Add(1)
+		go func(run func() error) {
+			defer wg.Done()
+			errs <- run()
+		}(command)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Command failed: %v", err)
+		}
+	}
+
+	if len(spans) != len(commands) {
+		t.Fatalf("Expected %d adapter calls, got %d", len(commands), len(spans))
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+	for i := 1; i < len(spans); i++ {
+		if spans[i].start.Before(spans[i-1].end) {
+			t.Errorf("Adapter call %d started at %v, before call %d returned at %v",
+				i, spans[i].start, i-1, spans[i-1].end)
+		}
+	}
+}
+
+func TestCommandsToDifferentRadiosRunConcurrently(t *testing.T) {
+	entered := make(chan string, 2)
+	release := make(chan struct{})
+	blocking := func(id string) *MockAdapter {
+		return &MockAdapter{
+			SetPowerFunc: func(ctx context.Context, dBm float64) error {
+				entered <- id
+				<-release
+				return nil
+			},
+		}
+	}
+	orchestrator := newLockTestOrchestrator(t, map[string]*MockAdapter{
+		"radio-01": blocking("radio-01"),
+		"radio-02": blocking("radio-02"),
+	})
+
+	done := make(chan error, 2)
+	for _, id := range []string{"radio-01", "radio-02"} {
+		go func(id string) { done <- orchestrator.SetPower(context.Background(), id, 20) }(id)
+	}
+
+	// Both adapters are entered while neither command has returned
+	for i := 0; i < 2; i++ {
+		select {
+		case <-entered:
+		case <-time.After(2 * time.Second):
+			t.Fatal("Commands to different radios did not run concurrently")
+		}
+	}
+	close(release)
+	for i := 0; i < 2; i++ {
+		if err := <-done; err != nil {
+			t.Errorf("SetPower failed: %v", err)
+		}
+	}
+}
+
+func TestQueuedCommandAbandonsWhenContextDone(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var powers []float64
+	orchestrator := newLockTestOrchestrator(t, map[string]*MockAdapter{
+		"radio-01": {
+			SetPowerFunc: func(ctx context.Context, dBm float64) error {
+				mu.Lock()
+				powers = append(powers, dBm)
+				mu.Unlock()
+				if dBm == 25 {
+					close(entered)
+					<-release
+				}
+				return nil
+			},
+		},
+	})
+	auditLogger := &MockAuditLogger{}
+	orchestrator.SetAuditLogger(auditLogger)
+
+	// The first command holds the radio
+	first := make(chan error, 1)
+	go func() { first <- orchestrator.SetPower(context.Background(), "radio-01", 25) }()
+	<-entered
+
+	// A command queued behind it gives up when its deadline passes
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := orchestrator.SetPower(ctx, "radio-01", 30); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the queued command to abandon with DeadlineExceeded, got %v", err)
+	}
+
+	close(release)
+	if err := <-first; err != nil {
+		t.Fatalf("First SetPower failed: %v", err)
+	}
+
+	// A command whose context is already done never queues
+	canceled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	if err := orchestrator.SetPower(canceled, "radio-01", 35); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a canceled context to abandon, got %v", err)
+	}
+
+	if len(powers) != 1 || powers[0] != 25 {
+		t.Errorf("Expected only the first command to reach the adapter, got %v", powers)
+	}
+	want := []string{"UNAVAILABLE", "SUCCESS", "UNAVAILABLE"}
+	if len(auditLogger.Actions) != len(want) {
+		t.Fatalf("Expected audit results %v, got %+v", want, auditLogger.Actions)
+	}
+	for i := range want {
+		if auditLogger.Actions[i].Result != want[i] {
+			t.Errorf("Audit record %d: expected %s, got %s", i, want[i], auditLogger.Actions[i].Result)
+		}
+	}
+}
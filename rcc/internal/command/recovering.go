@@ -0,0 +1,73 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/radio"
+)
+
+// recoveringPollInterval is how often a queued command rechecks the radio's
+// status while it waits for recovery.
+const recoveringPollInterval = 50 * time.Millisecond
+
+// awaitRecovery applies RecoveringCommandPolicy to a command for a radio
+// whose status is recovering. It returns nil once the command may be sent:
+// straight away with the allow policy or for a radio that is not
+// recovering, or when a queued command sees the radio back online. Rejected
+// commands and queued ones that time out, see the radio go offline or are
+// cancelled are audited as UNAVAILABLE.
+func (o *Orchestrator) awaitRecovery(ctx context.Context, action, radioID string, start time.Time) error {
+	policy := o.timing().RecoveringCommandPolicy
+	if policy != config.RecoveringPolicyReject && policy != config.RecoveringPolicyQueue {
+		return nil
+	}
+	if o.radioStatus(radioID) != radio.StatusRecovering {
+		return nil
+	}
+	if policy == config.RecoveringPolicyReject {
+		o.logAudit(ctx, action, radioID, "UNAVAILABLE", time.Since(start))
+		return adapter.ErrUnavailable
+	}
+
+	deadline := time.NewTimer(o.timing().RecoveringQueueTimeout)
+	defer deadline.Stop()
+	poll := time.NewTicker(recoveringPollInterval)
+	defer poll.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			o.logAudit(ctx, action, radioID, "UNAVAILABLE", time.Since(start))
+			return ctx.Err()
+		case <-deadline.C:
+			o.logAudit(ctx, action, radioID, "UNAVAILABLE", time.Since(start))
+			return adapter.ErrUnavailable
+		case <-poll.C:
+			switch o.radioStatus(radioID) {
+			case radio.StatusRecovering:
+				continue
+			case radio.StatusOffline:
+				o.logAudit(ctx, action, radioID, "UNAVAILABLE", time.Since(start))
+				return adapter.ErrUnavailable
+			default:
+				return nil
+			}
+		}
+	}
+}
+
+// radioStatus returns the radio's status as the radio manager reports it,
+// or "" when it cannot be read.
+func (o *Orchestrator) radioStatus(radioID string) string {
+	if o.radioManager == nil {
+		return ""
+	}
+	r, err := o.radioManager.GetRadio(radioID)
+	if err != nil || r == nil {
+		return ""
+	}
+	return r.Status
+}
@@ -0,0 +1,116 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/radio"
+)
+
+// statusRadioManager serves radio-01 with a status that can change while
+// a command is waiting on it.
+type statusRadioManager struct {
+	mu     sync.Mutex
+	status string
+}
+
+func (m *statusRadioManager) GetRadio(radioID string) (*radio.Radio, error) {
+	if radioID != "radio-01" {
+		return nil, ErrNotFound
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return &radio.Radio{ID: radioID, Status: m.status}, nil
+}
+
+func (m *statusRadioManager) SetActive(radioID string) error {
+	return nil
+}
+
+func (m *statusRadioManager) setStatus(status string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status = status
+}
+
+func setupRecoveringOrchestrator(t *testing.T, policy string) (*Orchestrator, *statusRadioManager, *int) {
+	cfg := config.LoadCBTimingBaseline()
+	cfg.RecoveringCommandPolicy = policy
+	cfg.RecoveringQueueTimeout = 2 * time.Second
+
+	orchestrator := &Orchestrator{}
+	orchestrator.SetConfig(cfg)
+	manager := &statusRadioManager{status: radio.StatusRecovering}
+	orchestrator.SetRadioManager(manager)
+
+	calls := 0
+	orchestrator.SetActiveAdapter(&MockAdapter{
+		SetPowerFunc: func(ctx context.Context, dBm float64) error {
+			calls++
+			return nil
+		},
+	})
+	return orchestrator, manager, &calls
+}
+
+func TestRecoveringCommandPolicyAllow(t *testing.T) {
+	orchestrator, _, calls := setupRecoveringOrchestrator(t, config.RecoveringPolicyAllow)
+
+	if err := orchestrator.SetPower(context.Background(), "radio-01", 20); err != nil {
+		t.Fatalf("Expected command to pass through while recovering, got %v", err)
+	}
+	if *calls != 1 {
+		t.Errorf("Expected one adapter call, got %d", *calls)
+	}
+}
+
+func TestRecoveringCommandPolicyReject(t *testing.T) {
+	orchestrator, _, calls := setupRecoveringOrchestrator(t, config.RecoveringPolicyReject)
+
+	err := orchestrator.SetPower(context.Background(), "radio-01", 20)
+	if !errors.Is(err, adapter.ErrUnavailable) {
+		t.Fatalf("Expected ErrUnavailable while recovering, got %v", err)
+	}
+	if *calls != 0 {
+		t.Errorf("Expected no adapter call, got %d", *calls)
+	}
+}
+
+func TestRecoveringCommandPolicyQueue(t *testing.T) {
+	orchestrator, manager, calls := setupRecoveringOrchestrator(t, config.RecoveringPolicyQueue)
+
+	// The command is held until the radio comes back online
+	time.AfterFunc(150*time.Millisecond, func() { manager.setStatus(radio.StatusOnline) })
+	start := time.Now()
+	if err := orchestrator.SetPower(context.Background(), "radio-01", 20); err != nil {
+		t.Fatalf("Expected queued command to succeed after recovery, got %v", err)
+	}
+	if waited := time.Since(start); waited < 150*time.Millisecond {
+		t.Errorf("Expected command to wait for recovery, returned after %v", waited)
+	}
+	if *calls != 1 {
+		t.Errorf("Expected one adapter call, got %d", *calls)
+	}
+
+	// A radio that stays recovering fails the command once the queue times out
+	manager.setStatus(radio.StatusRecovering)
+	orchestrator.timing().RecoveringQueueTimeout = 100 * time.Millisecond
+	if err := orchestrator.SetPower(context.Background(), "radio-01", 25); !errors.Is(err, adapter.ErrUnavailable) {
+		t.Errorf("Expected ErrUnavailable after queue timeout, got %v", err)
+	}
+
+	// As does one that goes offline while the command waits
+	time.AfterFunc(50*time.Millisecond, func() { manager.setStatus(radio.StatusOffline) })
+	orchestrator.timing().RecoveringQueueTimeout = 2 * time.Second
+	if err := orchestrator.SetPower(context.Background(), "radio-01", 25); !errors.Is(err, adapter.ErrUnavailable) {
+		t.Errorf("Expected ErrUnavailable when radio goes offline, got %v", err)
+	}
+	if *calls != 1 {
+		t.Errorf("Expected no further adapter calls, got %d", *calls)
+	}
+}
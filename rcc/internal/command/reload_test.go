@@ -0,0 +1,53 @@
+package command
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// TestSetConfigDuringCommands swaps the config while commands read it; run
+// with -race to check the swap is safe.
+func TestSetConfigDuringCommands(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.SetActiveAdapter(&MockAdapter{})
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	swapped := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			cfg := config.LoadCBTimingBaseline()
+			cfg.SkipNoopCommands = true
+			orchestrator.SetConfig(cfg)
+			if i == 0 {
+				close(swapped)
+			}
+			select {
+			case <-done:
+				return
+			default:
+			}
+		}
+	}()
+	<-swapped
+
+	for i := 0; i < 50; i++ {
+		if err := orchestrator.SetPower(context.Background(), "radio-01", float64(10+i%20)); err != nil {
+			t.Errorf("SetPower during reload failed: %v", err)
+		}
+		if err := orchestrator.SetChannelByIndex(context.Background(), "radio-01", 6, orchestrator.radioManager); err != nil {
+			t.Errorf("SetChannelByIndex during reload failed: %v", err)
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	if !orchestrator.timing().SkipNoopCommands {
+		t.Error("Expected commands to see the reloaded config")
+	}
+}
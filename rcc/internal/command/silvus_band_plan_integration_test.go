@@ -66,9 +66,8 @@ func TestOrchestrator_SilvusBandPlanIntegration(t *testing.T) {
 	}
 
 	// Create orchestrator with configuration
-	orchestrator := &Orchestrator{
-		config: cfg,
-	}
+	orchestrator := &Orchestrator{}
+	orchestrator.SetConfig(cfg)
 
 	// Create mock radio manager with radio data
 	radioManager := &SilvusTestRadioManager{
@@ -184,9 +183,8 @@ func TestOrchestrator_SilvusBandPlanFallback(t *testing.T) {
 		},
 	}
 
-	orchestrator := &Orchestrator{
-		config: cfg,
-	}
+	orchestrator := &Orchestrator{}
+	orchestrator.SetConfig(cfg)
 
 	// Create mock radio manager with full channel capabilities
 	radioManager := &SilvusTestRadioManager{
@@ -242,9 +240,8 @@ func TestOrchestrator_NoSilvusBandPlan(t *testing.T) {
 		SilvusBandPlan: nil,
 	}
 
-	orchestrator := &Orchestrator{
-		config: cfg,
-	}
+	orchestrator := &Orchestrator{}
+	orchestrator.SetConfig(cfg)
 
 	// Create mock radio manager
 	radioManager := &SilvusTestRadioManager{
@@ -0,0 +1,171 @@
+package command
+
+import (
+	"sync"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+)
+
+// stateCache holds the last RadioState read per radio so polling clients do
+// not query the adapter on every request.
+type stateCache struct {
+	mu      sync.Mutex
+	entries map[string]stateCacheEntry
+
+	// Bumped on invalidation so a read that raced a set is not cached. It
+	// doubles as the radio's state version for noop detection.
+	generations map[string]uint64
+
+	// Last known power and frequency per radio, dropped whenever the
+	// generation moves on without a record or the radio's status epoch
+	// changes, and trusted only for a max age.
+	known map[string]knownState
+}
+
+// knownState is what a successful set or read last established about a
+// radio, as of generation and the radio's status epoch.
+type knownState struct {
+	generation   uint64
+	epoch        uint64
+	powerDbm     float64
+	frequencyMhz float64
+	powerAt      time.Time // zero when power is unknown
+	frequencyAt  time.Time // zero when frequency is unknown
+}
+
+// stateCacheEntry is a cached state and when it stops being served.
+type stateCacheEntry struct {
+	state   adapter.RadioState
+	expires time.Time
+}
+
+// get returns a copy of the cached state for radioID if it has not expired,
+// along with the generation a subsequent put must match.
+func (c *stateCache) get(radioID string, now time.Time) (*adapter.RadioState, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	generation := c.generations[radioID]
+	entry, ok := c.entries[radioID]
+	if !ok || !now.Before(entry.expires) {
+		return nil, generation
+	}
+	state := entry.state
+	return &state, generation
+}
+
+// put caches state for ttl unless radioID was invalidated since generation
+// was read, and records it as known at status epoch.
+func (c *stateCache) put(radioID string, state *adapter.RadioState, generation, epoch uint64, now time.Time, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generations[radioID] != generation {
+		return
+	}
+	if c.entries == nil {
+		c.entries = make(map[string]stateCacheEntry)
+	}
+	c.entries[radioID] = stateCacheEntry{state: *state, expires: now.Add(ttl)}
+	c.setKnown(radioID, knownState{
+		generation:   generation,
+		epoch:        epoch,
+		powerDbm:     state.PowerDbm,
+		frequencyMhz: state.FrequencyMhz,
+		powerAt:      now,
+		frequencyAt:  now,
+	})
+}
+
+// recordPower invalidates radioID after a successful SetPower and records
+// dBm as its known power at the new generation.
+func (c *stateCache) recordPower(radioID string, dBm float64, epoch uint64, now time.Time) {
+	c.record(radioID, epoch, func(k *knownState) {
+		k.powerDbm, k.powerAt = dBm, now
+	})
+}
+
+// recordFrequency invalidates radioID after a successful SetChannel and
+// records frequencyMhz as its known frequency at the new generation.
+func (c *stateCache) recordFrequency(radioID string, frequencyMhz float64, epoch uint64, now time.Time) {
+	c.record(radioID, epoch, func(k *knownState) {
+		k.frequencyMhz, k.frequencyAt = frequencyMhz, now
+	})
+}
+
+// record invalidates radioID, carrying forward what was known at the old
+// generation and the same epoch with update applied.
+func (c *stateCache) record(radioID string, epoch uint64, update func(*knownState)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := c.currentKnown(radioID, epoch)
+	c.invalidate(radioID)
+
+	k.generation = c.generations[radioID]
+	k.epoch = epoch
+	update(&k)
+	c.setKnown(radioID, k)
+}
+
+// forget invalidates radioID and drops what is known about it, after an
+// adapter error leaves the radio's state in doubt.
+func (c *stateCache) forget(radioID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.invalidate(radioID)
+	delete(c.known, radioID)
+}
+
+// invalidate drops radioID's cached state and moves it to a new generation.
+// Callers hold c.mu.
+func (c *stateCache) invalidate(radioID string) {
+	if c.generations == nil {
+		c.generations = make(map[string]uint64)
+	}
+	c.generations[radioID]++
+	delete(c.entries, radioID)
+}
+
+// knownPower returns radioID's power as of its current generation and
+// epoch, if established within maxAge of now.
+func (c *stateCache) knownPower(radioID string, epoch uint64, now time.Time, maxAge time.Duration) (float64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	k := c.currentKnown(radioID, epoch)
+	return k.powerDbm, fresh(k.powerAt, now, maxAge)
+}
+
+// knownFrequency returns radioID's frequency as of its current generation
+// and epoch, if established within maxAge of now.
+func (c *stateCache) knownFrequency(radioID string, epoch uint64, now time.Time, maxAge time.Duration) (float64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	k := c.currentKnown(radioID, epoch)
+	return k.frequencyMhz, fresh(k.frequencyAt, now, maxAge)
+}
+
+// fresh reports whether a value established at is still trusted at now.
+func fresh(at, now time.Time, maxAge time.Duration) bool {
+	return !at.IsZero() && now.Before(at.Add(maxAge))
+}
+
+// currentKnown returns the known state for radioID if it is still at the
+// current generation and epoch. Callers hold c.mu.
+func (c *stateCache) currentKnown(radioID string, epoch uint64) knownState {
+	k, ok := c.known[radioID]
+	if !ok || k.generation != c.generations[radioID] || k.epoch != epoch {
+		return knownState{}
+	}
+	return k
+}
+
+// setKnown stores k for radioID. Callers hold c.mu.
+func (c *stateCache) setKnown(radioID string, k knownState) {
+	if c.known == nil {
+		c.known = make(map[string]knownState)
+	}
+	c.known[radioID] = k
+}
@@ -0,0 +1,110 @@
+package command
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+)
+
+// countingStateAdapter returns a MockAdapter reporting power, counting GetState calls.
+func countingStateAdapter(calls *atomic.Int32, power *atomic.Int64) *MockAdapter {
+	return &MockAdapter{
+		SetPowerFunc: func(ctx context.Context, dBm float64) error {
+			power.Store(int64(dBm))
+			return nil
+		},
+		GetStateFunc: func(ctx context.Context) (*adapter.RadioState, error) {
+			calls.Add(1)
+			return &adapter.RadioState{PowerDbm: float64(power.Load()), FrequencyMhz: 2412.0}, nil
+		},
+	}
+}
+
+func TestGetStateCachedWithinTTL(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.timing().StateCacheTTL = time.Minute
+	var calls atomic.Int32
+	var power atomic.Int64
+	power.Store(30)
+	orchestrator.SetActiveAdapter(countingStateAdapter(&calls, &power))
+	ctx := context.Background()
+
+	// Concurrent polling reads are served safely
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := orchestrator.GetState(ctx, "radio-01"); err != nil {
+				t.Errorf("GetState failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	calls.Store(0)
+
+	for i := 0; i < 5; i++ {
+		state, err := orchestrator.GetState(ctx, "radio-01")
+		if err != nil {
+			t.Fatalf("GetState failed: %v", err)
+		}
+		if state.PowerDbm != 30 {
+			t.Errorf("Expected cached power 30, got %v", state.PowerDbm)
+		}
+	}
+	if _, err := orchestrator.GetChannel(ctx, "radio-01"); err != nil {
+		t.Fatalf("GetChannel failed: %v", err)
+	}
+	if got := calls.Load(); got != 0 {
+		t.Errorf("Expected reads within TTL to hit the cache, got %d adapter calls", got)
+	}
+
+	// forceRefresh bypasses the cache
+	if _, err := orchestrator.RefreshState(ctx, "radio-01"); err != nil {
+		t.Fatalf("RefreshState failed: %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Expected RefreshState to query the adapter once, got %d calls", got)
+	}
+}
+
+func TestGetStateCacheInvalidatedBySet(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.timing().StateCacheTTL = time.Minute
+	var calls atomic.Int32
+	var power atomic.Int64
+	power.Store(30)
+	orchestrator.SetActiveAdapter(countingStateAdapter(&calls, &power))
+	ctx := context.Background()
+
+	if _, err := orchestrator.GetState(ctx, "radio-01"); err != nil {
+		t.Fatalf("GetState failed: %v", err)
+	}
+	if err := orchestrator.SetPower(ctx, "radio-01", 20); err != nil {
+		t.Fatalf("SetPower failed: %v", err)
+	}
+
+	state, err := orchestrator.GetState(ctx, "radio-01")
+	if err != nil {
+		t.Fatalf("GetState failed: %v", err)
+	}
+	if state.PowerDbm != 20 {
+		t.Errorf("Expected fresh power 20 after SetPower, got %v", state.PowerDbm)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("Expected SetPower to invalidate the cache (2 adapter calls), got %d", got)
+	}
+
+	// A TTL of 0 disables caching
+	orchestrator.timing().StateCacheTTL = 0
+	if _, err := orchestrator.GetState(ctx, "radio-01"); err != nil {
+		t.Fatalf("GetState failed: %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("Expected uncached read with TTL 0, got %d adapter calls", got)
+	}
+}
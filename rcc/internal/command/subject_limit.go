@@ -0,0 +1,53 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/radio-control/rcc/internal/auth"
+)
+
+// ErrSubjectBusy indicates the caller already has the maximum number of
+// commands in flight (config MaxCommandsPerSubject).
+var ErrSubjectBusy = errors.New("BUSY")
+
+// subjectCommands counts in-flight commands per authenticated subject so one
+// client cannot monopolize the orchestrator.
+type subjectCommands struct {
+	mu       sync.Mutex
+	inflight map[string]int
+}
+
+// acquireSubject reserves an in-flight slot for the caller's subject. The
+// returned release function must be called when the command completes.
+// Unauthenticated callers and a limit of 0 are not restricted.
+func (o *Orchestrator) acquireSubject(ctx context.Context, action, radioID string, start time.Time) (func(), error) {
+	claims := auth.GetClaimsFromContext(ctx)
+	limit := o.timing().MaxCommandsPerSubject
+	if claims == nil || claims.Subject == "" || limit <= 0 {
+		return func() {}, nil
+	}
+	subject := claims.Subject
+
+	o.subjects.mu.Lock()
+	if o.subjects.inflight == nil {
+		o.subjects.inflight = make(map[string]int)
+	}
+	if o.subjects.inflight[subject] >= limit {
+		o.subjects.mu.Unlock()
+		o.logAudit(ctx, action, radioID, "BUSY", time.Since(start))
+		return nil, ErrSubjectBusy
+	}
+	o.subjects.inflight[subject]++
+	o.subjects.mu.Unlock()
+
+	return func() {
+		o.subjects.mu.Lock()
+		if o.subjects.inflight[subject]--; o.subjects.inflight[subject] <= 0 {
+			delete(o.subjects.inflight, subject)
+		}
+		o.subjects.mu.Unlock()
+	}, nil
+}
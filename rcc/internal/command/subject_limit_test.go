@@ -0,0 +1,102 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/internal/radio"
+)
+
+// withSubject returns a context carrying controller claims for subject.
+func withSubject(subject string) context.Context {
+	return context.WithValue(context.Background(), auth.ClaimsKey, &auth.Claims{
+		Subject: subject,
+		Roles:   []string{auth.RoleController},
+		Scopes:  []string{auth.ScopeControl},
+	})
+}
+
+func TestPerSubjectLimitRejectsExcessConcurrentCommands(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.timing().MaxCommandsPerSubject = 2
+
+	started := make(chan struct{}, 10)
+	unblock := make(chan struct{})
+	orchestrator.SetActiveAdapter(&MockAdapter{
+		SetPowerFunc: func(ctx context.Context, dBm float64) error {
+			started <- struct{}{}
+			<-unblock
+			return nil
+		},
+	})
+
+	// Fill the subject's slots with slow commands, on two radios since
+	// commands to one radio run one at a time
+	orchestrator.radioManager.(*MockRadioManager).Radios["radio-02"] = &radio.Radio{ID: "radio-02"}
+	results := make(chan error, 2)
+	for _, radioID := range []string{"radio-01", "radio-02"} {
+		go func(radioID string) {
+			results <- orchestrator.SetPower(withSubject("client-a"), radioID, 20)
+		}(radioID)
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-started:
+		case <-time.After(time.Second):
+			t.Fatal("Slow commands never reached the adapter")
+		}
+	}
+
+	// Excess commands from the same subject are rejected without reaching the adapter
+	for i := 0; i < 3; i++ {
+		if err := orchestrator.SetPower(withSubject("client-a"), "radio-01", 20); !errors.Is(err, ErrSubjectBusy) {
+			t.Errorf("Excess command %d: expected ErrSubjectBusy, got %v", i, err)
+		}
+	}
+	if err := orchestrator.SetChannel(withSubject("client-a"), "radio-01", 2437); !errors.Is(err, ErrSubjectBusy) {
+		t.Errorf("Expected limit to cover all command types, got %v", err)
+	}
+
+	// Other subjects are unaffected
+	otherDone := make(chan error, 1)
+	go func() {
+		otherDone <- orchestrator.SetPower(withSubject("client-b"), "radio-01", 20)
+	}()
+
+	close(unblock)
+	for i := 0; i < 2; i++ {
+		if err := <-results; err != nil {
+			t.Errorf("Slow command failed: %v", err)
+		}
+	}
+	if err := <-otherDone; err != nil {
+		t.Errorf("Expected other subject's command to succeed, got %v", err)
+	}
+
+	// Slots are released once commands complete
+	if err := orchestrator.SetPower(withSubject("client-a"), "radio-01", 20); err != nil {
+		t.Errorf("Expected command after release to succeed, got %v", err)
+	}
+}
+
+func TestPerSubjectLimitIgnoresUnauthenticatedAndDisabled(t *testing.T) {
+	orchestrator := setupTestOrchestrator(t)
+	orchestrator.SetActiveAdapter(&MockAdapter{})
+	orchestrator.timing().MaxCommandsPerSubject = 0
+
+	release, err := orchestrator.acquireSubject(withSubject("client-a"), "setPower", "radio-01", time.Now())
+	if err != nil {
+		t.Fatalf("Expected no limit when disabled, got %v", err)
+	}
+	release()
+
+	orchestrator.timing().MaxCommandsPerSubject = 1
+	for i := 0; i < 3; i++ {
+		if _, err := orchestrator.acquireSubject(context.Background(), "setPower", "radio-01", time.Now()); err != nil {
+			t.Fatalf("Expected unauthenticated callers to be unlimited, got %v", err)
+		}
+	}
+}
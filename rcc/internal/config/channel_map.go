@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/radio-control/rcc/internal/adapter"
+)
+
+// DeriveChannelMap expands the band plan for a model and band into concrete
+// channels ordered by index. Maps are derived once and cached; callers get
+// their own copy. Unknown models or bands, and plans that repeat an index,
+// return an error.
+func (sbp *SilvusBandPlan) DeriveChannelMap(model, band string) ([]adapter.Channel, error) {
+	if sbp == nil || sbp.Models == nil {
+		return nil, fmt.Errorf("no Silvus band plan configured")
+	}
+
+	sbp.channelMapsMu.Lock()
+	defer sbp.channelMapsMu.Unlock()
+
+	key := model + "/" + band
+	channels, cached := sbp.channelMaps[key]
+	if !cached {
+		var err error
+		channels, err = sbp.deriveChannelMap(model, band)
+		if err != nil {
+			return nil, err
+		}
+		if sbp.channelMaps == nil {
+			sbp.channelMaps = make(map[string][]adapter.Channel)
+		}
+		sbp.channelMaps[key] = channels
+	}
+
+	return append([]adapter.Channel(nil), channels...), nil
+}
+
+// deriveChannelMap builds the channel map without consulting the cache.
+func (sbp *SilvusBandPlan) deriveChannelMap(model, band string) ([]adapter.Channel, error) {
+	modelBands, exists := sbp.Models[model]
+	if !exists {
+		return nil, fmt.Errorf("model %s not found in band plan", model)
+	}
+	planned, exists := modelBands[band]
+	if !exists {
+		return nil, fmt.Errorf("band %s not found for model %s", band, model)
+	}
+
+	channels := make([]adapter.Channel, 0, len(planned))
+	for _, channel := range planned {
+		channels = append(channels, adapter.Channel{
+			Index:        channel.ChannelIndex,
+			FrequencyMhz: channel.FrequencyMhz,
+			Label:        channel.Label,
+			Usage:        channel.Usage,
+		})
+	}
+	sort.Slice(channels, func(i, j int) bool { return channels[i].Index < channels[j].Index })
+
+	for i := 1; i < len(channels); i++ {
+		if channels[i].Index == channels[i-1].Index {
+			return nil, fmt.Errorf("channel index %d repeated in model %s band %s", channels[i].Index, model, band)
+		}
+	}
+	return channels, nil
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxCommandTimeoutOverride bounds per-model command timeouts so a
+// misconfigured model cannot hold a command, and the radio's lock, for
+// minutes.
+const MaxCommandTimeoutOverride = 2 * time.Minute
+
+// CommandTimeoutOverride replaces the CB-TIMING command timeouts for one
+// radio model. Zero fields keep the default.
+type CommandTimeoutOverride struct {
+	SetPower    time.Duration `json:"setPower,omitempty"`
+	SetChannel  time.Duration `json:"setChannel,omitempty"`
+	SelectRadio time.Duration `json:"selectRadio,omitempty"`
+	GetState    time.Duration `json:"getState,omitempty"`
+}
+
+// CommandTimeout returns the timeout for action (setPower, setChannel,
+// selectRadio or getState) on a radio model, matched case-insensitively:
+// the model's override when it has one, capped at MaxCommandTimeoutOverride,
+// else the CB-TIMING default.
+func (config *TimingConfig) CommandTimeout(model, action string) time.Duration {
+	var defaultTimeout time.Duration
+	switch action {
+	case "setPower":
+		defaultTimeout = config.CommandTimeoutSetPower
+	case "setChannel":
+		defaultTimeout = config.CommandTimeoutSetChannel
+	case "selectRadio":
+		defaultTimeout = config.CommandTimeoutSelectRadio
+	default:
+		defaultTimeout = config.CommandTimeoutGetState
+	}
+
+	for overrideModel, override := range config.CommandTimeoutOverrides {
+		if !strings.EqualFold(overrideModel, model) {
+			continue
+		}
+		timeout := override.forAction(action)
+		if timeout <= 0 {
+			return defaultTimeout
+		}
+		if timeout > MaxCommandTimeoutOverride {
+			return MaxCommandTimeoutOverride
+		}
+		return timeout
+	}
+	return defaultTimeout
+}
+
+// forAction returns the override for action, or 0 if it keeps the default.
+func (o CommandTimeoutOverride) forAction(action string) time.Duration {
+	switch action {
+	case "setPower":
+		return o.SetPower
+	case "setChannel":
+		return o.SetChannel
+	case "selectRadio":
+		return o.SelectRadio
+	default:
+		return o.GetState
+	}
+}
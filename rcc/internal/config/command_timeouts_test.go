@@ -0,0 +1,34 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCommandTimeoutOverrides(t *testing.T) {
+	cfg := LoadCBTimingBaseline()
+	cfg.CommandTimeoutOverrides = map[string]CommandTimeoutOverride{
+		"Slow-Radio": {SetChannel: 90 * time.Second, SelectRadio: 10 * time.Minute},
+	}
+
+	tests := []struct {
+		name   string
+		model  string
+		action string
+		want   time.Duration
+	}{
+		{"override", "Slow-Radio", "setChannel", 90 * time.Second},
+		{"model matched case-insensitively", "slow-radio", "setChannel", 90 * time.Second},
+		{"unset field keeps default", "Slow-Radio", "setPower", cfg.CommandTimeoutSetPower},
+		{"override capped", "Slow-Radio", "selectRadio", MaxCommandTimeoutOverride},
+		{"other model keeps default", "Silvus-Scout", "setChannel", cfg.CommandTimeoutSetChannel},
+		{"unknown model keeps default", "", "getState", cfg.CommandTimeoutGetState},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cfg.CommandTimeout(tt.model, tt.action); got != tt.want {
+				t.Errorf("CommandTimeout(%q, %q) = %v, want %v", tt.model, tt.action, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package config
+
+import "errors"
+
+// ErrGroupNotFound is returned by GetGroup when no radio group has the given
+// ID.
+var ErrGroupNotFound = errors.New("radio group not found")
+
+// GetGroup returns the member radio IDs of the radio group groupID from the
+// running configuration, or ErrGroupNotFound.
+func (s *Store) GetGroup(groupID string) ([]string, error) {
+	return s.Current().GetGroup(groupID)
+}
+
+// GetGroup returns the member radio IDs of the radio group groupID, or
+// ErrGroupNotFound.
+func (config *TimingConfig) GetGroup(groupID string) ([]string, error) {
+	if config == nil {
+		return nil, ErrGroupNotFound
+	}
+	members, ok := config.RadioGroups[groupID]
+	if !ok {
+		return nil, ErrGroupNotFound
+	}
+	return append([]string(nil), members...), nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -41,7 +42,7 @@ func Load() (*TimingConfig, error) {
 	}
 
 	// Validate the final configuration
-	if err := ValidateTiming(config); err != nil {
+	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("configuration validation failed: %w", err)
 	}
 
@@ -137,6 +138,31 @@ func applyEnvOverrides(config *TimingConfig) error {
 		}
 	}
 
+	if val := os.Getenv("RCC_TIMING_STATE_CACHE_TTL"); val != "" {
+		if duration, err := time.ParseDuration(val); err == nil {
+			config.StateCacheTTL = duration
+		}
+	}
+
+	if val := os.Getenv("RCC_TIMING_CAPABILITIES_MAX_AGE"); val != "" {
+		if duration, err := time.ParseDuration(val); err == nil {
+			config.CapabilitiesMaxAge = duration
+		}
+	}
+
+	// Circuit breaker configuration
+	if val := os.Getenv("RCC_TIMING_BREAKER_FAILURE_THRESHOLD"); val != "" {
+		if threshold, err := strconv.Atoi(val); err == nil {
+			config.BreakerFailureThreshold = threshold
+		}
+	}
+
+	if val := os.Getenv("RCC_TIMING_BREAKER_COOLDOWN"); val != "" {
+		if duration, err := time.ParseDuration(val); err == nil {
+			config.BreakerCooldown = duration
+		}
+	}
+
 	// Event buffer configuration
 	if val := os.Getenv("RCC_TIMING_EVENT_BUFFER_SIZE"); val != "" {
 		if size, err := strconv.Atoi(val); err == nil {
@@ -150,6 +176,168 @@ func applyEnvOverrides(config *TimingConfig) error {
 		}
 	}
 
+	if val := os.Getenv("RCC_TIMING_SYSTEM_STATS_INTERVAL"); val != "" {
+		if duration, err := time.ParseDuration(val); err == nil {
+			config.SystemStatsInterval = duration
+		}
+	}
+
+	if val := os.Getenv("RCC_EVENT_ID_STATE_PATH"); val != "" {
+		config.EventIDStatePath = val
+	}
+
+	if val := os.Getenv("RCC_EVENT_STORE_PATH"); val != "" {
+		config.EventStorePath = val
+	}
+
+	if val := os.Getenv("RCC_MAX_COMMANDS_PER_SUBJECT"); val != "" {
+		if limit, err := strconv.Atoi(val); err == nil {
+			config.MaxCommandsPerSubject = limit
+		}
+	}
+
+	if val := os.Getenv("RCC_MAX_BODY_BYTES"); val != "" {
+		if limit, err := strconv.ParseInt(val, 10, 64); err == nil {
+			config.MaxBodyBytes = limit
+		}
+	}
+
+	if val := os.Getenv("RCC_MAX_FREQUENCY_CHANGES_PER_MINUTE"); val != "" {
+		if limit, err := strconv.Atoi(val); err == nil {
+			config.MaxFrequencyChangesPerMinute = limit
+		}
+	}
+
+	if val := os.Getenv("RCC_ALLOWED_MODELS"); val != "" {
+		config.AllowedModels = splitList(val)
+	}
+
+	if val := os.Getenv("RCC_POWER_OUT_OF_RANGE_POLICY"); val != "" {
+		config.PowerOutOfRangePolicy = val
+	}
+
+	if val := os.Getenv("RCC_RECOVERING_COMMAND_POLICY"); val != "" {
+		config.RecoveringCommandPolicy = val
+	}
+
+	if val := os.Getenv("RCC_TIMING_COMMAND_DEDUP_WINDOW"); val != "" {
+		if duration, err := time.ParseDuration(val); err == nil {
+			config.CommandDedupWindow = duration
+		}
+	}
+
+	if val := os.Getenv("RCC_TIMING_RECOVERING_QUEUE_TIMEOUT"); val != "" {
+		if duration, err := time.ParseDuration(val); err == nil {
+			config.RecoveringQueueTimeout = duration
+		}
+	}
+
+	if val := os.Getenv("RCC_CHANNEL_STEP_POLICY"); val != "" {
+		config.ChannelStepPolicy = val
+	}
+
+	if val := os.Getenv("RCC_AUDIT_ANONYMOUS_ACTOR"); val != "" {
+		config.AnonymousActorName = val
+	}
+
+	if val := os.Getenv("RCC_AUDIT_HASH_CHAIN"); val != "" {
+		if enabled, err := strconv.ParseBool(val); err == nil {
+			config.AuditHashChain = enabled
+		}
+	}
+
+	if val := os.Getenv("RCC_SKIP_NOOP_COMMANDS"); val != "" {
+		if enabled, err := strconv.ParseBool(val); err == nil {
+			config.SkipNoopCommands = enabled
+		}
+	}
+
+	if val := os.Getenv("RCC_HTTPS_ONLY"); val != "" {
+		config.HTTPSOnly = val
+	}
+
+	if val := os.Getenv("RCC_TRUSTED_PROXY_CIDRS"); val != "" {
+		config.TrustedProxyCIDRs = splitList(val)
+	}
+
+	if val := os.Getenv("RCC_PPROF_ENABLED"); val != "" {
+		if enabled, err := strconv.ParseBool(val); err == nil {
+			config.PprofEnabled = enabled
+		}
+	}
+
+	if val := os.Getenv("RCC_PPROF_ALLOWED_CIDRS"); val != "" {
+		config.PprofAllowedCIDRs = splitList(val)
+	}
+
+	if val := os.Getenv("RCC_DEBUG_RAW_RESPONSES"); val != "" {
+		if enabled, err := strconv.ParseBool(val); err == nil {
+			config.DebugRawResponses = enabled
+		}
+	}
+
+	if val := os.Getenv("RCC_DEV_MODE"); val != "" {
+		if enabled, err := strconv.ParseBool(val); err == nil {
+			config.DevMode = enabled
+		}
+	}
+
+	if val := os.Getenv("RCC_METRICS_REQUIRE_AUTH"); val != "" {
+		if required, err := strconv.ParseBool(val); err == nil {
+			config.MetricsRequireAuth = required
+		}
+	}
+
+	if val := os.Getenv("RCC_EVENT_HMAC_KEY"); val != "" {
+		config.EventHMACKey = val
+	}
+
+	if val := os.Getenv("RCC_DEGRADED_HEALTH_OK"); val != "" {
+		if ok, err := strconv.ParseBool(val); err == nil {
+			config.DegradedHealthOK = ok
+		}
+	}
+
+	if val := os.Getenv("RCC_HEARTBEAT_STATUS"); val != "" {
+		if enabled, err := strconv.ParseBool(val); err == nil {
+			config.HeartbeatStatus = enabled
+		}
+	}
+
+	// SSE response headers
+	if val := os.Getenv("RCC_SSE_CHARSET"); val != "" {
+		config.SSECharset = val
+	}
+
+	if val := os.Getenv("RCC_SSE_HEADERS"); val != "" {
+		var headers map[string]string
+		if err := json.Unmarshal([]byte(val), &headers); err == nil {
+			config.SSEHeaders = mergeHeaders(config.SSEHeaders, headers)
+		}
+	}
+
+	if val := os.Getenv("RCC_TIMING_SSE_SHUTDOWN_GRACE"); val != "" {
+		if duration, err := time.ParseDuration(val); err == nil {
+			config.SSEShutdownGrace = duration
+		}
+	}
+
+	if val := os.Getenv("RCC_SSE_CLIENT_BUFFER_SIZE"); val != "" {
+		if size, err := strconv.Atoi(val); err == nil {
+			config.SSEClientBufferSize = size
+		}
+	}
+
+	if val := os.Getenv("RCC_SSE_SLOW_CLIENT_POLICY"); val != "" {
+		config.SSESlowClientPolicy = val
+	}
+
+	if val := os.Getenv("RCC_TIMING_SSE_INACTIVITY_TIMEOUT"); val != "" {
+		if duration, err := time.ParseDuration(val); err == nil {
+			config.SSEInactivityTimeout = duration
+		}
+	}
+
 	// Load Silvus band plan from environment variable
 	if val := os.Getenv("RCC_SILVUS_BAND_PLAN"); val != "" {
 		bandPlan, err := loadSilvusBandPlanFromJSON(val)
@@ -226,16 +414,149 @@ func mergeTimingConfigs(current, file *TimingConfig) *TimingConfig {
 	if file.CommandTimeoutGetState != 0 {
 		merged.CommandTimeoutGetState = file.CommandTimeoutGetState
 	}
+	if file.StateCacheTTL != 0 {
+		merged.StateCacheTTL = file.StateCacheTTL
+	}
+	if file.CapabilitiesMaxAge != 0 {
+		merged.CapabilitiesMaxAge = file.CapabilitiesMaxAge
+	}
+	if file.BreakerFailureThreshold != 0 {
+		merged.BreakerFailureThreshold = file.BreakerFailureThreshold
+	}
+	if file.BreakerCooldown != 0 {
+		merged.BreakerCooldown = file.BreakerCooldown
+	}
 	if file.EventBufferSize != 0 {
 		merged.EventBufferSize = file.EventBufferSize
 	}
 	if file.EventBufferRetention != 0 {
 		merged.EventBufferRetention = file.EventBufferRetention
 	}
+	if file.SystemStatsInterval != 0 {
+		merged.SystemStatsInterval = file.SystemStatsInterval
+	}
+	if file.EventIDStatePath != "" {
+		merged.EventIDStatePath = file.EventIDStatePath
+	}
+	if file.EventStorePath != "" {
+		merged.EventStorePath = file.EventStorePath
+	}
+	if file.MaxCommandsPerSubject != 0 {
+		merged.MaxCommandsPerSubject = file.MaxCommandsPerSubject
+	}
+	if file.MaxBodyBytes != 0 {
+		merged.MaxBodyBytes = file.MaxBodyBytes
+	}
+	if file.MaxFrequencyChangesPerMinute != 0 {
+		merged.MaxFrequencyChangesPerMinute = file.MaxFrequencyChangesPerMinute
+	}
+	if file.SSECharset != "" {
+		merged.SSECharset = file.SSECharset
+	}
+	if file.EventCoalesceWindows != nil {
+		merged.EventCoalesceWindows = file.EventCoalesceWindows
+	}
+	if file.SSEHeaders != nil {
+		merged.SSEHeaders = mergeHeaders(merged.SSEHeaders, file.SSEHeaders)
+	}
+	if file.SSEShutdownGrace != 0 {
+		merged.SSEShutdownGrace = file.SSEShutdownGrace
+	}
+	if file.SSEClientBufferSize != 0 {
+		merged.SSEClientBufferSize = file.SSEClientBufferSize
+	}
+	if file.SSESlowClientPolicy != "" {
+		merged.SSESlowClientPolicy = file.SSESlowClientPolicy
+	}
+	if file.SSEInactivityTimeout != 0 {
+		merged.SSEInactivityTimeout = file.SSEInactivityTimeout
+	}
+	if file.PowerOutOfRangePolicy != "" {
+		merged.PowerOutOfRangePolicy = file.PowerOutOfRangePolicy
+	}
+	if file.RecoveringCommandPolicy != "" {
+		merged.RecoveringCommandPolicy = file.RecoveringCommandPolicy
+	}
+	if file.RecoveringQueueTimeout != 0 {
+		merged.RecoveringQueueTimeout = file.RecoveringQueueTimeout
+	}
+	if file.ChannelStepPolicy != "" {
+		merged.ChannelStepPolicy = file.ChannelStepPolicy
+	}
+	if file.AnonymousActorName != "" {
+		merged.AnonymousActorName = file.AnonymousActorName
+	}
+	if file.AllowedModels != nil {
+		merged.AllowedModels = file.AllowedModels
+	}
+	if file.SkipNoopCommands {
+		merged.SkipNoopCommands = true
+	}
+	if file.CommandDedupWindow != 0 {
+		merged.CommandDedupWindow = file.CommandDedupWindow
+	}
+	if file.HTTPSOnly != "" {
+		merged.HTTPSOnly = file.HTTPSOnly
+	}
+	if file.TrustedProxyCIDRs != nil {
+		merged.TrustedProxyCIDRs = file.TrustedProxyCIDRs
+	}
+	if file.PprofEnabled {
+		merged.PprofEnabled = true
+	}
+	if file.PprofAllowedCIDRs != nil {
+		merged.PprofAllowedCIDRs = file.PprofAllowedCIDRs
+	}
+	if file.DebugRawResponses {
+		merged.DebugRawResponses = true
+	}
+	if file.AuditHashChain {
+		merged.AuditHashChain = true
+	}
+	if file.DevMode {
+		merged.DevMode = true
+	}
+	if file.MetricsRequireAuth {
+		merged.MetricsRequireAuth = true
+	}
+	if file.DegradedHealthOK {
+		merged.DegradedHealthOK = true
+	}
+	if file.HeartbeatStatus {
+		merged.HeartbeatStatus = true
+	}
+	if file.CommandTimeoutOverrides != nil {
+		merged.CommandTimeoutOverrides = file.CommandTimeoutOverrides
+	}
+	if file.PowerLimits != nil {
+		merged.PowerLimits = file.PowerLimits
+	}
+	if file.Presets != nil {
+		merged.Presets = file.Presets
+	}
+	if file.RadioGroups != nil {
+		merged.RadioGroups = file.RadioGroups
+	}
+	if file.RoleActions != nil {
+		merged.RoleActions = file.RoleActions
+	}
 
 	return &merged
 }
 
+// mergeHeaders returns a new header map with overrides applied on top of base,
+// so configuring one header keeps the defaults for the others.
+func mergeHeaders(base, overrides map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(overrides))
+	for name, value := range base {
+		merged[name] = value
+	}
+	for name, value := range overrides {
+		merged[name] = value
+	}
+	return merged
+}
+
 // GetEnvVar returns the value of an environment variable with a default.
 func GetEnvVar(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -274,6 +595,18 @@ func GetEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// splitList splits a comma-separated env value, trimming spaces and
+// dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // loadSilvusBandPlanFromJSON loads a Silvus band plan from JSON string.
 func loadSilvusBandPlanFromJSON(jsonStr string) (*SilvusBandPlan, error) {
 	var bandPlan SilvusBandPlan
@@ -323,3 +323,20 @@ func TestGetEnvInt(t *testing.T) {
 		t.Errorf("GetEnvInt() = %d, want 10", value)
 	}
 }
+
+func TestMergeSSEHeadersKeepsDefaults(t *testing.T) {
+	current := LoadCBTimingBaseline()
+	merged := mergeTimingConfigs(current, &TimingConfig{
+		SSEHeaders: map[string]string{"X-Proxy-Hint": "stream"},
+	})
+
+	if merged.SSEHeaders["X-Accel-Buffering"] != "no" || merged.SSEHeaders["X-Proxy-Hint"] != "stream" {
+		t.Errorf("Expected default and configured headers, got %v", merged.SSEHeaders)
+	}
+	if _, ok := current.SSEHeaders["X-Proxy-Hint"]; ok {
+		t.Error("Merge must not modify the current config's headers")
+	}
+	if merged.SSECharset != "utf-8" {
+		t.Errorf("Expected default charset, got %q", merged.SSECharset)
+	}
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrPresetNotFound is returned by GetPreset when the model has no preset
+// with the given name.
+var ErrPresetNotFound = errors.New("preset not found")
+
+// Preset is a named channel and power setting, e.g. a mission channel
+// operators know as "Alpha".
+type Preset struct {
+	FrequencyMhz float64 `json:"frequencyMhz"`
+	PowerDbm     float64 `json:"powerDbm"`
+}
+
+// GetPreset returns the preset called name for the radio model (matched
+// case-insensitively) from the running configuration, or ErrPresetNotFound.
+func (s *Store) GetPreset(model, name string) (Preset, error) {
+	return s.Current().GetPreset(model, name)
+}
+
+// GetPreset returns the preset called name for the radio model (matched
+// case-insensitively), or ErrPresetNotFound.
+func (config *TimingConfig) GetPreset(model, name string) (Preset, error) {
+	if config == nil {
+		return Preset{}, ErrPresetNotFound
+	}
+	for presetModel, presets := range config.Presets {
+		if !strings.EqualFold(presetModel, model) {
+			continue
+		}
+		if preset, ok := presets[name]; ok {
+			return preset, nil
+		}
+	}
+	return Preset{}, ErrPresetNotFound
+}
@@ -6,7 +6,10 @@ package config
 
 import (
 	"os"
+	"reflect"
 	"testing"
+
+	"github.com/radio-control/rcc/internal/adapter"
 )
 
 // TestSilvusBandPlan_ChannelMapping tests channel mapping functionality.
@@ -376,3 +379,58 @@ func contains(s, substr string) bool {
 				return false
 			}())))
 }
+
+// TestSilvusBandPlan_DeriveChannelMap tests expanding a band plan into channels.
+func TestSilvusBandPlan_DeriveChannelMap(t *testing.T) {
+	// 2.4 GHz Silvus channels are 5 MHz apart from 2412 MHz; listed out of order
+	bandPlan := &SilvusBandPlan{
+		Models: map[string]map[string][]SilvusChannel{
+			"Silvus-Scout": {
+				"2.4GHz": {
+					{ChannelIndex: 11, FrequencyMhz: 2462.0},
+					{ChannelIndex: 1, FrequencyMhz: 2412.0, Label: "Command Net"},
+					{ChannelIndex: 6, FrequencyMhz: 2437.0},
+				},
+				"dup": {
+					{ChannelIndex: 1, FrequencyMhz: 2412.0},
+					{ChannelIndex: 1, FrequencyMhz: 2417.0},
+				},
+			},
+		},
+	}
+
+	want := []adapter.Channel{
+		{Index: 1, FrequencyMhz: 2412.0, Label: "Command Net"},
+		{Index: 6, FrequencyMhz: 2437.0},
+		{Index: 11, FrequencyMhz: 2462.0},
+	}
+	channels, err := bandPlan.DeriveChannelMap("Silvus-Scout", "2.4GHz")
+	if err != nil {
+		t.Fatalf("DeriveChannelMap failed: %v", err)
+	}
+	if !reflect.DeepEqual(channels, want) {
+		t.Errorf("Expected %+v, got %+v", want, channels)
+	}
+
+	// Cached maps are returned as copies
+	channels[0].FrequencyMhz = 0
+	again, err := bandPlan.DeriveChannelMap("Silvus-Scout", "2.4GHz")
+	if err != nil || !reflect.DeepEqual(again, want) {
+		t.Errorf("Expected cached map unchanged, got %+v (err %v)", again, err)
+	}
+
+	for _, tc := range []struct{ model, band string }{
+		{"Unknown-Model", "2.4GHz"},
+		{"Silvus-Scout", "900MHz"},
+		{"Silvus-Scout", "dup"},
+	} {
+		if _, err := bandPlan.DeriveChannelMap(tc.model, tc.band); err == nil {
+			t.Errorf("Expected error for model %s band %s", tc.model, tc.band)
+		}
+	}
+
+	var nilPlan *SilvusBandPlan
+	if _, err := nilPlan.DeriveChannelMap("Silvus-Scout", "2.4GHz"); err == nil {
+		t.Error("Expected error for nil band plan")
+	}
+}
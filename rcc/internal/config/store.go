@@ -0,0 +1,53 @@
+package config
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Store holds the running configuration and replaces it on Reload. Readers
+// call Current and never see a partially loaded configuration.
+type Store struct {
+	load func() (*TimingConfig, error)
+
+	// Serializes Reload; Current does not take it
+	reloadMu sync.Mutex
+	current  atomic.Pointer[TimingConfig]
+}
+
+// NewStore loads the configuration with Load and returns a store holding it.
+func NewStore() (*Store, error) {
+	return newStore(Load)
+}
+
+func newStore(load func() (*TimingConfig, error)) (*Store, error) {
+	cfg, err := load()
+	if err != nil {
+		return nil, err
+	}
+	s := &Store{load: load}
+	s.current.Store(cfg)
+	return s, nil
+}
+
+// Current returns the running configuration. Callers must treat it as
+// read-only; Reload replaces it rather than modifying it.
+func (s *Store) Current() *TimingConfig {
+	return s.current.Load()
+}
+
+// Reload loads and validates the configuration again from the same sources
+// as Load. On success the new configuration becomes current and is
+// returned. On failure the running configuration is kept and the error is
+// returned.
+func (s *Store) Reload() (*TimingConfig, error) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	cfg, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	s.current.Store(cfg)
+	return cfg, nil
+}
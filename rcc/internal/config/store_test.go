@@ -0,0 +1,39 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStoreReload(t *testing.T) {
+	t.Setenv("RCC_TIMING_HEARTBEAT_INTERVAL", "20s")
+	store, err := NewStore()
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	initial := store.Current()
+	if initial.HeartbeatInterval != 20*time.Second {
+		t.Fatalf("Expected heartbeat 20s, got %v", initial.HeartbeatInterval)
+	}
+
+	t.Setenv("RCC_TIMING_HEARTBEAT_INTERVAL", "25s")
+	reloaded, err := store.Reload()
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if reloaded.HeartbeatInterval != 25*time.Second || store.Current() != reloaded {
+		t.Errorf("Expected the reloaded config to become current, got %v", store.Current().HeartbeatInterval)
+	}
+	if initial.HeartbeatInterval != 20*time.Second {
+		t.Error("Expected Reload to replace the config rather than modify it")
+	}
+
+	// A config that fails validation keeps the running one
+	t.Setenv("RCC_MAX_FREQUENCY_CHANGES_PER_MINUTE", "-1")
+	if _, err := store.Reload(); err == nil {
+		t.Fatal("Expected Reload to fail validation")
+	}
+	if store.Current() != reloaded {
+		t.Error("Expected a failed reload to keep the running config")
+	}
+}
@@ -2,7 +2,10 @@ package config
 
 import (
 	"fmt"
+	"sync"
 	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
 )
 
 // TimingConfig maps CB-TIMING v0.3 structure.
@@ -27,24 +30,246 @@ type TimingConfig struct {
 	CommandTimeoutSelectRadio time.Duration
 	CommandTimeoutGetState    time.Duration
 
+	// Per-model command timeouts (case-insensitive) for radios slower than
+	// the defaults above, capped at MaxCommandTimeoutOverride. Nil applies
+	// the defaults to every model.
+	CommandTimeoutOverrides map[string]CommandTimeoutOverride
+
+	// How long a radio's GetState result is served from cache before the
+	// adapter is queried again. 0 disables caching.
+	StateCacheTTL time.Duration
+
+	// How old a radio's capabilities may get before channel-index commands
+	// refresh them first, failing with STALE_CAPABILITIES if the refresh
+	// fails. 0 never treats capabilities as stale.
+	CapabilitiesMaxAge time.Duration
+
+	// Answer SetPower/SetChannel whose target equals the radio's last known
+	// state with a "noop" success instead of calling the adapter. Known
+	// state is trusted for StateCacheTTL and dropped on an adapter error or
+	// radio status change. Off by default.
+	SkipNoopCommands bool
+
+	// Answer SetPower/SetChannel that repeat the value last applied to the
+	// radio within this window with a "noChange" success instead of calling
+	// the adapter. 0 disables deduplication (the default).
+	CommandDedupWindow time.Duration
+
+	// Per-radio circuit breaker (fail fast while an adapter is down).
+	// A threshold of 0 disables the breaker.
+	BreakerFailureThreshold int
+	BreakerCooldown         time.Duration
+
 	// CB-TIMING §6.1 Event Buffer Configuration
 	EventBufferSize      int
 	EventBufferRetention time.Duration
 
+	// Interval between global systemStats telemetry events. 0 disables them.
+	SystemStatsInterval time.Duration
+
+	// Event ID state file so IDs keep increasing across restarts.
+	// Empty disables persistence.
+	EventIDStatePath string
+
+	// Event buffer file so Last-Event-ID replay works across restarts.
+	// Empty keeps buffers in memory only.
+	EventStorePath string
+
+	// Coalescing windows by event type: consecutive events of the type for
+	// one radio within the window are delivered once, as the latest. ready
+	// and fault events are never coalesced. Types not listed are not.
+	EventCoalesceWindows map[string]time.Duration
+
+	// SSE response Content-Type charset and extra headers, e.g. for proxies
+	// that buffer event streams. A header with an empty value is removed.
+	SSECharset string
+	SSEHeaders map[string]string
+
+	// How long Hub.Stop waits for clients to receive the shutdown event
+	// before closing their streams. 0 closes them immediately.
+	SSEShutdownGrace time.Duration
+
+	// SSE clients that have not received any event, heartbeats included,
+	// for this long are disconnected. Must exceed the heartbeat interval so
+	// idle but healthy clients stay connected. 0 disables the timeout.
+	SSEInactivityTimeout time.Duration
+
+	// Events queued per SSE client, and what the hub does when a slow
+	// client's queue is full: SlowClientDropOldest discards its oldest
+	// queued event, SlowClientDisconnect disconnects it. Publishing never
+	// waits on a slow client. A size of 0 queues 100; an empty policy
+	// drops oldest.
+	SSEClientBufferSize int
+	SSESlowClientPolicy string
+
+	// Maximum commands in flight per authenticated subject; excess commands
+	// fail with BUSY. 0 disables the limit.
+	MaxCommandsPerSubject int
+
+	// Largest JSON request body accepted by command endpoints, in bytes;
+	// larger bodies fail with 413 PAYLOAD_TOO_LARGE. 0 uses the API
+	// default of 64 KiB.
+	MaxBodyBytes int64
+
+	// Hardware protection: frequency changes allowed per radio in any
+	// one-minute window; excess SetChannel calls fail with THROTTLED.
+	// 0 disables the limit.
+	MaxFrequencyChangesPerMinute int
+
+	// Command allowlist per authenticated role, finer than the control scope.
+	// Actions are setPower, setChannel, selectRadio and cancel; "*" allows all.
+	// A nil map disables role enforcement.
+	RoleActions map[string][]string
+
+	// Radio models that may be commanded (case-insensitive); commands to
+	// other models fail with FORBIDDEN. Empty allows all models.
+	AllowedModels []string
+
+	// How SetPower handles requests outside the power range:
+	// PowerPolicyReject (INVALID_RANGE) or PowerPolicyClamp. Empty rejects.
+	PowerOutOfRangePolicy string
+
+	// How set commands to a radio whose status is "recovering" are handled:
+	// RecoveringPolicyAllow sends them as usual, RecoveringPolicyReject fails
+	// them with UNAVAILABLE, and RecoveringPolicyQueue holds each until the
+	// radio is back online, failing with UNAVAILABLE after
+	// RecoveringQueueTimeout or if the radio goes offline. Empty allows.
+	RecoveringCommandPolicy string
+	RecoveringQueueTimeout  time.Duration
+
+	// What channel stepping does past either end of the channel map:
+	// ChannelStepWrap to the other end or ChannelStepClamp at the end.
+	// Empty wraps.
+	ChannelStepPolicy string
+
+	// Per-band transmit power ceilings by radio model (case-insensitive).
+	// SetPower is checked against the limit for the band the radio is tuned
+	// to. Nil applies only the 0..39 dBm range.
+	PowerLimits map[string][]BandPowerLimit
+
+	// Named channel presets by radio model (case-insensitive) and preset
+	// name, applied with POST /radios/{id}/preset. Nil defines none.
+	Presets map[string]map[string]Preset
+
+	// Radio groups by group ID, each listing member radio IDs, commanded
+	// together with POST /groups/{groupId}/power. Nil defines none.
+	RadioGroups map[string][]string
+
+	// Chain each audit entry to the hash of the one before it, so edited
+	// logs can be detected with audit.VerifyChain. Off by default.
+	AuditHashChain bool
+
+	// Audit actor recorded for commands without an authenticated subject.
+	// Internal commands such as startup initialization record "system".
+	AnonymousActorName string
+
+	// Refuse plaintext HTTP: HTTPSOnlyRedirect answers it with a 308 to
+	// https, HTTPSOnlyReject with 400. Empty or HTTPSOnlyOff serves it.
+	// X-Forwarded-Proto counts only from TrustedProxyCIDRs, since clients
+	// can set it; by default no proxy is trusted.
+	HTTPSOnly         string
+	TrustedProxyCIDRs []string
+
+	// Runtime profiling under /debug/pprof, for admin-scoped tokens from
+	// PprofAllowedCIDRs only. Off by default; requires authentication, so
+	// startup fails if it is enabled on a server without it.
+	PprofEnabled      bool
+	PprofAllowedCIDRs []string
+
+	// Attach the adapter's raw vendor responses to power and channel API
+	// responses, for admin-scoped tokens only. A debugging aid that exposes
+	// vendor internals; off by default and never for production.
+	DebugRawResponses bool
+
+	// Register in-memory fake radios at startup so the API can be used
+	// without hardware. For local development; off by default and never
+	// for production.
+	DevMode bool
+
+	// Require a read-scoped token for /metrics scrapes. Off by default so
+	// Prometheus can scrape without credentials, like /health.
+	MetricsRequireAuth bool
+
+	// Answer a degraded /health with HTTP 200 and status "degraded" instead
+	// of 503, for orchestrators that restart on any non-200 probe.
+	DegradedHealthOK bool
+
+	// Add a status snapshot (active radios, uptime) to heartbeat events so
+	// clients see degradation without polling /health. Off by default;
+	// heartbeats then carry only a timestamp.
+	HeartbeatStatus bool
+
+	// Shared secret for a per-event HMAC-SHA256 in the event data's "hmac"
+	// field, so clients holding the key can verify events end to end.
+	// Empty disables signing.
+	EventHMACKey string `json:"-"`
+
 	// PRE-INT-09: Silvus Band Plan Configuration
 	SilvusBandPlan *SilvusBandPlan
 }
 
+// HTTPS-only modes for TimingConfig.HTTPSOnly.
+const (
+	HTTPSOnlyOff      = "off"
+	HTTPSOnlyRedirect = "redirect"
+	HTTPSOnlyReject   = "reject"
+)
+
+// Power out-of-range policies for TimingConfig.PowerOutOfRangePolicy.
+const (
+	PowerPolicyReject = "reject"
+	PowerPolicyClamp  = "clamp"
+)
+
+// Slow SSE client policies for TimingConfig.SSESlowClientPolicy.
+const (
+	SlowClientDropOldest = "drop_oldest"
+	SlowClientDisconnect = "disconnect"
+)
+
+// Recovering radio command policies for TimingConfig.RecoveringCommandPolicy.
+const (
+	RecoveringPolicyAllow  = "allow"
+	RecoveringPolicyReject = "reject"
+	RecoveringPolicyQueue  = "queue"
+)
+
+// Channel step policies for TimingConfig.ChannelStepPolicy.
+const (
+	ChannelStepWrap  = "wrap"
+	ChannelStepClamp = "clamp"
+)
+
+// BandPowerLimit caps transmit power while a radio is tuned within
+// [LowMhz, HighMhz].
+type BandPowerLimit struct {
+	Band    string  `json:"band"` // Capability band name, e.g. "2.4GHz"
+	LowMhz  float64 `json:"lowMhz"`
+	HighMhz float64 `json:"highMhz"`
+	MaxDbm  float64 `json:"maxDbm"`
+}
+
+// Contains reports whether frequencyMhz falls within the band.
+func (l BandPowerLimit) Contains(frequencyMhz float64) bool {
+	return frequencyMhz >= l.LowMhz && frequencyMhz <= l.HighMhz
+}
+
 // SilvusBandPlan represents Silvus radio band plan configuration.
 type SilvusBandPlan struct {
 	// Band plans organized by model and band
 	Models map[string]map[string][]SilvusChannel `json:"models"`
+
+	// Derived channel maps by model and band, see DeriveChannelMap
+	channelMapsMu sync.Mutex
+	channelMaps   map[string][]adapter.Channel
 }
 
 // SilvusChannel represents a single channel in a Silvus band plan.
 type SilvusChannel struct {
 	ChannelIndex int     `json:"channelIndex"`
 	FrequencyMhz float64 `json:"frequencyMhz"`
+	Label        string  `json:"label,omitempty"`
+	Usage        string  `json:"usage,omitempty"`
 }
 
 // LoadCBTimingBaseline returns CB-TIMING v0.3 baseline values.
@@ -70,9 +295,62 @@ func LoadCBTimingBaseline() *TimingConfig {
 		CommandTimeoutSelectRadio: 5 * time.Second,  // CB-TIMING §5
 		CommandTimeoutGetState:    5 * time.Second,  // CB-TIMING §5
 
+		// Absorb dashboard polling bursts without serving noticeably stale state
+		StateCacheTTL: 1 * time.Second,
+
+		// Circuit breaker: open after 5 consecutive failures, probe again after one normal probe interval
+		BreakerFailureThreshold: 5,
+		BreakerCooldown:         30 * time.Second,
+
 		// CB-TIMING §6.1: 50 events, 1 hour retention
 		EventBufferSize:      50,            // CB-TIMING §6.1
 		EventBufferRetention: 1 * time.Hour, // CB-TIMING §6.1
+
+		// Dashboard aggregates once per minute
+		SystemStatsInterval: 60 * time.Second,
+
+		// Stop nginx and similar proxies from buffering the event stream
+		SSECharset: "utf-8",
+		SSEHeaders: map[string]string{
+			"X-Accel-Buffering": "no",
+		},
+
+		// Let clients see a planned shutdown rather than a dropped stream
+		SSEShutdownGrace: 2 * time.Second,
+
+		// Four missed heartbeats means the consumer is gone
+		SSEInactivityTimeout: 60 * time.Second,
+
+		// A briefly stalled client catches up on recent events, missing the oldest
+		SSEClientBufferSize: 100,
+		SSESlowClientPolicy: SlowClientDropOldest,
+
+		// One client may run a few commands at once (e.g. power and channel on two radios)
+		MaxCommandsPerSubject: 4,
+
+		// Viewers cannot command, controllers can do anything, operators only set power
+		RoleActions: map[string][]string{
+			"viewer":     {},
+			"controller": {"*"},
+			"operator":   {"setPower"},
+		},
+
+		// Out-of-range power fails with INVALID_RANGE
+		PowerOutOfRangePolicy: PowerPolicyReject,
+
+		// Stepping past the last channel returns to the first
+		ChannelStepPolicy: ChannelStepWrap,
+
+		// Commands to a recovering radio go through unless configured
+		// otherwise; a queued one waits about two recovery probes
+		RecoveringCommandPolicy: RecoveringPolicyAllow,
+		RecoveringQueueTimeout:  10 * time.Second,
+
+		// Unauthenticated commands are audited as "anonymous"
+		AnonymousActorName: "anonymous",
+
+		// Profiling stays off; when enabled it is reachable from the host only
+		PprofAllowedCIDRs: []string{"127.0.0.0/8", "::1/128"},
 	}
 }
 
@@ -126,6 +404,23 @@ func (sbp *SilvusBandPlan) GetSilvusChannelIndex(model, band string, frequencyMh
 	return 0, fmt.Errorf("frequency %.1f MHz not found in model %s band %s", frequencyMhz, model, band)
 }
 
+// FindSilvusChannel returns the band plan channel at frequencyMhz in any
+// band of the model.
+func (sbp *SilvusBandPlan) FindSilvusChannel(model string, frequencyMhz float64) (SilvusChannel, bool) {
+	if sbp == nil || sbp.Models == nil {
+		return SilvusChannel{}, false
+	}
+
+	for _, channels := range sbp.Models[model] {
+		for _, channel := range channels {
+			if channel.FrequencyMhz == frequencyMhz {
+				return channel, true
+			}
+		}
+	}
+	return SilvusChannel{}, false
+}
+
 // HasModelBand checks if a model and band combination exists in the band plan.
 func (sbp *SilvusBandPlan) HasModelBand(model, band string) bool {
 	if sbp == nil || sbp.Models == nil {
@@ -38,6 +38,14 @@ func TestLoadCBTimingBaseline(t *testing.T) {
 		t.Errorf("CommandTimeoutSetChannel = %v, want 30s", cfg.CommandTimeoutSetChannel)
 	}
 
+	// Circuit breaker
+	if cfg.BreakerFailureThreshold != 5 {
+		t.Errorf("BreakerFailureThreshold = %d, want 5", cfg.BreakerFailureThreshold)
+	}
+	if cfg.BreakerCooldown != 30*time.Second {
+		t.Errorf("BreakerCooldown = %v, want 30s", cfg.BreakerCooldown)
+	}
+
 	// CB-TIMING §6.1
 	if cfg.EventBufferSize != 50 {
 		t.Errorf("EventBufferSize = %d, want 50", cfg.EventBufferSize)
@@ -165,6 +173,28 @@ func TestValidateTiming_ValidationErrors(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "negative_breaker_failure_threshold",
+			modify: func(c *TimingConfig) {
+				c.BreakerFailureThreshold = -1
+			},
+			wantErr: true,
+		},
+		{
+			name: "zero_breaker_cooldown_when_enabled",
+			modify: func(c *TimingConfig) {
+				c.BreakerCooldown = 0
+			},
+			wantErr: true,
+		},
+		{
+			name: "breaker_disabled_without_cooldown",
+			modify: func(c *TimingConfig) {
+				c.BreakerFailureThreshold = 0
+				c.BreakerCooldown = 0
+			},
+			wantErr: false,
+		},
 		{
 			name: "valid_config",
 			modify: func(c *TimingConfig) {
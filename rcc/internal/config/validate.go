@@ -4,126 +4,394 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"sort"
+	"strings"
 	"time"
 )
 
-// ValidateTiming enforces CB-TIMING v0.3 validation rules.
-func ValidateTiming(config *TimingConfig) error {
+// ValidationError lists every CB-TIMING violation found in a config.
+type ValidationError struct {
+	Violations []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%d timing violation(s): %s", len(e.Violations), strings.Join(e.Violations, "; "))
+}
+
+// Validate checks the config against CB-TIMING v0.3 rules and returns a
+// *ValidationError listing every violation, or nil if the config is valid.
+func (config *TimingConfig) Validate() error {
 	if config == nil {
 		return fmt.Errorf("config cannot be nil")
 	}
 
-	// Validate heartbeat configuration
-	if err := validateHeartbeat(config); err != nil {
-		return fmt.Errorf("heartbeat validation failed: %w", err)
+	var violations []string
+	violations = append(violations, validateHeartbeat(config)...)
+	violations = append(violations, validateProbes(config)...)
+	violations = append(violations, validateCommandTimeouts(config)...)
+	violations = append(violations, validateCircuitBreaker(config)...)
+	violations = append(violations, validateEventBuffer(config)...)
+	violations = append(violations, validateEventCoalescing(config)...)
+	violations = append(violations, validateRoleActions(config)...)
+	violations = append(violations, validatePowerLimits(config)...)
+	violations = append(violations, validatePresets(config)...)
+	violations = append(violations, validateRadioGroups(config)...)
+	switch config.HTTPSOnly {
+	case "", HTTPSOnlyOff, HTTPSOnlyRedirect, HTTPSOnlyReject:
+	default:
+		violations = append(violations, fmt.Sprintf("HTTPS-only mode must be %q, %q or %q, got %q",
+			HTTPSOnlyOff, HTTPSOnlyRedirect, HTTPSOnlyReject, config.HTTPSOnly))
 	}
-
-	// Validate probe configuration
-	if err := validateProbes(config); err != nil {
-		return fmt.Errorf("probe validation failed: %w", err)
+	for _, cidr := range config.TrustedProxyCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			violations = append(violations, fmt.Sprintf("trusted proxy CIDR %q is invalid: %v", cidr, err))
+		}
 	}
-
-	// Validate command timeouts
-	if err := validateCommandTimeouts(config); err != nil {
-		return fmt.Errorf("command timeout validation failed: %w", err)
+	for _, cidr := range config.PprofAllowedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			violations = append(violations, fmt.Sprintf("pprof allowed CIDR %q is invalid: %v", cidr, err))
+		}
+	}
+	if config.SSEShutdownGrace < 0 {
+		violations = append(violations, fmt.Sprintf("SSE shutdown grace must be non-negative, got %v", config.SSEShutdownGrace))
+	}
+	if config.SSEInactivityTimeout < 0 {
+		violations = append(violations, fmt.Sprintf("SSE inactivity timeout must be non-negative, got %v", config.SSEInactivityTimeout))
+	} else if config.SSEInactivityTimeout > 0 && config.SSEInactivityTimeout <= config.HeartbeatInterval+config.HeartbeatJitter {
+		violations = append(violations, fmt.Sprintf("SSE inactivity timeout %v must exceed heartbeat interval %v plus jitter %v",
+			config.SSEInactivityTimeout, config.HeartbeatInterval, config.HeartbeatJitter))
+	}
+	if config.SSEClientBufferSize < 0 {
+		violations = append(violations, fmt.Sprintf("SSE client buffer size must be non-negative, got %d", config.SSEClientBufferSize))
+	}
+	switch config.SSESlowClientPolicy {
+	case "", SlowClientDropOldest, SlowClientDisconnect:
+	default:
+		violations = append(violations, fmt.Sprintf("SSE slow client policy must be %q or %q, got %q", SlowClientDropOldest, SlowClientDisconnect, config.SSESlowClientPolicy))
+	}
+	if config.StateCacheTTL < 0 {
+		violations = append(violations, fmt.Sprintf("state cache TTL must be non-negative, got %v", config.StateCacheTTL))
+	}
+	if config.CapabilitiesMaxAge < 0 {
+		violations = append(violations, fmt.Sprintf("capabilities max age must be non-negative, got %v", config.CapabilitiesMaxAge))
+	}
+	if config.MaxCommandsPerSubject < 0 {
+		violations = append(violations, fmt.Sprintf("max commands per subject must be non-negative, got %d", config.MaxCommandsPerSubject))
+	}
+	if config.CommandDedupWindow < 0 {
+		violations = append(violations, fmt.Sprintf("command dedup window must be non-negative, got %v", config.CommandDedupWindow))
+	}
+	if config.MaxBodyBytes < 0 {
+		violations = append(violations, fmt.Sprintf("max body bytes must be non-negative, got %d", config.MaxBodyBytes))
+	}
+	if config.MaxFrequencyChangesPerMinute < 0 {
+		violations = append(violations, fmt.Sprintf("max frequency changes per minute must be non-negative, got %d", config.MaxFrequencyChangesPerMinute))
+	}
+	switch config.PowerOutOfRangePolicy {
+	case "", PowerPolicyReject, PowerPolicyClamp:
+	default:
+		violations = append(violations, fmt.Sprintf("power out-of-range policy must be %q or %q, got %q", PowerPolicyReject, PowerPolicyClamp, config.PowerOutOfRangePolicy))
+	}
+	switch config.RecoveringCommandPolicy {
+	case "", RecoveringPolicyAllow, RecoveringPolicyReject:
+	case RecoveringPolicyQueue:
+		if config.RecoveringQueueTimeout <= 0 {
+			violations = append(violations, fmt.Sprintf("recovering queue timeout must be positive with policy %q, got %v", RecoveringPolicyQueue, config.RecoveringQueueTimeout))
+		}
+	default:
+		violations = append(violations, fmt.Sprintf("recovering command policy must be %q, %q or %q, got %q",
+			RecoveringPolicyAllow, RecoveringPolicyReject, RecoveringPolicyQueue, config.RecoveringCommandPolicy))
 	}
 
-	// Validate event buffer configuration
-	if err := validateEventBuffer(config); err != nil {
-		return fmt.Errorf("event buffer validation failed: %w", err)
+	switch config.ChannelStepPolicy {
+	case "", ChannelStepWrap, ChannelStepClamp:
+	default:
+		violations = append(violations, fmt.Sprintf("channel step policy must be %q or %q, got %q", ChannelStepWrap, ChannelStepClamp, config.ChannelStepPolicy))
 	}
 
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
 	return nil
 }
 
+// ValidateTiming enforces CB-TIMING v0.3 validation rules.
+func ValidateTiming(config *TimingConfig) error {
+	return config.Validate()
+}
+
 // validateHeartbeat validates heartbeat timing parameters.
-func validateHeartbeat(config *TimingConfig) error {
+func validateHeartbeat(config *TimingConfig) []string {
+	var violations []string
+
 	// Heartbeat interval must be positive
 	if config.HeartbeatInterval <= 0 {
-		return fmt.Errorf("heartbeat interval must be positive, got %v", config.HeartbeatInterval)
+		violations = append(violations, fmt.Sprintf("heartbeat interval must be positive, got %v", config.HeartbeatInterval))
 	}
 
-	// Heartbeat jitter must be positive and ≤ 50% of interval
-	maxJitter := config.HeartbeatInterval / 2
+	// Heartbeat jitter must be non-negative and ≤ 50% of interval
 	if config.HeartbeatJitter < 0 {
-		return fmt.Errorf("heartbeat jitter must be non-negative, got %v", config.HeartbeatJitter)
+		violations = append(violations, fmt.Sprintf("heartbeat jitter must be non-negative, got %v", config.HeartbeatJitter))
 	}
-	if config.HeartbeatJitter > maxJitter {
-		return fmt.Errorf("heartbeat jitter %v exceeds 50%% of interval %v", config.HeartbeatJitter, config.HeartbeatInterval)
+	if config.HeartbeatInterval > 0 && config.HeartbeatJitter > config.HeartbeatInterval/2 {
+		violations = append(violations, fmt.Sprintf("heartbeat jitter %v exceeds 50%% of interval %v", config.HeartbeatJitter, config.HeartbeatInterval))
 	}
 
 	// Heartbeat timeout must be ≥ interval
 	if config.HeartbeatTimeout < config.HeartbeatInterval {
-		return fmt.Errorf("heartbeat timeout %v must be >= interval %v", config.HeartbeatTimeout, config.HeartbeatInterval)
+		violations = append(violations, fmt.Sprintf("heartbeat timeout %v must be >= interval %v", config.HeartbeatTimeout, config.HeartbeatInterval))
 	}
 
-	return nil
+	return violations
 }
 
 // validateProbes validates probe timing parameters.
-func validateProbes(config *TimingConfig) error {
+func validateProbes(config *TimingConfig) []string {
+	var violations []string
+
 	// Normal probe interval must be positive
 	if config.ProbeNormalInterval <= 0 {
-		return fmt.Errorf("probe normal interval must be positive, got %v", config.ProbeNormalInterval)
+		violations = append(violations, fmt.Sprintf("probe normal interval must be positive, got %v", config.ProbeNormalInterval))
 	}
 
 	// Recovering probe configuration
 	if config.ProbeRecoveringInitial <= 0 {
-		return fmt.Errorf("probe recovering initial must be positive, got %v", config.ProbeRecoveringInitial)
+		violations = append(violations, fmt.Sprintf("probe recovering initial must be positive, got %v", config.ProbeRecoveringInitial))
 	}
 	if config.ProbeRecoveringBackoff < 1.0 {
-		return fmt.Errorf("probe recovering backoff must be >= 1.0, got %v", config.ProbeRecoveringBackoff)
+		violations = append(violations, fmt.Sprintf("probe recovering backoff must be >= 1.0, got %v", config.ProbeRecoveringBackoff))
 	}
 	if config.ProbeRecoveringMax < config.ProbeRecoveringInitial {
-		return fmt.Errorf("probe recovering max %v must be >= initial %v", config.ProbeRecoveringMax, config.ProbeRecoveringInitial)
+		violations = append(violations, fmt.Sprintf("probe recovering max %v must be >= initial %v", config.ProbeRecoveringMax, config.ProbeRecoveringInitial))
 	}
 
 	// Offline probe configuration
 	if config.ProbeOfflineInitial <= 0 {
-		return fmt.Errorf("probe offline initial must be positive, got %v", config.ProbeOfflineInitial)
+		violations = append(violations, fmt.Sprintf("probe offline initial must be positive, got %v", config.ProbeOfflineInitial))
 	}
 	if config.ProbeOfflineBackoff < 1.0 {
-		return fmt.Errorf("probe offline backoff must be >= 1.0, got %v", config.ProbeOfflineBackoff)
+		violations = append(violations, fmt.Sprintf("probe offline backoff must be >= 1.0, got %v", config.ProbeOfflineBackoff))
 	}
 	if config.ProbeOfflineMax < config.ProbeOfflineInitial {
-		return fmt.Errorf("probe offline max %v must be >= initial %v", config.ProbeOfflineMax, config.ProbeOfflineInitial)
+		violations = append(violations, fmt.Sprintf("probe offline max %v must be >= initial %v", config.ProbeOfflineMax, config.ProbeOfflineInitial))
 	}
 
-	return nil
+	return violations
 }
 
 // validateCommandTimeouts validates command timeout parameters.
-func validateCommandTimeouts(config *TimingConfig) error {
+func validateCommandTimeouts(config *TimingConfig) []string {
+	var violations []string
+
 	// All command timeouts must be positive
-	if config.CommandTimeoutSetPower <= 0 {
-		return fmt.Errorf("command timeout setPower must be positive, got %v", config.CommandTimeoutSetPower)
+	timeouts := []struct {
+		name    string
+		timeout time.Duration
+	}{
+		{"setPower", config.CommandTimeoutSetPower},
+		{"setChannel", config.CommandTimeoutSetChannel},
+		{"selectRadio", config.CommandTimeoutSelectRadio},
+		{"getState", config.CommandTimeoutGetState},
 	}
-	if config.CommandTimeoutSetChannel <= 0 {
-		return fmt.Errorf("command timeout setChannel must be positive, got %v", config.CommandTimeoutSetChannel)
+	for _, t := range timeouts {
+		if t.timeout <= 0 {
+			violations = append(violations, fmt.Sprintf("command timeout %s must be positive, got %v", t.name, t.timeout))
+		}
 	}
-	if config.CommandTimeoutSelectRadio <= 0 {
-		return fmt.Errorf("command timeout selectRadio must be positive, got %v", config.CommandTimeoutSelectRadio)
+
+	// Per-model overrides may be omitted but never negative or unbounded
+	models := make([]string, 0, len(config.CommandTimeoutOverrides))
+	for model := range config.CommandTimeoutOverrides {
+		models = append(models, model)
 	}
-	if config.CommandTimeoutGetState <= 0 {
-		return fmt.Errorf("command timeout getState must be positive, got %v", config.CommandTimeoutGetState)
+	sort.Strings(models)
+
+	for _, model := range models {
+		override := config.CommandTimeoutOverrides[model]
+		overrides := []struct {
+			name    string
+			timeout time.Duration
+		}{
+			{"setPower", override.SetPower},
+			{"setChannel", override.SetChannel},
+			{"selectRadio", override.SelectRadio},
+			{"getState", override.GetState},
+		}
+		for _, t := range overrides {
+			if t.timeout < 0 || t.timeout > MaxCommandTimeoutOverride {
+				violations = append(violations, fmt.Sprintf("command timeout %s for %s must be within 0-%v, got %v", t.name, model, MaxCommandTimeoutOverride, t.timeout))
+			}
+		}
 	}
 
-	return nil
+	return violations
+}
+
+// validateCircuitBreaker validates circuit breaker parameters.
+func validateCircuitBreaker(config *TimingConfig) []string {
+	var violations []string
+
+	// Threshold of 0 disables the breaker
+	if config.BreakerFailureThreshold < 0 {
+		violations = append(violations, fmt.Sprintf("breaker failure threshold must be non-negative, got %d", config.BreakerFailureThreshold))
+	}
+
+	// An enabled breaker needs a cooldown before it can half-open
+	if config.BreakerFailureThreshold > 0 && config.BreakerCooldown <= 0 {
+		violations = append(violations, fmt.Sprintf("breaker cooldown must be positive when breaker is enabled, got %v", config.BreakerCooldown))
+	}
+
+	return violations
 }
 
 // validateEventBuffer validates event buffer parameters.
-func validateEventBuffer(config *TimingConfig) error {
+func validateEventBuffer(config *TimingConfig) []string {
+	var violations []string
+
 	// Event buffer size must be positive
 	if config.EventBufferSize <= 0 {
-		return fmt.Errorf("event buffer size must be positive, got %d", config.EventBufferSize)
+		violations = append(violations, fmt.Sprintf("event buffer size must be positive, got %d", config.EventBufferSize))
 	}
 
 	// Event buffer retention must be positive
 	if config.EventBufferRetention <= 0 {
-		return fmt.Errorf("event buffer retention must be positive, got %v", config.EventBufferRetention)
+		violations = append(violations, fmt.Sprintf("event buffer retention must be positive, got %v", config.EventBufferRetention))
 	}
 
-	return nil
+	return violations
+}
+
+// validateEventCoalescing validates the per-type event coalescing windows.
+func validateEventCoalescing(config *TimingConfig) []string {
+	var violations []string
+
+	eventTypes := make([]string, 0, len(config.EventCoalesceWindows))
+	for eventType := range config.EventCoalesceWindows {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+
+	for _, eventType := range eventTypes {
+		switch window := config.EventCoalesceWindows[eventType]; {
+		case eventType == "ready" || eventType == "fault":
+			violations = append(violations, fmt.Sprintf("%s events are never coalesced", eventType))
+		case window < 0:
+			violations = append(violations, fmt.Sprintf("event coalesce window for %s must be non-negative, got %v", eventType, window))
+		}
+	}
+
+	return violations
+}
+
+// validateRoleActions validates the per-role command allowlist.
+func validateRoleActions(config *TimingConfig) []string {
+	var violations []string
+
+	known := map[string]bool{"*": true, "setPower": true, "setChannel": true, "selectRadio": true, "cancel": true}
+	roles := make([]string, 0, len(config.RoleActions))
+	for role := range config.RoleActions {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+
+	for _, role := range roles {
+		for _, action := range config.RoleActions[role] {
+			if !known[action] {
+				violations = append(violations, fmt.Sprintf("role %s allows unknown action %q", role, action))
+			}
+		}
+	}
+
+	return violations
+}
+
+// validatePowerLimits validates the per-band power ceilings.
+func validatePowerLimits(config *TimingConfig) []string {
+	var violations []string
+
+	models := make([]string, 0, len(config.PowerLimits))
+	for model := range config.PowerLimits {
+		models = append(models, model)
+	}
+	sort.Strings(models)
+
+	for _, model := range models {
+		for _, limit := range config.PowerLimits[model] {
+			if limit.LowMhz <= 0 || limit.HighMhz < limit.LowMhz {
+				violations = append(violations, fmt.Sprintf("power limit for %s band %q has invalid span %v-%v MHz", model, limit.Band, limit.LowMhz, limit.HighMhz))
+			}
+			if limit.MaxDbm < 0 || limit.MaxDbm > 39 {
+				violations = append(violations, fmt.Sprintf("power limit for %s band %q must be within 0-39 dBm, got %v", model, limit.Band, limit.MaxDbm))
+			}
+		}
+	}
+
+	return violations
+}
+
+// validatePresets validates the named channel presets.
+func validatePresets(config *TimingConfig) []string {
+	var violations []string
+
+	models := make([]string, 0, len(config.Presets))
+	for model := range config.Presets {
+		models = append(models, model)
+	}
+	sort.Strings(models)
+
+	for _, model := range models {
+		names := make([]string, 0, len(config.Presets[model]))
+		for name := range config.Presets[model] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			preset := config.Presets[model][name]
+			if preset.FrequencyMhz <= 0 {
+				violations = append(violations, fmt.Sprintf("preset %q for %s must have a positive frequency, got %v MHz", name, model, preset.FrequencyMhz))
+			}
+			if preset.PowerDbm < 0 || preset.PowerDbm > 39 {
+				violations = append(violations, fmt.Sprintf("preset %q for %s power must be within 0-39 dBm, got %v", name, model, preset.PowerDbm))
+			}
+		}
+	}
+
+	return violations
+}
+
+// validateRadioGroups validates the radio groups: each needs members, listed
+// once each.
+func validateRadioGroups(config *TimingConfig) []string {
+	var violations []string
+
+	groupIDs := make([]string, 0, len(config.RadioGroups))
+	for groupID := range config.RadioGroups {
+		groupIDs = append(groupIDs, groupID)
+	}
+	sort.Strings(groupIDs)
+
+	for _, groupID := range groupIDs {
+		members := config.RadioGroups[groupID]
+		if groupID == "" || strings.Contains(groupID, "/") {
+			violations = append(violations, fmt.Sprintf("radio group ID %q must be non-empty and contain no '/'", groupID))
+		}
+		if len(members) == 0 {
+			violations = append(violations, fmt.Sprintf("radio group %q must have at least one member", groupID))
+		}
+		seen := make(map[string]bool, len(members))
+		for _, radioID := range members {
+			if radioID == "" {
+				violations = append(violations, fmt.Sprintf("radio group %q has an empty radio ID", groupID))
+			} else if seen[radioID] {
+				violations = append(violations, fmt.Sprintf("radio group %q lists radio %q more than once", groupID, radioID))
+			}
+			seen[radioID] = true
+		}
+	}
+
+	return violations
 }
 
 // ValidateTimingConstraints validates additional timing constraints.
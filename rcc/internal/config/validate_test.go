@@ -0,0 +1,258 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateReportsEveryViolation(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *TimingConfig)
+		want   []string
+	}{
+		{
+			name: "heartbeat timeout below interval",
+			modify: func(c *TimingConfig) {
+				c.HeartbeatTimeout = 10 * time.Second
+			},
+			want: []string{"heartbeat timeout 10s must be >= interval 15s"},
+		},
+		{
+			name: "negative jitter",
+			modify: func(c *TimingConfig) {
+				c.HeartbeatJitter = -time.Second
+			},
+			want: []string{"heartbeat jitter must be non-negative, got -1s"},
+		},
+		{
+			name: "probe max below initial",
+			modify: func(c *TimingConfig) {
+				c.ProbeRecoveringMax = 2 * time.Second
+				c.ProbeOfflineBackoff = 0.5
+			},
+			want: []string{
+				"probe recovering max 2s must be >= initial 5s",
+				"probe offline backoff must be >= 1.0, got 0.5",
+			},
+		},
+		{
+			name: "unknown role action",
+			modify: func(c *TimingConfig) {
+				c.RoleActions["operator"] = []string{"setPower", "reboot"}
+			},
+			want: []string{`role operator allows unknown action "reboot"`},
+		},
+		{
+			name: "command timeout override out of bounds",
+			modify: func(c *TimingConfig) {
+				c.CommandTimeoutOverrides = map[string]CommandTimeoutOverride{
+					"Slow-Radio": {SetPower: 10 * time.Minute, GetState: -time.Second},
+				}
+			},
+			want: []string{
+				"command timeout setPower for Slow-Radio must be within 0-2m0s, got 10m0s",
+				"command timeout getState for Slow-Radio must be within 0-2m0s, got -1s",
+			},
+		},
+		{
+			name: "invalid event coalesce windows",
+			modify: func(c *TimingConfig) {
+				c.EventCoalesceWindows = map[string]time.Duration{
+					"channelChanged": -time.Millisecond,
+					"fault":          time.Second,
+					"powerChanged":   100 * time.Millisecond,
+				}
+			},
+			want: []string{
+				"event coalesce window for channelChanged must be non-negative, got -1ms",
+				"fault events are never coalesced",
+			},
+		},
+		{
+			name: "unknown power policy",
+			modify: func(c *TimingConfig) {
+				c.PowerOutOfRangePolicy = "round"
+			},
+			want: []string{`power out-of-range policy must be "reject" or "clamp", got "round"`},
+		},
+		{
+			name: "unknown channel step policy",
+			modify: func(c *TimingConfig) {
+				c.ChannelStepPolicy = "bounce"
+			},
+			want: []string{`channel step policy must be "wrap" or "clamp", got "bounce"`},
+		},
+		{
+			name: "unknown recovering command policy",
+			modify: func(c *TimingConfig) {
+				c.RecoveringCommandPolicy = "wait"
+			},
+			want: []string{`recovering command policy must be "allow", "reject" or "queue", got "wait"`},
+		},
+		{
+			name: "queue policy without timeout",
+			modify: func(c *TimingConfig) {
+				c.RecoveringCommandPolicy = RecoveringPolicyQueue
+				c.RecoveringQueueTimeout = 0
+			},
+			want: []string{`recovering queue timeout must be positive with policy "queue", got 0s`},
+		},
+		{
+			name: "negative dedup window",
+			modify: func(c *TimingConfig) {
+				c.CommandDedupWindow = -time.Second
+			},
+			want: []string{"command dedup window must be non-negative, got -1s"},
+		},
+		{
+			name: "invalid band power limit",
+			modify: func(c *TimingConfig) {
+				c.PowerLimits = map[string][]BandPowerLimit{
+					"silvus": {{Band: "5GHz", LowMhz: 5850, HighMhz: 5150, MaxDbm: 45}},
+				}
+			},
+			want: []string{
+				`power limit for silvus band "5GHz" has invalid span 5850-5150 MHz`,
+				`power limit for silvus band "5GHz" must be within 0-39 dBm, got 45`,
+			},
+		},
+		{
+			name: "invalid preset",
+			modify: func(c *TimingConfig) {
+				c.Presets = map[string]map[string]Preset{
+					"silvus": {"Alpha": {FrequencyMhz: 0, PowerDbm: 40}},
+				}
+			},
+			want: []string{
+				`preset "Alpha" for silvus must have a positive frequency, got 0 MHz`,
+				`preset "Alpha" for silvus power must be within 0-39 dBm, got 40`,
+			},
+		},
+		{
+			name: "invalid radio groups",
+			modify: func(c *TimingConfig) {
+				c.RadioGroups = map[string][]string{
+					"platoon-1": {"silvus-001", "silvus-001"},
+					"empty":     {},
+				}
+			},
+			want: []string{
+				`radio group "empty" must have at least one member`,
+				`radio group "platoon-1" lists radio "silvus-001" more than once`,
+			},
+		},
+		{
+			name: "SSE inactivity timeout within heartbeat interval",
+			modify: func(c *TimingConfig) {
+				c.SSEInactivityTimeout = 15 * time.Second
+			},
+			want: []string{"SSE inactivity timeout 15s must exceed heartbeat interval 15s plus jitter 2s"},
+		},
+		{
+			name: "invalid SSE slow client settings",
+			modify: func(c *TimingConfig) {
+				c.SSEClientBufferSize = -1
+				c.SSESlowClientPolicy = "block"
+			},
+			want: []string{
+				"SSE client buffer size must be non-negative, got -1",
+				`SSE slow client policy must be "drop_oldest" or "disconnect", got "block"`,
+			},
+		},
+		{
+			name: "invalid pprof CIDR",
+			modify: func(c *TimingConfig) {
+				c.PprofAllowedCIDRs = []string{"127.0.0.0/8", "localhost"}
+			},
+			want: []string{`pprof allowed CIDR "localhost" is invalid: invalid CIDR address: localhost`},
+		},
+		{
+			name: "negative max body bytes",
+			modify: func(c *TimingConfig) {
+				c.MaxBodyBytes = -1
+			},
+			want: []string{"max body bytes must be non-negative, got -1"},
+		},
+		{
+			name: "invalid HTTPS-only mode",
+			modify: func(c *TimingConfig) {
+				c.HTTPSOnly = "always"
+			},
+			want: []string{`HTTPS-only mode must be "off", "redirect" or "reject", got "always"`},
+		},
+		{
+			name: "invalid trusted proxy CIDR",
+			modify: func(c *TimingConfig) {
+				c.TrustedProxyCIDRs = []string{"proxy.local"}
+			},
+			want: []string{`trusted proxy CIDR "proxy.local" is invalid: invalid CIDR address: proxy.local`},
+		},
+		{
+			name: "violations across sections",
+			modify: func(c *TimingConfig) {
+				c.HeartbeatJitter = -time.Second
+				c.HeartbeatTimeout = time.Second
+				c.CommandTimeoutGetState = 0
+				c.BreakerCooldown = 0
+				c.EventBufferSize = 0
+			},
+			want: []string{
+				"heartbeat jitter must be non-negative, got -1s",
+				"heartbeat timeout 1s must be >= interval 15s",
+				"command timeout getState must be positive, got 0s",
+				"breaker cooldown must be positive when breaker is enabled, got 0s",
+				"event buffer size must be positive, got 0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := LoadCBTimingBaseline()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected *ValidationError, got %v", err)
+			}
+			if strings.Join(validationErr.Violations, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Violations =\n%s\nwant\n%s", strings.Join(validationErr.Violations, "\n"), strings.Join(tt.want, "\n"))
+			}
+			for _, msg := range tt.want {
+				if !strings.Contains(err.Error(), msg) {
+					t.Errorf("Error %q does not mention %q", err.Error(), msg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateAcceptsBaseline(t *testing.T) {
+	if err := LoadCBTimingBaseline().Validate(); err != nil {
+		t.Errorf("Baseline config should be valid, got %v", err)
+	}
+
+	var cfg *TimingConfig
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for nil config")
+	}
+}
+
+func TestLoadFailsOnInvalidTiming(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("RCC_TIMING_HEARTBEAT_TIMEOUT", "5s")
+	t.Setenv("RCC_TIMING_HEARTBEAT_JITTER", "-1s")
+
+	_, err := Load()
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected Load to fail with *ValidationError, got %v", err)
+	}
+	if len(validationErr.Violations) != 2 {
+		t.Errorf("Expected 2 violations, got %v", validationErr.Violations)
+	}
+}
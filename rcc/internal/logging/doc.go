@@ -0,0 +1,10 @@
+// Package logging implements structured JSON logging for the Radio Control Container.
+//
+// Each log line is a single JSON object with level, message, and the request's
+// correlation ID, so a command can be traced from the API gateway through the
+// orchestrator, adapter, and audit log.
+//
+// Architecture References:
+//   - Architecture §8.6: Audit log schema (shared correlationId)
+//   - OpenAPI v1 §2: correlationId in the response envelope
+package logging
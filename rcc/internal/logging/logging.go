@@ -0,0 +1,148 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Log levels
+const (
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+// Fields holds additional structured fields for a log line, e.g. radioId and action.
+type Fields map[string]interface{}
+
+// CorrelationIDHeader is the HTTP header carrying the correlation ID.
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// correlationIDKey is the context key for the correlation ID.
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a copy of ctx carrying the correlation ID.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID returns the correlation ID carried by ctx, or "" if none.
+func CorrelationID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// NewCorrelationID generates a random correlation ID.
+func NewCorrelationID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// Fall back to a timestamp if the random source fails
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// MaxCorrelationIDLength bounds client-supplied correlation IDs.
+const MaxCorrelationIDLength = 64
+
+// ValidCorrelationID reports whether a client-supplied correlation ID may be
+// used as is: 1 to MaxCorrelationIDLength letters, digits, '-', '_', '.' or
+// ':'. The limits keep IDs from injecting into or bloating log lines.
+func ValidCorrelationID(id string) bool {
+	if id == "" || len(id) > MaxCorrelationIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Logger writes JSON log lines. It is safe for concurrent use.
+type Logger struct {
+	mu  sync.Mutex
+	out io.Writer
+
+	// now is overridable for tests
+	now func() time.Time
+}
+
+// New creates a logger writing JSON lines to out.
+func New(out io.Writer) *Logger {
+	return &Logger{out: out, now: time.Now}
+}
+
+// defaultLogger writes to stderr.
+var defaultLogger = New(os.Stderr)
+
+// Default returns the process-wide logger writing to stderr.
+func Default() *Logger {
+	return defaultLogger
+}
+
+// Info logs an informational message.
+func (l *Logger) Info(ctx context.Context, msg string, fields Fields) {
+	l.log(ctx, LevelInfo, msg, fields)
+}
+
+// Warn logs a warning.
+func (l *Logger) Warn(ctx context.Context, msg string, fields Fields) {
+	l.log(ctx, LevelWarn, msg, fields)
+}
+
+// Error logs an error.
+func (l *Logger) Error(ctx context.Context, msg string, fields Fields) {
+	l.log(ctx, LevelError, msg, fields)
+}
+
+// Fatal logs an error and exits the process with status 1.
+func (l *Logger) Fatal(ctx context.Context, msg string, fields Fields) {
+	l.log(ctx, LevelError, msg, fields)
+	os.Exit(1)
+}
+
+// log writes a single JSON line. Reserved keys (ts, level, msg, correlationId)
+// take precedence over fields with the same name.
+func (l *Logger) log(ctx context.Context, level, msg string, fields Fields) {
+	if l == nil {
+		return
+	}
+
+	line := make(map[string]interface{}, len(fields)+4)
+	for k, v := range fields {
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		line[k] = v
+	}
+	line["ts"] = l.now().UTC().Format(time.RFC3339Nano)
+	line["level"] = level
+	line["msg"] = msg
+	if id := CorrelationID(ctx); id != "" {
+		line["correlationId"] = id
+	}
+
+	data, err := json.Marshal(line)
+	if err != nil {
+		data = []byte(fmt.Sprintf(`{"level":"error","msg":%q}`, "failed to marshal log line: "+err.Error()))
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.out.Write(append(data, '\n'))
+}
@@ -0,0 +1,98 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func decodeLine(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	t.Helper()
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("Log line is not valid JSON: %v: %q", err, buf.String())
+	}
+	return line
+}
+
+func TestLoggerWritesStructuredJSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf)
+	logger.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	ctx := WithCorrelationID(context.Background(), "corr-123")
+	logger.Warn(ctx, "Command failed", Fields{
+		"radioId": "silvus-001",
+		"action":  "setPower",
+		"error":   errors.New("UNAVAILABLE"),
+		"level":   "ignored",
+	})
+
+	line := decodeLine(t, &buf)
+	want := map[string]interface{}{
+		"ts":            "2025-01-02T03:04:05Z",
+		"level":         LevelWarn,
+		"msg":           "Command failed",
+		"correlationId": "corr-123",
+		"radioId":       "silvus-001",
+		"action":        "setPower",
+		"error":         "UNAVAILABLE",
+	}
+	for key, value := range want {
+		if line[key] != value {
+			t.Errorf("%s = %v, want %v", key, line[key], value)
+		}
+	}
+}
+
+func TestLoggerOmitsMissingCorrelationID(t *testing.T) {
+	var buf bytes.Buffer
+	New(&buf).Info(context.Background(), "Starting", nil)
+
+	line := decodeLine(t, &buf)
+	if _, ok := line["correlationId"]; ok {
+		t.Errorf("Expected no correlationId without context, got %v", line["correlationId"])
+	}
+	if line["level"] != LevelInfo {
+		t.Errorf("Expected info level, got %v", line["level"])
+	}
+}
+
+func TestNilLoggerIsNoOp(t *testing.T) {
+	var logger *Logger
+	logger.Info(context.Background(), "ignored", nil)
+}
+
+func TestNewCorrelationIDIsUnique(t *testing.T) {
+	a, b := NewCorrelationID(), NewCorrelationID()
+	if a == "" || a == b {
+		t.Errorf("Expected distinct non-empty IDs, got %q and %q", a, b)
+	}
+	if CorrelationID(WithCorrelationID(context.Background(), a)) != a {
+		t.Error("Expected correlation ID to round-trip through context")
+	}
+}
+
+func TestValidCorrelationID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"trace-7f3a_01.span:2", true},
+		{strings.Repeat("a", MaxCorrelationIDLength), true},
+		{"", false},
+		{strings.Repeat("a", MaxCorrelationIDLength+1), false},
+		{"id\n{\"level\":\"error\"}", false},
+		{"id with spaces", false},
+		{"id\"quoted", false},
+	}
+	for _, tt := range tests {
+		if got := ValidCorrelationID(tt.id); got != tt.want {
+			t.Errorf("ValidCorrelationID(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
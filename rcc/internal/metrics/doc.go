@@ -0,0 +1,10 @@
+// Package metrics implements the Prometheus scrape endpoint for the Radio
+// Control Container.
+//
+// Registry counts command outcomes and latencies reported by the
+// orchestrator and tracks connected SSE telemetry clients, and renders them
+// in the Prometheus text exposition format without a client library.
+//
+// Architecture References:
+//   - Architecture §8.6: Metrics (command latency, SSE clients)
+package metrics
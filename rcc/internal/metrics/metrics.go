@@ -0,0 +1,194 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ContentType is the Prometheus text exposition format served by Handler.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// LatencyBuckets are the upper bounds, in seconds, of the command latency
+// histogram (the Prometheus client defaults).
+var LatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Registry holds the service's metrics. The zero value is not usable; use
+// NewRegistry.
+type Registry struct {
+	mu        sync.Mutex
+	commands  map[commandKey]uint64
+	latencies map[string]*histogram
+
+	sseClients atomic.Int64
+
+	sseDropped     atomic.Uint64
+	sseSlowClients atomic.Uint64
+}
+
+// commandKey labels rcc_commands_total.
+type commandKey struct {
+	action string
+	result string
+}
+
+// histogram is a cumulative latency histogram over LatencyBuckets.
+type histogram struct {
+	counts []uint64 // per bucket, non-cumulative
+	count  uint64
+	sum    float64
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		commands:  make(map[commandKey]uint64),
+		latencies: make(map[string]*histogram),
+	}
+}
+
+// ObserveCommand counts a command outcome and records its latency.
+func (r *Registry) ObserveCommand(action, result string, latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.commands[commandKey{action: action, result: result}]++
+
+	h, ok := r.latencies[action]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(LatencyBuckets))}
+		r.latencies[action] = h
+	}
+	seconds := latency.Seconds()
+	for i, bound := range LatencyBuckets {
+		if seconds <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// SSEClientConnected increments rcc_sse_clients.
+func (r *Registry) SSEClientConnected() {
+	r.sseClients.Add(1)
+}
+
+// SSEClientDisconnected decrements rcc_sse_clients.
+func (r *Registry) SSEClientDisconnected() {
+	r.sseClients.Add(-1)
+}
+
+// SSEEventDropped increments rcc_sse_events_dropped_total, counting an event
+// a slow SSE client missed because its queue was full.
+func (r *Registry) SSEEventDropped() {
+	r.sseDropped.Add(1)
+}
+
+// SSESlowClientDisconnected increments rcc_sse_slow_clients_disconnected_total.
+func (r *Registry) SSESlowClientDisconnected() {
+	r.sseSlowClients.Add(1)
+}
+
+// WriteTo renders the metrics in the Prometheus text format.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	commands := make([]commandKey, 0, len(r.commands))
+	for key := range r.commands {
+		commands = append(commands, key)
+	}
+	sort.Slice(commands, func(i, j int) bool {
+		if commands[i].action != commands[j].action {
+			return commands[i].action < commands[j].action
+		}
+		return commands[i].result < commands[j].result
+	})
+	actions := make([]string, 0, len(r.latencies))
+	for action := range r.latencies {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	fmt.Fprintln(cw, "# HELP rcc_commands_total Commands handled by the orchestrator, by action and result.")
+	fmt.Fprintln(cw, "# TYPE rcc_commands_total counter")
+	for _, key := range commands {
+		fmt.Fprintf(cw, "rcc_commands_total{action=%s,result=%s} %d\n",
+			quoteLabel(key.action), quoteLabel(key.result), r.commands[key])
+	}
+
+	fmt.Fprintln(cw, "# HELP rcc_command_latency_seconds Command latency by action.")
+	fmt.Fprintln(cw, "# TYPE rcc_command_latency_seconds histogram")
+	for _, action := range actions {
+		h := r.latencies[action]
+		label := quoteLabel(action)
+		var cumulative uint64
+		for i, bound := range LatencyBuckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(cw, "rcc_command_latency_seconds_bucket{action=%s,le=\"%s\"} %d\n",
+				label, strconv.FormatFloat(bound, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(cw, "rcc_command_latency_seconds_bucket{action=%s,le=\"+Inf\"} %d\n", label, h.count)
+		fmt.Fprintf(cw, "rcc_command_latency_seconds_sum{action=%s} %s\n", label, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(cw, "rcc_command_latency_seconds_count{action=%s} %d\n", label, h.count)
+	}
+	r.mu.Unlock()
+
+	fmt.Fprintln(cw, "# HELP rcc_sse_clients Connected SSE telemetry clients.")
+	fmt.Fprintln(cw, "# TYPE rcc_sse_clients gauge")
+	fmt.Fprintf(cw, "rcc_sse_clients %d\n", r.sseClients.Load())
+
+	fmt.Fprintln(cw, "# HELP rcc_sse_events_dropped_total Events dropped for slow SSE clients.")
+	fmt.Fprintln(cw, "# TYPE rcc_sse_events_dropped_total counter")
+	fmt.Fprintf(cw, "rcc_sse_events_dropped_total %d\n", r.sseDropped.Load())
+
+	fmt.Fprintln(cw, "# HELP rcc_sse_slow_clients_disconnected_total SSE clients disconnected for falling behind.")
+	fmt.Fprintln(cw, "# TYPE rcc_sse_slow_clients_disconnected_total counter")
+	fmt.Fprintf(cw, "rcc_sse_slow_clients_disconnected_total %d\n", r.sseSlowClients.Load())
+
+	if cw.err != nil {
+		return cw.n, cw.err
+	}
+	return cw.n, cw.w.Flush()
+}
+
+// Handler serves the registry for Prometheus scrapes.
+func (r *Registry) Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		_, _ = r.WriteTo(w)
+	}
+}
+
+// quoteLabel quotes a label value, escaping backslash, quote and newline as
+// the text format requires.
+func quoteLabel(value string) string {
+	return `"` + labelEscaper.Replace(value) + `"`
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// countingWriter tracks bytes written and the first error.
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRegistryTextFormat(t *testing.T) {
+	registry := NewRegistry()
+	registry.ObserveCommand("setPower", "SUCCESS", 20*time.Millisecond)
+	registry.ObserveCommand("setPower", "SUCCESS", 3*time.Second)
+	registry.ObserveCommand("setPower", "INVALID_RANGE", time.Millisecond)
+	registry.ObserveCommand(`odd"action`, "SUCCESS", 0)
+	registry.SSEClientConnected()
+	registry.SSEClientConnected()
+	registry.SSEClientDisconnected()
+	registry.SSEEventDropped()
+	registry.SSEEventDropped()
+	registry.SSESlowClientDisconnected()
+
+	var out strings.Builder
+	if _, err := registry.WriteTo(&out); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	text := out.String()
+
+	for _, want := range []string{
+		"# TYPE rcc_commands_total counter",
+		`rcc_commands_total{action="setPower",result="SUCCESS"} 2`,
+		`rcc_commands_total{action="setPower",result="INVALID_RANGE"} 1`,
+		`rcc_commands_total{action="odd\"action",result="SUCCESS"} 1`,
+		"# TYPE rcc_command_latency_seconds histogram",
+		// Buckets are cumulative
+		`rcc_command_latency_seconds_bucket{action="setPower",le="0.005"} 1`,
+		`rcc_command_latency_seconds_bucket{action="setPower",le="0.025"} 2`,
+		`rcc_command_latency_seconds_bucket{action="setPower",le="2.5"} 2`,
+		`rcc_command_latency_seconds_bucket{action="setPower",le="5"} 3`,
+		`rcc_command_latency_seconds_bucket{action="setPower",le="+Inf"} 3`,
+		`rcc_command_latency_seconds_sum{action="setPower"} 3.021`,
+		`rcc_command_latency_seconds_count{action="setPower"} 3`,
+		"# TYPE rcc_sse_clients gauge",
+		"rcc_sse_clients 1",
+		"# TYPE rcc_sse_events_dropped_total counter",
+		"rcc_sse_events_dropped_total 2",
+		"# TYPE rcc_sse_slow_clients_disconnected_total counter",
+		"rcc_sse_slow_clients_disconnected_total 1",
+	} {
+		if !strings.Contains(text, want+"\n") {
+			t.Errorf("Expected line %q in:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package radio
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/telemetry"
+)
+
+// Radio status values reported in List() and telemetry (Telemetry SSE v1 §3.1).
+const (
+	StatusOnline     = "online"
+	StatusRecovering = "recovering"
+	StatusOffline    = "offline"
+)
+
+// FaultRadioOffline is the fault code published when a radio misses the heartbeat timeout.
+const FaultRadioOffline = "RADIO_OFFLINE"
+
+// maxConcurrentProbes bounds simultaneous probes (CB-TIMING §4.2).
+const maxConcurrentProbes = 3
+
+// maxProbeTick bounds how often the probe loop checks for due radios.
+const maxProbeTick = time.Second
+
+// EventPublisher publishes per-radio telemetry events.
+type EventPublisher interface {
+	PublishRadio(radioID string, event telemetry.Event) error
+}
+
+// Compile-time assertion that the telemetry hub can publish probe events
+var _ EventPublisher = (*telemetry.Hub)(nil)
+
+// healthMonitor runs periodic GetState probes per CB-TIMING §4.1.
+// Radios are probed at the normal cadence while healthy, back off through the
+// recovering cadence after a missed probe, and drop to the offline cadence once
+// no probe has succeeded within the heartbeat timeout (CB-TIMING §3.1).
+type healthMonitor struct {
+	cfg       *config.TimingConfig
+	publisher EventPublisher
+
+	mu     sync.Mutex
+	probes map[string]*probeState
+
+	stop chan struct{}
+	done sync.WaitGroup
+
+	// now is overridable for tests
+	now func() time.Time
+}
+
+// probeState tracks the probe schedule for a single radio.
+type probeState struct {
+	status   string
+	lastOK   time.Time
+	interval time.Duration
+	next     time.Time
+}
+
+// StartHealthProbes starts background GetState probes for all registered radios,
+// publishing a RADIO_OFFLINE fault when a radio misses the heartbeat timeout and
+// a state event when it comes back online. Calling it again while running is a no-op.
+func (m *Manager) StartHealthProbes(cfg *config.TimingConfig, publisher EventPublisher) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.health != nil || cfg == nil {
+		return
+	}
+
+	h := &healthMonitor{
+		cfg:       cfg,
+		publisher: publisher,
+		probes:    make(map[string]*probeState),
+		stop:      make(chan struct{}),
+		now:       time.Now,
+	}
+	m.health = h
+
+	h.done.Add(1)
+	go m.runHealthProbes(h)
+}
+
+// StopHealthProbes stops background probes and waits for in-flight probes to finish.
+func (m *Manager) StopHealthProbes() {
+	m.mu.Lock()
+	h := m.health
+	m.health = nil
+	m.mu.Unlock()
+
+	if h == nil {
+		return
+	}
+	close(h.stop)
+	h.done.Wait()
+}
+
+// runHealthProbes is the probe loop; it checks for due radios every tick.
+func (m *Manager) runHealthProbes(h *healthMonitor) {
+	defer h.done.Done()
+
+	ticker := time.NewTicker(h.tick())
+	defer ticker.Stop()
+
+	for {
+		m.probeDue(h)
+
+		select {
+		case <-h.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// probeDue probes every radio whose next probe time has passed and waits for the probes to finish.
+func (m *Manager) probeDue(h *healthMonitor) {
+	m.mu.RLock()
+	due := make(map[string]adapter.IRadioAdapter)
+	now := h.now()
+
+	h.mu.Lock()
+	for id, radioAdapter := range m.adapters {
+		radio, exists := m.radios[id]
+		if !exists {
+			continue
+		}
+		ps, tracked := h.probes[id]
+		if !tracked {
+			ps = &probeState{status: radio.Status, lastOK: radio.LastSeen}
+			if ps.status != StatusOffline {
+				ps.status = StatusOnline
+			}
+			if ps.lastOK.IsZero() {
+				ps.lastOK = now
+			}
+			h.probes[id] = ps
+		}
+		if !now.Before(ps.next) {
+			due[id] = radioAdapter
+		}
+	}
+	// Forget radios that were removed
+	for id := range h.probes {
+		if _, exists := m.adapters[id]; !exists {
+			delete(h.probes, id)
+		}
+	}
+	h.mu.Unlock()
+	m.mu.RUnlock()
+
+	sem := make(chan struct{}, maxConcurrentProbes)
+	var wg sync.WaitGroup
+	for id, radioAdapter := range due {
+		wg.Add(1)
+		go func(id string, radioAdapter adapter.IRadioAdapter) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			m.probeRadio(h, id, radioAdapter)
+		}(id, radioAdapter)
+	}
+	wg.Wait()
+}
+
+// probeRadio runs a single GetState probe and applies the resulting state transition.
+func (m *Manager) probeRadio(h *healthMonitor, radioID string, radioAdapter adapter.IRadioAdapter) {
+	ctx, cancel := context.WithTimeout(context.Background(), h.cfg.CommandTimeoutGetState)
+	state, err := radioAdapter.GetState(ctx)
+	cancel()
+
+	now := h.now()
+
+	h.mu.Lock()
+	ps, tracked := h.probes[radioID]
+	if !tracked {
+		h.mu.Unlock()
+		return
+	}
+	previous := ps.status
+	if err == nil {
+		ps.status = StatusOnline
+		ps.lastOK = now
+		ps.interval = h.cfg.ProbeNormalInterval
+	} else if now.Sub(ps.lastOK) >= h.cfg.HeartbeatTimeout {
+		ps.interval = backoff(previous == StatusOffline, ps.interval,
+			h.cfg.ProbeOfflineInitial, h.cfg.ProbeOfflineBackoff, h.cfg.ProbeOfflineMax)
+		ps.status = StatusOffline
+	} else {
+		ps.interval = backoff(previous == StatusRecovering, ps.interval,
+			h.cfg.ProbeRecoveringInitial, h.cfg.ProbeRecoveringBackoff, h.cfg.ProbeRecoveringMax)
+		ps.status = StatusRecovering
+	}
+	ps.next = now.Add(ps.interval)
+	status, lastOK := ps.status, ps.lastOK
+	h.mu.Unlock()
+
+	m.mu.Lock()
+	radio, exists := m.radios[radioID]
+	if exists {
+		radio.setStatus(status)
+		if err == nil {
+			if state != nil {
+				radio.State = state
+			}
+			radio.LastSeen = now
+		}
+	}
+	m.mu.Unlock()
+	if !exists || h.publisher == nil {
+		return
+	}
+
+	switch {
+	case status == StatusOffline && previous != StatusOffline:
+		_ = h.publisher.PublishRadio(radioID, telemetry.Event{
+			Type: "fault",
+			Data: map[string]interface{}{
+				"radioId": radioID,
+				"code":    FaultRadioOffline,
+				"message": "Radio missed heartbeat timeout",
+				"details": map[string]interface{}{
+					"lastSeen":   lastOK.UTC().Format(time.RFC3339),
+					"timeoutSec": h.cfg.HeartbeatTimeout.Seconds(),
+				},
+				"ts": now.UTC().Format(time.RFC3339),
+			},
+		})
+	case status == StatusOnline && previous != StatusOnline:
+		data := map[string]interface{}{
+			"radioId": radioID,
+			"status":  StatusOnline,
+			"ts":      now.UTC().Format(time.RFC3339),
+		}
+		if state != nil {
+			data["powerDbm"] = state.PowerDbm
+			data["frequencyMhz"] = state.FrequencyMhz
+		}
+		_ = h.publisher.PublishRadio(radioID, telemetry.Event{Type: "state", Data: data})
+	}
+}
+
+// tick returns the probe loop resolution: the shortest configured cadence, capped at maxProbeTick.
+func (h *healthMonitor) tick() time.Duration {
+	tick := maxProbeTick
+	for _, interval := range []time.Duration{h.cfg.ProbeNormalInterval, h.cfg.ProbeRecoveringInitial, h.cfg.ProbeOfflineInitial} {
+		if interval > 0 && interval < tick {
+			tick = interval
+		}
+	}
+	return tick
+}
+
+// backoff returns the next probe interval: initial on entering a state,
+// otherwise the current interval scaled by factor and capped at max.
+func backoff(sameState bool, current, initial time.Duration, factor float64, max time.Duration) time.Duration {
+	if !sameState || current <= 0 {
+		return initial
+	}
+	next := time.Duration(float64(current) * factor)
+	if max > 0 && next > max {
+		next = max
+	}
+	return next
+}
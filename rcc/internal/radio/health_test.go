@@ -0,0 +1,198 @@
+package radio
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/telemetry"
+)
+
+// recordingPublisher captures published telemetry events.
+type recordingPublisher struct {
+	events chan telemetry.Event
+}
+
+func newRecordingPublisher() *recordingPublisher {
+	return &recordingPublisher{events: make(chan telemetry.Event, 100)}
+}
+
+func (p *recordingPublisher) PublishRadio(radioID string, event telemetry.Event) error {
+	event.Radio = radioID
+	p.events <- event
+	return nil
+}
+
+// waitForEvent returns the first event of the given type, failing after timeout.
+func (p *recordingPublisher) waitForEvent(t *testing.T, eventType string, timeout time.Duration) telemetry.Event {
+	t.Helper()
+	deadline := time.After(timeout)
+	for {
+		select {
+		case event := <-p.events:
+			if event.Type == eventType {
+				return event
+			}
+		case <-deadline:
+			t.Fatalf("Timed out waiting for %s event", eventType)
+			return telemetry.Event{}
+		}
+	}
+}
+
+// stallingAdapter stops answering GetState until the context expires while stalled.
+func stallingAdapter(stalled *atomic.Bool) *MockAdapter {
+	return &MockAdapter{
+		GetStateFunc: func(ctx context.Context) (*adapter.RadioState, error) {
+			if stalled.Load() {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return &adapter.RadioState{PowerDbm: 30, FrequencyMhz: 2412}, nil
+		},
+	}
+}
+
+// fastProbeConfig returns timing scaled down to milliseconds for tests.
+func fastProbeConfig() *config.TimingConfig {
+	cfg := config.LoadCBTimingBaseline()
+	cfg.HeartbeatTimeout = 60 * time.Millisecond
+	cfg.ProbeNormalInterval = 10 * time.Millisecond
+	cfg.ProbeRecoveringInitial = 10 * time.Millisecond
+	cfg.ProbeRecoveringMax = 20 * time.Millisecond
+	cfg.ProbeOfflineInitial = 10 * time.Millisecond
+	cfg.ProbeOfflineMax = 40 * time.Millisecond
+	cfg.CommandTimeoutGetState = 10 * time.Millisecond
+	return cfg
+}
+
+func TestHealthProbesPublishOfflineFaultAndRecovery(t *testing.T) {
+	manager := NewManager()
+	var stalled atomic.Bool
+	if err := manager.LoadCapabilities("radio-01", stallingAdapter(&stalled), time.Second); err != nil {
+		t.Fatalf("LoadCapabilities failed: %v", err)
+	}
+
+	publisher := newRecordingPublisher()
+	manager.StartHealthProbes(fastProbeConfig(), publisher)
+	defer manager.StopHealthProbes()
+
+	// Adapter stops responding
+	stalled.Store(true)
+
+	fault := publisher.waitForEvent(t, "fault", 2*time.Second)
+	if fault.Radio != "radio-01" || fault.Data["code"] != FaultRadioOffline {
+		t.Fatalf("Expected RADIO_OFFLINE fault for radio-01, got %+v", fault)
+	}
+	if got := manager.List().Items[0].Status; got != StatusOffline {
+		t.Errorf("Expected radio listed as offline, got %q", got)
+	}
+
+	// Adapter recovers
+	stalled.Store(false)
+
+	state := publisher.waitForEvent(t, "state", 2*time.Second)
+	if state.Data["status"] != StatusOnline || state.Data["radioId"] != "radio-01" {
+		t.Fatalf("Expected online state event, got %+v", state)
+	}
+	if got := manager.List().Items[0].Status; got != StatusOnline {
+		t.Errorf("Expected radio listed as online after recovery, got %q", got)
+	}
+}
+
+func TestHealthProbeCadenceFollowsConfig(t *testing.T) {
+	cfg := config.LoadCBTimingBaseline()
+	manager := NewManager()
+	var failing atomic.Bool
+	manager.LoadCapabilities("radio-01", &MockAdapter{
+		GetStateFunc: func(ctx context.Context) (*adapter.RadioState, error) {
+			if failing.Load() {
+				return nil, adapter.ErrUnavailable
+			}
+			return &adapter.RadioState{}, nil
+		},
+	}, time.Second)
+
+	var mu sync.Mutex
+	clock := time.Unix(1700000000, 0)
+	publisher := newRecordingPublisher()
+	h := &healthMonitor{
+		cfg:       cfg,
+		publisher: publisher,
+		probes:    make(map[string]*probeState),
+		now: func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+			return clock
+		},
+	}
+	manager.radios["radio-01"].LastSeen = clock
+
+	// probeAfter advances the clock to the next scheduled probe and runs it
+	probeAfter := func() time.Duration {
+		h.mu.Lock()
+		next := time.Time{}
+		if ps, ok := h.probes["radio-01"]; ok {
+			next = ps.next
+		}
+		h.mu.Unlock()
+		mu.Lock()
+		if next.After(clock) {
+			clock = next
+		}
+		mu.Unlock()
+
+		manager.probeDue(h)
+
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		return h.probes["radio-01"].interval
+	}
+
+	if got := probeAfter(); got != cfg.ProbeNormalInterval {
+		t.Fatalf("Expected normal cadence %v, got %v", cfg.ProbeNormalInterval, got)
+	}
+
+	failing.Store(true)
+
+	// Recovering cadence: 5s, backing off by 1.5 while within the heartbeat timeout
+	for _, want := range []time.Duration{5 * time.Second, 7500 * time.Millisecond, 11250 * time.Millisecond} {
+		if got := probeAfter(); got != want {
+			t.Errorf("Expected recovering interval %v, got %v", want, got)
+		}
+		if status := manager.radios["radio-01"].Status; status != StatusRecovering {
+			t.Errorf("Expected recovering status, got %q", status)
+		}
+	}
+
+	// Offline once the 45s heartbeat timeout has elapsed since the last success
+	// (30s + 5s + 7.5s + 11.25s = 53.75s): 10s, backing off by 2
+	for _, want := range []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second} {
+		if got := probeAfter(); got != want {
+			t.Errorf("Expected offline interval %v, got %v", want, got)
+		}
+	}
+	if status := manager.radios["radio-01"].Status; status != StatusOffline {
+		t.Errorf("Expected offline status, got %q", status)
+	}
+
+	// Backoff is capped at the configured maximum
+	if got := backoff(true, 12*time.Second, cfg.ProbeRecoveringInitial, cfg.ProbeRecoveringBackoff, cfg.ProbeRecoveringMax); got != cfg.ProbeRecoveringMax {
+		t.Errorf("Expected recovering backoff capped at %v, got %v", cfg.ProbeRecoveringMax, got)
+	}
+
+	// Exactly one offline fault for the transition
+	faults := 0
+	for len(publisher.events) > 0 {
+		if event := <-publisher.events; event.Type == "fault" {
+			faults++
+		}
+	}
+	if faults != 1 {
+		t.Errorf("Expected 1 offline fault, got %d", faults)
+	}
+}
@@ -5,10 +5,12 @@ package radio
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
 )
 
 // Radio represents a single radio with its capabilities and current state.
@@ -19,6 +21,18 @@ type Radio struct {
 	Capabilities *adapter.RadioCapabilities `json:"capabilities"`
 	State        *adapter.RadioState       `json:"state"`
 	LastSeen     time.Time                 `json:"lastSeen,omitempty"`
+
+	// When Capabilities were last loaded from the adapter
+	CapabilitiesLoadedAt time.Time `json:"-"`
+
+	// Bumped whenever Status changes or the adapter is replaced, so state
+	// learned about the radio before then is not trusted
+	StatusEpoch uint64 `json:"-"`
+
+	// Supported frequency profiles cached when the radio was selected, and
+	// when; a zero time means none have been cached
+	FrequencyProfiles         []adapter.FrequencyProfile `json:"-"`
+	FrequencyProfilesCachedAt time.Time                  `json:"-"`
 }
 
 // RadioList represents the response format for GET /radios.
@@ -33,6 +47,15 @@ type Manager struct {
 	radios        map[string]*Radio
 	activeRadioID string
 	adapters      map[string]adapter.IRadioAdapter
+
+	// Configured band plan supplying channels adapters do not report (nil disables)
+	bandPlan *config.SilvusBandPlan
+
+	// Background health probes (nil when not running)
+	health *healthMonitor
+
+	// now is overridable for tests
+	now func() time.Time
 }
 
 // NewManager creates a new radio manager.
@@ -40,6 +63,7 @@ func NewManager() *Manager {
 	return &Manager{
 		radios:   make(map[string]*Radio),
 		adapters: make(map[string]adapter.IRadioAdapter),
+		now:      time.Now,
 	}
 }
 
@@ -71,17 +95,25 @@ func (m *Manager) LoadCapabilities(radioID string, radioAdapter adapter.IRadioAd
 	}
 
 	// Create radio entry
+	model := m.getModelFromCapabilities(capabilities)
+	bands := m.getBandsFromAdapter(radioAdapter)
+	supportsSetPower, supportsSetChannel := m.getCommandSupportFromAdapter(radioAdapter)
 	radio := &Radio{
 		ID:     radioID,
-		Model:  m.getModelFromCapabilities(capabilities),
+		Model:  model,
 		Status: m.determineStatus(err),
 		Capabilities: &adapter.RadioCapabilities{
 			MinPowerDbm: m.getMinPowerFromCapabilities(capabilities),
 			MaxPowerDbm: m.getMaxPowerFromCapabilities(capabilities),
-			Channels:    m.getChannelsFromCapabilities(capabilities, radioAdapter),
+			Channels:    m.channelsFor(model, bands, capabilities, radioAdapter),
+			Bands:       m.bandsFor(model, bands),
+
+			SupportsSetPower:   &supportsSetPower,
+			SupportsSetChannel: &supportsSetChannel,
 		},
-		State:    state,
-		LastSeen: time.Now(),
+		State:                state,
+		LastSeen:             m.now(),
+		CapabilitiesLoadedAt: m.now(),
 	}
 
 	m.radios[radioID] = radio
@@ -94,6 +126,14 @@ func (m *Manager) LoadCapabilities(radioID string, radioAdapter adapter.IRadioAd
 	return nil
 }
 
+// SetBandPlan sets the band plan used for radios whose adapter reports no
+// channels. It applies to capabilities loaded or refreshed afterwards.
+func (m *Manager) SetBandPlan(bandPlan *config.SilvusBandPlan) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bandPlan = bandPlan
+}
+
 // SetActive sets the active radio with existence check.
 func (m *Manager) SetActive(radioID string) error {
 	m.mu.Lock()
@@ -144,6 +184,32 @@ func (m *Manager) GetActiveAdapter() (adapter.IRadioAdapter, string, error) {
 	return adapter, m.activeRadioID, nil
 }
 
+// GetAdapter returns the adapter registered for a radio.
+func (m *Manager) GetAdapter(radioID string) (adapter.IRadioAdapter, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	radioAdapter, exists := m.adapters[radioID]
+	if !exists {
+		return nil, fmt.Errorf("no adapter for radio %s", radioID)
+	}
+	return radioAdapter, nil
+}
+
+// SetAdapter replaces the adapter for a known radio without reloading its
+// capabilities, e.g. after reconnecting to the radio.
+func (m *Manager) SetAdapter(radioID string, radioAdapter adapter.IRadioAdapter) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.radios[radioID]; !exists {
+		return fmt.Errorf("radio %s not found", radioID)
+	}
+	m.adapters[radioID] = radioAdapter
+	m.radios[radioID].StatusEpoch++
+	return nil
+}
+
 // List returns the radio list matching OpenAPI schema.
 func (m *Manager) List() *RadioList {
 	m.mu.RLock()
@@ -154,6 +220,9 @@ func (m *Manager) List() *RadioList {
 		items = append(items, *radio)
 	}
 
+	// Stable ordering by radio ID for deterministic paging
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+
 	return &RadioList{
 		ActiveRadioID: m.activeRadioID,
 		Items:         items,
@@ -197,7 +266,7 @@ func (m *Manager) UpdateState(radioID string, state *adapter.RadioState) error {
 
 	radio.State = state
 	radio.LastSeen = time.Now()
-	radio.Status = "online"
+	radio.setStatus("online")
 
 	return nil
 }
@@ -212,12 +281,21 @@ func (m *Manager) UpdateStatus(radioID string, status string) error {
 		return fmt.Errorf("radio %s not found", radioID)
 	}
 
-	radio.Status = status
+	radio.setStatus(status)
 	radio.LastSeen = time.Now()
 
 	return nil
 }
 
+// setStatus sets Status, bumping StatusEpoch when it changes. Callers hold
+// the manager's lock.
+func (r *Radio) setStatus(status string) {
+	if r.Status != status {
+		r.Status = status
+		r.StatusEpoch++
+	}
+}
+
 // RemoveRadio removes a radio from the inventory.
 func (m *Manager) RemoveRadio(radioID string) error {
 	m.mu.Lock()
@@ -263,12 +341,56 @@ func (m *Manager) RefreshCapabilities(radioID string, timeout time.Duration) err
 	}
 
 	// Update capabilities
-	radio.Capabilities.Channels = m.getChannelsFromCapabilities(capabilities, radioAdapter)
-	radio.LastSeen = time.Now()
+	bands := m.getBandsFromAdapter(radioAdapter)
+	radio.Capabilities.Channels = m.channelsFor(radio.Model, bands, capabilities, radioAdapter)
+	radio.Capabilities.Bands = m.bandsFor(radio.Model, bands)
+	radio.LastSeen = m.now()
+	radio.CapabilitiesLoadedAt = radio.LastSeen
 
 	return nil
 }
 
+// CapabilitiesAge returns how long ago a radio's capabilities were loaded
+// or last refreshed.
+func (m *Manager) CapabilitiesAge(radioID string) (time.Duration, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	radio, exists := m.radios[radioID]
+	if !exists {
+		return 0, fmt.Errorf("radio %s not found", radioID)
+	}
+	return m.now().Sub(radio.CapabilitiesLoadedAt), nil
+}
+
+// CacheFrequencyProfiles stores a radio's supported frequency profiles on its
+// entry. Empty profiles record that the radio reports none.
+func (m *Manager) CacheFrequencyProfiles(radioID string, profiles []adapter.FrequencyProfile) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	radio, exists := m.radios[radioID]
+	if !exists {
+		return fmt.Errorf("radio %s not found", radioID)
+	}
+	radio.FrequencyProfiles = profiles
+	radio.FrequencyProfilesCachedAt = m.now()
+	return nil
+}
+
+// CachedFrequencyProfiles returns a radio's cached frequency profiles; ok is
+// false if none have been cached for it.
+func (m *Manager) CachedFrequencyProfiles(radioID string) ([]adapter.FrequencyProfile, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	radio, exists := m.radios[radioID]
+	if !exists || radio.FrequencyProfilesCachedAt.IsZero() {
+		return nil, false
+	}
+	return radio.FrequencyProfiles, true
+}
+
 // Helper methods for capability processing
 
 func (m *Manager) getModelFromCapabilities(capabilities []adapter.FrequencyProfile) string {
@@ -306,6 +428,61 @@ func (m *Manager) getChannelsFromCapabilities(capabilities []adapter.FrequencyPr
 	return channels
 }
 
+// channelsFor returns the channels the adapter reports, falling back to the
+// band plan's derived channel map for the model when it reports none. The
+// first reported band found in the plan is used, or the model's only band
+// when the adapter reports no bands.
+func (m *Manager) channelsFor(model string, bands []string, capabilities []adapter.FrequencyProfile, radioAdapter adapter.IRadioAdapter) []adapter.Channel {
+	channels := m.getChannelsFromCapabilities(capabilities, radioAdapter)
+	if len(channels) > 0 || m.bandPlan == nil {
+		return channels
+	}
+
+	if len(bands) == 0 {
+		bands = m.bandPlan.GetAvailableBands(model)
+		if len(bands) != 1 {
+			return channels
+		}
+	}
+	for _, band := range bands {
+		if derived, err := m.bandPlan.DeriveChannelMap(model, band); err == nil {
+			return derived
+		}
+	}
+	return channels
+}
+
+func (m *Manager) getBandsFromAdapter(radioAdapter adapter.IRadioAdapter) []string {
+	// Bands are optional; only adapters that know their band plan report them
+	if bandAdapter, ok := radioAdapter.(interface{ GetBands() []string }); ok {
+		return bandAdapter.GetBands()
+	}
+	return nil
+}
+
+// bandsFor returns the bands the adapter reports, falling back to the band
+// plan's bands for the model, sorted, when it reports none.
+func (m *Manager) bandsFor(model string, reported []string) []string {
+	if len(reported) > 0 || m.bandPlan == nil {
+		return reported
+	}
+	bands := m.bandPlan.GetAvailableBands(model)
+	if len(bands) == 0 {
+		return reported
+	}
+	sort.Strings(bands)
+	return bands
+}
+
+// getCommandSupportFromAdapter reports which commands the adapter's radio
+// accepts; adapters that do not say accept all of them.
+func (m *Manager) getCommandSupportFromAdapter(radioAdapter adapter.IRadioAdapter) (setPower, setChannel bool) {
+	if reporter, ok := radioAdapter.(adapter.CommandSupportReporter); ok {
+		return reporter.SupportsSetPower(), reporter.SupportsSetChannel()
+	}
+	return true, true
+}
+
 func (m *Manager) determineStatus(err error) string {
 	if err != nil {
 		return "offline"
@@ -2,11 +2,14 @@ package radio
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
 )
 
 // MockAdapter is a mock implementation of IRadioAdapter for testing.
@@ -16,6 +19,7 @@ type MockAdapter struct {
 	SetFrequencyFunc               func(ctx context.Context, frequencyMhz float64) error
 	ReadPowerActualFunc            func(ctx context.Context) (float64, error)
 	SupportedFrequencyProfilesFunc func(ctx context.Context) ([]adapter.FrequencyProfile, error)
+	PingFunc                       func(ctx context.Context) error
 }
 
 func (m *MockAdapter) GetState(ctx context.Context) (*adapter.RadioState, error) {
@@ -59,6 +63,13 @@ func (m *MockAdapter) SupportedFrequencyProfiles(ctx context.Context) ([]adapter
 	}, nil
 }
 
+func (m *MockAdapter) Ping(ctx context.Context) error {
+	if m.PingFunc != nil {
+		return m.PingFunc(ctx)
+	}
+	return nil
+}
+
 func TestNewManager(t *testing.T) {
 	manager := NewManager()
 
@@ -128,6 +139,46 @@ func TestLoadCapabilities(t *testing.T) {
 	}
 }
 
+func TestLoadCapabilitiesFallsBackToBandPlan(t *testing.T) {
+	manager := NewManager()
+	manager.SetBandPlan(&config.SilvusBandPlan{
+		Models: map[string]map[string][]config.SilvusChannel{
+			"Unknown-Radio": {
+				"2.4GHz": {
+					{ChannelIndex: 6, FrequencyMhz: 2437.0},
+					{ChannelIndex: 1, FrequencyMhz: 2412.0},
+				},
+			},
+		},
+	})
+	// The adapter reports no frequency profiles and so no channels
+	mockAdapter := &MockAdapter{
+		SupportedFrequencyProfilesFunc: func(ctx context.Context) ([]adapter.FrequencyProfile, error) {
+			return []adapter.FrequencyProfile{}, nil
+		},
+	}
+
+	if err := manager.LoadCapabilities("radio-01", mockAdapter, 2*time.Second); err != nil {
+		t.Fatalf("LoadCapabilities() failed: %v", err)
+	}
+
+	want := []adapter.Channel{{Index: 1, FrequencyMhz: 2412.0}, {Index: 6, FrequencyMhz: 2437.0}}
+	if got := manager.radios["radio-01"].Capabilities.Channels; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected band plan channels %+v, got %+v", want, got)
+	}
+	if got := manager.radios["radio-01"].Capabilities.Bands; !reflect.DeepEqual(got, []string{"2.4GHz"}) {
+		t.Errorf("Expected band plan bands [2.4GHz], got %v", got)
+	}
+
+	// Channels the adapter reports take precedence over the band plan
+	if err := manager.LoadCapabilities("radio-02", &MockAdapter{}, 2*time.Second); err != nil {
+		t.Fatalf("LoadCapabilities() failed: %v", err)
+	}
+	if got := manager.radios["radio-02"].Capabilities.Channels; len(got) != 3 || got[0].FrequencyMhz != 2412.0 {
+		t.Errorf("Expected adapter channels, got %+v", got)
+	}
+}
+
 func TestLoadCapabilitiesWithError(t *testing.T) {
 	manager := NewManager()
 
@@ -420,6 +471,19 @@ func TestUpdateStatus(t *testing.T) {
 		t.Errorf("Expected status 'offline', got '%s'", radio.Status)
 	}
 
+	// A status change or a new adapter moves the status epoch on; an
+	// unchanged status does not
+	epoch := radio.StatusEpoch
+	_ = manager.UpdateStatus("radio-01", "offline")
+	if radio.StatusEpoch != epoch {
+		t.Errorf("Expected epoch %d for an unchanged status, got %d", epoch, radio.StatusEpoch)
+	}
+	_ = manager.UpdateStatus("radio-01", "online")
+	_ = manager.SetAdapter("radio-01", &MockAdapter{})
+	if radio.StatusEpoch != epoch+2 {
+		t.Errorf("Expected epoch %d after a status change and reconnect, got %d", epoch+2, radio.StatusEpoch)
+	}
+
 	// Test with non-existent radio
 	err = manager.UpdateStatus("radio-99", "offline")
 	if err == nil {
@@ -484,6 +548,44 @@ func TestRefreshCapabilities(t *testing.T) {
 	}
 }
 
+func TestCapabilitiesAge(t *testing.T) {
+	manager := NewManager()
+	clock := time.Now()
+	manager.now = func() time.Time { return clock }
+	mockAdapter := &MockAdapter{}
+	if err := manager.LoadCapabilities("radio-01", mockAdapter, 5*time.Second); err != nil {
+		t.Fatalf("LoadCapabilities() failed: %v", err)
+	}
+
+	clock = clock.Add(time.Hour)
+	if age, err := manager.CapabilitiesAge("radio-01"); err != nil || age != time.Hour {
+		t.Errorf("Expected age 1h, got %v (%v)", age, err)
+	}
+
+	// A failed refresh keeps the old load time
+	mockAdapter.SupportedFrequencyProfilesFunc = func(ctx context.Context) ([]adapter.FrequencyProfile, error) {
+		return nil, errors.New("radio unreachable")
+	}
+	if err := manager.RefreshCapabilities("radio-01", 5*time.Second); err == nil {
+		t.Fatal("Expected refresh to fail")
+	}
+	if age, _ := manager.CapabilitiesAge("radio-01"); age != time.Hour {
+		t.Errorf("Expected age 1h after failed refresh, got %v", age)
+	}
+
+	mockAdapter.SupportedFrequencyProfilesFunc = nil
+	if err := manager.RefreshCapabilities("radio-01", 5*time.Second); err != nil {
+		t.Fatalf("RefreshCapabilities() failed: %v", err)
+	}
+	if age, _ := manager.CapabilitiesAge("radio-01"); age != 0 {
+		t.Errorf("Expected age 0 after refresh, got %v", age)
+	}
+
+	if _, err := manager.CapabilitiesAge("radio-99"); err == nil {
+		t.Error("Expected error for non-existent radio")
+	}
+}
+
 func TestMultipleRadios(t *testing.T) {
 	manager := NewManager()
 
@@ -563,3 +665,62 @@ type MockError struct {
 func (e *MockError) Error() string {
 	return e.Message
 }
+
+func TestGetAndSetAdapter(t *testing.T) {
+	manager := NewManager()
+	first := &MockAdapter{}
+	if err := manager.LoadCapabilities("radio-01", first, time.Second); err != nil {
+		t.Fatalf("LoadCapabilities failed: %v", err)
+	}
+
+	got, err := manager.GetAdapter("radio-01")
+	if err != nil || got != first {
+		t.Errorf("Expected registered adapter, got %v, %v", got, err)
+	}
+	if _, err := manager.GetAdapter("radio-02"); err == nil {
+		t.Error("Expected error for unknown radio")
+	}
+
+	second := &MockAdapter{}
+	if err := manager.SetAdapter("radio-01", second); err != nil {
+		t.Fatalf("SetAdapter failed: %v", err)
+	}
+	if got, _ := manager.GetAdapter("radio-01"); got != second {
+		t.Error("Expected SetAdapter to replace the adapter")
+	}
+	if err := manager.SetAdapter("radio-02", second); err == nil {
+		t.Error("Expected SetAdapter to reject unknown radio")
+	}
+}
+
+// fixedPowerAdapter is a MockAdapter for a radio that cannot change power.
+type fixedPowerAdapter struct {
+	MockAdapter
+}
+
+func (a *fixedPowerAdapter) SupportsSetPower() bool   { return false }
+func (a *fixedPowerAdapter) SupportsSetChannel() bool { return true }
+
+func TestLoadCapabilitiesDiscoversCommandSupport(t *testing.T) {
+	manager := NewManager()
+	if err := manager.LoadCapabilities("radio-01", &MockAdapter{}, 2*time.Second); err != nil {
+		t.Fatalf("LoadCapabilities() failed: %v", err)
+	}
+	if err := manager.LoadCapabilities("radio-02", &fixedPowerAdapter{}, 2*time.Second); err != nil {
+		t.Fatalf("LoadCapabilities() failed: %v", err)
+	}
+
+	// Adapters that do not report support accept every command
+	caps := manager.radios["radio-01"].Capabilities
+	if !caps.CanSetPower() || !caps.CanSetChannel() {
+		t.Errorf("Expected radio-01 to support all commands, got %+v", caps)
+	}
+
+	caps = manager.radios["radio-02"].Capabilities
+	if caps.SupportsSetPower == nil || *caps.SupportsSetPower || caps.CanSetPower() {
+		t.Errorf("Expected radio-02 to report fixed power, got %+v", caps)
+	}
+	if !caps.CanSetChannel() {
+		t.Errorf("Expected radio-02 to support channel changes, got %+v", caps)
+	}
+}
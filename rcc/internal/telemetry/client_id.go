@@ -0,0 +1,37 @@
+package telemetry
+
+import (
+	"net/http"
+
+	"github.com/radio-control/rcc/internal/auth"
+)
+
+// ClientIDHeader carries an optional stable connection ID on SSE
+// subscriptions. Subscribing again with the same ID and token subject closes
+// the earlier connection, as when a reconnecting browser briefly holds both.
+const ClientIDHeader = "X-Client-ID"
+
+// requestSubject returns the subject of the token that authenticated r, or
+// "" when the request carries no claims.
+func requestSubject(r *http.Request) string {
+	if claims := auth.GetClaimsFromRequest(r); claims != nil {
+		return claims.Subject
+	}
+	return ""
+}
+
+// duplicatesOf returns the registered clients with client's connection ID
+// opened by the same subject; another subject cannot close a stream by
+// reusing its ID. Caller must hold h.mu.
+func (h *Hub) duplicatesOf(client *Client) []*Client {
+	if client.connectionID == "" {
+		return nil
+	}
+	var duplicates []*Client
+	for _, other := range h.clients {
+		if other.connectionID == client.connectionID && other.subject == client.subject {
+			duplicates = append(duplicates, other)
+		}
+	}
+	return duplicates
+}
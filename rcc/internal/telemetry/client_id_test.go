@@ -0,0 +1,104 @@
+package telemetry
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/internal/config"
+)
+
+func subscribeWithClientID(ctx context.Context, hub *Hub, clientID string) (*threadSafeResponseWriter, chan error) {
+	return subscribeAs(ctx, hub, clientID, "")
+}
+
+// subscribeAs subscribes with clientID as a token authenticated for subject.
+func subscribeAs(ctx context.Context, hub *Hub, clientID, subject string) (*threadSafeResponseWriter, chan error) {
+	req := httptest.NewRequest("GET", "/telemetry", nil)
+	if subject != "" {
+		req = req.WithContext(context.WithValue(req.Context(), auth.ClaimsKey, &auth.Claims{Subject: subject}))
+	}
+	if clientID != "" {
+		req.Header.Set(ClientIDHeader, clientID)
+	}
+	w := newThreadSafeResponseWriter()
+	done := make(chan error, 1)
+	go func() { done <- hub.Subscribe(ctx, w, req) }()
+	return w, done
+}
+
+func TestSubscribeWithSameClientIDClosesEarlierConnection(t *testing.T) {
+	hub := NewHub(config.LoadCBTimingBaseline())
+	defer hub.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, firstDone := subscribeWithClientID(ctx, hub, "browser-tab-1")
+	waitForClients(t, hub, 1)
+	second, secondDone := subscribeWithClientID(ctx, hub, "browser-tab-1")
+
+	select {
+	case <-firstDone:
+	case <-time.After(2 * time.Second):
+		t.Fatal("First connection was not closed by the duplicate subscription")
+	}
+	waitForClients(t, hub, 1)
+
+	// The replacement keeps receiving events
+	_ = hub.PublishRadio("radio-01", Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": 20}})
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(second.String(), "event: powerChanged") {
+		if time.Now().After(deadline) {
+			t.Fatalf("Replacement connection did not receive the event:\n%s", second.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	select {
+	case err := <-secondDone:
+		t.Fatalf("Replacement connection closed: %v", err)
+	default:
+	}
+}
+
+func TestSubscribeWithDifferentClientIDsKeepsBoth(t *testing.T) {
+	hub := NewHub(config.LoadCBTimingBaseline())
+	defer hub.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subscribeWithClientID(ctx, hub, "browser-tab-1")
+	waitForClients(t, hub, 1)
+	subscribeWithClientID(ctx, hub, "browser-tab-2")
+	waitForClients(t, hub, 2)
+	subscribeWithClientID(ctx, hub, "")
+	waitForClients(t, hub, 3)
+
+	time.Sleep(50 * time.Millisecond)
+	if n := hub.clientCount(); n != 3 {
+		t.Errorf("Expected 3 clients, have %d", n)
+	}
+}
+
+func TestSubscribeWithSameClientIDFromOtherSubjectKeepsBoth(t *testing.T) {
+	hub := NewHub(config.LoadCBTimingBaseline())
+	defer hub.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, firstDone := subscribeAs(ctx, hub, "browser-tab-1", "operator-a")
+	waitForClients(t, hub, 1)
+	subscribeAs(ctx, hub, "browser-tab-1", "operator-b")
+	waitForClients(t, hub, 2)
+
+	select {
+	case err := <-firstDone:
+		t.Fatalf("Another subject closed the first connection: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if n := hub.clientCount(); n != 2 {
+		t.Errorf("Expected 2 clients, have %d", n)
+	}
+}
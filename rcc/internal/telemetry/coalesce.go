@@ -0,0 +1,96 @@
+package telemetry
+
+import (
+	"time"
+)
+
+// coalescedEvent is the latest of a run of same-type events for one radio,
+// held until its window ends.
+type coalescedEvent struct {
+	event Event
+	timer *time.Timer
+}
+
+// coalesceWindow returns how long events of eventType are held for
+// coalescing, per the config EventCoalesceWindows. ready and fault events
+// are never coalesced.
+func (h *Hub) coalesceWindow(eventType string) time.Duration {
+	if h.config == nil || eventType == "ready" || eventType == "fault" {
+		return 0
+	}
+	return h.config.EventCoalesceWindows[eventType]
+}
+
+// coalesce holds a radio event whose type has a coalescing window, replacing
+// any same-type event already held for the radio, and reports whether it
+// did. The held event is published when the window that started with the
+// first event of the run ends, or as soon as a different-type event for
+// the radio is published, so events keep their order. Event IDs are
+// assigned when held events are published, so they stay monotonic.
+func (h *Hub) coalesce(event Event) bool {
+	if event.Radio == "" {
+		return false
+	}
+	window := h.coalesceWindow(event.Type)
+
+	h.coalesceMu.Lock()
+	held := h.coalesced[event.Radio]
+	if held != nil && window > 0 && held.event.Type == event.Type {
+		held.event = event
+		h.coalesceMu.Unlock()
+		return true
+	}
+
+	// A different event ends the radio's run; publish the held one first
+	var flushed *Event
+	if held != nil {
+		held.timer.Stop()
+		delete(h.coalesced, event.Radio)
+		flushed = &held.event
+	}
+	if window > 0 {
+		if h.coalesced == nil {
+			h.coalesced = make(map[string]*coalescedEvent)
+		}
+		next := &coalescedEvent{event: event}
+		next.timer = time.AfterFunc(window, func() { h.flushCoalesced(event.Radio, next) })
+		h.coalesced[event.Radio] = next
+	}
+	h.coalesceMu.Unlock()
+
+	if flushed != nil {
+		_ = h.publish(*flushed)
+	}
+	return window > 0
+}
+
+// flushCoalesced publishes held when its window ends, unless a later event
+// has already published it.
+func (h *Hub) flushCoalesced(radioID string, held *coalescedEvent) {
+	h.coalesceMu.Lock()
+	if h.coalesced[radioID] != held {
+		h.coalesceMu.Unlock()
+		return
+	}
+	delete(h.coalesced, radioID)
+	event := held.event
+	h.coalesceMu.Unlock()
+
+	_ = h.publish(event)
+}
+
+// flushAllCoalesced publishes every held event now, e.g. before shutdown.
+func (h *Hub) flushAllCoalesced() {
+	h.coalesceMu.Lock()
+	events := make([]Event, 0, len(h.coalesced))
+	for radioID, held := range h.coalesced {
+		held.timer.Stop()
+		events = append(events, held.event)
+		delete(h.coalesced, radioID)
+	}
+	h.coalesceMu.Unlock()
+
+	for _, event := range events {
+		_ = h.publish(event)
+	}
+}
@@ -0,0 +1,139 @@
+package telemetry
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/config"
+)
+
+func coalesceConfig(window time.Duration) *config.TimingConfig {
+	cfg := config.LoadCBTimingBaseline()
+	cfg.EventCoalesceWindows = map[string]time.Duration{
+		"channelChanged": window,
+		"fault":          window, // ignored: faults are never coalesced
+	}
+	return cfg
+}
+
+// collectEvents subscribes a client and returns the radio events it is sent
+// until quiet passes without one.
+func collectEvents(t *testing.T, hub *Hub, publish func(), quiet time.Duration) []Event {
+	t.Helper()
+	events := make(chan Event, 100)
+	client := &Client{ID: "coalesce-test", Request: httptest.NewRequest("GET", "/telemetry", nil), Events: make(chan Event, 100)}
+	client.Context, client.Cancel = context.WithCancel(context.Background())
+	defer client.Cancel()
+	client.send = func(event Event) error {
+		events <- event
+		return nil
+	}
+	go func() { _ = hub.serveClient(client) }()
+	waitForClients(t, hub, 1)
+
+	publish()
+
+	var received []Event
+	for {
+		select {
+		case event := <-events:
+			if event.Radio != "" {
+				received = append(received, event)
+			}
+		case <-time.After(quiet):
+			return received
+		}
+	}
+}
+
+func assertMonotonicIDs(t *testing.T, events []Event) {
+	t.Helper()
+	for i := 1; i < len(events); i++ {
+		if events[i].ID <= events[i-1].ID {
+			t.Errorf("Event IDs not monotonic: %d after %d", events[i].ID, events[i-1].ID)
+		}
+	}
+}
+
+func TestCoalesceDeliversLatestChannelChanged(t *testing.T) {
+	hub := NewHub(coalesceConfig(50 * time.Millisecond))
+	defer hub.Stop()
+
+	received := collectEvents(t, hub, func() {
+		_ = hub.PublishRadio("radio-01", Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": 20.0}})
+		for i := 0; i < 10; i++ {
+			_ = hub.PublishRadio("radio-01", Event{Type: "channelChanged", Data: map[string]interface{}{"frequencyMhz": 2412.0 + float64(i)}})
+		}
+	}, 200*time.Millisecond)
+
+	if len(received) != 2 || received[0].Type != "powerChanged" || received[1].Type != "channelChanged" {
+		t.Fatalf("Expected powerChanged then one channelChanged, got %+v", received)
+	}
+	if freq := received[1].Data["frequencyMhz"]; freq != 2421.0 {
+		t.Errorf("Expected the latest frequency 2421, got %v", freq)
+	}
+	assertMonotonicIDs(t, received)
+
+	// Replay holds the coalesced event under the ID it was delivered with
+	buffered := hub.EventsAfter("radio-01", 0, 0)
+	if len(buffered) != 2 || buffered[1].ID != received[1].ID {
+		t.Errorf("Expected the buffer to match delivery, got %+v", buffered)
+	}
+
+	// systemStats still counts every command outcome
+	if acks := hub.stats.acks.Load(); acks != 11 {
+		t.Errorf("Expected 11 acknowledged events counted, got %d", acks)
+	}
+}
+
+func TestCoalesceNeverHoldsFaults(t *testing.T) {
+	hub := NewHub(coalesceConfig(50 * time.Millisecond))
+	defer hub.Stop()
+
+	channel := func(freq float64) Event {
+		return Event{Type: "channelChanged", Data: map[string]interface{}{"frequencyMhz": freq}}
+	}
+	received := collectEvents(t, hub, func() {
+		_ = hub.PublishRadio("radio-01", channel(2412))
+		_ = hub.PublishRadio("radio-01", channel(2417))
+		_ = hub.PublishRadio("radio-01", Event{Type: "fault", Data: map[string]interface{}{"code": "BUSY"}})
+		_ = hub.PublishRadio("radio-01", Event{Type: "fault", Data: map[string]interface{}{"code": "UNAVAILABLE"}})
+		_ = hub.PublishRadio("radio-01", channel(2422))
+	}, 200*time.Millisecond)
+
+	// The fault ends the first run, which is delivered ahead of it
+	want := []struct {
+		eventType string
+		key       string
+		value     interface{}
+	}{
+		{"channelChanged", "frequencyMhz", 2417.0},
+		{"fault", "code", "BUSY"},
+		{"fault", "code", "UNAVAILABLE"},
+		{"channelChanged", "frequencyMhz", 2422.0},
+	}
+	if len(received) != len(want) {
+		t.Fatalf("Expected %d events, got %+v", len(want), received)
+	}
+	for i, w := range want {
+		if received[i].Type != w.eventType || received[i].Data[w.key] != w.value {
+			t.Errorf("Event %d: expected %s with %s=%v, got %+v", i, w.eventType, w.key, w.value, received[i])
+		}
+	}
+	assertMonotonicIDs(t, received)
+}
+
+func TestStopFlushesCoalescedEvents(t *testing.T) {
+	hub := NewHub(coalesceConfig(time.Hour))
+	_ = hub.PublishRadio("radio-01", Event{Type: "channelChanged", Data: map[string]interface{}{"frequencyMhz": 2412.0}})
+	if len(hub.EventsAfter("radio-01", 0, 0)) != 0 {
+		t.Fatal("Expected the event to be held")
+	}
+
+	hub.Stop()
+	if buffered := hub.EventsAfter("radio-01", 0, 0); len(buffered) != 1 {
+		t.Errorf("Expected Stop to publish the held event, got %+v", buffered)
+	}
+}
@@ -0,0 +1,63 @@
+package telemetry
+
+import (
+	"time"
+)
+
+// shutdownEventType is sent to every client when the hub stops.
+const shutdownEventType = "shutdown"
+
+// shutdownReconnectDelay is the reconnect hint in the shutdown event, long
+// enough for a restarted service to come back up.
+const shutdownReconnectDelay = 5 * time.Second
+
+// drainClients has every connected client's handler write a shutdown event,
+// then waits up to the config SSEShutdownGrace for them to deliver it and
+// disconnect. A grace of 0 or less skips the drain.
+func (h *Hub) drainClients() {
+	grace := time.Duration(0)
+	if h.config != nil {
+		grace = h.config.SSEShutdownGrace
+	}
+	if grace <= 0 {
+		return
+	}
+
+	h.mu.RLock()
+	remaining := len(h.clients)
+	h.mu.RUnlock()
+	if remaining == 0 {
+		return
+	}
+	deadline := time.After(grace)
+
+	// Set before closing draining, which publishes it to the handlers
+	h.shutdownEvent = Event{
+		ID:   h.getNextEventID(""),
+		Type: shutdownEventType,
+		Data: map[string]interface{}{
+			"reason":           "server shutting down",
+			"reconnectAfterMs": shutdownReconnectDelay.Milliseconds(),
+			"ts":               time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+	close(h.draining)
+
+	// Clients unregister once the shutdown event is written
+	poll := time.NewTicker(10 * time.Millisecond)
+	defer poll.Stop()
+	for {
+		h.mu.RLock()
+		remaining = len(h.clients)
+		h.mu.RUnlock()
+		if remaining == 0 {
+			return
+		}
+
+		select {
+		case <-poll.C:
+		case <-deadline:
+			return
+		}
+	}
+}
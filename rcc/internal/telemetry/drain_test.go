@@ -0,0 +1,61 @@
+package telemetry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/config"
+)
+
+func TestStopDrainsClientsWithShutdownEvent(t *testing.T) {
+	hub := NewHub(config.LoadCBTimingBaseline())
+	conn := dialTelemetryWS(t, hub, "")
+
+	if ready := readFrame(t, conn); ready.Type != "ready" {
+		t.Fatalf("Expected ready frame, got %+v", ready)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		hub.Stop()
+		close(stopped)
+	}()
+
+	shutdown := readFrame(t, conn)
+	if shutdown.Type != shutdownEventType {
+		t.Fatalf("Expected shutdown frame, got %+v", shutdown)
+	}
+	if shutdown.Data["reconnectAfterMs"] != float64(shutdownReconnectDelay.Milliseconds()) {
+		t.Errorf("Expected reconnect hint in shutdown event, got %v", shutdown.Data)
+	}
+
+	// The connection closes after the shutdown event
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, _, err := conn.ReadMessage(); err == nil {
+		t.Error("Expected connection to close after shutdown event")
+	}
+
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stop did not return after clients drained")
+	}
+}
+
+func TestStopSkipsDrainWithoutGrace(t *testing.T) {
+	cfg := config.LoadCBTimingBaseline()
+	cfg.SSEShutdownGrace = 0
+	hub := NewHub(cfg)
+	conn := dialTelemetryWS(t, hub, "")
+
+	if ready := readFrame(t, conn); ready.Type != "ready" {
+		t.Fatalf("Expected ready frame, got %+v", ready)
+	}
+	hub.Stop()
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var event Event
+	if err := conn.ReadJSON(&event); err == nil && event.Type == shutdownEventType {
+		t.Error("Expected no shutdown event with zero grace")
+	}
+}
@@ -0,0 +1,72 @@
+package telemetry
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// EventHMACField is the data field carrying an event's HMAC when
+// EventHMACKey is configured.
+const EventHMACField = "hmac"
+
+// EventHMAC returns the hex HMAC-SHA256 of an event under key. The signed
+// message is the event ID, a newline, the event type, a newline, and the
+// event data without its hmac field as compact JSON with sorted keys and no
+// HTML escaping.
+func EventHMAC(key []byte, id int64, eventType string, data map[string]interface{}) (string, error) {
+	unsigned := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		if k != EventHMACField {
+			unsigned[k] = v
+		}
+	}
+
+	var payload bytes.Buffer
+	enc := json.NewEncoder(&payload)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(unsigned); err != nil {
+		return "", fmt.Errorf("failed to encode event data: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "%d\n%s\n", id, eventType)
+	mac.Write(bytes.TrimSuffix(payload.Bytes(), []byte("\n")))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// VerifyEventHMAC reports whether data carries a valid HMAC for the event
+// under key.
+func VerifyEventHMAC(key []byte, id int64, eventType string, data map[string]interface{}) bool {
+	got, ok := data[EventHMACField].(string)
+	if !ok {
+		return false
+	}
+	want, err := EventHMAC(key, id, eventType, data)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(got), []byte(want))
+}
+
+// signEvent returns event with its HMAC added to a copy of its data, or
+// event unchanged when no key is configured.
+func (h *Hub) signEvent(event Event) (Event, error) {
+	if h.config == nil || h.config.EventHMACKey == "" {
+		return event, nil
+	}
+	sum, err := EventHMAC([]byte(h.config.EventHMACKey), event.ID, event.Type, event.Data)
+	if err != nil {
+		return event, err
+	}
+	signed := make(map[string]interface{}, len(event.Data)+1)
+	for k, v := range event.Data {
+		signed[k] = v
+	}
+	signed[EventHMACField] = sum
+	event.Data = signed
+	return event, nil
+}
@@ -0,0 +1,58 @@
+package telemetry
+
+import (
+	"testing"
+
+	"github.com/radio-control/rcc/internal/config"
+)
+
+func TestEventsCarryHMACWhenKeyConfigured(t *testing.T) {
+	cfg := config.LoadCBTimingBaseline()
+	cfg.EventHMACKey = "shared-secret"
+	hub := NewHub(cfg)
+	defer hub.Stop()
+	conn := dialTelemetryWS(t, hub, "")
+
+	ready := readFrame(t, conn)
+	if !VerifyEventHMAC([]byte("shared-secret"), ready.ID, ready.Type, ready.Data) {
+		t.Errorf("Expected ready event to carry a valid HMAC, got %v", ready.Data)
+	}
+
+	data := map[string]interface{}{"radioId": "radio-01", "powerDbm": 20, "note": "<tx>"}
+	if err := hub.PublishRadio("radio-01", Event{Type: "powerChanged", Data: data}); err != nil {
+		t.Fatalf("PublishRadio failed: %v", err)
+	}
+	event := readFrame(t, conn)
+	if event.Type != "powerChanged" {
+		t.Fatalf("Expected powerChanged, got %+v", event)
+	}
+	if !VerifyEventHMAC([]byte("shared-secret"), event.ID, event.Type, event.Data) {
+		t.Errorf("Expected valid HMAC, got %v", event.Data)
+	}
+	if VerifyEventHMAC([]byte("other-secret"), event.ID, event.Type, event.Data) {
+		t.Error("Expected HMAC to fail under a different key")
+	}
+	if _, mutated := data[EventHMACField]; mutated {
+		t.Error("Signing must not modify the publisher's data map")
+	}
+
+	// Tampering with any field breaks verification
+	event.Data["powerDbm"] = 30.0
+	if VerifyEventHMAC([]byte("shared-secret"), event.ID, event.Type, event.Data) {
+		t.Error("Expected HMAC to fail after tampering with data")
+	}
+}
+
+func TestEventsUnsignedByDefault(t *testing.T) {
+	hub := NewHub(config.LoadCBTimingBaseline())
+	defer hub.Stop()
+	conn := dialTelemetryWS(t, hub, "")
+	readFrame(t, conn)
+
+	if err := hub.PublishRadio("radio-01", Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": 20}}); err != nil {
+		t.Fatalf("PublishRadio failed: %v", err)
+	}
+	if event := readFrame(t, conn); event.Data[EventHMACField] != nil {
+		t.Errorf("Expected no HMAC without a key, got %v", event.Data)
+	}
+}
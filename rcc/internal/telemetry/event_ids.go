@@ -0,0 +1,122 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+)
+
+// eventIDReserveBlock is how many event IDs are reserved per state file write.
+// After a crash, counters resume from the reserved ceiling, so IDs never repeat.
+const eventIDReserveBlock = 1000
+
+// eventIDStore persists per-radio event ID counters so IDs keep increasing
+// across restarts (CB-TIMING §6.1 Last-Event-ID resume).
+type eventIDStore struct {
+	path string
+
+	mu       sync.Mutex
+	reserved map[string]int64 // Highest ID each counter may issue before the next write
+}
+
+// PersistEventIDs enables event ID persistence at path. Saved counters are
+// loaded so event IDs continue above their pre-restart values. Must be called
+// before any events are published.
+func (h *Hub) PersistEventIDs(path string) error {
+	saved, err := readEventIDState(path)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for radioID, id := range saved {
+		counter, exists := h.radioIDs[radioID]
+		if !exists {
+			counter = new(int64)
+			h.radioIDs[radioID] = counter
+		}
+		if atomic.LoadInt64(counter) < id {
+			atomic.StoreInt64(counter, id)
+		}
+	}
+	h.eventIDs = &eventIDStore{path: path, reserved: saved}
+	return nil
+}
+
+// reserve ensures id is covered by the persisted ceiling for radioID,
+// reserving the next block when it is not.
+func (s *eventIDStore) reserve(radioID string, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id <= s.reserved[radioID] {
+		return nil
+	}
+	s.reserved[radioID] = id + eventIDReserveBlock
+	return writeEventIDState(s.path, s.reserved)
+}
+
+// save persists the exact counters, used on clean shutdown.
+func (s *eventIDStore) save(counters map[string]int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for radioID, id := range counters {
+		s.reserved[radioID] = id
+	}
+	return writeEventIDState(s.path, s.reserved)
+}
+
+// readEventIDState reads saved counters; a missing file yields no counters.
+func readEventIDState(path string) (map[string]int64, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return make(map[string]int64), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event ID state: %w", err)
+	}
+
+	counters := make(map[string]int64)
+	if err := json.Unmarshal(data, &counters); err != nil {
+		return nil, fmt.Errorf("failed to parse event ID state %s: %w", path, err)
+	}
+	return counters, nil
+}
+
+// writeEventIDState writes counters atomically via a temp file and rename.
+func writeEventIDState(path string, counters map[string]int64) error {
+	data, err := json.Marshal(counters)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event ID state: %w", err)
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("failed to write event ID state: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to path via a temp file and rename, so readers
+// never see a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
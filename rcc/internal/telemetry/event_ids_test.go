@@ -0,0 +1,87 @@
+package telemetry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// issueIDs assigns n event IDs for radioID and returns the last one.
+func issueIDs(hub *Hub, radioID string, n int) int64 {
+	var last int64
+	for i := 0; i < n; i++ {
+		last = hub.getNextEventID(radioID)
+	}
+	return last
+}
+
+func TestEventIDsResumeAfterRestart(t *testing.T) {
+	cfg := config.LoadCBTimingBaseline()
+	path := filepath.Join(t.TempDir(), "event-ids.json")
+
+	hub := NewHub(cfg)
+	if err := hub.PersistEventIDs(path); err != nil {
+		t.Fatalf("PersistEventIDs failed: %v", err)
+	}
+	lastRadio := issueIDs(hub, "radio-01", 5)
+	lastGlobal := issueIDs(hub, "", 3)
+	hub.Stop()
+
+	restarted := NewHub(cfg)
+	defer restarted.Stop()
+	if err := restarted.PersistEventIDs(path); err != nil {
+		t.Fatalf("PersistEventIDs after restart failed: %v", err)
+	}
+
+	if id := restarted.getNextEventID("radio-01"); id != lastRadio+1 {
+		t.Errorf("Expected radio-01 to resume at %d, got %d", lastRadio+1, id)
+	}
+	if id := restarted.getNextEventID(""); id != lastGlobal+1 {
+		t.Errorf("Expected global counter to resume at %d, got %d", lastGlobal+1, id)
+	}
+}
+
+func TestEventIDsStayAboveIssuedAfterCrash(t *testing.T) {
+	cfg := config.LoadCBTimingBaseline()
+	path := filepath.Join(t.TempDir(), "event-ids.json")
+
+	hub := NewHub(cfg)
+	if err := hub.PersistEventIDs(path); err != nil {
+		t.Fatalf("PersistEventIDs failed: %v", err)
+	}
+	last := issueIDs(hub, "radio-01", eventIDReserveBlock+10)
+	// No Stop: simulate a crash
+
+	restarted := NewHub(cfg)
+	defer restarted.Stop()
+	if err := restarted.PersistEventIDs(path); err != nil {
+		t.Fatalf("PersistEventIDs after crash failed: %v", err)
+	}
+	if id := restarted.getNextEventID("radio-01"); id <= last {
+		t.Errorf("Expected ID above pre-crash value %d, got %d", last, id)
+	}
+}
+
+func TestEventIDsWithoutPersistenceStartAtOne(t *testing.T) {
+	hub := NewHub(config.LoadCBTimingBaseline())
+	defer hub.Stop()
+
+	if id := hub.getNextEventID("radio-01"); id != 1 {
+		t.Errorf("Expected first ID 1 without persistence, got %d", id)
+	}
+}
+
+func TestPersistEventIDsRejectsCorruptState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "event-ids.json")
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatalf("Failed to write state: %v", err)
+	}
+
+	hub := NewHub(config.LoadCBTimingBaseline())
+	defer hub.Stop()
+	if err := hub.PersistEventIDs(path); err == nil {
+		t.Error("Expected error for corrupt event ID state")
+	}
+}
@@ -0,0 +1,153 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// EventStore persists per-radio event buffers so Last-Event-ID replay
+// survives restarts (CB-TIMING §6.1).
+type EventStore interface {
+	// Load returns the saved events per radio, oldest first.
+	Load() (map[string][]StoredEvent, error)
+
+	// Save replaces the saved events for radioID.
+	Save(radioID string, events []StoredEvent) error
+}
+
+// StoredEvent is a buffered event with the time it was buffered, so
+// EventBufferRetention still applies after a restart.
+type StoredEvent struct {
+	Event
+	BufferedAt time.Time `json:"bufferedAt"`
+}
+
+// FileEventStore is an EventStore backed by a single JSON file, rewritten
+// atomically on every save.
+type FileEventStore struct {
+	path string
+
+	mu     sync.Mutex
+	events map[string][]StoredEvent
+	loaded bool
+}
+
+// NewFileEventStore creates a file-backed event store at path. The file is
+// created on first save.
+func NewFileEventStore(path string) *FileEventStore {
+	return &FileEventStore{path: path, events: make(map[string][]StoredEvent)}
+}
+
+// Load reads the saved events; a missing file yields no events.
+func (s *FileEventStore) Load() (map[string][]StoredEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.loadLocked(); err != nil {
+		return nil, err
+	}
+
+	events := make(map[string][]StoredEvent, len(s.events))
+	for radioID, radioEvents := range s.events {
+		events[radioID] = append([]StoredEvent(nil), radioEvents...)
+	}
+	return events, nil
+}
+
+// Save replaces the events for radioID and rewrites the file.
+func (s *FileEventStore) Save(radioID string, events []StoredEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Keep other radios' saved events when saving before any Load
+	if err := s.loadLocked(); err != nil {
+		return err
+	}
+	s.events[radioID] = events
+
+	data, err := json.Marshal(s.events)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event store: %w", err)
+	}
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("failed to write event store: %w", err)
+	}
+	return nil
+}
+
+// loadLocked reads the file once; callers must hold s.mu.
+func (s *FileEventStore) loadLocked() error {
+	if s.loaded {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		s.loaded = true
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read event store: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.events); err != nil {
+		return fmt.Errorf("failed to parse event store %s: %w", s.path, err)
+	}
+	s.loaded = true
+	return nil
+}
+
+// NewHubWithStore creates a hub whose per-radio event buffers are saved to
+// store on every buffered event and reloaded here, so Last-Event-ID replay
+// works across restarts. Reloaded events older than EventBufferRetention
+// are not replayed. Event ID counters resume above the reloaded events.
+func NewHubWithStore(timingConfig *config.TimingConfig, store EventStore) (*Hub, error) {
+	saved, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	hub := NewHub(timingConfig)
+	hub.store = store
+
+	loadedAt := time.Now()
+	for radioID, events := range saved {
+		buffer := hub.newEventBuffer()
+		for _, event := range events {
+			// Events saved without a time are aged from this restart
+			bufferedAt := event.BufferedAt
+			if bufferedAt.IsZero() {
+				bufferedAt = loadedAt
+			}
+			buffer.addEventAt(event.Event, bufferedAt)
+		}
+		hub.buffers[radioID] = buffer
+
+		var lastID int64
+		for _, event := range events {
+			if event.ID > lastID {
+				lastID = event.ID
+			}
+		}
+		counter := new(int64)
+		atomic.StoreInt64(counter, lastID)
+		hub.radioIDs[radioID] = counter
+	}
+	return hub, nil
+}
+
+// saveBuffer writes buffer's events for radioID to the hub's store. Saves are
+// serialized so a slower older snapshot never overwrites a newer one.
+func (h *Hub) saveBuffer(radioID string, buffer *EventBuffer) {
+	h.storeMu.Lock()
+	defer h.storeMu.Unlock()
+
+	// Best effort: a failed write only loses replay history after a restart
+	_ = h.store.Save(radioID, buffer.storedEvents())
+}
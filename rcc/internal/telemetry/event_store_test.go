@@ -0,0 +1,103 @@
+package telemetry
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/config"
+)
+
+func TestHubWithStoreReplaysAcrossRestart(t *testing.T) {
+	cfg := config.LoadCBTimingBaseline()
+	path := filepath.Join(t.TempDir(), "events.json")
+
+	hub, err := NewHubWithStore(cfg, NewFileEventStore(path))
+	if err != nil {
+		t.Fatalf("NewHubWithStore failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		hub.PublishRadio("radio-01", Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": float64(20 + i)}})
+	}
+	hub.Stop()
+
+	// A new process reopens the same file
+	restarted, err := NewHubWithStore(cfg, NewFileEventStore(path))
+	if err != nil {
+		t.Fatalf("NewHubWithStore after restart failed: %v", err)
+	}
+	defer restarted.Stop()
+
+	events := restarted.buffers["radio-01"].GetEventsAfter(1)
+	if len(events) != 2 || events[0].ID != 2 || events[1].ID != 3 {
+		t.Fatalf("Expected reloaded events 2 and 3, got %+v", events)
+	}
+
+	conn := dialTelemetryWS(t, restarted, "?radio=radio-01&lastEventId=1")
+	if ready := readFrame(t, conn); ready.Type != "ready" {
+		t.Fatalf("Expected ready frame, got %+v", ready)
+	}
+	for _, want := range []float64{21, 22} {
+		event := readFrame(t, conn)
+		if event.Type != "powerChanged" || event.Data["powerDbm"] != want {
+			t.Errorf("Expected replayed powerChanged %v, got %+v", want, event)
+		}
+	}
+
+	// IDs continue above the persisted events
+	if id := restarted.getNextEventID("radio-01"); id <= 3 {
+		t.Errorf("Expected next radio-01 ID above 3, got %d", id)
+	}
+}
+
+func TestHubWithoutStoreKeepsBuffersInMemory(t *testing.T) {
+	hub := NewHub(config.LoadCBTimingBaseline())
+	defer hub.Stop()
+
+	if hub.store != nil {
+		t.Fatal("Expected NewHub to have no event store")
+	}
+	hub.PublishRadio("radio-01", Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": 20.0}})
+	if size := hub.buffers["radio-01"].GetSize(); size != 1 {
+		t.Errorf("Expected 1 buffered event, got %d", size)
+	}
+}
+
+func TestHubWithStoreAppliesRetentionAcrossRestart(t *testing.T) {
+	cfg := config.LoadCBTimingBaseline()
+	cfg.EventBufferRetention = time.Hour
+	path := filepath.Join(t.TempDir(), "events.json")
+
+	// One event saved two hours ago, one just now
+	store := NewFileEventStore(path)
+	now := time.Now()
+	err := store.Save("radio-01", []StoredEvent{
+		{Event: Event{ID: 1, Type: "powerChanged", Radio: "radio-01", Data: map[string]interface{}{"powerDbm": 20.0}}, BufferedAt: now.Add(-2 * time.Hour)},
+		{Event: Event{ID: 2, Type: "powerChanged", Radio: "radio-01", Data: map[string]interface{}{"powerDbm": 21.0}}, BufferedAt: now},
+	})
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	hub, err := NewHubWithStore(cfg, NewFileEventStore(path))
+	if err != nil {
+		t.Fatalf("NewHubWithStore failed: %v", err)
+	}
+	defer hub.Stop()
+
+	events := hub.buffers["radio-01"].GetEventsAfter(0)
+	if len(events) != 1 || events[0].ID != 2 {
+		t.Fatalf("Expected only event 2 within retention, got %+v", events)
+	}
+
+	// Expired events are dropped from the store on the next save, while
+	// IDs still continue above everything that was saved
+	hub.PublishRadio("radio-01", Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": 22.0}})
+	saved, err := NewFileEventStore(path).Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if got := saved["radio-01"]; len(got) != 2 || got[0].ID != 2 || got[1].ID != 3 || got[1].BufferedAt.IsZero() {
+		t.Errorf("Expected saved events 2 and 3 with buffer times, got %+v", got)
+	}
+}
@@ -0,0 +1,71 @@
+package telemetry
+
+import (
+	"errors"
+	"slices"
+)
+
+// ErrClientNotFound is returned by SetClientFilter when no connected client
+// subscribed with the given connection ID and subject.
+var ErrClientNotFound = errors.New("telemetry client not found")
+
+// heartbeatEventType is never filtered out, so a filtered stream still shows
+// the connection is alive and is not closed for inactivity.
+const heartbeatEventType = "heartbeat"
+
+// ClientFilter limits which events a client receives. An empty filter
+// delivers everything.
+type ClientFilter struct {
+	// Deliver only these event types; empty delivers every type
+	EventTypes []string `json:"eventTypes,omitempty"`
+	// Never deliver these event types
+	ExcludeEventTypes []string `json:"excludeEventTypes,omitempty"`
+	// Deliver only events for these radios; empty delivers every radio.
+	// Events not tied to a radio are always delivered.
+	Radios []string `json:"radios,omitempty"`
+}
+
+// accepts reports whether event passes the filter.
+func (f *ClientFilter) accepts(event Event) bool {
+	if f == nil || event.Type == heartbeatEventType {
+		return true
+	}
+	if len(f.EventTypes) > 0 && !slices.Contains(f.EventTypes, event.Type) {
+		return false
+	}
+	if slices.Contains(f.ExcludeEventTypes, event.Type) {
+		return false
+	}
+	if len(f.Radios) > 0 && event.Radio != "" && !slices.Contains(f.Radios, event.Radio) {
+		return false
+	}
+	return true
+}
+
+// SetClientFilter replaces the event filter of the connected clients that
+// subscribed with connectionID in ClientIDHeader under the token subject
+// subject. It applies from the next published event; events already queued
+// are still delivered. Returns ErrClientNotFound when no such client is
+// connected, including when the stream belongs to another subject, so
+// connection IDs of other subjects' streams are not disclosed.
+func (h *Hub) SetClientFilter(connectionID, subject string, filter ClientFilter) error {
+	if connectionID == "" {
+		return ErrClientNotFound
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	found := false
+	for _, client := range h.clients {
+		if client.connectionID == connectionID && client.subject == subject {
+			f := filter
+			client.filter.Store(&f)
+			found = true
+		}
+	}
+	if !found {
+		return ErrClientNotFound
+	}
+	return nil
+}
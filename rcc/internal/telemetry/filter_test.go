@@ -0,0 +1,88 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/config"
+)
+
+func TestClientFilterAccepts(t *testing.T) {
+	filter := &ClientFilter{
+		EventTypes:        []string{"state", "fault", "powerChanged"},
+		ExcludeEventTypes: []string{"powerChanged"},
+		Radios:            []string{"radio-01"},
+	}
+	tests := []struct {
+		event Event
+		want  bool
+	}{
+		{Event{Type: "state", Radio: "radio-01"}, true},
+		{Event{Type: "state", Radio: "radio-02"}, false},
+		{Event{Type: "channelChanged", Radio: "radio-01"}, false},
+		{Event{Type: "powerChanged", Radio: "radio-01"}, false},
+		{Event{Type: "fault"}, true},
+		{Event{Type: heartbeatEventType, Radio: "radio-02"}, true},
+	}
+	for _, tt := range tests {
+		if got := filter.accepts(tt.event); got != tt.want {
+			t.Errorf("accepts(%s on %q) = %v, want %v", tt.event.Type, tt.event.Radio, got, tt.want)
+		}
+	}
+
+	var none *ClientFilter
+	if !none.accepts(Event{Type: "state"}) {
+		t.Error("Expected a nil filter to accept every event")
+	}
+}
+
+func TestSetClientFilterStopsExcludedEvents(t *testing.T) {
+	hub := NewHub(config.LoadCBTimingBaseline())
+	defer hub.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w, _ := subscribeWithClientID(ctx, hub, "console-1")
+	waitForStream(t, w, "event: ready")
+	_ = hub.PublishRadio("radio-01", Event{Type: "state", Data: map[string]interface{}{"status": "online"}})
+	waitForStream(t, w, "event: state")
+
+	if err := hub.SetClientFilter("console-1", "", ClientFilter{ExcludeEventTypes: []string{"state"}}); err != nil {
+		t.Fatalf("SetClientFilter failed: %v", err)
+	}
+	_ = hub.PublishRadio("radio-01", Event{Type: "state", Data: map[string]interface{}{"status": "offline"}})
+	_ = hub.PublishRadio("radio-01", Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": 20}})
+	stream := waitForStream(t, w, "event: powerChanged")
+	if count := strings.Count(stream, "event: state"); count != 1 {
+		t.Errorf("Expected no state events after filtering, got %d in total:\n%s", count, stream)
+	}
+
+	if err := hub.SetClientFilter("console-2", "", ClientFilter{}); !errors.Is(err, ErrClientNotFound) {
+		t.Errorf("Expected ErrClientNotFound for an unknown client, got %v", err)
+	}
+}
+
+func TestSetClientFilterRejectsOtherSubject(t *testing.T) {
+	hub := NewHub(config.LoadCBTimingBaseline())
+	defer hub.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w, _ := subscribeAs(ctx, hub, "console-1", "operator-a")
+	waitForStream(t, w, "event: ready")
+
+	err := hub.SetClientFilter("console-1", "operator-b", ClientFilter{ExcludeEventTypes: []string{"state"}})
+	if !errors.Is(err, ErrClientNotFound) {
+		t.Fatalf("Expected ErrClientNotFound for another subject's stream, got %v", err)
+	}
+
+	// The owner's stream is left unfiltered
+	_ = hub.PublishRadio("radio-01", Event{Type: "state", Data: map[string]interface{}{"status": "online"}})
+	waitForStream(t, w, "event: state")
+
+	if err := hub.SetClientFilter("console-1", "operator-a", ClientFilter{}); err != nil {
+		t.Errorf("Expected the owning subject to set the filter, got %v", err)
+	}
+}
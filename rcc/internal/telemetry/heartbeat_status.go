@@ -0,0 +1,32 @@
+package telemetry
+
+// HeartbeatStatus is the compact system status carried by heartbeat events
+// when a StatusProvider is set.
+type HeartbeatStatus struct {
+	// Radios currently online
+	ActiveRadios int
+
+	// Seconds since the service started
+	UptimeSec int64
+}
+
+// StatusProvider supplies the status snapshot for each heartbeat. It is
+// called from the heartbeat goroutine and must not block.
+type StatusProvider interface {
+	HeartbeatStatus() HeartbeatStatus
+}
+
+// SetStatusProvider adds provider's status to every heartbeat's data as
+// "status". Without one, heartbeats carry only a timestamp. Must be called
+// before clients subscribe.
+func (h *Hub) SetStatusProvider(provider StatusProvider) {
+	h.status = provider
+}
+
+// data returns the status in its event data form.
+func (s HeartbeatStatus) data() map[string]interface{} {
+	return map[string]interface{}{
+		"activeRadios": s.ActiveRadios,
+		"uptimeSec":    s.UptimeSec,
+	}
+}
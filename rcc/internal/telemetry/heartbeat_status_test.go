@@ -0,0 +1,75 @@
+package telemetry
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/config"
+)
+
+type fixedStatus HeartbeatStatus
+
+func (s fixedStatus) HeartbeatStatus() HeartbeatStatus { return HeartbeatStatus(s) }
+
+// nextHeartbeat subscribes a client and returns the first heartbeat it is sent.
+func nextHeartbeat(t *testing.T, hub *Hub) Event {
+	t.Helper()
+	events := make(chan Event, 10)
+	client := &Client{ID: "heartbeat-test", Request: httptest.NewRequest("GET", "/telemetry", nil), Events: make(chan Event, 100)}
+	client.Context, client.Cancel = context.WithCancel(context.Background())
+	client.send = func(event Event) error {
+		events <- event
+		return nil
+	}
+	go func() { _ = hub.serveClient(client) }()
+	defer client.Cancel()
+
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case event := <-events:
+			if event.Type == "heartbeat" {
+				return event
+			}
+		case <-timeout:
+			t.Fatal("No heartbeat received")
+		}
+	}
+}
+
+func heartbeatConfig() *config.TimingConfig {
+	cfg := config.LoadCBTimingBaseline()
+	cfg.HeartbeatInterval = 20 * time.Millisecond
+	cfg.HeartbeatJitter = 0
+	return cfg
+}
+
+func TestHeartbeatCarriesStatusFromProvider(t *testing.T) {
+	hub := NewHub(heartbeatConfig())
+	defer hub.Stop()
+	hub.SetStatusProvider(fixedStatus{ActiveRadios: 3, UptimeSec: 42})
+
+	heartbeat := nextHeartbeat(t, hub)
+	status, ok := heartbeat.Data["status"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected status in heartbeat data, got %v", heartbeat.Data)
+	}
+	if status["activeRadios"] != 3 || status["uptimeSec"] != int64(42) {
+		t.Errorf("status = %v, want activeRadios 3 and uptimeSec 42", status)
+	}
+	if _, ok := heartbeat.Data["ts"]; !ok {
+		t.Error("Expected ts alongside status")
+	}
+}
+
+func TestHeartbeatWithoutProviderCarriesOnlyTimestamp(t *testing.T) {
+	hub := NewHub(heartbeatConfig())
+	defer hub.Stop()
+
+	heartbeat := nextHeartbeat(t, hub)
+	if len(heartbeat.Data) != 2 || heartbeat.Data["ts"] == nil || heartbeat.Data[SchemaVersionField] == nil {
+		t.Errorf("heartbeat data = %v, want only ts and schemaVersion", heartbeat.Data)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/metrics"
 )
 
 // Event represents a telemetry event with SSE formatting.
@@ -21,9 +22,13 @@ type Event struct {
 	Type  string                 `json:"type"`
 	Data  map[string]interface{} `json:"data"`
 	Radio string                 `json:"radio,omitempty"`
+
+	// SSE reconnect delay sent as a retry field; zero sends none. Not
+	// buffered or sent over WebSocket.
+	Retry time.Duration `json:"-"`
 }
 
-// Client represents an SSE client connection.
+// Client represents a telemetry client connection (SSE or WebSocket).
 type Client struct {
 	ID      string
 	Writer  http.ResponseWriter
@@ -34,7 +39,22 @@ type Client struct {
 	Radio   string
 	Events  chan Event
 	once    sync.Once
-	mu      sync.Mutex // Protect Writer access
+	mu      sync.Mutex              // Protect Writer access
+	send    func(event Event) error // Non-SSE transport writer; nil writes SSE to Writer
+
+	// Stable connection ID from ClientIDHeader; a new subscription with the
+	// same ID and subject replaces this one. Empty when the client sent none.
+	connectionID string
+
+	// Token subject that opened the stream; only the same subject may
+	// change its filter or replace it
+	subject string
+
+	// Unix nanoseconds of the last event delivered, for SSEInactivityTimeout
+	lastDeliveredAt atomic.Int64
+
+	// Events the client asked for with SetClientFilter; nil delivers all
+	filter atomic.Pointer[ClientFilter]
 }
 
 // Hub manages SSE telemetry distribution with per-radio buffering.
@@ -55,6 +75,26 @@ type Hub struct {
 	// Per-radio event buffers
 	buffers map[string]*EventBuffer
 
+	// Event ID persistence across restarts (nil when disabled)
+	eventIDs *eventIDStore
+
+	// Event buffer persistence across restarts (nil keeps buffers in memory only)
+	store   EventStore
+	storeMu sync.Mutex
+
+	// Command outcome counts for systemStats events
+	stats systemStatsCounters
+
+	// Events held for coalescing by radio (config EventCoalesceWindows)
+	coalesced  map[string]*coalescedEvent
+	coalesceMu sync.Mutex
+
+	// Connected SSE client gauge for /metrics (nil disables)
+	metrics *metrics.Registry
+
+	// Status snapshot added to heartbeats (nil sends only a timestamp)
+	status StatusProvider
+
 	// Configuration
 	config *config.TimingConfig
 
@@ -65,15 +105,26 @@ type Hub struct {
 	// Synchronization for shutdown
 	done chan struct{}
 	wg   sync.WaitGroup
+
+	// Closed by drainClients once shutdownEvent is set; each client handler
+	// then writes the event itself rather than it being sent on a channel
+	// the handler may be closing
+	draining      chan struct{}
+	shutdownEvent Event
 }
 
 // EventBuffer maintains a circular buffer of events for a specific radio.
 type EventBuffer struct {
 	mu       sync.RWMutex
 	events   []Event
+	added    []time.Time // when each of events was buffered
 	capacity int
 	nextID   int64
 	created  time.Time
+
+	// Events older than retention are not replayed; 0 keeps them until
+	// pushed out by capacity
+	retention time.Duration
 }
 
 // NewHub creates a new telemetry hub with the specified configuration.
@@ -84,54 +135,95 @@ func NewHub(timingConfig *config.TimingConfig) *Hub {
 		buffers:  make(map[string]*EventBuffer),
 		config:   timingConfig,
 		done:     make(chan struct{}),
+		draining: make(chan struct{}),
 	}
 
 	return hub
 }
 
+// SetMetrics tracks connected SSE clients in registry. Must be called
+// before clients subscribe.
+func (h *Hub) SetMetrics(registry *metrics.Registry) {
+	h.metrics = registry
+}
+
 // Subscribe handles SSE client subscription with Last-Event-ID resume support.
 func (h *Hub) Subscribe(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 	// Set SSE headers
-	w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
-	w.Header().Set("Cache-Control", "no-cache")
-	w.Header().Set("Connection", "keep-alive")
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Cache-Control")
+	h.setSSEHeaders(w.Header())
 
 	// Create client context
 	clientCtx, cancel := context.WithCancel(ctx)
 
-	// Generate client ID
-	clientID := fmt.Sprintf("client_%d", time.Now().UnixNano())
-
-	// Parse Last-Event-ID header for resume
-	lastEventID := int64(0)
-	if lastIDStr := r.Header.Get("Last-Event-ID"); lastIDStr != "" {
-		if id, err := strconv.ParseInt(lastIDStr, 10, 64); err == nil {
-			lastEventID = id
-		}
-	}
-
-	// Extract radio ID from query parameter
-	radioID := r.URL.Query().Get("radio")
-
 	// Create client
 	client := &Client{
-		ID:      clientID,
+		ID:      fmt.Sprintf("client_%d", time.Now().UnixNano()),
 		Writer:  w,
 		Request: r,
 		Context: clientCtx,
 		Cancel:  cancel,
-		LastID:  lastEventID,
-		Radio:   radioID,
-		Events:  make(chan Event, 100), // Buffer for client events
+		LastID:  parseLastEventID(r.Header.Get("Last-Event-ID")),
+		Radio:   r.URL.Query().Get("radio"),
+		Events:  make(chan Event, h.clientBufferSize()),
+
+		connectionID: r.Header.Get(ClientIDHeader),
+		subject:      requestSubject(r),
 	}
 
+	return h.serveClient(client)
+}
+
+// setSSEHeaders sets the SSE protocol headers, then the configured charset and
+// extra headers; an extra header with an empty value removes that header.
+func (h *Hub) setSSEHeaders(header http.Header) {
+	charset := "utf-8"
+	if h.config != nil && h.config.SSECharset != "" {
+		charset = h.config.SSECharset
+	}
+
+	header.Set("Content-Type", "text/event-stream; charset="+charset)
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("Access-Control-Allow-Origin", "*")
+	header.Set("Access-Control-Allow-Headers", "Cache-Control, "+ClientIDHeader)
+
+	if h.config == nil {
+		return
+	}
+	for name, value := range h.config.SSEHeaders {
+		if value == "" {
+			header.Del(name)
+		} else {
+			header.Set(name, value)
+		}
+	}
+}
+
+// serveClient registers a client, sends the ready event and any replay, then
+// delivers events until the client disconnects. SSE and WebSocket clients share
+// this path so buffering, resume, and heartbeats behave identically.
+func (h *Hub) serveClient(client *Client) error {
+	clientID := client.ID
+	lastEventID := client.LastID
+
 	// Register client
 	h.mu.Lock()
+	replaced := h.duplicatesOf(client)
 	h.clients[clientID] = client
+	if h.metrics != nil && client.send == nil {
+		h.metrics.SSEClientConnected()
+	}
 	h.mu.Unlock()
 
+	// A reconnecting client may still hold its old stream; close it so
+	// events are not delivered twice
+	for _, old := range replaced {
+		h.disconnectClient(old)
+	}
+
+	// Watch from the start so a client that never reads the ready event is caught
+	h.watchInactivity(client)
+
 	// Send initial ready event
 	if err := h.sendReadyEvent(client); err != nil {
 		h.unregisterClient(clientID)
@@ -159,8 +251,22 @@ func (h *Hub) Subscribe(ctx context.Context, w http.ResponseWriter, r *http.Requ
 	return nil
 }
 
-// Publish publishes an event to all connected clients.
+// Publish publishes an event to all connected clients. Radio events whose
+// type has a window in the config EventCoalesceWindows are coalesced first.
 func (h *Hub) Publish(event Event) error {
+	// Count every outcome, including events coalesced away
+	h.stats.record(event)
+	if h.coalesce(event) {
+		return nil
+	}
+	return h.publish(event)
+}
+
+// publish assigns, buffers and delivers an event without coalescing it.
+func (h *Hub) publish(event Event) error {
+	// Version before buffering so replayed and /events reads carry it too
+	event = withSchemaVersion(event)
+
 	// Assign event ID if not set (needs write lock)
 	if event.ID == 0 {
 		event.ID = h.getNextEventID(event.Radio)
@@ -179,19 +285,16 @@ func (h *Hub) Publish(event Event) error {
 	}
 	h.mu.RUnlock()
 
-	// Send to all clients without holding the lock
+	// Send to all clients without holding the lock; a slow client never
+	// delays the others
 	for _, client := range clients {
 		select {
-		case <-client.Context.Done():
-			// Client context cancelled, skip this client - PRIORITY
-			continue
 		case <-h.done:
 			// Hub is shutting down, don't send
 			return nil
-		case client.Events <- event:
-		case <-time.After(100 * time.Millisecond):
-			// Drop event if client is slow to prevent blocking
+		default:
 		}
+		h.deliver(client, event)
 	}
 
 	return nil
@@ -203,11 +306,13 @@ func (h *Hub) PublishRadio(radioID string, event Event) error {
 	return h.Publish(event)
 }
 
-// sendReadyEvent sends the initial ready event to a client.
+// sendReadyEvent sends the initial ready event to a client. Its
+// schemaVersion tells the client which payload schema the stream uses.
 func (h *Hub) sendReadyEvent(client *Client) error {
 	readyEvent := Event{
-		ID:   h.getNextEventID(client.Radio),
-		Type: "ready",
+		ID:    h.getNextEventID(client.Radio),
+		Type:  "ready",
+		Retry: h.readyRetry(),
 		Data: map[string]interface{}{
 			"snapshot": map[string]interface{}{
 				"activeRadioId": "",              // TODO: Get from radio manager
@@ -242,13 +347,62 @@ func (h *Hub) replayEvents(client *Client, lastEventID int64) error {
 	return nil
 }
 
-// sendEventToClient sends a single event to a client via SSE.
+// EventsAfter returns up to limit of radioID's buffered events with IDs above
+// afterID, oldest first and versioned and signed as for delivery. A limit of
+// 0 or less returns them all. A radio without a buffer has no events.
+func (h *Hub) EventsAfter(radioID string, afterID int64, limit int) []Event {
+	h.mu.RLock()
+	buffer, exists := h.buffers[radioID]
+	h.mu.RUnlock()
+
+	events := []Event{}
+	if !exists {
+		return events
+	}
+	for _, event := range buffer.GetEventsAfter(afterID) {
+		if limit > 0 && len(events) == limit {
+			break
+		}
+		event = withSchemaVersion(event)
+		if signed, err := h.signEvent(event); err == nil {
+			event = signed
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// sendEventToClient sends a single event to a client via SSE, or via the
+// client's own transport writer when it has one.
 func (h *Hub) sendEventToClient(client *Client, event Event) error {
 	// Protect Writer access with mutex to prevent race conditions
 	client.mu.Lock()
 	defer client.mu.Unlock()
 
+	// Ready and shutdown events are not published, so version them here;
+	// signing covers the version
+	event = withSchemaVersion(event)
+
+	// Sign at delivery so replayed, heartbeat and shutdown events carry an HMAC too
+	event, err := h.signEvent(event)
+	if err != nil {
+		return err
+	}
+
+	if client.send != nil {
+		if err := client.send(event); err != nil {
+			return err
+		}
+		client.delivered(time.Now())
+		return nil
+	}
+
 	// Format as SSE
+	if event.Retry > 0 {
+		if _, err := fmt.Fprintf(client.Writer, "retry: %d\n", event.Retry.Milliseconds()); err != nil {
+			return fmt.Errorf("failed to write retry: %w", err)
+		}
+	}
 	if event.ID > 0 {
 		if _, err := fmt.Fprintf(client.Writer, "id: %d\n", event.ID); err != nil {
 			return fmt.Errorf("failed to write event ID: %w", err)
@@ -273,18 +427,32 @@ func (h *Hub) sendEventToClient(client *Client, event Event) error {
 		flusher.Flush()
 	}
 
+	client.delivered(time.Now())
 	return nil
 }
 
+// parseLastEventID parses a resume event ID; invalid or empty values mean no resume.
+func parseLastEventID(value string) int64 {
+	if value == "" {
+		return 0
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
 // handleClient manages a client connection and event delivery.
 func (h *Hub) handleClient(client *Client) {
 	defer func() {
-		// Close the client's event channel when the handler exits
+		// Unregister first: it cancels the client context, which publishers
+		// check before queueing to the channel closed next
+		h.unregisterClient(client.ID)
 		// Use sync.Once to ensure the channel is only closed once
 		client.once.Do(func() {
 			close(client.Events)
 		})
-		h.unregisterClient(client.ID)
 	}()
 
 	for {
@@ -304,6 +472,13 @@ func (h *Hub) handleClient(client *Client) {
 		case <-timeout.C:
 			// Loop continues, rechecks context
 			continue
+		case <-h.draining:
+			timeout.Stop()
+			// Nothing follows the shutdown event; close the stream
+			shutdown := h.shutdownEvent
+			shutdown.Retry = h.shutdownRetry()
+			_ = h.sendEventToClient(client, shutdown)
+			return
 		case event, ok := <-client.Events:
 			timeout.Stop()
 			if !ok {
@@ -326,6 +501,9 @@ func (h *Hub) unregisterClient(clientID string) {
 		// Don't close the channel here to avoid race with heartbeat
 		// The channel will be closed when the client goroutine exits
 		delete(h.clients, clientID)
+		if h.metrics != nil && client.send == nil {
+			h.metrics.SSEClientDisconnected()
+		}
 
 		// Stop heartbeat if no clients remain
 		if len(h.clients) == 0 && h.heartbeatTicker != nil {
@@ -348,26 +526,30 @@ func (h *Hub) getNextEventID(radioID string) int64 {
 	// Try to get existing counter with read lock
 	h.mu.RLock()
 	counter, exists := h.radioIDs[radioID]
+	store := h.eventIDs
 	h.mu.RUnlock()
 
-	if exists {
-		// Use atomic operation for fast path
-		return atomic.AddInt64(counter, 1)
-	}
-
-	// Create new counter with write lock
-	h.mu.Lock()
-	// Double-check pattern: another goroutine might have created it
-	counter, exists = h.radioIDs[radioID]
 	if !exists {
-		var initial int64 = 0
-		counter = &initial
-		h.radioIDs[radioID] = counter
+		// Create new counter with write lock
+		h.mu.Lock()
+		// Double-check pattern: another goroutine might have created it
+		counter, exists = h.radioIDs[radioID]
+		if !exists {
+			var initial int64 = 0
+			counter = &initial
+			h.radioIDs[radioID] = counter
+		}
+		h.mu.Unlock()
 	}
-	h.mu.Unlock()
 
 	// Use atomic operation
-	return atomic.AddInt64(counter, 1)
+	id := atomic.AddInt64(counter, 1)
+
+	// Best effort: a failed write only risks reuse after a crash
+	if store != nil {
+		_ = store.reserve(radioID, id)
+	}
+	return id
 }
 
 // bufferEvent adds an event to the per-radio buffer.
@@ -381,15 +563,18 @@ func (h *Hub) bufferEvent(event Event) {
 	}
 
 	h.mu.Lock()
-	defer h.mu.Unlock()
-
 	buffer, exists := h.buffers[event.Radio]
 	if !exists {
-		buffer = NewEventBuffer(h.config.EventBufferSize)
+		buffer = h.newEventBuffer()
 		h.buffers[event.Radio] = buffer
 	}
-
 	buffer.AddEvent(event)
+	store := h.store
+	h.mu.Unlock()
+
+	if store != nil {
+		h.saveBuffer(event.Radio, buffer)
+	}
 }
 
 // startHeartbeat starts the heartbeat ticker.
@@ -442,13 +627,22 @@ func (h *Hub) sendHeartbeat() {
 			"ts": time.Now().UTC().Format(time.RFC3339),
 		},
 	}
+	if h.status != nil {
+		heartbeatEvent.Data["status"] = h.status.HeartbeatStatus().data()
+	}
 
 	h.Publish(heartbeatEvent)
 }
 
-// Stop stops the telemetry hub and cleans up resources.
+// Stop stops the telemetry hub and cleans up resources. Connected clients
+// first receive a shutdown event and get up to the config SSEShutdownGrace
+// to flush it before their connections are closed.
 func (h *Hub) Stop() {
-	// Signal shutdown first
+	// Held events precede the shutdown event
+	h.flushAllCoalesced()
+	h.drainClients()
+
+	// Signal shutdown
 	close(h.done)
 
 	// Force cancel all client contexts immediately
@@ -494,7 +688,17 @@ func (h *Hub) Stop() {
 		})
 	}
 	h.clients = make(map[string]*Client)
+	store := h.eventIDs
+	counters := make(map[string]int64, len(h.radioIDs))
+	for radioID, counter := range h.radioIDs {
+		counters[radioID] = atomic.LoadInt64(counter)
+	}
 	h.mu.Unlock()
+
+	// Persist exact counters so a clean restart resumes without a gap
+	if store != nil {
+		_ = store.save(counters)
+	}
 }
 
 // NewEventBuffer creates a new event buffer with the specified capacity.
@@ -507,8 +711,21 @@ func NewEventBuffer(capacity int) *EventBuffer {
 	}
 }
 
+// newEventBuffer creates a buffer sized and aged by the hub's config.
+func (h *Hub) newEventBuffer() *EventBuffer {
+	buffer := NewEventBuffer(h.config.EventBufferSize)
+	buffer.retention = h.config.EventBufferRetention
+	return buffer
+}
+
 // AddEvent adds an event to the buffer.
 func (b *EventBuffer) AddEvent(event Event) {
+	b.addEventAt(event, time.Now())
+}
+
+// addEventAt adds an event buffered at the given time, as when reloading
+// saved events.
+func (b *EventBuffer) addEventAt(event Event, at time.Time) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
@@ -520,28 +737,59 @@ func (b *EventBuffer) AddEvent(event Event) {
 
 	// Add to buffer
 	b.events = append(b.events, event)
+	b.added = append(b.added, at)
 
 	// Maintain capacity
 	if len(b.events) > b.capacity {
 		b.events = b.events[1:]
+		b.added = b.added[1:]
 	}
 }
 
-// GetEventsAfter returns events after the specified ID.
+// GetEventsAfter returns events after the specified ID that are within the
+// buffer's retention.
 func (b *EventBuffer) GetEventsAfter(lastID int64) []Event {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
 	var result []Event
-	for _, event := range b.events {
-		if event.ID > lastID {
-			result = append(result, event)
+	for i := b.firstRetained(time.Now()); i < len(b.events); i++ {
+		if b.events[i].ID > lastID {
+			result = append(result, b.events[i])
 		}
 	}
 
 	return result
 }
 
+// storedEvents returns the retained events with their buffer times.
+func (b *EventBuffer) storedEvents() []StoredEvent {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	first := b.firstRetained(time.Now())
+	result := make([]StoredEvent, 0, len(b.events)-first)
+	for i := first; i < len(b.events); i++ {
+		result = append(result, StoredEvent{Event: b.events[i], BufferedAt: b.added[i]})
+	}
+	return result
+}
+
+// firstRetained returns the index of the oldest event buffered within
+// retention of now. Callers must hold b.mu.
+func (b *EventBuffer) firstRetained(now time.Time) int {
+	if b.retention <= 0 {
+		return 0
+	}
+	cutoff := now.Add(-b.retention)
+	for i, at := range b.added {
+		if at.After(cutoff) {
+			return i
+		}
+	}
+	return len(b.events)
+}
+
 // GetCapacity returns the buffer capacity.
 func (b *EventBuffer) GetCapacity() int {
 	return b.capacity
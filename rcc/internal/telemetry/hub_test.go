@@ -1001,3 +1001,55 @@ func TestEventIDGenerationRace(t *testing.T) {
 	t.Logf("Generated %d unique IDs with %d goroutines, %d events each",
 		len(seen), goroutines, eventsPerGoroutine)
 }
+
+func TestHubSubscribeSetsConfiguredSSEHeaders(t *testing.T) {
+	cfg := config.LoadCBTimingBaseline()
+	cfg.SSECharset = "iso-8859-1"
+	cfg.SSEHeaders = map[string]string{
+		"X-Accel-Buffering":           "no",
+		"X-Proxy-Hint":                "stream",
+		"Access-Control-Allow-Origin": "", // Removed
+	}
+	hub := NewHub(cfg)
+	defer hub.Stop()
+
+	req := httptest.NewRequest("GET", "/telemetry", nil)
+	w := newThreadSafeResponseWriter()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := hub.Subscribe(ctx, w, req); err != nil {
+		t.Fatalf("Subscribe() failed: %v", err)
+	}
+
+	if got := w.Header().Get("Content-Type"); got != "text/event-stream; charset=iso-8859-1" {
+		t.Errorf("Expected configured charset, got %q", got)
+	}
+	if got := w.Header().Get("X-Accel-Buffering"); got != "no" {
+		t.Errorf("Expected X-Accel-Buffering: no, got %q", got)
+	}
+	if got := w.Header().Get("X-Proxy-Hint"); got != "stream" {
+		t.Errorf("Expected X-Proxy-Hint: stream, got %q", got)
+	}
+	if _, ok := w.Header()["Access-Control-Allow-Origin"]; ok {
+		t.Error("Expected empty-valued header to be removed")
+	}
+	if got := w.Header().Get("Cache-Control"); got != "no-cache" {
+		t.Errorf("Expected protocol headers to remain, got Cache-Control %q", got)
+	}
+}
+
+func TestHubSubscribeDefaultDisablesProxyBuffering(t *testing.T) {
+	hub := NewHub(config.LoadCBTimingBaseline())
+	defer hub.Stop()
+
+	w := newThreadSafeResponseWriter()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := hub.Subscribe(ctx, w, httptest.NewRequest("GET", "/telemetry", nil)); err != nil {
+		t.Fatalf("Subscribe() failed: %v", err)
+	}
+
+	if got := w.Header().Get("X-Accel-Buffering"); got != "no" {
+		t.Errorf("Expected default X-Accel-Buffering: no, got %q", got)
+	}
+}
@@ -0,0 +1,63 @@
+package telemetry
+
+import (
+	"net/http"
+	"time"
+)
+
+// inactivityChecksPerTimeout is how often within one SSEInactivityTimeout a
+// client's last delivery is checked, bounding how late a disconnect can be.
+const inactivityChecksPerTimeout = 4
+
+// watchInactivity disconnects client once it has gone the config
+// SSEInactivityTimeout without an event being delivered to it, as when it
+// stops reading and writes block. It returns when the client disconnects or
+// the hub stops, and does nothing when the timeout is disabled.
+func (h *Hub) watchInactivity(client *Client) {
+	if h.config == nil || h.config.SSEInactivityTimeout <= 0 {
+		return
+	}
+	timeout := h.config.SSEInactivityTimeout
+	client.delivered(time.Now())
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+
+		ticker := time.NewTicker(timeout / inactivityChecksPerTimeout)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-client.Context.Done():
+				return
+			case <-h.done:
+				return
+			case now := <-ticker.C:
+				if now.Sub(client.lastDelivery()) >= timeout {
+					h.disconnectClient(client)
+					return
+				}
+			}
+		}
+	}()
+}
+
+// disconnectClient unregisters client and unblocks any write in progress
+// so its handler returns. WebSocket clients have no Writer; their writes are
+// bounded by wsWriteTimeout.
+func (h *Hub) disconnectClient(client *Client) {
+	h.unregisterClient(client.ID)
+	if client.Writer != nil {
+		_ = http.NewResponseController(client.Writer).SetWriteDeadline(time.Now())
+	}
+}
+
+// delivered records that an event reached the client at t.
+func (c *Client) delivered(t time.Time) {
+	c.lastDeliveredAt.Store(t.UnixNano())
+}
+
+// lastDelivery returns when an event last reached the client.
+func (c *Client) lastDelivery() time.Time {
+	return time.Unix(0, c.lastDeliveredAt.Load())
+}
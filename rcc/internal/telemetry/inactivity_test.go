@@ -0,0 +1,97 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// blockingResponseWriter stands in for a client that never reads: writes
+// block until a write deadline is set, then fail as a net.Conn would.
+type blockingResponseWriter struct {
+	headers  http.Header
+	deadline chan struct{}
+	once     sync.Once
+}
+
+func newBlockingResponseWriter() *blockingResponseWriter {
+	return &blockingResponseWriter{headers: make(http.Header), deadline: make(chan struct{})}
+}
+
+func (w *blockingResponseWriter) Header() http.Header { return w.headers }
+
+func (w *blockingResponseWriter) WriteHeader(statusCode int) {}
+
+func (w *blockingResponseWriter) Write(data []byte) (int, error) {
+	<-w.deadline
+	return 0, os.ErrDeadlineExceeded
+}
+
+func (w *blockingResponseWriter) SetWriteDeadline(deadline time.Time) error {
+	w.once.Do(func() { close(w.deadline) })
+	return nil
+}
+
+func (h *Hub) clientCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients)
+}
+
+func TestInactiveClientDisconnected(t *testing.T) {
+	cfg := config.LoadCBTimingBaseline()
+	cfg.SSEInactivityTimeout = 100 * time.Millisecond
+	hub := NewHub(cfg)
+	defer hub.Stop()
+
+	req := httptest.NewRequest("GET", "/telemetry", nil)
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- hub.Subscribe(context.Background(), newBlockingResponseWriter(), req)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Subscribe() did not return for a client whose writes block")
+	}
+	if elapsed := time.Since(start); elapsed < cfg.SSEInactivityTimeout {
+		t.Errorf("Client disconnected after %v, before the %v inactivity timeout", elapsed, cfg.SSEInactivityTimeout)
+	}
+	if n := hub.clientCount(); n != 0 {
+		t.Errorf("Expected inactive client to be unregistered, %d clients remain", n)
+	}
+}
+
+func TestClientReceivingHeartbeatsStaysConnected(t *testing.T) {
+	cfg := config.LoadCBTimingBaseline()
+	cfg.HeartbeatInterval = 20 * time.Millisecond
+	cfg.HeartbeatJitter = 0
+	cfg.SSEInactivityTimeout = 100 * time.Millisecond
+	hub := NewHub(cfg)
+	defer hub.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := httptest.NewRequest("GET", "/telemetry", nil)
+	done := make(chan error, 1)
+	go func() {
+		done <- hub.Subscribe(ctx, newThreadSafeResponseWriter(), req)
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("Client receiving heartbeats was disconnected: %v", err)
+	case <-time.After(4 * cfg.SSEInactivityTimeout):
+	}
+	if n := hub.clientCount(); n != 1 {
+		t.Errorf("Expected client to stay registered, got %d clients", n)
+	}
+}
@@ -0,0 +1,30 @@
+package telemetry
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// readyRetry returns the SSE reconnect delay sent with the ready event: the
+// first probe interval for a recovering radio, so clients of a briefly
+// unreachable service retry at the pace the service itself checks radios.
+func (h *Hub) readyRetry() time.Duration {
+	if h.config == nil {
+		return 0
+	}
+	return h.config.ProbeRecoveringInitial
+}
+
+// shutdownRetry returns the SSE reconnect delay sent to one client with the
+// shutdown event, drawn across the recovering probe range so clients of a
+// restarting service reconnect spread out rather than all at once.
+func (h *Hub) shutdownRetry() time.Duration {
+	if h.config == nil {
+		return 0
+	}
+	low, high := h.config.ProbeRecoveringInitial, h.config.ProbeRecoveringMax
+	if high <= low {
+		return low
+	}
+	return low + rand.N(high-low) + time.Millisecond
+}
@@ -0,0 +1,73 @@
+package telemetry
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// waitForStream waits until the SSE stream written to w contains want.
+func waitForStream(t *testing.T, w *threadSafeResponseWriter, want string) string {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		stream := w.String()
+		if strings.Contains(stream, want) {
+			return stream
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Stream does not contain %q:\n%s", want, stream)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestReadyEventCarriesRetry(t *testing.T) {
+	cfg := config.LoadCBTimingBaseline()
+	cfg.ProbeRecoveringInitial = 1500 * time.Millisecond
+	hub := NewHub(cfg)
+	defer hub.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w, _ := subscribeWithClientID(ctx, hub, "")
+	stream := waitForStream(t, w, "event: ready")
+	if !strings.HasPrefix(stream, "retry: 1500\n") {
+		t.Errorf("Expected ready event to open with retry: 1500, got:\n%s", stream)
+	}
+
+	// Later events carry no retry field
+	_ = hub.PublishRadio("radio-01", Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": 20}})
+	stream = waitForStream(t, w, "event: powerChanged")
+	if count := strings.Count(stream, "retry:"); count != 1 {
+		t.Errorf("Expected one retry field, got %d:\n%s", count, stream)
+	}
+}
+
+func TestShutdownEventCarriesStaggeredRetry(t *testing.T) {
+	cfg := config.LoadCBTimingBaseline()
+	cfg.ProbeRecoveringInitial = 2 * time.Second
+	cfg.ProbeRecoveringMax = 8 * time.Second
+	hub := NewHub(cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w, _ := subscribeWithClientID(ctx, hub, "")
+	waitForStream(t, w, "event: ready")
+	hub.Stop()
+
+	stream := waitForStream(t, w, "event: "+shutdownEventType)
+	match := regexp.MustCompile(`retry: (\d+)\nid: \d+\nevent: ` + shutdownEventType).FindStringSubmatch(stream)
+	if match == nil {
+		t.Fatalf("Expected retry field on the shutdown event, got:\n%s", stream)
+	}
+	retryMs, _ := strconv.Atoi(match[1])
+	if retryMs <= 2000 || retryMs > 8000 {
+		t.Errorf("Expected shutdown retry within (2000, 8000] ms, got %d", retryMs)
+	}
+}
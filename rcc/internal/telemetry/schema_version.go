@@ -0,0 +1,24 @@
+package telemetry
+
+// SchemaVersion is the version of the event payload schema, carried in
+// every event's data as SchemaVersionField. Bump it here, and in
+// docs/contract/telemetry.schema.json, when event payloads change.
+const SchemaVersion = "1.1.0"
+
+// SchemaVersionField is the data field carrying SchemaVersion.
+const SchemaVersionField = "schemaVersion"
+
+// withSchemaVersion returns event with SchemaVersionField added to a copy of
+// its data, or event unchanged when its data already carries one.
+func withSchemaVersion(event Event) Event {
+	if _, ok := event.Data[SchemaVersionField]; ok {
+		return event
+	}
+	versioned := make(map[string]interface{}, len(event.Data)+1)
+	for k, v := range event.Data {
+		versioned[k] = v
+	}
+	versioned[SchemaVersionField] = SchemaVersion
+	event.Data = versioned
+	return event
+}
@@ -0,0 +1,70 @@
+package telemetry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/config"
+)
+
+func TestEveryEventCarriesSchemaVersion(t *testing.T) {
+	hub := NewHub(config.LoadCBTimingBaseline())
+	conn := dialTelemetryWS(t, hub, "")
+
+	expectVersion := func(event Event) {
+		t.Helper()
+		if event.Data[SchemaVersionField] != SchemaVersion {
+			t.Errorf("%s event data = %v, want %s %q", event.Type, event.Data, SchemaVersionField, SchemaVersion)
+		}
+	}
+
+	// The ready event advertises the version the stream uses
+	ready := readFrame(t, conn)
+	if ready.Type != "ready" {
+		t.Fatalf("Expected ready frame, got %+v", ready)
+	}
+	expectVersion(ready)
+
+	published := []string{"state", "powerChanged", "channelChanged", "fault"}
+	for _, eventType := range published {
+		_ = hub.PublishRadio("radio-01", Event{Type: eventType, Data: map[string]interface{}{"radioId": "radio-01"}})
+	}
+	_ = hub.Publish(Event{Type: "state"})
+	hub.sendHeartbeat()
+	hub.publishSystemStats(time.Minute, nil)
+	for _, eventType := range append(published, "state", heartbeatEventType, "systemStats") {
+		event := readFrame(t, conn)
+		if event.Type != eventType {
+			t.Fatalf("Expected %s frame, got %+v", eventType, event)
+		}
+		expectVersion(event)
+	}
+
+	// Buffered events are versioned for replay and /radios/{id}/events
+	buffered := hub.EventsAfter("radio-01", 0, 0)
+	if len(buffered) != len(published) {
+		t.Fatalf("Expected %d buffered events, got %d", len(published), len(buffered))
+	}
+	for _, event := range buffered {
+		expectVersion(event)
+	}
+
+	go hub.Stop()
+	shutdown := readFrame(t, conn)
+	if shutdown.Type != shutdownEventType {
+		t.Fatalf("Expected shutdown frame, got %+v", shutdown)
+	}
+	expectVersion(shutdown)
+}
+
+func TestWithSchemaVersionCopiesData(t *testing.T) {
+	data := map[string]interface{}{"powerDbm": 20}
+	event := withSchemaVersion(Event{Type: "powerChanged", Data: data})
+
+	if event.Data[SchemaVersionField] != SchemaVersion || event.Data["powerDbm"] != 20 {
+		t.Errorf("versioned data = %v, want powerDbm and %s", event.Data, SchemaVersionField)
+	}
+	if _, ok := data[SchemaVersionField]; ok {
+		t.Error("Expected the publisher's data map to be left unchanged")
+	}
+}
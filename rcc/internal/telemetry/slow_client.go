@@ -0,0 +1,61 @@
+package telemetry
+
+import (
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// defaultClientBufferSize is the per-client event queue length when the
+// config SSEClientBufferSize is unset.
+const defaultClientBufferSize = 100
+
+// clientBufferSize returns the per-client event queue length.
+func (h *Hub) clientBufferSize() int {
+	if h.config == nil || h.config.SSEClientBufferSize <= 0 {
+		return defaultClientBufferSize
+	}
+	return h.config.SSEClientBufferSize
+}
+
+// deliver queues event for client without waiting, unless the client's
+// filter excludes it. When the queue is full the config SSESlowClientPolicy
+// applies: SlowClientDisconnect disconnects the client, otherwise its oldest
+// queued event is dropped to make room.
+func (h *Hub) deliver(client *Client, event Event) {
+	if client.Context.Err() != nil || !client.filter.Load().accepts(event) {
+		return
+	}
+
+	select {
+	case client.Events <- event:
+		return
+	default:
+	}
+
+	if h.config != nil && h.config.SSESlowClientPolicy == config.SlowClientDisconnect {
+		h.disconnectClient(client)
+		if h.metrics != nil {
+			h.metrics.SSESlowClientDisconnected()
+		}
+		return
+	}
+
+	// The handler or another publisher may take from or fill the queue
+	// meanwhile; give up after a few tries rather than spin
+	for attempt := 0; attempt < 3; attempt++ {
+		select {
+		case <-client.Events:
+			if h.metrics != nil {
+				h.metrics.SSEEventDropped()
+			}
+		default:
+		}
+		select {
+		case client.Events <- event:
+			return
+		default:
+		}
+	}
+	if h.metrics != nil {
+		h.metrics.SSEEventDropped()
+	}
+}
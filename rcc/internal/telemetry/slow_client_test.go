@@ -0,0 +1,115 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/metrics"
+)
+
+// subscribeStalledAndHealthy connects a client whose writes block and one
+// that reads normally, returning the healthy client's writer once both are
+// registered.
+func subscribeStalledAndHealthy(t *testing.T, hub *Hub) *threadSafeResponseWriter {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	stalled := newBlockingResponseWriter()
+	t.Cleanup(func() { _ = stalled.SetWriteDeadline(time.Now()) })
+	go func() { _ = hub.Subscribe(ctx, stalled, httptest.NewRequest("GET", "/telemetry", nil)) }()
+	waitForClients(t, hub, 1)
+
+	healthy := newThreadSafeResponseWriter()
+	go func() { _ = hub.Subscribe(ctx, healthy, httptest.NewRequest("GET", "/telemetry", nil)) }()
+	waitForClients(t, hub, 2)
+	return healthy
+}
+
+func waitForClients(t *testing.T, hub *Hub, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for hub.clientCount() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d clients, have %d", n, hub.clientCount())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func slowClientConfig(policy string) *config.TimingConfig {
+	cfg := config.LoadCBTimingBaseline()
+	cfg.SSEClientBufferSize = 4
+	cfg.SSESlowClientPolicy = policy
+	cfg.SSEInactivityTimeout = 0
+	cfg.SSEShutdownGrace = 0
+	return cfg
+}
+
+func TestStalledClientDoesNotDelayHealthyClient(t *testing.T) {
+	hub := NewHub(slowClientConfig(config.SlowClientDropOldest))
+	defer hub.Stop()
+	registry := metrics.NewRegistry()
+	hub.SetMetrics(registry)
+	healthy := subscribeStalledAndHealthy(t, hub)
+
+	const events = 50
+	start := time.Now()
+	for i := 1; i <= events; i++ {
+		_ = hub.PublishRadio("radio-01", Event{Type: "powerChanged", Data: map[string]interface{}{"seq": i}})
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Publishing %d events took %v with a stalled client", events, elapsed)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(healthy.String(), fmt.Sprintf(`"seq":%d`, events)) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Healthy client did not receive the last event:\n%s", healthy.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// The stalled client stays connected, missing its oldest events
+	if n := hub.clientCount(); n != 2 {
+		t.Errorf("Expected both clients connected under drop_oldest, have %d", n)
+	}
+	var out strings.Builder
+	_, _ = registry.WriteTo(&out)
+	if strings.Contains(out.String(), "rcc_sse_events_dropped_total 0\n") {
+		t.Errorf("Expected dropped events to be counted:\n%s", out.String())
+	}
+}
+
+func TestStalledClientDisconnectedWhenQueueFull(t *testing.T) {
+	hub := NewHub(slowClientConfig(config.SlowClientDisconnect))
+	defer hub.Stop()
+	registry := metrics.NewRegistry()
+	hub.SetMetrics(registry)
+	healthy := subscribeStalledAndHealthy(t, hub)
+
+	// Paced so only the stalled client falls behind
+	for i := 1; i <= 10; i++ {
+		_ = hub.PublishRadio("radio-01", Event{Type: "powerChanged", Data: map[string]interface{}{"seq": i}})
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	waitForClients(t, hub, 1)
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(healthy.String(), `"seq":10`) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Healthy client did not receive the last event:\n%s", healthy.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	var out strings.Builder
+	_, _ = registry.WriteTo(&out)
+	if !strings.Contains(out.String(), "rcc_sse_slow_clients_disconnected_total 1\n") {
+		t.Errorf("Expected one slow client disconnect counted:\n%s", out.String())
+	}
+}
@@ -0,0 +1,83 @@
+package telemetry
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// systemStatsCounters counts command outcomes seen by the hub since the last
+// systemStats event. Acknowledged powerChanged/channelChanged events are
+// successes; fault events are errors.
+type systemStatsCounters struct {
+	acks   atomic.Int64
+	faults atomic.Int64
+}
+
+// record updates the counters for a published event.
+func (c *systemStatsCounters) record(event Event) {
+	switch event.Type {
+	case "powerChanged", "channelChanged":
+		c.acks.Add(1)
+	case "fault":
+		c.faults.Add(1)
+	}
+}
+
+// StartSystemStats publishes a global "systemStats" event every interval with
+// the radio count (from radioCount), active subscribers, and the fault count and
+// error rate since the previous event. It stops with the hub, and a
+// non-positive interval disables it.
+func (h *Hub) StartSystemStats(interval time.Duration, radioCount func() int) {
+	if interval <= 0 {
+		return
+	}
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				h.publishSystemStats(interval, radioCount)
+			case <-h.done:
+				return
+			}
+		}
+	}()
+}
+
+// publishSystemStats publishes one systemStats event and resets the counters.
+func (h *Hub) publishSystemStats(interval time.Duration, radioCount func() int) {
+	acks := h.stats.acks.Swap(0)
+	faults := h.stats.faults.Swap(0)
+
+	errorRate := 0.0
+	if total := acks + faults; total > 0 {
+		errorRate = float64(faults) / float64(total)
+	}
+
+	radios := 0
+	if radioCount != nil {
+		radios = radioCount()
+	}
+
+	h.mu.RLock()
+	subscribers := len(h.clients)
+	h.mu.RUnlock()
+
+	_ = h.Publish(Event{
+		Type: "systemStats",
+		Data: map[string]interface{}{
+			"radioCount":  radios,
+			"subscribers": subscribers,
+			"faultCount":  faults,
+			"errorRate":   errorRate,
+			"intervalSec": interval.Seconds(),
+			"ts":          time.Now().UTC().Format(time.RFC3339),
+		},
+	})
+}
@@ -0,0 +1,47 @@
+package telemetry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/config"
+)
+
+func TestSystemStatsPublishedOnInterval(t *testing.T) {
+	hub := NewHub(config.LoadCBTimingBaseline())
+	defer hub.Stop()
+	conn := dialTelemetryWS(t, hub, "")
+
+	if ready := readFrame(t, conn); ready.Type != "ready" {
+		t.Fatalf("Expected ready frame, got %+v", ready)
+	}
+
+	// One success and one fault before the first tick
+	hub.PublishRadio("radio-01", Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": 20.0}})
+	hub.PublishRadio("radio-01", Event{Type: "fault", Data: map[string]interface{}{"code": "UNAVAILABLE"}})
+
+	hub.StartSystemStats(50*time.Millisecond, func() int { return 2 })
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		event := readFrame(t, conn)
+		if event.Type != "systemStats" {
+			continue
+		}
+		if event.Radio != "" {
+			t.Errorf("Expected global systemStats event, got radio %q", event.Radio)
+		}
+		if event.Data["radioCount"] != 2.0 {
+			t.Errorf("Expected radioCount 2, got %v", event.Data["radioCount"])
+		}
+		if event.Data["subscribers"] != 1.0 {
+			t.Errorf("Expected 1 subscriber, got %v", event.Data["subscribers"])
+		}
+		if event.Data["faultCount"] != 1.0 || event.Data["errorRate"] != 0.5 {
+			t.Errorf("Expected faultCount 1 and errorRate 0.5, got %v and %v",
+				event.Data["faultCount"], event.Data["errorRate"])
+		}
+		return
+	}
+	t.Fatal("No systemStats event received")
+}
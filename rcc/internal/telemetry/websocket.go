@@ -0,0 +1,71 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsWriteTimeout bounds each frame write so a stalled client cannot block delivery.
+const wsWriteTimeout = 10 * time.Second
+
+// wsUpgrader accepts any origin, matching the SSE stream's open CORS policy;
+// access is governed by bearer auth on the route.
+var wsUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// SubscribeWebSocket handles a WebSocket telemetry subscription, an alternative
+// to SSE for proxies that mangle event streams. Clients receive the same events,
+// one JSON text frame per event ({"id","type","data","radio"}), including
+// heartbeat frames. Resume with ?lastEventId= (browsers cannot set the
+// Last-Event-ID header on WebSocket requests, though it is honored too).
+func (h *Hub) SubscribeWebSocket(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	lastID := r.URL.Query().Get("lastEventId")
+	if lastID == "" {
+		lastID = r.Header.Get("Last-Event-ID")
+	}
+
+	// Upgrade writes its own HTTP error response on failure
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return fmt.Errorf("websocket upgrade failed: %w", err)
+	}
+	defer conn.Close()
+
+	clientCtx, cancel := context.WithCancel(ctx)
+	client := &Client{
+		ID:      fmt.Sprintf("ws_client_%d", time.Now().UnixNano()),
+		Request: r,
+		Context: clientCtx,
+		Cancel:  cancel,
+		LastID:  parseLastEventID(lastID),
+		Radio:   r.URL.Query().Get("radio"),
+		Events:  make(chan Event, h.clientBufferSize()),
+		subject: requestSubject(r),
+		send: func(event Event) error {
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			return conn.WriteJSON(event)
+		},
+	}
+
+	// Reading processes pings and close frames; a read error means the client left
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	err = h.serveClient(client)
+
+	// Best effort close handshake; the connection is closed regardless
+	_ = conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	return err
+}
@@ -0,0 +1,120 @@
+package telemetry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// dialTelemetryWS starts a WebSocket telemetry server for hub and connects to it.
+func dialTelemetryWS(t *testing.T, hub *Hub, query string) *websocket.Conn {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = hub.SubscribeWebSocket(r.Context(), w, r)
+	}))
+	t.Cleanup(ts.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+query, nil)
+	if err != nil {
+		t.Fatalf("Failed to dial telemetry WebSocket: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// readFrame reads the next event frame, failing the test after a timeout.
+func readFrame(t *testing.T, conn *websocket.Conn) Event {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var event Event
+	if err := conn.ReadJSON(&event); err != nil {
+		t.Fatalf("Failed to read frame: %v", err)
+	}
+	return event
+}
+
+func TestWebSocketReceivesReadyAndPublishedEvents(t *testing.T) {
+	hub := NewHub(config.LoadCBTimingBaseline())
+	defer hub.Stop()
+	conn := dialTelemetryWS(t, hub, "")
+
+	if ready := readFrame(t, conn); ready.Type != "ready" {
+		t.Fatalf("Expected ready frame, got %+v", ready)
+	}
+
+	if err := hub.PublishRadio("radio-01", Event{
+		Type: "powerChanged",
+		Data: map[string]interface{}{"radioId": "radio-01", "powerDbm": 25.0},
+	}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	event := readFrame(t, conn)
+	if event.Type != "powerChanged" || event.Radio != "radio-01" || event.ID == 0 {
+		t.Errorf("Unexpected event frame: %+v", event)
+	}
+	if event.Data["powerDbm"] != 25.0 {
+		t.Errorf("Expected powerDbm 25, got %v", event.Data["powerDbm"])
+	}
+}
+
+func TestWebSocketResumesFromLastEventIDParam(t *testing.T) {
+	hub := NewHub(config.LoadCBTimingBaseline())
+	defer hub.Stop()
+
+	// Buffer three events before the client connects
+	for i := 0; i < 3; i++ {
+		hub.PublishRadio("radio-01", Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": float64(20 + i)}})
+	}
+
+	conn := dialTelemetryWS(t, hub, "?radio=radio-01&lastEventId=1")
+	if ready := readFrame(t, conn); ready.Type != "ready" {
+		t.Fatalf("Expected ready frame, got %+v", ready)
+	}
+
+	for _, want := range []int64{2, 3} {
+		event := readFrame(t, conn)
+		if event.ID != want || event.Type != "powerChanged" {
+			t.Errorf("Expected replayed event %d, got %+v", want, event)
+		}
+	}
+}
+
+func TestWebSocketDisconnectUnregistersClient(t *testing.T) {
+	hub := NewHub(config.LoadCBTimingBaseline())
+	defer hub.Stop()
+	conn := dialTelemetryWS(t, hub, "")
+	readFrame(t, conn)
+
+	conn.Close()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		hub.mu.RLock()
+		remaining := len(hub.clients)
+		hub.mu.RUnlock()
+		if remaining == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("Expected client to be unregistered after disconnect")
+}
+
+func TestWebSocketReceivesHeartbeatFrames(t *testing.T) {
+	cfg := config.LoadCBTimingBaseline()
+	cfg.HeartbeatInterval = 50 * time.Millisecond
+	cfg.HeartbeatJitter = 0
+	hub := NewHub(cfg)
+	defer hub.Stop()
+	conn := dialTelemetryWS(t, hub, "")
+	readFrame(t, conn)
+
+	if event := readFrame(t, conn); event.Type != "heartbeat" || event.Data["ts"] == nil {
+		t.Errorf("Expected heartbeat frame, got %+v", event)
+	}
+}
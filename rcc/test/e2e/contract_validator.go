@@ -11,6 +11,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/radio-control/rcc/internal/telemetry"
 )
 
 // ContractValidator validates E2E test responses against API contracts
@@ -277,6 +279,9 @@ func (cv *ContractValidator) ValidateSSEEvent(t *testing.T, event string) {
 		if err := json.Unmarshal([]byte(dataStr), &data); err != nil {
 			t.Errorf("Invalid JSON in data field: %v", err)
 		}
+		if err := checkSchemaVersion(data); err != nil {
+			t.Errorf("%s event: %v", eventType, err)
+		}
 
 		// Validate specific event types
 		switch eventType {
@@ -569,6 +574,19 @@ func (cv *ContractValidator) normalizeDynamicFields(data map[string]interface{})
 	}
 }
 
+// checkSchemaVersion reports an error unless event data carries the
+// telemetry schema version the service publishes.
+func checkSchemaVersion(data map[string]interface{}) error {
+	version, ok := data[telemetry.SchemaVersionField]
+	if !ok {
+		return fmt.Errorf("missing %s field", telemetry.SchemaVersionField)
+	}
+	if version != telemetry.SchemaVersion {
+		return fmt.Errorf("%s is %v, want %s", telemetry.SchemaVersionField, version, telemetry.SchemaVersion)
+	}
+	return nil
+}
+
 // validateReadyEvent validates a ready event against the schema
 func (cv *ContractValidator) validateReadyEvent(t *testing.T, data map[string]interface{}) {
 	if snapshot, ok := data["snapshot"].(map[string]interface{}); ok {
@@ -2,6 +2,7 @@
 package e2e
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
@@ -12,10 +13,10 @@ func TestSSEValidation_EventParsing(t *testing.T) {
 
 	// Test SSE event parsing with multi-line format
 	testEvents := []string{
-		"event: ready\ndata: {\"snapshot\":{\"activeRadioId\":\"\",\"radios\":[]}}\nid: 1\n\n",
-		"event: heartbeat\ndata: {\"timestamp\":\"2025-10-03T10:00:00Z\"}\nid: 2\n\n",
-		"event: powerChanged\ndata: {\"radioId\":\"silvus-001\",\"powerDbm\":25.0,\"ts\":\"2025-10-03T10:00:01Z\"}\nid: 3\n\n",
-		"event: channelChanged\ndata: {\"radioId\":\"silvus-001\",\"channelIndex\":1,\"frequencyMhz\":2400.0,\"ts\":\"2025-10-03T10:00:02Z\"}\nid: 4\n\n",
+		"event: ready\ndata: {\"schemaVersion\":\"1.1.0\",\"snapshot\":{\"activeRadioId\":\"\",\"radios\":[]}}\nid: 1\n\n",
+		"event: heartbeat\ndata: {\"schemaVersion\":\"1.1.0\",\"timestamp\":\"2025-10-03T10:00:00Z\"}\nid: 2\n\n",
+		"event: powerChanged\ndata: {\"schemaVersion\":\"1.1.0\",\"radioId\":\"silvus-001\",\"powerDbm\":25.0,\"ts\":\"2025-10-03T10:00:01Z\"}\nid: 3\n\n",
+		"event: channelChanged\ndata: {\"schemaVersion\":\"1.1.0\",\"radioId\":\"silvus-001\",\"channelIndex\":1,\"frequencyMhz\":2400.0,\"ts\":\"2025-10-03T10:00:02Z\"}\nid: 4\n\n",
 	}
 
 	t.Logf("=== SSE EVENT VALIDATION ===")
@@ -35,11 +36,11 @@ func TestSSEValidation_LastEventIDReplay(t *testing.T) {
 
 	// Simulate Last-Event-ID reconnection
 	events := []string{
-		"event: ready\ndata: {\"snapshot\":{\"activeRadioId\":\"silvus-001\",\"radios\":[{\"id\":\"silvus-001\"}]}}\nid: 1\n\n",
-		"event: heartbeat\ndata: {\"timestamp\":\"2025-10-03T10:00:00Z\"}\nid: 2\n\n",
-		"event: powerChanged\ndata: {\"radioId\":\"silvus-001\",\"powerDbm\":25.0,\"ts\":\"2025-10-03T10:00:01Z\"}\nid: 3\n\n",
-		"event: heartbeat\ndata: {\"timestamp\":\"2025-10-03T10:00:15Z\"}\nid: 4\n\n",
-		"event: powerChanged\ndata: {\"radioId\":\"silvus-001\",\"powerDbm\":30.0,\"ts\":\"2025-10-03T10:00:16Z\"}\nid: 5\n\n",
+		"event: ready\ndata: {\"schemaVersion\":\"1.1.0\",\"snapshot\":{\"activeRadioId\":\"silvus-001\",\"radios\":[{\"id\":\"silvus-001\"}]}}\nid: 1\n\n",
+		"event: heartbeat\ndata: {\"schemaVersion\":\"1.1.0\",\"timestamp\":\"2025-10-03T10:00:00Z\"}\nid: 2\n\n",
+		"event: powerChanged\ndata: {\"schemaVersion\":\"1.1.0\",\"radioId\":\"silvus-001\",\"powerDbm\":25.0,\"ts\":\"2025-10-03T10:00:01Z\"}\nid: 3\n\n",
+		"event: heartbeat\ndata: {\"schemaVersion\":\"1.1.0\",\"timestamp\":\"2025-10-03T10:00:15Z\"}\nid: 4\n\n",
+		"event: powerChanged\ndata: {\"schemaVersion\":\"1.1.0\",\"radioId\":\"silvus-001\",\"powerDbm\":30.0,\"ts\":\"2025-10-03T10:00:16Z\"}\nid: 5\n\n",
 	}
 
 	t.Logf("=== LAST-EVENT-ID REPLAY TEST ===")
@@ -99,19 +100,19 @@ func TestSSEValidation_EventTypes(t *testing.T) {
 	}{
 		{
 			"ready",
-			`{"snapshot":{"activeRadioId":"silvus-001","radios":[{"id":"silvus-001","name":"Silvus Radio 1"}]}}`,
+			`{"schemaVersion":"1.1.0","snapshot":{"activeRadioId":"silvus-001","radios":[{"id":"silvus-001","name":"Silvus Radio 1"}]}}`,
 		},
 		{
 			"heartbeat",
-			`{"timestamp":"2025-10-03T10:00:00Z"}`,
+			`{"schemaVersion":"1.1.0","timestamp":"2025-10-03T10:00:00Z"}`,
 		},
 		{
 			"powerChanged",
-			`{"radioId":"silvus-001","powerDbm":25.0,"ts":"2025-10-03T10:00:01Z"}`,
+			`{"schemaVersion":"1.1.0","radioId":"silvus-001","powerDbm":25.0,"ts":"2025-10-03T10:00:01Z"}`,
 		},
 		{
 			"channelChanged",
-			`{"radioId":"silvus-001","channelIndex":1,"frequencyMhz":2400.0,"ts":"2025-10-03T10:00:02Z"}`,
+			`{"schemaVersion":"1.1.0","radioId":"silvus-001","channelIndex":1,"frequencyMhz":2400.0,"ts":"2025-10-03T10:00:02Z"}`,
 		},
 	}
 
@@ -127,3 +128,27 @@ func TestSSEValidation_EventTypes(t *testing.T) {
 
 	t.Logf("=== EVENT TYPE VALIDATION COMPLETE ===")
 }
+
+func TestSSEValidation_SchemaVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{"current", `{"schemaVersion":"1.1.0","powerDbm":25.0}`, false},
+		{"missing", `{"powerDbm":25.0}`, true},
+		{"other version", `{"schemaVersion":"1.0.0","powerDbm":25.0}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data map[string]interface{}
+			if err := json.Unmarshal([]byte(tt.data), &data); err != nil {
+				t.Fatalf("Invalid test data: %v", err)
+			}
+			if err := checkSchemaVersion(data); (err != nil) != tt.wantErr {
+				t.Errorf("checkSchemaVersion(%s) = %v, want error %v", tt.data, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package fixtures
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/radio-control/rcc/internal/auth"
+)
+
+// authTokens are the fixed bearer tokens TokenVerifier accepts.
+var authTokens = map[string]auth.Claims{
+	"viewer-token": {
+		Subject: "user-123",
+		Roles:   []string{auth.RoleViewer},
+		Scopes:  []string{auth.ScopeRead, auth.ScopeTelemetry},
+	},
+	"controller-token": {
+		Subject: "admin-456",
+		Roles:   []string{auth.RoleController},
+		Scopes:  []string{auth.ScopeRead, auth.ScopeControl, auth.ScopeTelemetry},
+	},
+	// Monitoring client: may subscribe to telemetry but not list radios
+	"telemetry-token": {
+		Subject: "monitor-789",
+		Roles:   []string{auth.RoleViewer},
+		Scopes:  []string{auth.ScopeTelemetry},
+	},
+	// Scheduler or other automation acting with controller rights
+	"service-token": {
+		Subject: "scheduler-001",
+		Roles:   []string{auth.RoleController},
+		Scopes:  []string{auth.ScopeRead, auth.ScopeControl, auth.ScopeTelemetry},
+		Service: true,
+	},
+	// Operator diagnosing the service itself
+	"admin-token": {
+		Subject: "ops-001",
+		Roles:   []string{auth.RoleController},
+		Scopes:  []string{auth.ScopeRead, auth.ScopeControl, auth.ScopeTelemetry, auth.ScopeAdmin, auth.ScopeAuditRead},
+	},
+	// May retune radios but not change transmit power
+	"channel-token": {
+		Subject: "tuner-001",
+		Roles:   []string{auth.RoleController},
+		Scopes:  []string{auth.ScopeRead, auth.ScopeChannel, auth.ScopeTelemetry},
+	},
+	// May change transmit power but not retune radios
+	"power-token": {
+		Subject: "power-001",
+		Roles:   []string{auth.RoleController},
+		Scopes:  []string{auth.ScopeRead, auth.ScopePower, auth.ScopeTelemetry},
+	},
+}
+
+// TokenVerifier is an auth.TokenVerifier for tests that accepts a fixed set
+// of bearer tokens ("viewer-token", "controller-token", "telemetry-token",
+// "service-token", "admin-token", "channel-token" and "power-token") and
+// rejects every other. It lives outside the auth package so that no
+// production binary links it.
+type TokenVerifier struct{}
+
+// VerifyToken returns the claims of a fixture token.
+func (TokenVerifier) VerifyToken(token string) (*auth.Claims, error) {
+	claims, ok := authTokens[token]
+	if !ok {
+		return nil, fmt.Errorf("token verification failed")
+	}
+	// Callers may modify the claims; keep the fixtures intact
+	claims.Roles = slices.Clone(claims.Roles)
+	claims.Scopes = slices.Clone(claims.Scopes)
+	return &claims, nil
+}
+
+// NewAuthMiddleware creates an auth middleware accepting the TokenVerifier
+// fixture tokens.
+func NewAuthMiddleware() *auth.Middleware {
+	return auth.NewMiddlewareWithTokenVerifier(TokenVerifier{})
+}
@@ -6,7 +6,6 @@ import (
 	"context"
 	"testing"
 
-	"github.com/radio-control/rcc/internal/auth"
 	"github.com/radio-control/rcc/test/fixtures"
 	"github.com/radio-control/rcc/test/harness"
 )
@@ -14,7 +13,7 @@ import (
 // TestAuthIntegration_ValidTokenAccepted tests that valid tokens are accepted.
 func TestAuthIntegration_ValidTokenAccepted(t *testing.T) {
 	// Arrange: Create auth middleware
-	authMiddleware := auth.NewMiddleware()
+	authMiddleware := fixtures.NewAuthMiddleware()
 
 	// Create a context with a valid token
 	validToken := fixtures.ValidToken()
@@ -53,7 +52,7 @@ func TestAuthIntegration_ValidTokenAccepted(t *testing.T) {
 // TestAuthIntegration_ExpiredTokenRejected tests that expired tokens are rejected.
 func TestAuthIntegration_ExpiredTokenRejected(t *testing.T) {
 	// Arrange: Create auth middleware
-	authMiddleware := auth.NewMiddleware()
+	authMiddleware := fixtures.NewAuthMiddleware()
 
 	// Create a context with an expired token
 	expiredToken := fixtures.ExpiredToken()
@@ -85,7 +84,7 @@ func TestAuthIntegration_ExpiredTokenRejected(t *testing.T) {
 // TestAuthIntegration_RoleEnforcement tests that different roles have appropriate permissions.
 func TestAuthIntegration_RoleEnforcement(t *testing.T) {
 	// Arrange: Create auth middleware
-	authMiddleware := auth.NewMiddleware()
+	authMiddleware := fixtures.NewAuthMiddleware()
 
 	// Test different role tokens
 	roles := []struct {
@@ -141,7 +140,7 @@ func TestAuthIntegration_RoleEnforcement(t *testing.T) {
 // TestAuthIntegration_InvalidTokenRejected tests that invalid tokens are rejected.
 func TestAuthIntegration_InvalidTokenRejected(t *testing.T) {
 	// Arrange: Create auth middleware
-	authMiddleware := auth.NewMiddleware()
+	authMiddleware := fixtures.NewAuthMiddleware()
 
 	// Test various invalid token scenarios
 	invalidTokens := []struct {
@@ -182,7 +181,7 @@ func TestAuthIntegration_InvalidTokenRejected(t *testing.T) {
 // TestAuthIntegration_ContextPropagation tests that auth context is properly propagated.
 func TestAuthIntegration_ContextPropagation(t *testing.T) {
 	// Arrange: Create auth middleware
-	authMiddleware := auth.NewMiddleware()
+	authMiddleware := fixtures.NewAuthMiddleware()
 
 	// Create a context with authentication
 	validToken := fixtures.ValidToken()
@@ -5,13 +5,12 @@ package auth_test
 import (
 	"testing"
 
-	"github.com/radio-control/rcc/internal/auth"
 	"github.com/radio-control/rcc/test/fixtures"
 )
 
 func TestAuthFlow_TokenValidation(t *testing.T) {
 	// Arrange: real auth middleware for integration testing
-	authMiddleware := auth.NewMiddleware()
+	authMiddleware := fixtures.NewAuthMiddleware()
 
 	// Use test fixtures for consistent token scenarios
 	validToken := fixtures.ValidToken()
@@ -32,7 +31,7 @@ func TestAuthFlow_TokenValidation(t *testing.T) {
 
 func TestAuthFlow_PermissionEnforcement(t *testing.T) {
 	// Test permission enforcement in API → Orchestrator flow
-	authMiddleware := auth.NewMiddleware()
+	authMiddleware := fixtures.NewAuthMiddleware()
 
 	// Use test fixtures for different permission levels
 	adminToken := fixtures.AdminToken()
@@ -53,7 +52,7 @@ func TestAuthFlow_PermissionEnforcement(t *testing.T) {
 
 func TestAuthFlow_SessionManagement(t *testing.T) {
 	// Test session lifecycle and expiration
-	authMiddleware := auth.NewMiddleware()
+	authMiddleware := fixtures.NewAuthMiddleware()
 
 	// Test session management (simplified for integration)
 	t.Logf("Testing session management with auth middleware")
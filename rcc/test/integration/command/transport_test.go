@@ -0,0 +1,37 @@
+//go:build integration
+
+package command_test
+
+import (
+	"context"
+	"testing"
+
+	integration_harness "github.com/radio-control/rcc/test/integration/harness"
+)
+
+// TestCommand_SetPower_AcrossTransports runs SetPower end-to-end against the
+// fake adapter and the real silvus-mock JSON-RPC transport.
+func TestCommand_SetPower_AcrossTransports(t *testing.T) {
+	for _, transport := range []integration_harness.Transport{
+		integration_harness.TransportFake,
+		integration_harness.TransportSilvusMock,
+	} {
+		t.Run(string(transport), func(t *testing.T) {
+			orch, _, _, _, radioAdapter := integration_harness.BuildTestStackWithTransport(t, transport)
+			ctx := context.Background()
+
+			if err := orch.SetPower(ctx, integration_harness.StackRadioID, 25.0); err != nil {
+				t.Fatalf("SetPower failed: %v", err)
+			}
+
+			// Read back through the adapter to confirm the setting reached the radio
+			state, err := radioAdapter.GetState(ctx)
+			if err != nil {
+				t.Fatalf("GetState failed: %v", err)
+			}
+			if state.PowerDbm != 25.0 {
+				t.Errorf("Expected power 25 dBm after SetPower, got %v", state.PowerDbm)
+			}
+		})
+	}
+}
@@ -279,6 +279,7 @@ func (a *ErrorInjectingAdapter) ReadPowerActual(ctx context.Context) (float64, e
 func (a *ErrorInjectingAdapter) SupportedFrequencyProfiles(ctx context.Context) ([]adapter.FrequencyProfile, error) {
 	return nil, nil
 }
+func (a *ErrorInjectingAdapter) Ping(ctx context.Context) error { return nil }
 
 // TimeoutInjectingAdapter injects timeouts for testing
 type TimeoutInjectingAdapter struct {
@@ -303,6 +304,7 @@ func (a *TimeoutInjectingAdapter) ReadPowerActual(ctx context.Context) (float64,
 func (a *TimeoutInjectingAdapter) SupportedFrequencyProfiles(ctx context.Context) ([]adapter.FrequencyProfile, error) {
 	return nil, nil
 }
+func (a *TimeoutInjectingAdapter) Ping(ctx context.Context) error { return nil }
 
 // AuditEntry represents the audit log schema per Architecture §8.6
 type AuditEntry struct {
@@ -2,13 +2,23 @@
 
 // Package harness provides a minimal in-process integration test harness.
 // Boundary: command+radio+adapter+telemetry+audit; no HTTP; deterministic.
+// The silvus-mock transport is the exception: the adapter speaks JSON-RPC over
+// HTTP to a real emulator.
 package harness
 
 import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
 	"testing"
 	"time"
 
 	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/adapter/silvus"
 	"github.com/radio-control/rcc/internal/audit"
 	"github.com/radio-control/rcc/internal/command"
 	"github.com/radio-control/rcc/internal/radio"
@@ -17,6 +27,24 @@ import (
 	"github.com/radio-control/rcc/test/integration/fixtures"
 )
 
+// Transport selects the radio adapter wired by BuildTestStackWithTransport.
+type Transport string
+
+const (
+	// TransportFake uses the in-process FakeAdapter.
+	TransportFake Transport = "fake"
+	// TransportSilvusMock uses a real SilvusAdapter against the silvus-mock emulator.
+	TransportSilvusMock Transport = "silvus-mock"
+)
+
+// SilvusMockURLEnv points the silvus-mock transport at a running emulator
+// (e.g. http://127.0.0.1:8080) instead of building and starting one.
+const SilvusMockURLEnv = "RCC_SILVUS_MOCK_URL"
+
+// StackRadioID is the radio seeded by BuildTestStack in every transport, so
+// tests that only use the ports run unchanged against either adapter.
+const StackRadioID = "fake-001"
+
 // BuildCommandStack wires real implementations via public constructors only.
 // Returns components via their public interfaces/ports with automatic cleanup.
 // Only mocks external radio adapter - all internal components are real.
@@ -64,14 +92,92 @@ type Radios map[string]adapter.IRadioAdapter
 
 // BuildTestStack creates a complete test stack with fake adapters and fixtures.
 func BuildTestStack(t *testing.T) (orch command.OrchestratorPort, rm *radio.Manager, tele *telemetry.Hub, auditLogger *audit.Logger, adapter adapter.IRadioAdapter) {
-	// Create fake adapters (only external dependency mocked)
-	fakeAdapter := fakes.NewFakeAdapter("fake-001").
-		WithInitial(20.0, 2412.0, nil) // No channels needed for basic tests
+	return BuildTestStackWithTransport(t, TransportFake)
+}
+
+// BuildTestStackWithTransport creates the BuildTestStack stack with the
+// StackRadioID radio served by the given transport.
+func BuildTestStackWithTransport(t *testing.T, transport Transport) (orch command.OrchestratorPort, rm *radio.Manager, tele *telemetry.Hub, auditLogger *audit.Logger, adapter adapter.IRadioAdapter) {
+	switch transport {
+	case TransportFake:
+		// Create fake adapters (only external dependency mocked)
+		fakeAdapter := fakes.NewFakeAdapter(StackRadioID).
+			WithInitial(20.0, 2412.0, nil) // No channels needed for basic tests
+		return BuildCommandStack(t, Radios{StackRadioID: fakeAdapter})
+	case TransportSilvusMock:
+		baseURL := os.Getenv(SilvusMockURLEnv)
+		if baseURL == "" {
+			baseURL = StartSilvusMock(t)
+		}
+		silvusAdapter := silvus.NewSilvusAdapter(StackRadioID, baseURL, 5*time.Second)
+		return BuildCommandStack(t, Radios{StackRadioID: silvusAdapter})
+	default:
+		t.Fatalf("Unknown test transport %q", transport)
+		return nil, nil, nil, nil, nil
+	}
+}
+
+// StartSilvusMock builds and boots the silvus-mock emulator from the
+// repository, returning its base URL. The process is stopped at test cleanup.
+// The test is skipped when the silvus-mock module is not checked out.
+func StartSilvusMock(t *testing.T) string {
+	t.Helper()
+
+	_, file, _, _ := runtime.Caller(0)
+	mockDir := filepath.Join(filepath.Dir(file), "../../../../silvus-mock")
+	if _, err := os.Stat(mockDir); err != nil {
+		t.Skipf("silvus-mock not available at %s: %v", mockDir, err)
+	}
+
+	workDir := t.TempDir()
+	binary := filepath.Join(workDir, "silvusmock")
 
-	seedRadios := Radios{
-		"fake-001": fakeAdapter,
+	build := exec.Command("go", "build", "-o", binary, "./cmd/silvusmock")
+	build.Dir = mockDir
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build silvus-mock: %v\n%s", err, out)
 	}
 
-	// Build the command stack
-	return BuildCommandStack(t, seedRadios)
+	httpPort := freePort(t)
+	configPath := filepath.Join(workDir, "silvus-mock.yaml")
+	configYAML := fmt.Sprintf("network:\n  http:\n    port: %d\n  maintenance:\n    port: %d\n", httpPort, freePort(t))
+	if err := os.WriteFile(configPath, []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write silvus-mock config: %v", err)
+	}
+
+	cmd := exec.Command(binary)
+	cmd.Dir = workDir
+	cmd.Env = append(os.Environ(), "CBTIMING_CONFIG="+configPath)
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Failed to start silvus-mock: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", httpPort)
+
+	// Wait for the JSON-RPC endpoint to accept connections
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		resp, err := http.Post(baseURL+silvus.APIPath, "application/json", nil)
+		if err == nil {
+			resp.Body.Close()
+			return baseURL
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("silvus-mock did not become ready at %s", baseURL)
+	return ""
+}
+
+// freePort reserves an ephemeral TCP port and releases it for the mock to bind.
+func freePort(t *testing.T) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to reserve port: %v", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
 }
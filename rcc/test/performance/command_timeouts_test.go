@@ -76,6 +76,10 @@ func (f *SimpleFakeAdapter) SetStatus(status string) error {
 	return nil
 }
 
+func (f *SimpleFakeAdapter) Ping(ctx context.Context) error {
+	return nil
+}
+
 // TestCommandTimeouts_ValidateCB_TIMING tests that command execution times
 // stay within CB-TIMING budget constraints.
 func TestCommandTimeouts_ValidateCB_TIMING(t *testing.T) {
@@ -0,0 +1,72 @@
+package maintenance
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// handleLineCommands serves fault injection commands, one per line, until
+// the client disconnects. Each command is answered with "OK" or "ERROR <reason>":
+//
+//	SET_MODE busy|unavailable|normal  fail every radio command with BUSY or UNAVAILABLE
+//	SET_DELAY <ms>                    delay every radio command
+//	RESET                             clear injected faults, delays, and blackout
+func (s *Server) handleLineCommands(conn net.Conn, reader *bufio.Reader) {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		reply := "OK"
+		if err := s.executeLineCommand(line); err != nil {
+			reply = "ERROR " + err.Error()
+		}
+		if _, err := fmt.Fprintf(conn, "%s\n", reply); err != nil {
+			log.Printf("Failed to write maintenance reply: %v", err)
+			return
+		}
+		log.Printf("Maintenance line command processed: command=%q, reply=%q, client=%s", line, reply, conn.RemoteAddr())
+
+		// Keep the session open while the client is active
+		conn.SetDeadline(time.Now().Add(s.connectionTimeout))
+	}
+}
+
+// executeLineCommand applies a single line command to the radio state
+func (s *Server) executeLineCommand(line string) error {
+	fields := strings.Fields(line)
+	command, args := strings.ToUpper(fields[0]), fields[1:]
+
+	switch command {
+	case "SET_MODE":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: SET_MODE busy|unavailable|normal")
+		}
+		return s.state.SetFaultMode(strings.ToLower(args[0]))
+	case "SET_DELAY":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: SET_DELAY <ms>")
+		}
+		ms, err := strconv.Atoi(args[0])
+		if err != nil || ms < 0 {
+			return fmt.Errorf("invalid delay %q, must be a non-negative number of milliseconds", args[0])
+		}
+		s.state.SetResponseDelay(time.Duration(ms) * time.Millisecond)
+		return nil
+	case "RESET":
+		if len(args) != 0 {
+			return fmt.Errorf("usage: RESET")
+		}
+		s.state.ResetFaults()
+		return nil
+	default:
+		return fmt.Errorf("unknown command %s", fields[0])
+	}
+}
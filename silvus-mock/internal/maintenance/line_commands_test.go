@@ -0,0 +1,147 @@
+package maintenance
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/silvus-mock/internal/jsonrpc"
+)
+
+// startTestServer serves maintenance connections on an ephemeral loopback port.
+func startTestServer(t *testing.T, server *Server) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go server.Serve(listener)
+	t.Cleanup(func() { server.Close() })
+	return listener.Addr().String()
+}
+
+// sendLine writes a maintenance line command and returns the reply line.
+func sendLine(t *testing.T, conn net.Conn, reader *bufio.Reader, command string) string {
+	t.Helper()
+	conn.SetDeadline(time.Now().Add(2 * time.Second))
+	if _, err := conn.Write([]byte(command + "\n")); err != nil {
+		t.Fatalf("Failed to send %q: %v", command, err)
+	}
+	reply, err := reader.ReadString('\n')
+	if err != nil {
+		t.Fatalf("Failed to read reply to %q: %v", command, err)
+	}
+	return strings.TrimSpace(reply)
+}
+
+// callJSONRPC posts a JSON-RPC request to the HTTP endpoint and returns the response.
+func callJSONRPC(t *testing.T, server *jsonrpc.Server, method string, params []string) jsonrpc.Response {
+	t.Helper()
+	body, _ := json.Marshal(jsonrpc.Request{JSONRPC: "2.0", Method: method, Params: params, ID: "1"})
+	req := httptest.NewRequest(http.MethodPost, "/streamscape_api", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	server.HandleRequest(rr, req)
+
+	var response jsonrpc.Response
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal JSON-RPC response: %v", err)
+	}
+	return response
+}
+
+func errorMessage(response jsonrpc.Response) string {
+	if errMap, ok := response.Error.(map[string]interface{}); ok {
+		message, _ := errMap["message"].(string)
+		return message
+	}
+	return ""
+}
+
+func TestLineCommandsDriveJSONRPCFaults(t *testing.T) {
+	cfg := createTestConfig()
+	radioState := createTestRadioState(cfg)
+	defer radioState.Close()
+	addr := startTestServer(t, NewServer(cfg, radioState))
+	rpc := jsonrpc.NewServer(cfg, radioState)
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Failed to connect to maintenance port: %v", err)
+	}
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	if reply := sendLine(t, conn, reader, "SET_MODE busy"); reply != "OK" {
+		t.Fatalf("Expected OK for SET_MODE busy, got %q", reply)
+	}
+	if got := errorMessage(callJSONRPC(t, rpc, "power_dBm", nil)); got != "BUSY" {
+		t.Errorf("Expected BUSY from JSON-RPC in busy mode, got %q", got)
+	}
+
+	if reply := sendLine(t, conn, reader, "SET_MODE unavailable"); reply != "OK" {
+		t.Fatalf("Expected OK for SET_MODE unavailable, got %q", reply)
+	}
+	if got := errorMessage(callJSONRPC(t, rpc, "freq", nil)); got != "UNAVAILABLE" {
+		t.Errorf("Expected UNAVAILABLE from JSON-RPC in unavailable mode, got %q", got)
+	}
+
+	// RESET restores normal processing on the same session
+	if reply := sendLine(t, conn, reader, "RESET"); reply != "OK" {
+		t.Fatalf("Expected OK for RESET, got %q", reply)
+	}
+	response := callJSONRPC(t, rpc, "power_dBm", nil)
+	if response.Error != nil {
+		t.Errorf("Expected no error after RESET, got %v", response.Error)
+	}
+}
+
+func TestLineCommandSetDelay(t *testing.T) {
+	cfg := createTestConfig()
+	radioState := createTestRadioState(cfg)
+	defer radioState.Close()
+	addr := startTestServer(t, NewServer(cfg, radioState))
+	rpc := jsonrpc.NewServer(cfg, radioState)
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Failed to connect to maintenance port: %v", err)
+	}
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	if reply := sendLine(t, conn, reader, "SET_DELAY 200"); reply != "OK" {
+		t.Fatalf("Expected OK for SET_DELAY, got %q", reply)
+	}
+	start := time.Now()
+	callJSONRPC(t, rpc, "power_dBm", nil)
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("Expected command delayed by at least 200ms, took %v", elapsed)
+	}
+}
+
+func TestLineCommandErrors(t *testing.T) {
+	cfg := createTestConfig()
+	radioState := createTestRadioState(cfg)
+	defer radioState.Close()
+	addr := startTestServer(t, NewServer(cfg, radioState))
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("Failed to connect to maintenance port: %v", err)
+	}
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	for _, command := range []string{"FROBNICATE", "SET_MODE sleepy", "SET_MODE", "SET_DELAY -5", "SET_DELAY soon"} {
+		if reply := sendLine(t, conn, reader, command); !strings.HasPrefix(reply, "ERROR ") {
+			t.Errorf("Expected error line for %q, got %q", command, reply)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package maintenance
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -59,9 +60,13 @@ func (s *Server) ListenAndServe() error {
 	if err != nil {
 		return fmt.Errorf("failed to listen on port %d: %v", s.config.Network.Maintenance.Port, err)
 	}
-	s.listener = listener
-
 	log.Printf("Maintenance server listening on port %d", s.config.Network.Maintenance.Port)
+	return s.Serve(listener)
+}
+
+// Serve accepts maintenance connections on listener until Close is called
+func (s *Server) Serve(listener net.Listener) error {
+	s.listener = listener
 
 	for {
 		select {
@@ -97,9 +102,16 @@ func (s *Server) handleConnection(conn net.Conn) {
 	// Set connection timeout
 	conn.SetDeadline(time.Now().Add(30 * time.Second))
 
+	// JSON-RPC requests start with '{'; anything else is a line command session
+	reader := bufio.NewReader(conn)
+	if first, err := reader.Peek(1); err == nil && first[0] != '{' {
+		s.handleLineCommands(conn, reader)
+		return
+	}
+
 	// Read JSON-RPC request
 	var req Request
-	decoder := json.NewDecoder(conn)
+	decoder := json.NewDecoder(reader)
 	if err := decoder.Decode(&req); err != nil {
 		log.Printf("Failed to decode JSON-RPC request: %v", err)
 		s.writeErrorResponse(conn, -32700, "Parse error", nil)
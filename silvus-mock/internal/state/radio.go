@@ -23,6 +23,8 @@ type RadioState struct {
 	softBootDuration    time.Duration // Channel change blackout
 	powerChangeDuration time.Duration // Power change blackout
 	radioResetDuration  time.Duration // Radio reset blackout
+	faultMode           string        // Injected fault, set over the maintenance port
+	responseDelay       time.Duration // Injected delay before each command
 	commandQueue        chan Command
 	stopChan            chan struct{}
 	wg                  sync.WaitGroup  // For graceful shutdown
@@ -30,6 +32,13 @@ type RadioState struct {
 	cancel              context.CancelFunc
 }
 
+// Fault modes injected with SetFaultMode
+const (
+	FaultModeNormal      = "normal"
+	FaultModeBusy        = "busy"
+	FaultModeUnavailable = "unavailable"
+)
+
 // PowerLimits holds power range limits
 type PowerLimits struct {
 	MinDBm int
@@ -97,9 +106,30 @@ func (rs *RadioState) commandWorker() {
 
 // processCommand processes a single command
 func (rs *RadioState) processCommand(cmd Command) {
+	// Injected delay is served outside the lock so status reads stay responsive
+	rs.mu.RLock()
+	delay := rs.responseDelay
+	rs.mu.RUnlock()
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-rs.ctx.Done():
+		}
+	}
+
 	rs.mu.Lock()
 	defer rs.mu.Unlock()
 
+	// Injected faults take precedence over the radio's own state
+	switch rs.faultMode {
+	case FaultModeBusy:
+		cmd.Response <- CommandResponse{Error: "BUSY"}
+		return
+	case FaultModeUnavailable:
+		cmd.Response <- CommandResponse{Error: "UNAVAILABLE"}
+		return
+	}
+
 	// Check if we're in blackout
 	// ICD §6.1.1: During soft-boot, avoid concurrent API calls
 	// All commands (including reads) should return UNAVAILABLE during blackout
@@ -322,6 +352,41 @@ func (rs *RadioState) GetStatus() (string, int, bool) {
 	return rs.currentFreq, rs.currentPower, rs.IsAvailable()
 }
 
+// SetFaultMode makes every subsequent command fail with BUSY or UNAVAILABLE,
+// or restores normal processing with FaultModeNormal.
+func (rs *RadioState) SetFaultMode(mode string) error {
+	switch mode {
+	case FaultModeNormal, FaultModeBusy, FaultModeUnavailable:
+	default:
+		return fmt.Errorf("invalid fault mode %q, must be one of: %s, %s, %s",
+			mode, FaultModeNormal, FaultModeBusy, FaultModeUnavailable)
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if mode == FaultModeNormal {
+		mode = ""
+	}
+	rs.faultMode = mode
+	return nil
+}
+
+// SetResponseDelay delays every subsequent command by d.
+func (rs *RadioState) SetResponseDelay(d time.Duration) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.responseDelay = d
+}
+
+// ResetFaults clears injected faults and delays and ends any blackout.
+func (rs *RadioState) ResetFaults() {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.faultMode = ""
+	rs.responseDelay = 0
+	rs.blackoutUntil = time.Time{}
+}
+
 // ExecuteCommand executes a command and returns the response
 func (rs *RadioState) ExecuteCommand(cmdType string, params []string) CommandResponse {
 	response := make(chan CommandResponse, 1)
@@ -1,13 +1,17 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -34,8 +38,23 @@ type Config struct {
 			JitterMs          int `json:"jitterMs"`
 		} `json:"retry"`
 	} `json:"timing"`
+
+	// ServiceToken is an optional bearer token injected when the client sends no Authorization header
+	ServiceToken string `json:"serviceToken,omitempty"`
+
+	// Audit log destination and size-based rotation
+	Audit struct {
+		Path         string `json:"path"`
+		MaxSizeBytes int64  `json:"maxSizeBytes"`
+	} `json:"audit"`
 }
 
+const (
+	// Audit log defaults used when config.json omits them
+	defaultAuditPath         = "audit.log"
+	defaultAuditMaxSizeBytes = 10 * 1024 * 1024
+)
+
 // AuditEntry represents a structured audit log entry
 type AuditEntry struct {
 	Timestamp     time.Time `json:"timestamp"`
@@ -49,6 +68,21 @@ type AuditEntry struct {
 
 var config Config
 
+// auditMu serializes audit log writes and rotation
+var auditMu sync.Mutex
+
+// hopByHopHeaders are connection-specific headers that must not be forwarded by a proxy (RFC 7230 §6.1)
+var hopByHopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
 func loadConfig() error {
 	data, err := os.ReadFile("config.json")
 	if err != nil {
@@ -59,9 +93,64 @@ func loadConfig() error {
 		return fmt.Errorf("failed to parse config.json: %w", err)
 	}
 
+	if err := validateConfig(&config); err != nil {
+		return fmt.Errorf("invalid config.json: %w", err)
+	}
+
 	return nil
 }
 
+// validateConfig checks that the RCC base URL is usable and that timing values
+// are positive, reporting every problem found.
+func validateConfig(cfg *Config) error {
+	var errs []error
+
+	if cfg.RCCBaseURL == "" {
+		errs = append(errs, errors.New("rccBaseUrl is required"))
+	} else if u, err := url.Parse(cfg.RCCBaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, fmt.Errorf("rccBaseUrl %q must be an absolute http(s) URL", cfg.RCCBaseURL))
+	}
+
+	t := cfg.Timing
+	positive := []struct {
+		name  string
+		value int
+	}{
+		{"timing.heartbeatIntervalSec", t.HeartbeatIntervalSec},
+		{"timing.heartbeatTimeoutSec", t.HeartbeatTimeoutSec},
+		{"timing.probeNormalSec", t.ProbeNormalSec},
+		{"timing.probeRecoveringMinSec", t.ProbeRecoveringMinSec},
+		{"timing.probeRecoveringMaxSec", t.ProbeRecoveringMaxSec},
+		{"timing.probeOfflineMinSec", t.ProbeOfflineMinSec},
+		{"timing.probeOfflineMaxSec", t.ProbeOfflineMaxSec},
+		{"timing.cmdTimeoutsSec.setPower", t.CmdTimeoutsSec.SetPower},
+		{"timing.cmdTimeoutsSec.setChannel", t.CmdTimeoutsSec.SetChannel},
+		{"timing.cmdTimeoutsSec.selectRadio", t.CmdTimeoutsSec.SelectRadio},
+		{"timing.cmdTimeoutsSec.getState", t.CmdTimeoutsSec.GetState},
+		{"timing.retry.busyBaseMs", t.Retry.BusyBaseMs},
+		{"timing.retry.unavailableBaseMs", t.Retry.UnavailableBaseMs},
+	}
+	for _, field := range positive {
+		if field.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive, got %d", field.name, field.value))
+		}
+	}
+	if t.Retry.JitterMs < 0 {
+		errs = append(errs, fmt.Errorf("timing.retry.jitterMs must not be negative, got %d", t.Retry.JitterMs))
+	}
+	if t.ProbeRecoveringMinSec > t.ProbeRecoveringMaxSec {
+		errs = append(errs, errors.New("timing.probeRecoveringMinSec must not exceed timing.probeRecoveringMaxSec"))
+	}
+	if t.ProbeOfflineMinSec > t.ProbeOfflineMaxSec {
+		errs = append(errs, errors.New("timing.probeOfflineMinSec must not exceed timing.probeOfflineMaxSec"))
+	}
+	if cfg.Audit.MaxSizeBytes < 0 {
+		errs = append(errs, fmt.Errorf("audit.maxSizeBytes must not be negative, got %d", cfg.Audit.MaxSizeBytes))
+	}
+
+	return errors.Join(errs...)
+}
+
 func logAudit(entry AuditEntry) {
 	// Log to console
 	log.Printf("AUDIT: %+v", entry)
@@ -81,30 +170,35 @@ func reverseProxy(w http.ResponseWriter, r *http.Request) {
 		targetURL += "?" + r.URL.RawQuery
 	}
 
-	// Create request to RCC
-	req, err := http.NewRequest(r.Method, targetURL, r.Body)
+	isSSE := strings.HasPrefix(r.URL.Path, "/telemetry")
+
+	// Create request to RCC, canceled when the browser disconnects
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, targetURL, r.Body)
 	if err != nil {
 		http.Error(w, "Failed to create request", http.StatusInternalServerError)
 		return
 	}
 
-	// Copy headers
-	for key, values := range r.Header {
-		for _, value := range values {
-			req.Header.Add(key, value)
-		}
+	// Copy end-to-end headers
+	copyHeaders(req.Header, r.Header)
+
+	// Forward client credentials, or inject the service token for server-to-server calls
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		req.Header.Set("Authorization", auth)
+	} else if config.ServiceToken != "" {
+		req.Header.Set("Authorization", "Bearer "+config.ServiceToken)
 	}
 
 	// Handle SSE with Last-Event-ID injection
-	if strings.HasPrefix(r.URL.Path, "/telemetry") {
+	if isSSE {
 		lastEventID := r.URL.Query().Get("lastEventId")
 		if lastEventID != "" {
 			req.Header.Set("Last-Event-ID", lastEventID)
 		}
 	}
 
-	// Make request
-	client := &http.Client{Timeout: 30 * time.Second}
+	// Make request with a timeout matched to the path class
+	client := &http.Client{Timeout: proxyTimeout(r)}
 	resp, err := client.Do(req)
 	if err != nil {
 		http.Error(w, "Failed to connect to RCC", http.StatusBadGateway)
@@ -113,19 +207,107 @@ func reverseProxy(w http.ResponseWriter, r *http.Request) {
 	defer resp.Body.Close()
 
 	// Copy response headers
-	for key, values := range resp.Header {
-		for _, value := range values {
-			w.Header().Add(key, value)
-		}
-	}
+	copyHeaders(w.Header(), resp.Header)
 
 	// Set status
 	w.WriteHeader(resp.StatusCode)
 
+	// Stream SSE so each event reaches the browser immediately
+	if isSSE {
+		streamBody(w, resp.Body)
+		return
+	}
+
 	// Copy body
 	io.Copy(w, resp.Body)
 }
 
+const (
+	// defaultProxyTimeout applies to proxied requests with no configured command timeout
+	defaultProxyTimeout = 30 * time.Second
+
+	// proxyTimeoutHeadroom lets the RCC report its own command timeout before the proxy gives up
+	proxyTimeoutHeadroom = 2 * time.Second
+)
+
+// proxyTimeout returns the client timeout for a proxied request. Commands use
+// the CB-TIMING command timeouts from config.json, reads use the getState
+// timeout, and the telemetry SSE stream is long-lived so no timeout applies.
+func proxyTimeout(r *http.Request) time.Duration {
+	if strings.HasPrefix(r.URL.Path, "/telemetry") {
+		return 0
+	}
+
+	timeouts := config.Timing.CmdTimeoutsSec
+	seconds := 0
+	switch {
+	case r.Method == http.MethodGet:
+		seconds = timeouts.GetState
+	case r.URL.Path == "/radios/select":
+		seconds = timeouts.SelectRadio
+	case strings.HasSuffix(r.URL.Path, "/power"):
+		seconds = timeouts.SetPower
+	case strings.HasSuffix(r.URL.Path, "/channel"):
+		seconds = timeouts.SetChannel
+	}
+
+	if seconds <= 0 {
+		return defaultProxyTimeout
+	}
+	return time.Duration(seconds)*time.Second + proxyTimeoutHeadroom
+}
+
+// copyHeaders copies src into dst, dropping hop-by-hop headers and any
+// headers listed in the Connection header.
+func copyHeaders(dst, src http.Header) {
+	skip := make(map[string]bool)
+	for _, key := range hopByHopHeaders {
+		skip[key] = true
+	}
+	for _, value := range src.Values("Connection") {
+		for _, key := range strings.Split(value, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				skip[http.CanonicalHeaderKey(key)] = true
+			}
+		}
+	}
+
+	for key, values := range src {
+		if skip[http.CanonicalHeaderKey(key)] {
+			continue
+		}
+		for _, value := range values {
+			dst.Add(key, value)
+		}
+	}
+}
+
+// streamBody copies body to w, flushing after every chunk read.
+func streamBody(w http.ResponseWriter, body io.Reader) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		io.Copy(w, body)
+		return
+	}
+
+	// Send headers before the first event arrives
+	flusher.Flush()
+
+	buf := make([]byte, 4096)
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			if _, writeErr := w.Write(buf[:n]); writeErr != nil {
+				return
+			}
+			flusher.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
 func handleAudit(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -138,20 +320,103 @@ func handleAudit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Append to audit log file
-	file, err := os.OpenFile("audit.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	jsonData, err := json.Marshal(entry)
 	if err != nil {
-		http.Error(w, "Failed to open audit log", http.StatusInternalServerError)
+		http.Error(w, "Failed to encode audit entry", http.StatusInternalServerError)
 		return
 	}
-	defer file.Close()
 
-	jsonData, _ := json.Marshal(entry)
-	file.Write(append(jsonData, '\n'))
+	if err := writeAuditLine(append(jsonData, '\n')); err != nil {
+		log.Printf("Audit write failed: %v", err)
+		http.Error(w, "Failed to write audit log", http.StatusInternalServerError)
+		return
+	}
 
 	w.WriteHeader(http.StatusOK)
 }
 
+// writeAuditLine appends line to the configured audit log, rotating it to
+// <path>.1 first when the write would exceed the configured maximum size.
+func writeAuditLine(line []byte) error {
+	auditMu.Lock()
+	defer auditMu.Unlock()
+
+	path := config.Audit.Path
+	if path == "" {
+		path = defaultAuditPath
+	}
+	maxSize := config.Audit.MaxSizeBytes
+	if maxSize <= 0 {
+		maxSize = defaultAuditMaxSizeBytes
+	}
+
+	if info, err := os.Stat(path); err == nil && info.Size() > 0 && info.Size()+int64(len(line)) > maxSize {
+		if err := os.Rename(path, path+".1"); err != nil {
+			return fmt.Errorf("failed to rotate audit log: %w", err)
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	if _, err := file.Write(line); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close audit log: %w", err)
+	}
+	return nil
+}
+
+// rccHealthTimeout bounds how long /healthz waits for the RCC health check
+const rccHealthTimeout = 5 * time.Second
+
+// handleHealthz reports whether the web-ui is up and can reach the RCC.
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	status := http.StatusOK
+	body := map[string]string{"status": "ok"}
+	if err := checkRCCHealth(r.Context()); err != nil {
+		status = http.StatusServiceUnavailable
+		body = map[string]string{"status": "unavailable", "reason": err.Error()}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// checkRCCHealth pings the RCC health endpoint through the configured base URL.
+func checkRCCHealth(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, rccHealthTimeout)
+	defer cancel()
+
+	healthURL := strings.TrimRight(config.RCCBaseURL, "/") + "/api/v1/health"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthURL, nil)
+	if err != nil {
+		return fmt.Errorf("invalid RCC health URL: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("RCC unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("RCC health returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
 func main() {
 	// Load configuration
 	if err := loadConfig(); err != nil {
@@ -175,6 +440,9 @@ func main() {
 	// Audit endpoint
 	http.HandleFunc("/audit", handleAudit)
 
+	// Health endpoint for orchestrators
+	http.HandleFunc("/healthz", handleHealthz)
+
 	// Start server
 	log.Println("RCC Web UI server starting on http://0.0.0.0:3000")
 	log.Printf("Proxying to RCC at %s", config.RCCBaseURL)
@@ -0,0 +1,392 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestReverseProxySSEFlushesIncrementally verifies SSE chunks reach the client
+// as they are produced upstream rather than when the stream ends.
+func TestReverseProxySSEFlushesIncrementally(t *testing.T) {
+	release := make(chan struct{})
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+
+		fmt.Fprint(w, "id: 1\nevent: ready\ndata: {}\n\n")
+		flusher.Flush()
+
+		// Hold the stream open until the first event has been observed downstream
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			return
+		}
+
+		fmt.Fprint(w, "id: 2\nevent: heartbeat\ndata: {}\n\n")
+		flusher.Flush()
+	}))
+	defer upstream.Close()
+	defer close(release)
+
+	config.RCCBaseURL = upstream.URL
+	proxy := httptest.NewServer(http.HandlerFunc(reverseProxy))
+	defer proxy.Close()
+
+	resp, err := http.Get(proxy.URL + "/telemetry")
+	if err != nil {
+		t.Fatalf("Failed to connect to proxy: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Expected Content-Type text/event-stream, got %q", ct)
+	}
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	readEvent := func() string {
+		var event []string
+		for {
+			select {
+			case line, ok := <-lines:
+				if !ok || line == "" {
+					return strings.Join(event, "\n")
+				}
+				event = append(event, line)
+			case <-time.After(2 * time.Second):
+				t.Fatalf("Timed out waiting for SSE event; proxy is buffering (got %q so far)", event)
+			}
+		}
+	}
+
+	// The first event must arrive while upstream is still holding the stream open
+	if first := readEvent(); !strings.Contains(first, "event: ready") {
+		t.Fatalf("Expected ready event first, got %q", first)
+	}
+
+	release <- struct{}{}
+
+	if second := readEvent(); !strings.Contains(second, "event: heartbeat") {
+		t.Fatalf("Expected heartbeat event second, got %q", second)
+	}
+}
+
+// TestReverseProxyForwardsAuthorization verifies the Authorization header is
+// forwarded and hop-by-hop headers are stripped in both directions.
+func TestReverseProxyForwardsAuthorization(t *testing.T) {
+	var received http.Header
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.Header().Set("Connection", "X-Upstream-Hop")
+		w.Header().Set("X-Upstream-Hop", "secret")
+		w.Header().Set("Keep-Alive", "timeout=5")
+		w.Header().Set("X-Upstream", "ok")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer upstream.Close()
+
+	config.RCCBaseURL = upstream.URL
+	config.ServiceToken = "service-token"
+	defer func() { config.ServiceToken = "" }()
+
+	req := httptest.NewRequest("GET", "/radios", nil)
+	req.Header.Set("Authorization", "Bearer client-token")
+	req.Header.Set("Connection", "X-Client-Hop")
+	req.Header.Set("X-Client-Hop", "drop-me")
+	req.Header.Set("Keep-Alive", "timeout=5")
+	req.Header.Set("Proxy-Authorization", "Basic abc")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("X-Custom", "keep-me")
+	w := httptest.NewRecorder()
+
+	reverseProxy(w, req)
+
+	if got := received.Get("Authorization"); got != "Bearer client-token" {
+		t.Errorf("Expected client Authorization to be forwarded, got %q", got)
+	}
+	if got := received.Get("X-Custom"); got != "keep-me" {
+		t.Errorf("Expected end-to-end header to be forwarded, got %q", got)
+	}
+	for _, header := range []string{"X-Client-Hop", "Keep-Alive", "Proxy-Authorization", "Upgrade"} {
+		if got := received.Get(header); got != "" {
+			t.Errorf("Expected hop-by-hop header %s to be stripped, got %q", header, got)
+		}
+	}
+
+	if got := w.Header().Get("X-Upstream"); got != "ok" {
+		t.Errorf("Expected upstream response header to be copied, got %q", got)
+	}
+	for _, header := range []string{"Connection", "X-Upstream-Hop", "Keep-Alive"} {
+		if got := w.Header().Get(header); got != "" {
+			t.Errorf("Expected hop-by-hop response header %s to be stripped, got %q", header, got)
+		}
+	}
+}
+
+// TestReverseProxyInjectsServiceToken verifies the configured service token is
+// used only when the client supplies no Authorization header.
+func TestReverseProxyInjectsServiceToken(t *testing.T) {
+	var receivedAuth string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedAuth = r.Header.Get("Authorization")
+	}))
+	defer upstream.Close()
+
+	config.RCCBaseURL = upstream.URL
+	config.ServiceToken = "service-token"
+	defer func() { config.ServiceToken = "" }()
+
+	reverseProxy(httptest.NewRecorder(), httptest.NewRequest("GET", "/radios", nil))
+
+	if receivedAuth != "Bearer service-token" {
+		t.Errorf("Expected injected service token, got %q", receivedAuth)
+	}
+
+	// Without a configured token no Authorization header is sent
+	config.ServiceToken = ""
+	reverseProxy(httptest.NewRecorder(), httptest.NewRequest("GET", "/radios", nil))
+
+	if receivedAuth != "" {
+		t.Errorf("Expected no Authorization header, got %q", receivedAuth)
+	}
+}
+
+// TestHealthzReflectsRCCReachability verifies /healthz follows the RCC health endpoint.
+func TestHealthzReflectsRCCReachability(t *testing.T) {
+	rccStatus := http.StatusOK
+	rcc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/health" {
+			t.Errorf("Expected health check on /api/v1/health, got %s", r.URL.Path)
+		}
+		w.WriteHeader(rccStatus)
+	}))
+	defer rcc.Close()
+
+	healthz := func() (int, map[string]string) {
+		w := httptest.NewRecorder()
+		handleHealthz(w, httptest.NewRequest("GET", "/healthz", nil))
+		var body map[string]string
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to decode healthz body: %v", err)
+		}
+		return w.Code, body
+	}
+
+	config.RCCBaseURL = rcc.URL
+	if code, body := healthz(); code != http.StatusOK || body["status"] != "ok" {
+		t.Errorf("Expected 200 ok with reachable RCC, got %d %v", code, body)
+	}
+
+	// RCC reachable but unhealthy
+	rccStatus = http.StatusServiceUnavailable
+	if code, body := healthz(); code != http.StatusServiceUnavailable || !strings.Contains(body["reason"], "503") {
+		t.Errorf("Expected 503 with status reason, got %d %v", code, body)
+	}
+
+	// RCC unreachable
+	rcc.Close()
+	code, body := healthz()
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 with unreachable RCC, got %d", code)
+	}
+	if !strings.Contains(body["reason"], "unreachable") {
+		t.Errorf("Expected unreachable reason, got %q", body["reason"])
+	}
+}
+
+// postAudit sends an audit entry to handleAudit and returns the response code.
+func postAudit(t *testing.T, entry AuditEntry) int {
+	body, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("Failed to marshal audit entry: %v", err)
+	}
+	w := httptest.NewRecorder()
+	handleAudit(w, httptest.NewRequest("POST", "/audit", strings.NewReader(string(body))))
+	return w.Code
+}
+
+// TestHandleAuditWritesConfiguredPath verifies entries land in the configured audit log.
+func TestHandleAuditWritesConfiguredPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "web-audit.log")
+	config.Audit.Path = path
+	config.Audit.MaxSizeBytes = 0
+	defer func() { config.Audit.Path = "" }()
+
+	if code := postAudit(t, AuditEntry{Actor: "operator", RadioID: "radio-01", Action: "setPower", Result: "SUCCESS"}); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected audit log at configured path: %v", err)
+	}
+	var written AuditEntry
+	if err := json.Unmarshal([]byte(strings.TrimSpace(string(data))), &written); err != nil {
+		t.Fatalf("Failed to decode written entry: %v", err)
+	}
+	if written.RadioID != "radio-01" || written.Action != "setPower" {
+		t.Errorf("Unexpected entry written: %+v", written)
+	}
+}
+
+// TestHandleAuditRotatesBySize verifies the audit log rotates once it exceeds the size limit.
+func TestHandleAuditRotatesBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	config.Audit.Path = path
+	config.Audit.MaxSizeBytes = 200
+	defer func() { config.Audit.Path = ""; config.Audit.MaxSizeBytes = 0 }()
+
+	for i := 0; i < 3; i++ {
+		if code := postAudit(t, AuditEntry{RadioID: fmt.Sprintf("radio-%02d", i), Action: "setPower"}); code != http.StatusOK {
+			t.Fatalf("Entry %d: expected 200, got %d", i, code)
+		}
+	}
+
+	rotated, err := os.ReadFile(path + ".1")
+	if err != nil {
+		t.Fatalf("Expected rotated audit log: %v", err)
+	}
+	current, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected current audit log: %v", err)
+	}
+	if int64(len(current)) > config.Audit.MaxSizeBytes {
+		t.Errorf("Expected current log under %d bytes, got %d", config.Audit.MaxSizeBytes, len(current))
+	}
+	if !strings.Contains(string(current), "radio-02") || strings.Contains(string(rotated), "radio-02") {
+		t.Errorf("Expected latest entry in current log only")
+	}
+}
+
+// TestHandleAuditWriteFailure verifies write failures surface as 500.
+func TestHandleAuditWriteFailure(t *testing.T) {
+	config.Audit.Path = filepath.Join(t.TempDir(), "missing-dir", "audit.log")
+	defer func() { config.Audit.Path = "" }()
+
+	if code := postAudit(t, AuditEntry{RadioID: "radio-01", Action: "setPower"}); code != http.StatusInternalServerError {
+		t.Errorf("Expected 500 on write failure, got %d", code)
+	}
+}
+
+// writeTestConfig writes config.json in a temporary working directory after
+// applying mutate to the shipped configuration.
+func writeTestConfig(t *testing.T, mutate func(map[string]interface{})) {
+	data, err := os.ReadFile("config.json")
+	if err != nil {
+		t.Fatalf("Failed to read shipped config.json: %v", err)
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("Failed to parse shipped config.json: %v", err)
+	}
+	mutate(raw)
+
+	out, err := json.Marshal(raw)
+	if err != nil {
+		t.Fatalf("Failed to marshal config: %v", err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), out, 0644); err != nil {
+		t.Fatalf("Failed to write config.json: %v", err)
+	}
+	t.Chdir(dir)
+
+	saved := config
+	config = Config{}
+	t.Cleanup(func() { config = saved })
+}
+
+// TestLoadConfigAcceptsShippedConfig verifies the bundled config.json passes validation.
+func TestLoadConfigAcceptsShippedConfig(t *testing.T) {
+	writeTestConfig(t, func(map[string]interface{}) {})
+
+	if err := loadConfig(); err != nil {
+		t.Fatalf("Expected shipped config to load, got %v", err)
+	}
+}
+
+// TestLoadConfigRejectsEmptyRCCBaseURL verifies a missing RCC URL fails fast with a clear message.
+func TestLoadConfigRejectsEmptyRCCBaseURL(t *testing.T) {
+	writeTestConfig(t, func(raw map[string]interface{}) {
+		raw["rccBaseUrl"] = ""
+	})
+
+	err := loadConfig()
+	if err == nil {
+		t.Fatal("Expected error for empty rccBaseUrl")
+	}
+	if !strings.Contains(err.Error(), "rccBaseUrl is required") {
+		t.Errorf("Expected descriptive rccBaseUrl error, got %q", err)
+	}
+}
+
+// TestLoadConfigRejectsInvalidValues verifies malformed URLs and non-positive timing are reported together.
+func TestLoadConfigRejectsInvalidValues(t *testing.T) {
+	writeTestConfig(t, func(raw map[string]interface{}) {
+		raw["rccBaseUrl"] = "localhost:8080"
+		timing := raw["timing"].(map[string]interface{})
+		timing["heartbeatIntervalSec"] = 0
+		timing["cmdTimeoutsSec"].(map[string]interface{})["setPower"] = -1
+	})
+
+	err := loadConfig()
+	if err == nil {
+		t.Fatal("Expected validation error")
+	}
+	for _, want := range []string{"rccBaseUrl", "timing.heartbeatIntervalSec", "timing.cmdTimeoutsSec.setPower"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to mention %s, got %q", want, err)
+		}
+	}
+}
+
+// TestProxyTimeoutByPathClass verifies commands use their configured timeouts
+// and the telemetry stream has no client timeout.
+func TestProxyTimeoutByPathClass(t *testing.T) {
+	saved := config.Timing
+	defer func() { config.Timing = saved }()
+	config.Timing.CmdTimeoutsSec.SetPower = 10
+	config.Timing.CmdTimeoutsSec.SetChannel = 30
+	config.Timing.CmdTimeoutsSec.SelectRadio = 5
+	config.Timing.CmdTimeoutsSec.GetState = 5
+
+	tests := []struct {
+		method string
+		path   string
+		want   time.Duration
+	}{
+		{"POST", "/radios/radio-01/power", 10*time.Second + proxyTimeoutHeadroom},
+		{"POST", "/radios/radio-01/channel", 30*time.Second + proxyTimeoutHeadroom},
+		{"POST", "/radios/select", 5*time.Second + proxyTimeoutHeadroom},
+		{"GET", "/radios", 5*time.Second + proxyTimeoutHeadroom},
+		{"GET", "/radios/radio-01/power", 5*time.Second + proxyTimeoutHeadroom},
+		{"GET", "/telemetry", 0},
+	}
+	for _, tt := range tests {
+		if got := proxyTimeout(httptest.NewRequest(tt.method, tt.path, nil)); got != tt.want {
+			t.Errorf("%s %s: expected timeout %v, got %v", tt.method, tt.path, tt.want, got)
+		}
+	}
+
+	// Unconfigured command timeouts fall back to the default
+	config.Timing.CmdTimeoutsSec.SetPower = 0
+	if got := proxyTimeout(httptest.NewRequest("POST", "/radios/radio-01/power", nil)); got != defaultProxyTimeout {
+		t.Errorf("Expected default timeout %v, got %v", defaultProxyTimeout, got)
+	}
+}